@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/di"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 // @contact.name   API Support
@@ -17,7 +21,11 @@ func main() {
 	log := logger.New()
 	config.SetSwaggerDefaultInfo(cfg)
 
+	// the interrupt stops the server and its workers
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	if server, err := di.InitializeAPI(cfg, log); err == nil {
-		server.Start(cfg, log)
+		server.Start(ctx, cfg, log)
 	}
 }
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS stays_blocked_dates;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stays_blocked_dates
+(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stay_id UUID NOT NULL,
+    date_start TIMESTAMP NOT NULL,
+    date_end TIMESTAMP NOT NULL,
+    reason VARCHAR(255) DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (stay_id) REFERENCES stays(id) ON DELETE CASCADE
+);
+
+CREATE INDEX stays_blocked_dates_stay_id_idx ON stays_blocked_dates (stay_id, date_start, date_end);
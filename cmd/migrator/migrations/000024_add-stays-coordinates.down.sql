@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS stays_lat_lon_idx;
+
+ALTER TABLE stays
+    DROP COLUMN IF EXISTS lat,
+    DROP COLUMN IF EXISTS lon;
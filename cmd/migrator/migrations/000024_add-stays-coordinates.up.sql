@@ -0,0 +1,14 @@
+ALTER TABLE stays
+    ADD COLUMN IF NOT EXISTS lat DOUBLE PRECISION DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS lon DOUBLE PRECISION DEFAULT NULL;
+
+-- Existing stays get the coordinates of their city until the owner sets the exact ones
+UPDATE stays s
+SET lat = l.lat::DOUBLE PRECISION,
+    lon = l.lon::DOUBLE PRECISION
+FROM locations l
+WHERE s.location_id = l.id
+  AND l.lat ~ '^-?[0-9]+(\.[0-9]+)?$'
+  AND l.lon ~ '^-?[0-9]+(\.[0-9]+)?$';
+
+CREATE INDEX IF NOT EXISTS stays_lat_lon_idx ON stays (lat, lon);
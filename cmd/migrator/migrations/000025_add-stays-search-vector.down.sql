@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS stays_search_vector_idx;
+
+ALTER TABLE stays
+    DROP COLUMN IF EXISTS search_vector;
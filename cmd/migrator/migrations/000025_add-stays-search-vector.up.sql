@@ -0,0 +1,9 @@
+ALTER TABLE stays
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(describe_property, '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(address, '')), 'C') ||
+        setweight(to_tsvector('russian', coalesce(owners_rules, '')), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS stays_search_vector_idx ON stays USING GIN (search_vector);
@@ -0,0 +1,28 @@
+ALTER TABLE contracts
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN price TYPE FLOAT USING price::FLOAT;
+
+DROP INDEX IF EXISTS stays_currency_price_idx;
+
+ALTER TABLE stays
+    DROP CONSTRAINT IF EXISTS stays_price_check,
+    DROP CONSTRAINT IF EXISTS stays_rooms_count_check,
+    DROP CONSTRAINT IF EXISTS stays_beds_count_check,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE stays
+    ALTER COLUMN price DROP DEFAULT,
+    ALTER COLUMN rooms_count DROP DEFAULT,
+    ALTER COLUMN beds_count DROP DEFAULT;
+
+ALTER TABLE stays
+    ALTER COLUMN price TYPE TEXT USING price::TEXT,
+    ALTER COLUMN rooms_count TYPE TEXT USING rooms_count::TEXT,
+    ALTER COLUMN beds_count TYPE TEXT USING beds_count::TEXT;
+
+ALTER TABLE stays
+    ALTER COLUMN price SET DEFAULT '',
+    ALTER COLUMN rooms_count SET DEFAULT '',
+    ALTER COLUMN beds_count SET DEFAULT '';
+
+DROP TABLE IF EXISTS currency_rates;
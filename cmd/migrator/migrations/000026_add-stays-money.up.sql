@@ -0,0 +1,67 @@
+CREATE TABLE IF NOT EXISTS currency_rates
+(
+    currency   CHAR(3) PRIMARY KEY,
+    -- rate is the price of one unit of the currency in the base currency (RUB)
+    rate       NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO currency_rates (currency, rate) VALUES ('RUB', 1) ON CONFLICT DO NOTHING;
+
+ALTER TABLE stays
+    ALTER COLUMN price DROP DEFAULT,
+    ALTER COLUMN rooms_count DROP DEFAULT,
+    ALTER COLUMN beds_count DROP DEFAULT;
+
+-- The prices were typed by hand, e.g. '1 500,50 ₽', the spaces and the rouble sign are dropped
+-- and the decimal comma becomes a point. Empty values were never set and become zero.
+UPDATE stays
+SET price       = replace(regexp_replace(regexp_replace(price, '[\s\u00a0]', '', 'g'),
+                                         '^(₽|rub)|(₽|руб\.?|р\.?|rub)$', '', 'gi'), ',', '.'),
+    rooms_count = trim(rooms_count),
+    beds_count  = trim(beds_count);
+
+-- Anything else left is not a number, it is not guessed and the migration stops until it is fixed by hand
+DO
+$$
+DECLARE
+    invalid TEXT;
+BEGIN
+    SELECT string_agg(id::TEXT, ', ')
+    INTO invalid
+    FROM stays
+    WHERE price !~ '^([0-9]+(\.[0-9]+)?)?$'
+       OR rooms_count !~ '^[0-9]*$'
+       OR beds_count !~ '^[0-9]*$';
+
+    IF invalid IS NOT NULL THEN
+        RAISE EXCEPTION 'stays % have price, rooms_count or beds_count that is not a number', invalid;
+    END IF;
+END
+$$;
+
+ALTER TABLE stays
+    ALTER COLUMN price TYPE NUMERIC(12, 2)
+        USING CASE WHEN price = '' THEN 0 ELSE price::NUMERIC(12, 2) END,
+    ALTER COLUMN rooms_count TYPE INTEGER
+        USING CASE WHEN rooms_count = '' THEN 0 ELSE rooms_count::INTEGER END,
+    ALTER COLUMN beds_count TYPE INTEGER
+        USING CASE WHEN beds_count = '' THEN 0 ELSE beds_count::INTEGER END;
+
+ALTER TABLE stays
+    ALTER COLUMN price SET DEFAULT 0,
+    ALTER COLUMN rooms_count SET DEFAULT 0,
+    ALTER COLUMN beds_count SET DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB' REFERENCES currency_rates (currency);
+
+ALTER TABLE stays
+    ADD CONSTRAINT stays_price_check CHECK (price >= 0),
+    ADD CONSTRAINT stays_rooms_count_check CHECK (rooms_count >= 0),
+    ADD CONSTRAINT stays_beds_count_check CHECK (beds_count >= 0);
+
+CREATE INDEX IF NOT EXISTS stays_currency_price_idx ON stays (currency, price);
+
+ALTER TABLE contracts
+    ALTER COLUMN price TYPE NUMERIC(12, 2) USING round(price::NUMERIC, 2),
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
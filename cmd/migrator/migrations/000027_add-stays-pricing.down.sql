@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS stays_seasonal_prices;
+DROP TABLE IF EXISTS stays_pricing;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS stays_pricing
+(
+    stay_id                   UUID PRIMARY KEY,
+    weekend_surcharge_percent NUMERIC(6, 2)  NOT NULL DEFAULT 0 CHECK (weekend_surcharge_percent >= 0),
+    weekly_discount_percent   NUMERIC(5, 2)  NOT NULL DEFAULT 0 CHECK (weekly_discount_percent BETWEEN 0 AND 100),
+    monthly_discount_percent  NUMERIC(5, 2)  NOT NULL DEFAULT 0 CHECK (monthly_discount_percent BETWEEN 0 AND 100),
+    cleaning_fee              NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (cleaning_fee >= 0),
+    extra_guest_fee           NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (extra_guest_fee >= 0),
+    included_guests           INTEGER        NOT NULL DEFAULT 1 CHECK (included_guests >= 1),
+    created_at                TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (stay_id) REFERENCES stays (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS stays_seasonal_prices
+(
+    id         UUID PRIMARY KEY        DEFAULT uuid_generate_v4(),
+    stay_id    UUID           NOT NULL,
+    name       VARCHAR(255)   NOT NULL DEFAULT '',
+    date_start DATE           NOT NULL,
+    date_end   DATE           NOT NULL,
+    price      NUMERIC(12, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK (date_end > date_start),
+    FOREIGN KEY (stay_id) REFERENCES stays (id) ON DELETE CASCADE
+);
+
+CREATE INDEX stays_seasonal_prices_stay_id_idx ON stays_seasonal_prices (stay_id, date_start, date_end);
@@ -0,0 +1,21 @@
+ALTER TABLE stays
+    DROP COLUMN IF EXISTS instant_book;
+
+DROP TABLE IF EXISTS reservations_status_history;
+
+DROP INDEX IF EXISTS reservations_stay_id_status_idx;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS check_in BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS check_out BOOLEAN DEFAULT FALSE;
+
+UPDATE reservations
+SET check_in  = status IN ('checked_in', 'completed'),
+    check_out = status = 'completed';
+
+-- Reservations that never happened had no place in the old model
+DELETE FROM reservations
+WHERE status IN ('declined', 'cancelled', 'no_show');
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS status;
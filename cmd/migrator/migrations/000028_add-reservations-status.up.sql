@@ -0,0 +1,42 @@
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'approved', 'declined', 'paid', 'checked_in', 'completed', 'cancelled', 'no_show'));
+
+-- Reservations made before the lifecycle were booked instantly
+UPDATE reservations
+SET status = CASE
+                 WHEN check_out THEN 'completed'
+                 WHEN check_in THEN 'checked_in'
+                 ELSE 'approved'
+    END;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS check_in,
+    DROP COLUMN IF EXISTS check_out;
+
+CREATE INDEX IF NOT EXISTS reservations_stay_id_status_idx ON reservations (stay_id, status);
+
+CREATE TABLE IF NOT EXISTS reservations_status_history
+(
+    id             UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    reservation_id UUID        NOT NULL,
+    from_status    VARCHAR(20),
+    to_status      VARCHAR(20) NOT NULL,
+    changed_by     UUID,
+    reason         TEXT        NOT NULL DEFAULT '',
+    created_at     TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (reservation_id) REFERENCES reservations (id) ON DELETE CASCADE,
+    FOREIGN KEY (changed_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS reservations_status_history_reservation_id_idx
+    ON reservations_status_history (reservation_id, created_at);
+
+INSERT INTO reservations_status_history (reservation_id, from_status, to_status, reason, created_at)
+SELECT id, NULL, status, 'migrated', updated_at
+FROM reservations;
+
+-- Stays book instantly unless the owner wants to approve every request
+ALTER TABLE stays
+    ADD COLUMN IF NOT EXISTS instant_book BOOLEAN NOT NULL DEFAULT TRUE;
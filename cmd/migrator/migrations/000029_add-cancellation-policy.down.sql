@@ -0,0 +1,13 @@
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS refund_percent,
+    DROP COLUMN IF EXISTS refund_amount,
+    DROP COLUMN IF EXISTS refund_currency,
+    DROP COLUMN IF EXISTS refund_status,
+    DROP COLUMN IF EXISTS cancellation_policy,
+    DROP COLUMN IF EXISTS cancellation_tiers;
+
+ALTER TABLE stays
+    DROP CONSTRAINT IF EXISTS stays_cancellation_policy_check,
+    DROP COLUMN IF EXISTS cancellation_tiers,
+    ALTER COLUMN cancellation_policy TYPE TEXT,
+    ALTER COLUMN cancellation_policy SET DEFAULT '';
@@ -0,0 +1,37 @@
+-- Free text policies can't be interpreted, they are kept in the owners rules and replaced by the flexible one
+UPDATE stays
+SET owners_rules = concat_ws(E'\n\n', NULLIF(owners_rules, ''), 'Cancellation policy: ' || cancellation_policy)
+WHERE lower(trim(cancellation_policy)) NOT IN ('flexible', 'moderate', 'strict')
+  AND trim(cancellation_policy) <> '';
+
+ALTER TABLE stays
+    ALTER COLUMN cancellation_policy TYPE VARCHAR(20)
+        USING CASE
+                  WHEN lower(trim(cancellation_policy)) IN ('flexible', 'moderate', 'strict')
+                      THEN lower(trim(cancellation_policy))
+                  ELSE 'flexible'
+        END,
+    ALTER COLUMN cancellation_policy SET DEFAULT 'flexible',
+    ALTER COLUMN cancellation_policy SET NOT NULL,
+    ADD CONSTRAINT stays_cancellation_policy_check
+        CHECK (cancellation_policy IN ('flexible', 'moderate', 'strict', 'custom')),
+    -- cancellation_tiers holds the days before arrival and refund percents of the custom policy
+    ADD COLUMN IF NOT EXISTS cancellation_tiers JSONB;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS refund_percent  NUMERIC(5, 2),
+    ADD COLUMN IF NOT EXISTS refund_amount   NUMERIC(12, 2),
+    ADD COLUMN IF NOT EXISTS refund_currency CHAR(3),
+    ADD COLUMN IF NOT EXISTS refund_status   VARCHAR(20)
+        CHECK (refund_status IN ('not_required', 'requested', 'failed'));
+
+-- The policy in force at booking time applies to the cancellation, later changes of the stay policy don't
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS cancellation_policy VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS cancellation_tiers  JSONB;
+
+UPDATE reservations r
+SET cancellation_policy = s.cancellation_policy,
+    cancellation_tiers  = s.cancellation_tiers
+FROM stays s
+WHERE s.id = r.stay_id;
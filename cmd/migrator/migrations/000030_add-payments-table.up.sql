@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS payments
+(
+    id                  UUID PRIMARY KEY        DEFAULT uuid_generate_v4(),
+    idempotence_key     VARCHAR(64)    NOT NULL UNIQUE,
+    -- payments outlive the reservations and users they were made for
+    reservation_id      UUID,
+    user_id             UUID,
+    provider_payment_id VARCHAR(64),
+    status              VARCHAR(32)    NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'waiting_for_capture', 'succeeded', 'canceled')),
+    amount              NUMERIC(12, 2) NOT NULL,
+    currency            CHAR(3)        NOT NULL,
+    -- request and response are the raw payloads exchanged with the payment provider
+    request             JSONB          NOT NULL,
+    response            JSONB,
+    created_at          TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (reservation_id) REFERENCES reservations (id) ON DELETE SET NULL,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS payments_reservation_id_idx ON payments (reservation_id);
+
+-- a reservation is paid once, a new payment can be made only after the previous one is canceled
+CREATE UNIQUE INDEX IF NOT EXISTS payments_reservation_id_active_key ON payments (reservation_id)
+    WHERE status <> 'canceled';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS role_object;
+
+DELETE FROM adm_object WHERE route IN ('/locations', '/advantages', '/user');
@@ -0,0 +1,29 @@
+INSERT INTO role (id, name)
+VALUES (1, 'user'),
+       (2, 'admin')
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('role', 'id'), GREATEST((SELECT MAX(id) FROM role), 1));
+
+CREATE TABLE IF NOT EXISTS role_object
+(
+    role_id   INTEGER REFERENCES role (id) ON DELETE CASCADE,
+    object_id INTEGER REFERENCES adm_object (id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, object_id)
+);
+
+CREATE INDEX IF NOT EXISTS role_object_object_id_idx ON role_object (object_id);
+
+-- The objects of the routes only the granted roles can change
+INSERT INTO adm_object (route, action)
+SELECT o.route, o.action
+FROM (VALUES ('/locations', ARRAY ['create', 'update', 'delete']),
+             ('/advantages', ARRAY ['create', 'update', 'delete']),
+             ('/user', ARRAY ['update', 'delete'])) AS o(route, action)
+WHERE NOT EXISTS (SELECT 1 FROM adm_object a WHERE a.route = o.route);
+
+INSERT INTO role_object (role_id, object_id)
+SELECT 2, id
+FROM adm_object
+WHERE route IN ('/locations', '/advantages', '/user')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS stays_reviews_stay_id_idx;
+
+DROP INDEX IF EXISTS stays_rating_score_idx;
+
+ALTER TABLE stays
+    DROP COLUMN IF EXISTS rating_score,
+    DROP COLUMN IF EXISTS reviews_count;
@@ -0,0 +1,9 @@
+-- rating is the plain average of the reviews, rating_score weights it with the prior of
+-- staysreviews.PriorMean over staysreviews.PriorWeight reviews, so a stay without reviews starts from the prior
+ALTER TABLE stays
+    ADD COLUMN IF NOT EXISTS reviews_count INT   NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_score  FLOAT NOT NULL DEFAULT 3.5;
+
+CREATE INDEX IF NOT EXISTS stays_rating_score_idx ON stays (rating_score DESC);
+
+CREATE INDEX IF NOT EXISTS stays_reviews_stay_id_idx ON stays_reviews (stay_id);
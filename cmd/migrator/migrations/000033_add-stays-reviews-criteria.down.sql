@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS stays_reviews_reservation_id_key;
+
+ALTER TABLE stays_reviews
+    DROP COLUMN IF EXISTS replied_at,
+    DROP COLUMN IF EXISTS reply,
+    DROP COLUMN IF EXISTS value,
+    DROP COLUMN IF EXISTS communication,
+    DROP COLUMN IF EXISTS location,
+    DROP COLUMN IF EXISTS accuracy,
+    DROP COLUMN IF EXISTS cleanliness,
+    DROP COLUMN IF EXISTS reservation_id;
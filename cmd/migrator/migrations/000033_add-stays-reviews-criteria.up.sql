@@ -0,0 +1,27 @@
+-- reviews are written for a completed reservation, the reviews written before keep no reservation
+ALTER TABLE stays_reviews
+    ADD COLUMN IF NOT EXISTS reservation_id UUID REFERENCES reservations (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS cleanliness    FLOAT,
+    ADD COLUMN IF NOT EXISTS accuracy       FLOAT,
+    ADD COLUMN IF NOT EXISTS location       FLOAT,
+    ADD COLUMN IF NOT EXISTS communication  FLOAT,
+    ADD COLUMN IF NOT EXISTS value          FLOAT,
+    ADD COLUMN IF NOT EXISTS reply          TEXT,
+    ADD COLUMN IF NOT EXISTS replied_at     TIMESTAMP;
+
+-- the overall rating of the old reviews stands for every criterion
+UPDATE stays_reviews
+SET cleanliness   = COALESCE(rating, 0),
+    accuracy      = COALESCE(rating, 0),
+    location      = COALESCE(rating, 0),
+    communication = COALESCE(rating, 0),
+    value         = COALESCE(rating, 0);
+
+ALTER TABLE stays_reviews
+    ALTER COLUMN cleanliness SET NOT NULL,
+    ALTER COLUMN accuracy SET NOT NULL,
+    ALTER COLUMN location SET NOT NULL,
+    ALTER COLUMN communication SET NOT NULL,
+    ALTER COLUMN value SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS stays_reviews_reservation_id_key ON stays_reviews (reservation_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS guest_reviews;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS review_deadline;
@@ -0,0 +1,30 @@
+-- the checkout opens the review window of the reservation for reservation.ReviewWindowDays days
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS review_deadline TIMESTAMP;
+
+UPDATE reservations r
+SET review_deadline = h.completed_at + INTERVAL '14 days'
+FROM (
+    SELECT reservation_id, MAX(created_at) AS completed_at
+    FROM reservations_status_history
+    WHERE to_status = 'completed'
+    GROUP BY reservation_id
+) h
+WHERE h.reservation_id = r.id
+  AND r.status = 'completed'
+  AND r.review_deadline IS NULL;
+
+CREATE TABLE IF NOT EXISTS guest_reviews
+(
+    id             UUID PRIMARY KEY   DEFAULT uuid_generate_v4(),
+    reservation_id UUID      NOT NULL UNIQUE REFERENCES reservations (id) ON DELETE CASCADE,
+    stay_id        UUID      NOT NULL REFERENCES stays (id) ON DELETE CASCADE,
+    host_id        UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    guest_id       UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    rating         FLOAT     NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    description    TEXT      NOT NULL DEFAULT '',
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS guest_reviews_guest_id_idx ON guest_reviews (guest_id);
@@ -0,0 +1,20 @@
+DELETE FROM adm_object WHERE route = '/moderation';
+
+DROP TABLE IF EXISTS reports_notes;
+
+DROP INDEX IF EXISTS users_reports_status_idx;
+DROP INDEX IF EXISTS stays_reports_status_idx;
+
+ALTER TABLE users_reports
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS assignee_id,
+    DROP COLUMN IF EXISTS action,
+    DROP COLUMN IF EXISTS resolution_comment,
+    DROP COLUMN IF EXISTS resolved_at;
+
+ALTER TABLE stays_reports
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS assignee_id,
+    DROP COLUMN IF EXISTS action,
+    DROP COLUMN IF EXISTS resolution_comment,
+    DROP COLUMN IF EXISTS resolved_at;
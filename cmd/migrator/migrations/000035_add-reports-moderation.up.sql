@@ -0,0 +1,41 @@
+ALTER TABLE stays_reports
+    ADD COLUMN IF NOT EXISTS status             VARCHAR(16) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'in_review', 'resolved', 'rejected')),
+    ADD COLUMN IF NOT EXISTS assignee_id        UUID REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS action             VARCHAR(16),
+    ADD COLUMN IF NOT EXISTS resolution_comment TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS resolved_at        TIMESTAMP;
+
+ALTER TABLE users_reports
+    ADD COLUMN IF NOT EXISTS status             VARCHAR(16) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'in_review', 'resolved', 'rejected')),
+    ADD COLUMN IF NOT EXISTS assignee_id        UUID REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS action             VARCHAR(16),
+    ADD COLUMN IF NOT EXISTS resolution_comment TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS resolved_at        TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS stays_reports_status_idx ON stays_reports (status, created_at);
+CREATE INDEX IF NOT EXISTS users_reports_status_idx ON users_reports (status, created_at);
+
+-- the internal notes of the moderators, the reporters never see them
+CREATE TABLE IF NOT EXISTS reports_notes
+(
+    id          UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    report_type VARCHAR(8)  NOT NULL CHECK (report_type IN ('stay', 'user')),
+    report_id   UUID        NOT NULL,
+    author_id   UUID        REFERENCES users (id) ON DELETE SET NULL,
+    text        TEXT        NOT NULL,
+    created_at  TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS reports_notes_report_idx ON reports_notes (report_type, report_id, created_at);
+
+INSERT INTO adm_object (route, action)
+SELECT '/moderation', ARRAY ['read', 'create', 'update']
+WHERE NOT EXISTS (SELECT 1 FROM adm_object WHERE route = '/moderation');
+
+INSERT INTO role_object (role_id, object_id)
+SELECT 2, id
+FROM adm_object
+WHERE route = '/moderation'
+ON CONFLICT DO NOTHING;
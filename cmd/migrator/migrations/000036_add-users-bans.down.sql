@@ -0,0 +1,8 @@
+UPDATE adm_object
+SET action = array_remove(action, 'delete')
+WHERE route = '/moderation';
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS suspended_until,
+    DROP COLUMN IF EXISTS suspension_reason,
+    DROP COLUMN IF EXISTS banned_at;
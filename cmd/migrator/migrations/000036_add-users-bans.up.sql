@@ -0,0 +1,11 @@
+-- the suspension blocks the user until the time, the ban has the reason of the suspension and no end
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS suspended_until   TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS suspension_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS banned_at         TIMESTAMP;
+
+-- the moderators reinstate the users
+UPDATE adm_object
+SET action = array_append(action, 'delete')
+WHERE route = '/moderation'
+  AND NOT 'delete' = ANY (action);
@@ -0,0 +1,13 @@
+DELETE
+FROM role_object
+WHERE object_id IN (SELECT id FROM adm_object WHERE route = '/stays/review');
+
+DELETE
+FROM adm_object
+WHERE route = '/stays/review';
+
+DROP INDEX IF EXISTS stays_status_idx;
+
+ALTER TABLE stays
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS review_comment;
@@ -0,0 +1,21 @@
+-- the stays go through draft -> pending_review -> published, the hosts archive and unarchive them.
+-- The stays listed before are kept published, the new ones start as drafts.
+ALTER TABLE stays
+    ADD COLUMN IF NOT EXISTS status         VARCHAR(16) NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'pending_review', 'published', 'archived')),
+    ADD COLUMN IF NOT EXISTS review_comment TEXT        NOT NULL DEFAULT '';
+
+ALTER TABLE stays
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX IF NOT EXISTS stays_status_idx ON stays (status, created_at);
+
+INSERT INTO adm_object (route, action)
+SELECT '/stays/review', ARRAY ['read', 'update']
+WHERE NOT EXISTS (SELECT 1 FROM adm_object WHERE route = '/stays/review');
+
+INSERT INTO role_object (role_id, object_id)
+SELECT 2, id
+FROM adm_object
+WHERE route = '/stays/review'
+ON CONFLICT DO NOTHING;
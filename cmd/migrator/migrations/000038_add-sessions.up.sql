@@ -0,0 +1,27 @@
+-- the login of a user on a device, ended by the logout, the revoke or the reuse of a rotated refresh token
+CREATE TABLE IF NOT EXISTS sessions
+(
+    id            UUID PRIMARY KEY   DEFAULT uuid_generate_v4(),
+    user_id       UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_agent    TEXT      NOT NULL DEFAULT '',
+    ip            TEXT      NOT NULL DEFAULT '',
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at    TIMESTAMP NOT NULL,
+    revoked_at    TIMESTAMP,
+    revoke_reason VARCHAR(32)
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id) WHERE revoked_at IS NULL;
+
+-- the refresh tokens of the sessions, a token is used once and replaced by the next one
+CREATE TABLE IF NOT EXISTS sessions_refresh_tokens
+(
+    id         UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    session_id UUID        NOT NULL REFERENCES sessions (id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at    TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS sessions_refresh_tokens_session_id_idx ON sessions_refresh_tokens (session_id);
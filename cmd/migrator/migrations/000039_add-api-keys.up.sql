@@ -0,0 +1,16 @@
+-- the API keys of the users, only the hash of a key is kept
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id           UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    user_id      UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     VARCHAR(64)  NOT NULL UNIQUE,
+    scopes       TEXT[]       NOT NULL,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    expires_at   TIMESTAMP,
+    revoked_at   TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_user_id_idx ON api_keys (user_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,12 @@
+-- the failed login and OTP attempts counted by the key shared between the server nodes
+CREATE TABLE IF NOT EXISTS auth_attempts
+(
+    key          VARCHAR(320) PRIMARY KEY,
+    failures     INTEGER   NOT NULL DEFAULT 0,
+    lockouts     INTEGER   NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    updated_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS auth_attempts_expires_at_idx ON auth_attempts (expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS message_chat_id_created_at_idx;
+
+DROP TABLE IF EXISTS chat_read;
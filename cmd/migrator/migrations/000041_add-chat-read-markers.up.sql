@@ -0,0 +1,14 @@
+-- the last message of the chat every participant has read, the later messages of the others are unread
+CREATE TABLE IF NOT EXISTS chat_read
+(
+    chat_id            UUID      NOT NULL,
+    user_id            UUID      NOT NULL,
+    message_id         UUID      NOT NULL,
+    message_created_at TIMESTAMP NOT NULL,
+    read_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chat_id, user_id),
+    FOREIGN KEY (chat_id) REFERENCES chat (chat_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE INDEX IF NOT EXISTS message_chat_id_created_at_idx ON message (chat_id, created_at, id);
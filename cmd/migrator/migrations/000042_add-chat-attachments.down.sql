@@ -0,0 +1,4 @@
+ALTER TABLE message
+    DROP COLUMN IF EXISTS attachment_id;
+
+DROP TABLE IF EXISTS chat_attachments;
@@ -0,0 +1,20 @@
+-- the files sent in the chats, they are kept under the private root and downloaded by the participants only
+CREATE TABLE IF NOT EXISTS chat_attachments
+(
+    id         UUID PRIMARY KEY,
+    chat_id    UUID         NOT NULL,
+    user_id    UUID         NOT NULL,
+    type       VARCHAR(16)  NOT NULL,
+    mime_type  VARCHAR(64)  NOT NULL,
+    name       VARCHAR(255) NOT NULL,
+    path       VARCHAR(255) NOT NULL,
+    size       BIGINT       NOT NULL,
+    width      INTEGER,
+    height     INTEGER,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES chat (chat_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+ALTER TABLE message
+    ADD COLUMN IF NOT EXISTS attachment_id UUID REFERENCES chat_attachments (id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+DELETE FROM adm_object WHERE route = '/support';
+
+-- only the escalations set the operators of the chats
+UPDATE chat SET operator_id = NULL WHERE operator_id IS NOT NULL;
+
+DROP TABLE IF EXISTS chat_escalations;
@@ -0,0 +1,32 @@
+-- the chats escalated to the support, the assigned operator is set as the operator of the chat until it is closed
+CREATE TABLE IF NOT EXISTS chat_escalations
+(
+    id                UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    chat_id           UUID        NOT NULL REFERENCES chat (chat_id) ON DELETE CASCADE,
+    requester_id      UUID        REFERENCES users (id) ON DELETE SET NULL,
+    reason            TEXT        NOT NULL DEFAULT '',
+    status            VARCHAR(16) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'assigned', 'closed')),
+    operator_id       UUID        REFERENCES users (id) ON DELETE SET NULL,
+    resolution_note   TEXT        NOT NULL DEFAULT '',
+    response_due_at   TIMESTAMP   NOT NULL,
+    resolution_due_at TIMESTAMP   NOT NULL,
+    assigned_at       TIMESTAMP,
+    closed_at         TIMESTAMP,
+    created_at        TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- a chat has one escalation in work at most
+CREATE UNIQUE INDEX IF NOT EXISTS chat_escalations_chat_id_active_key ON chat_escalations (chat_id) WHERE status <> 'closed';
+CREATE INDEX IF NOT EXISTS chat_escalations_status_idx ON chat_escalations (status, created_at);
+
+INSERT INTO adm_object (route, action)
+SELECT '/support', ARRAY ['read', 'update']
+WHERE NOT EXISTS (SELECT 1 FROM adm_object WHERE route = '/support');
+
+INSERT INTO role_object (role_id, object_id)
+SELECT 2, id
+FROM adm_object
+WHERE route = '/support'
+ON CONFLICT DO NOTHING;
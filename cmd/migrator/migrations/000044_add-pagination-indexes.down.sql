@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS reservations_user_id_created_at_idx;
+DROP INDEX IF EXISTS stays_reviews_stay_id_created_at_idx;
+DROP INDEX IF EXISTS stays_reviews_created_at_id_idx;
+DROP INDEX IF EXISTS favourite_user_id_created_at_idx;
+DROP INDEX IF EXISTS locations_created_at_id_idx;
+DROP INDEX IF EXISTS stays_created_at_id_idx;
@@ -0,0 +1,7 @@
+-- the cursor pages are read in the (created_at, id) order, the filtered lists lead with their filter column
+CREATE INDEX IF NOT EXISTS stays_created_at_id_idx ON stays (created_at, id);
+CREATE INDEX IF NOT EXISTS locations_created_at_id_idx ON locations (created_at, id);
+CREATE INDEX IF NOT EXISTS favourite_user_id_created_at_idx ON favourite (user_id, created_at, stay_id);
+CREATE INDEX IF NOT EXISTS stays_reviews_created_at_id_idx ON stays_reviews (created_at, id);
+CREATE INDEX IF NOT EXISTS stays_reviews_stay_id_created_at_idx ON stays_reviews (stay_id, created_at, id);
+CREATE INDEX IF NOT EXISTS reservations_user_id_created_at_idx ON reservations (user_id, created_at, id);
@@ -0,0 +1 @@
+DELETE FROM adm_object WHERE route = '/currency';
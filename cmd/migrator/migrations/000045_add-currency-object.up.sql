@@ -0,0 +1,10 @@
+-- the currency rates are managed by the roles granted the object, the admin role is granted it by default
+INSERT INTO adm_object (route, action)
+SELECT '/currency', ARRAY ['update', 'delete']
+WHERE NOT EXISTS (SELECT 1 FROM adm_object WHERE route = '/currency');
+
+INSERT INTO role_object (role_id, object_id)
+SELECT 2, id
+FROM adm_object
+WHERE route = '/currency'
+ON CONFLICT DO NOTHING;
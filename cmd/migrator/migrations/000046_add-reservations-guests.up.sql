@@ -0,0 +1,3 @@
+-- the reservations booked before are taken for a single guest
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS guests INTEGER NOT NULL DEFAULT 1 CHECK (guests >= 1);
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	db2 "github.com/imperatorofdwelling/Full-backend/internal/db"
+	"github.com/imperatorofdwelling/Full-backend/internal/repo/staysreviews"
+	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"log"
+)
+
+// The command recomputes the rating, the number of reviews and the rating score of every stay
+// from its reviews. It backfills the stays reviewed before the ratings were kept up to date
+// and is safe to run on a live database. The server counts the reviews revealed by their review window
+// closing on its own, run the command after the server was down for longer than a day.
+func main() {
+	cfg := config.LoadConfig()
+	db, err := db2.ConnectToBD(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	defer func(db *sql.DB) {
+		err := db.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}(db)
+
+	repo := staysreviews.Repo{Db: db}
+
+	count, err := repo.RecomputeStaysRatings(context.Background())
+	if err != nil {
+		log.Fatalf("recomputed %d stays: %v", count, err)
+	}
+
+	logrus.Infof("Ratings of %d stays recomputed", count)
+}
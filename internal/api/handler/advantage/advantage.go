@@ -24,8 +24,9 @@ const (
 )
 
 type Handler struct {
-	Svc interfaces.AdvantageService
-	Log *slog.Logger
+	Svc     interfaces.AdvantageService
+	RoleSvc interfaces.RoleService
+	Log     *slog.Logger
 }
 
 func (h *Handler) NewAdvantageHandler(r chi.Router) {
@@ -33,9 +34,13 @@ func (h *Handler) NewAdvantageHandler(r chi.Router) {
 		r.Group(func(r chi.Router) {
 			r.Use(mw.WithAuth)
 			r.Get("/all", h.GetAllAdvantages)
-			r.Patch("/{advantageId}", h.UpdateAdvantage)
-			r.Post("/create", h.CreateAdvantage)
-			r.Delete("/{advantageId}", h.RemoveAdvantage)
+
+			r.Group(func(r chi.Router) {
+				r.Use(mw.WithPermission(h.RoleSvc, "/advantages"))
+				r.Patch("/{advantageId}", h.UpdateAdvantage)
+				r.Post("/create", h.CreateAdvantage)
+				r.Delete("/{advantageId}", h.RemoveAdvantage)
+			})
 		})
 	})
 }
@@ -6,14 +6,18 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"github.com/dgrijalva/jwt-go/v4"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/auth"
 	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/auth"
 	modelPass "github.com/imperatorofdwelling/Full-backend/internal/domain/models/passwordOTP"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
@@ -22,8 +26,8 @@ import (
 	"github.com/imperatorofdwelling/Full-backend/pkg/validator"
 	"github.com/pkg/errors"
 	"log/slog"
+	"net"
 	"net/http"
-	"time"
 )
 
 type AuthHandler struct {
@@ -46,6 +50,21 @@ func (h *AuthHandler) NewAuthHandler(r chi.Router) {
 		r.Post("/confirm/email/otp/{otp}", h.ConfirmEmailOTP)
 		r.Post("/confirm/email/change/otp/{otp}", h.ConfirmEmailChangeOTP)
 	})
+
+	r.Route("/auth", func(r chi.Router) {
+		r.Post("/refresh", h.RefreshToken)
+		r.Post("/logout", h.Logout)
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithAuth)
+			r.Get("/sessions", h.GetSessions)
+			r.Delete("/sessions/{id}", h.RevokeSession)
+
+			r.Post("/api-keys", h.CreateAPIKey)
+			r.Get("/api-keys", h.GetAPIKeys)
+			r.Delete("/api-keys/{id}", h.RevokeAPIKey)
+		})
+	})
 }
 
 // Registration
@@ -114,14 +133,16 @@ func (h *AuthHandler) Registration(w http.ResponseWriter, r *http.Request) {
 // LoginUser
 //
 // @Summary Login an existing user
-// @Description Authenticates an existing user and returns a JWT token(claim USER_ID, ROLE_ID)
+// @Description Authenticates an existing user and starts the session on the device. The short-lived JWT access token(claim USER_ID, ROLE_ID, SID) and the refresh token are set as cookies
 // @Tags auth
 // @Accept  json
 // @Produce  json
 // @Param   request  body     model.Login  true  "Login"
 // @Success 200 {object} UUID
 // @Failure 401 {object} response.ResponseError
+// @Failure 403 {object} response.ResponseError "account_suspended or account_banned code"
 // @Failure 404 {object} response.ResponseError
+// @Failure 429 {object} response.ResponseError "too_many_attempts code, the wait is in the Retry-After header"
 // @Failure 400 {object} response.ResponseError
 // @Failure 500 {object} response.ResponseError
 // @Router /login [post]
@@ -132,7 +153,7 @@ func (h *AuthHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
-	cookie, err := r.Cookie("jwt-token")
+	_, err := r.Cookie(mw.AccessTokenCookie)
 	if err == nil {
 		responseApi.WriteError(w, r, http.StatusUnauthorized, errors.New("already logged in"))
 		return
@@ -145,7 +166,7 @@ func (h *AuthHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, userRoleID, err := h.Svc.Login(context.Background(), userCurrent)
+	userID, userRoleID, err := h.Svc.Login(r.Context(), userCurrent)
 	if err != nil {
 		if errors.Is(err, service.ErrNotFound) {
 			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(service.ErrNotFound))
@@ -155,32 +176,399 @@ func (h *AuthHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(service.ErrValid))
 			return
 		}
+		if errors.Is(err, service.ErrUserSuspended) {
+			responseApi.WriteErrorCode(w, r, http.StatusForbidden, user.SuspendedCode, err)
+			return
+		}
+		if errors.Is(err, service.ErrUserBanned) {
+			responseApi.WriteErrorCode(w, r, http.StatusForbidden, user.BannedCode, err)
+			return
+		}
+		if errors.Is(err, service.ErrTooManyAttempts) {
+			responseApi.WriteTooManyRequests(w, r, attempt.RetryAfter(err), attempt.LockedCode, err)
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(err))
 		return
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // token expires in 24 hours
-		"user_id": userID,
-		"role_id": userRoleID,
-	})
+	tokens, err := h.Svc.CreateSession(r.Context(), userID, userRoleID, deviceFromRequest(r))
+	if err != nil {
+		h.Log.Error("failed to create session", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	err = setSessionCookies(w, tokens)
+	if err != nil {
+		h.Log.Error("failed to generate token", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.New("failed to generate token")))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, userID.String())
+}
+
+// RefreshToken godoc
+//
+//	@Summary		Refresh access token
+//	@Description	Rotate the refresh token of the session and issue a new access token, both are set as cookies. The refresh token used twice revokes the whole session
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{string}	string	"user id"
+//	@Failure		401	{object}	response.ResponseError	"Invalid, expired or reused refresh token"
+//	@Failure		403	{object}	response.ResponseError	"account_suspended or account_banned code"
+//	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/auth/refresh [post]
+func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.RefreshToken"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	cookie, err := r.Cookie(mw.RefreshTokenCookie)
+	if err != nil {
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(service.ErrInvalidRefreshToken))
+		return
+	}
 
-	tokenString, err := token.SignedString([]byte("your-secret-key"))
+	tokens, err := h.Svc.RefreshSession(r.Context(), cookie.Value)
 	if err != nil {
-		http.Error(w, `{"error": "Failed to generate token"}`, http.StatusInternalServerError)
+		h.Log.Error("failed to refresh session", slogError.Err(err))
+		h.writeSessionError(w, r, err)
 		return
 	}
 
-	// Set token as a cookie
-	cookie = &http.Cookie{
-		Name:     "jwt-token",
-		Value:    tokenString,
-		Expires:  time.Now().Add(time.Hour * 24),
+	err = setSessionCookies(w, tokens)
+	if err != nil {
+		h.Log.Error("failed to generate token", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.New("failed to generate token")))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, tokens.Session.UserID.String())
+}
+
+// Logout godoc
+//
+//	@Summary		Logout
+//	@Description	End the session of the refresh token and remove the token cookies. The access token works until it expires
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{string}	string	"logged out"
+//	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/auth/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.Logout"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var refreshToken string
+	if cookie, err := r.Cookie(mw.RefreshTokenCookie); err == nil {
+		refreshToken = cookie.Value
+	}
+
+	err := h.Svc.Logout(r.Context(), refreshToken)
+	if err != nil {
+		h.Log.Error("failed to logout", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	clearSessionCookies(w)
+
+	responseApi.WriteJson(w, r, http.StatusOK, "logged out")
+}
+
+// GetSessions godoc
+//
+//	@Summary		Get sessions
+//	@Description	Get the active sessions of the logged in user on the devices, the last used first
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{array}		session.Session	"ok"
+//	@Failure		401	{object}	response.ResponseError	"Unauthorized"
+//	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/auth/sessions [get]
+func (h *AuthHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.GetSessions"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user id not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	sessions, err := h.Svc.GetSessions(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("failed to get sessions", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke session
+//	@Description	End the session of the logged in user on a device, its refresh token stops working
+//	@Tags			auth
+//	@Produce		json
+//	@Param			id	path		string	true	"session id"
+//	@Success		200	{string}	string	"session revoked"
+//	@Failure		400	{object}	response.ResponseError	"Invalid session id"
+//	@Failure		401	{object}	response.ResponseError	"Unauthorized"
+//	@Failure		404	{object}	response.ResponseError	"Session not found"
+//	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.RevokeSession"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user id not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	sessionID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse session id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.RevokeSession(r.Context(), userID, sessionID)
+	if err != nil {
+		h.Log.Error("failed to revoke session", slogError.Err(err))
+		h.writeSessionError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "session revoked")
+}
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create API key
+//	@Description	Create the API key of the logged in user for the integrations, it is sent as the bearer token of the Authorization header. The key is shown once in the response, only its prefix is kept visible
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		apikey.Entity	true	"API key"
+//	@Success		201		{object}	apikey.Created	"created"
+//	@Failure		400		{object}	response.ResponseError	"Invalid request"
+//	@Failure		401		{object}	response.ResponseError	"Unauthorized"
+//	@Failure		409		{object}	response.ResponseError	"API keys limit is reached"
+//	@Failure		500		{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/auth/api-keys [post]
+func (h *AuthHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.CreateAPIKey"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user id not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	var key apikey.Entity
+	if err := jsonReader.ReadJSON(w, r, &key); err != nil {
+		h.Log.Error("failed to decode request body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(errors.New("failed to decode request body")))
+		return
+	}
+
+	created, err := h.Svc.CreateAPIKey(r.Context(), userID, &key)
+	if err != nil {
+		h.Log.Error("failed to create api key", slogError.Err(err))
+		h.writeAPIKeyError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, created)
+}
+
+// GetAPIKeys godoc
+//
+//	@Summary		Get API keys
+//	@Description	Get the API keys of the logged in user that are not revoked, the newest first
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{array}		apikey.APIKey	"ok"
+//	@Failure		401	{object}	response.ResponseError	"Unauthorized"
+//	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/auth/api-keys [get]
+func (h *AuthHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.GetAPIKeys"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user id not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	keys, err := h.Svc.GetAPIKeys(r.Context(), userID)
+	if err != nil {
+		h.Log.Error("failed to get api keys", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, keys)
+}
+
+// RevokeAPIKey godoc
+//
+//	@Summary		Revoke API key
+//	@Description	Revoke the API key of the logged in user, the requests with it are rejected at once
+//	@Tags			auth
+//	@Produce		json
+//	@Param			id	path		string	true	"api key id"
+//	@Success		200	{string}	string	"api key revoked"
+//	@Failure		400	{object}	response.ResponseError	"Invalid api key id"
+//	@Failure		401	{object}	response.ResponseError	"Unauthorized"
+//	@Failure		404	{object}	response.ResponseError	"API key not found"
+//	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/auth/api-keys/{id} [delete]
+func (h *AuthHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.auth.RevokeAPIKey"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user id not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse api key id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.RevokeAPIKey(r.Context(), userID, id)
+	if err != nil {
+		h.Log.Error("failed to revoke api key", slogError.Err(err))
+		h.writeAPIKeyError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "api key revoked")
+}
+
+func (h *AuthHandler) writeAPIKeyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrAPIKeyLimit):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	case errors.Is(err, service.ErrAPIKeyNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
+
+func (h *AuthHandler) writeSessionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrRefreshTokenReused):
+		clearSessionCookies(w)
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(err))
+	case errors.Is(err, service.ErrInvalidRefreshToken):
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(err))
+	case errors.Is(err, service.ErrUserSuspended):
+		responseApi.WriteErrorCode(w, r, http.StatusForbidden, user.SuspendedCode, err)
+	case errors.Is(err, service.ErrUserBanned):
+		responseApi.WriteErrorCode(w, r, http.StatusForbidden, user.BannedCode, err)
+	case errors.Is(err, service.ErrSessionNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
+
+// setSessionCookies sets the new access token and the refresh token of the session
+func setSessionCookies(w http.ResponseWriter, tokens *session.Tokens) error {
+	accessToken, expiresAt, err := mw.NewAccessToken(tokens.Session.UserID, tokens.Session.RoleID, tokens.Session.ID)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     mw.AccessTokenCookie,
+		Value:    accessToken,
+		Path:     "/",
+		Expires:  expiresAt,
 		HttpOnly: true,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     mw.RefreshTokenCookie,
+		Value:    tokens.RefreshToken,
+		Path:     "/",
+		Expires:  tokens.Session.ExpiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	return nil
+}
+
+func clearSessionCookies(w http.ResponseWriter) {
+	for _, name := range []string{mw.AccessTokenCookie, mw.RefreshTokenCookie} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+		})
 	}
-	http.SetCookie(w, cookie)
+}
 
-	responseApi.WriteJson(w, r, http.StatusOK, userID.String())
+// deviceFromRequest describes the device of the new session
+func deviceFromRequest(r *http.Request) session.Device {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return session.Device{UserAgent: r.UserAgent(), IP: ip}
 }
 
 // ConfirmEmailOTP godoc
@@ -194,6 +582,7 @@ func (h *AuthHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 //	@Success		200	{string}	string	"OTP confirmed successfully!"
 //	@Failure		400	{object}	response.ResponseError	"Bad Request - invalid OTP"
 //	@Failure		401	{object}	response.ResponseError	"Unauthorized - user not logged in"
+//	@Failure		429	{object}	response.ResponseError	"too_many_attempts code, the OTP is invalidated when the attempts get locked"
 //	@Failure		500	{object}	response.ResponseError	"Internal Server Error - could not verify OTP"
 //	@Router			/confirm/email/otp/{otp} [post]
 func (h *AuthHandler) ConfirmEmailOTP(w http.ResponseWriter, r *http.Request) {
@@ -213,10 +602,10 @@ func (h *AuthHandler) ConfirmEmailOTP(w http.ResponseWriter, r *http.Request) {
 
 	otp := chi.URLParam(r, "otp")
 
-	err := h.Svc.CheckEmailOTP(context.Background(), userID, otp)
+	err := h.Svc.CheckEmailOTP(r.Context(), userID, otp)
 	if err != nil {
 		h.Log.Error("failed to check otp", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.Wrap(err, "could not check otp")))
+		h.writeOTPError(w, r, err)
 		return
 	}
 
@@ -233,6 +622,7 @@ func (h *AuthHandler) ConfirmEmailOTP(w http.ResponseWriter, r *http.Request) {
 //	 @Param   		request  body     modelPass.PasswordOTP  true  "Request body with email and otp"
 //		@Success		200		{string}	string	"OTP confirmed successfully!"
 //		@Failure		400		{object}	response.ResponseError	"Bad Request - invalid OTP or missing fields"
+//		@Failure		429		{object}	response.ResponseError	"too_many_attempts code, the OTP is invalidated when the attempts get locked"
 //		@Failure		500		{object}	response.ResponseError	"Internal Server Error - could not verify OTP"
 //		@Router			/confirm/password/otp [post]
 func (h *AuthHandler) ConfirmPasswordOTP(w http.ResponseWriter, r *http.Request) {
@@ -256,10 +646,10 @@ func (h *AuthHandler) ConfirmPasswordOTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.Svc.CheckPasswordOTP(context.Background(), req.Email, req.OTP)
+	err := h.Svc.CheckPasswordOTP(r.Context(), req.Email, req.OTP)
 	if err != nil {
 		h.Log.Error("failed to check otp", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.Wrap(err, "could not check otp")))
+		h.writeOTPError(w, r, err)
 		return
 	}
 
@@ -277,6 +667,7 @@ func (h *AuthHandler) ConfirmPasswordOTP(w http.ResponseWriter, r *http.Request)
 //	@Success		200	{string}	string	"OTP confirmed successfully!"
 //	@Failure		400	{object}	response.ResponseError	"Bad Request - invalid OTP"
 //	@Failure		401	{object}	response.ResponseError	"Unauthorized - user not logged in"
+//	@Failure		429	{object}	response.ResponseError	"too_many_attempts code, the OTP is invalidated when the attempts get locked"
 //	@Failure		500	{object}	response.ResponseError	"Internal Server Error - could not verify OTP"
 //	@Router			/confirm/email/change/otp/{otp} [post]
 func (h *AuthHandler) ConfirmEmailChangeOTP(w http.ResponseWriter, r *http.Request) {
@@ -296,13 +687,25 @@ func (h *AuthHandler) ConfirmEmailChangeOTP(w http.ResponseWriter, r *http.Reque
 
 	otp := chi.URLParam(r, "otp")
 
-	err := h.Svc.CheckEmailChangeOTP(context.Background(), userID, otp)
+	err := h.Svc.CheckEmailChangeOTP(r.Context(), userID, otp)
 
 	if err != nil {
 		h.Log.Error("failed to check otp", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.Wrap(err, "could not check otp")))
+		h.writeOTPError(w, r, err)
 		return
 	}
 
 	responseApi.WriteJson(w, r, http.StatusOK, "otp confirmed!")
 }
+
+// writeOTPError answers the failed OTP check, the locked attempts tell the client how long to wait
+func (h *AuthHandler) writeOTPError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrTooManyAttempts):
+		responseApi.WriteTooManyRequests(w, r, attempt.RetryAfter(err), attempt.LockedCode, err)
+	case errors.Is(err, service.ErrInvalidOTP):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.Wrap(err, "could not check otp")))
+	}
+}
@@ -2,12 +2,17 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/dgrijalva/jwt-go/v4"
 	"github.com/go-chi/chi/v5"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/auth"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
@@ -223,13 +228,20 @@ func TestAuthHandler_LoginUser_Success(t *testing.T) {
 
 		svc.On("Login", mock.Anything, mock.Anything).Return(id, 1, nil)
 
+		sessionID, _ := uuid.NewV4()
+		svc.On("CreateSession", mock.Anything, id, 1, mock.Anything).Return(&session.Tokens{
+			Session:      session.Session{ID: sessionID, UserID: id, RoleID: 1, ExpiresAt: time.Now().Add(session.RefreshTokenTTL)},
+			RefreshToken: "refresh",
+		}, nil)
+
 		router.ServeHTTP(r, req)
 
 		assert.Equal(t, http.StatusOK, r.Code)
 
 		cookies := r.Result().Cookies()
-		assert.Len(t, cookies, 1)
+		assert.Len(t, cookies, 2)
 		assert.Equal(t, "jwt-token", cookies[0].Name)
+		assert.Equal(t, "refresh-token", cookies[1].Name)
 	})
 }
 
@@ -696,3 +708,417 @@ func TestAuthHandler_ConfirmEmailChangeOTP_SVC_Success(t *testing.T) {
 	})
 
 }
+
+func TestAuthHandler_LoginUser_Blocked(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/login", hdl.LoginUser)
+
+	payload := `{"email": "testuser@example.com", "password": "password123"}`
+
+	t.Run("should return suspended error code", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(payload))
+
+		svc.On("Login", mock.Anything, mock.Anything).Return(uuid.Nil, -1, fmt.Errorf("service.auth.Login: %w: spam", service.ErrUserSuspended)).Once()
+
+		router.ServeHTTP(r, req)
+
+		var body map[string]string
+		_ = json.Unmarshal(r.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+		assert.Equal(t, user.SuspendedCode, body["code"])
+	})
+
+	t.Run("should return banned error code", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(payload))
+
+		svc.On("Login", mock.Anything, mock.Anything).Return(uuid.Nil, -1, fmt.Errorf("service.auth.Login: %w: fraud", service.ErrUserBanned)).Once()
+
+		router.ServeHTTP(r, req)
+
+		var body map[string]string
+		_ = json.Unmarshal(r.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+		assert.Equal(t, user.BannedCode, body["code"])
+	})
+}
+
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/auth/refresh", hdl.RefreshToken)
+
+	userID, _ := uuid.NewV4()
+	sessionID, _ := uuid.NewV4()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: mw.RefreshTokenCookie, Value: "refresh"})
+		return req
+	}
+
+	t.Run("should rotate the tokens", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RefreshSession", mock.Anything, "refresh").Return(&session.Tokens{
+			Session:      session.Session{ID: sessionID, UserID: userID, RoleID: 1, ExpiresAt: time.Now().Add(session.RefreshTokenTTL)},
+			RefreshToken: "next",
+		}, nil).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		cookies := r.Result().Cookies()
+		assert.Len(t, cookies, 2)
+		assert.Equal(t, mw.AccessTokenCookie, cookies[0].Name)
+		assert.Equal(t, "next", cookies[1].Value)
+	})
+
+	t.Run("should be unauthorized without the refresh token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("should clear the cookies of the reused token", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RefreshSession", mock.Anything, "refresh").Return(nil, fmt.Errorf("service.auth.RefreshSession: %w", service.ErrRefreshTokenReused)).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		cookies := r.Result().Cookies()
+		assert.Len(t, cookies, 2)
+		assert.Equal(t, -1, cookies[1].MaxAge)
+	})
+
+	t.Run("should be forbidden for the banned user", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RefreshSession", mock.Anything, "refresh").Return(nil, fmt.Errorf("service.auth.RefreshSession: %w: fraud", service.ErrUserBanned)).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/auth/logout", hdl.Logout)
+
+	t.Run("should revoke the session and clear the cookies", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+		req.AddCookie(&http.Cookie{Name: mw.RefreshTokenCookie, Value: "refresh"})
+
+		svc.On("Logout", mock.Anything, "refresh").Return(nil).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, r.Result().Cookies(), 2)
+	})
+
+	t.Run("should be internal error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+
+		svc.On("Logout", mock.Anything, "").Return(errors.New("db error")).Once()
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestAuthHandler_RevokeSession(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Delete("/auth/sessions/{id}", hdl.RevokeSession)
+
+	userID, _ := uuid.NewV4()
+	sessionID, _ := uuid.NewV4()
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/auth/sessions/"+id, nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RevokeSession", mock.Anything, userID.String(), sessionID).Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(sessionID.String()))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be not found error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RevokeSession", mock.Anything, userID.String(), sessionID).Return(service.ErrSessionNotFound).Once()
+
+		router.ServeHTTP(r, newRequest(sessionID.String()))
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("should be error parsing id", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestAuthHandler_CreateAPIKey(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/auth/api-keys", hdl.CreateAPIKey)
+
+	userID, _ := uuid.NewV4()
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/auth/api-keys", strings.NewReader(body))
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	body := `{"name":"Listing sync","scopes":["stays:read"]}`
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		created := &apikey.Created{
+			APIKey: apikey.APIKey{UserID: userID, Name: "Listing sync", Scopes: []apikey.Scope{apikey.ScopeStaysRead}},
+			Key:    apikey.Prefix + "secret",
+		}
+
+		svc.On("CreateAPIKey", mock.Anything, userID.String(), mock.AnythingOfType("*apikey.Entity")).Return(created, nil).Once()
+
+		router.ServeHTTP(r, newRequest(body))
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+		assert.Contains(t, r.Body.String(), created.Key)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateAPIKey", mock.Anything, userID.String(), mock.AnythingOfType("*apikey.Entity")).
+			Return(nil, fmt.Errorf("%w: unknown scope", service.ErrValid)).Once()
+
+		router.ServeHTTP(r, newRequest(body))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be limit error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateAPIKey", mock.Anything, userID.String(), mock.AnythingOfType("*apikey.Entity")).
+			Return(nil, service.ErrAPIKeyLimit).Once()
+
+		router.ServeHTTP(r, newRequest(body))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("{"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestAuthHandler_RevokeAPIKey(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Delete("/auth/api-keys/{id}", hdl.RevokeAPIKey)
+
+	userID, _ := uuid.NewV4()
+	keyID, _ := uuid.NewV4()
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/auth/api-keys/"+id, nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RevokeAPIKey", mock.Anything, userID.String(), keyID).Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(keyID.String()))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be not found error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RevokeAPIKey", mock.Anything, userID.String(), keyID).Return(service.ErrAPIKeyNotFound).Once()
+
+		router.ServeHTTP(r, newRequest(keyID.String()))
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("should be error parsing id", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestAuthHandler_LoginUser_TooManyAttempts(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/login", hdl.LoginUser)
+
+	payload := `{"email": "testuser@example.com", "password": "password123"}`
+
+	t.Run("should return retry after", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(payload))
+
+		locked := &attempt.LockedError{RetryAfter: 90 * time.Second}
+		svc.On("Login", mock.Anything, mock.Anything).
+			Return(uuid.Nil, -1, fmt.Errorf("service.auth.Login: %w: %w", service.ErrTooManyAttempts, locked)).Once()
+
+		router.ServeHTTP(r, req)
+
+		var body map[string]string
+		_ = json.Unmarshal(r.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+		assert.Equal(t, attempt.LockedCode, body["code"])
+		assert.Equal(t, "90", r.Header().Get("Retry-After"))
+	})
+}
+
+func TestAuthHandler_ConfirmPasswordOTP_Attempts(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := &mocks.AuthService{}
+	hdl := AuthHandler{
+		Log: log,
+		Svc: svc,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/otp", hdl.ConfirmPasswordOTP)
+
+	newRequest := func() *http.Request {
+		body := `{"email": "test@example.com", "otp": "123456"}`
+		return httptest.NewRequest(http.MethodPost, "/otp", strings.NewReader(body))
+	}
+
+	t.Run("should return invalid otp error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CheckPasswordOTP", mock.Anything, "test@example.com", "123456").
+			Return(fmt.Errorf("service.auth.CheckPasswordOTP: %w", service.ErrInvalidOTP)).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should return too many attempts error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		locked := &attempt.LockedError{RetryAfter: time.Minute}
+		svc.On("CheckPasswordOTP", mock.Anything, "test@example.com", "123456").
+			Return(fmt.Errorf("%w, it is invalidated: %w: %w", service.ErrInvalidOTP, service.ErrTooManyAttempts, locked)).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		var body map[string]string
+		_ = json.Unmarshal(r.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+		assert.Equal(t, attempt.LockedCode, body["code"])
+		assert.Equal(t, "60", r.Header().Get("Retry-After"))
+	})
+}
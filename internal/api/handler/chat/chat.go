@@ -1,29 +1,38 @@
 package chat
 
 import (
-	"fmt"
-	"github.com/dgrijalva/jwt-go/v4"
+	"encoding/json"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
-	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/connectionmanager"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/internal/service/file"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
+// attachmentFormOverhead is the room left in the attachment request for the rest of the multipart form
+const attachmentFormOverhead = 64 * 1024
+
+// upgrader is copied by every connection, HandleWebSocket sets CheckOrigin of the handler
 var upgrader = websocket.Upgrader{
-	CheckOrigin:     func(r *http.Request) bool { return true },
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
 	// Allows you to determine whether the server should compress messages.
@@ -34,6 +43,8 @@ type Handler struct {
 	Svc interfaces.ChatService
 	Log *slog.Logger
 	Cm  *connectionmanager.ConnectionManager
+	// Origins are the web origins allowed to open the websocket besides the origin of the API itself
+	Origins []string
 }
 
 func (h *Handler) NewChatHandler(r chi.Router) {
@@ -42,6 +53,9 @@ func (h *Handler) NewChatHandler(r chi.Router) {
 		r.Get("/", h.GetChatsByUserID)
 		r.Get("/{chatId}", h.GetMessagesByChatID)
 		r.Post("/{ownerId}", h.SendMessage)
+		r.Post("/{chatId}/read", h.MarkRead)
+		r.Post("/{chatId}/attachments", h.SendAttachment)
+		r.Get("/{chatId}/attachments/{attachmentId}", h.GetAttachment)
 		r.Handle("/ws/{chatId}", http.HandlerFunc(h.HandleWebSocket))
 	})
 }
@@ -49,11 +63,11 @@ func (h *Handler) NewChatHandler(r chi.Router) {
 // GetChatsByUserID godoc
 //
 //	@Summary		Get Chats
-//	@Description	Retrieve all chats for a user by their user ID
+//	@Description	Retrieve all chats for a user by their user ID with the last message and the unread count, the latest first
 //	@Tags			chats
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{array}		chat.Chat	"List of chats for the user"
+//	@Success		200	{array}		chat.Overview	"List of chats for the user"
 //	@Failure		401	{object}	response.ResponseError	"Unauthorized"
 //	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
 //	@Router			/chat [get]
@@ -85,12 +99,17 @@ func (h *Handler) GetChatsByUserID(w http.ResponseWriter, r *http.Request) {
 // GetMessagesByChatID godoc
 //
 //	@Summary		Get Messages by Chat ID
-//	@Description	Retrieve all messages for a chat by its chat ID
+//	@Description	Retrieve messages of a chat by its chat ID page by page, oldest first. Only the participants may read them
 //	@Tags			chats
 //	@Accept			json
 //	@Produce		json
 //	@Param			chatId	path		string	true	"The ID of the chat"
-//	@Success		200	{object}	message.Entity	"List of messages for the chat"
+//	@Param			limit	query		int		false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string	false	"next_cursor of the previous page"
+//	@Success		200	{object}	api.Page[message.Message]	"List of messages for the chat"
+//	@Failure		400	{object}	response.ResponseError	"Invalid page request"
+//	@Failure		401	{object}	response.ResponseError	"Unauthorized"
+//	@Failure		403	{object}	response.ResponseError	"User is not a participant of the chat"
 //	@Failure		404	{object}	response.ResponseError	"Chat not found"
 //	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
 //	@Router			/chat/{chatId} [get]
@@ -102,16 +121,37 @@ func (h *Handler) GetMessagesByChatID(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("user not logged in")))
+		return
+	}
+
 	id := chi.URLParam(r, "chatId")
 
-	messages, err := h.Svc.GetMessagesByChatID(context.Background(), id)
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	_, err = h.Svc.GetParticipantChat(r.Context(), id, userID)
+	if err != nil {
+		h.Log.Error("failed to get chat of participant", slogError.Err(err))
+		writeChatError(w, r, err)
+		return
+	}
+
+	messages, err := h.Svc.GetMessagesByChatID(r.Context(), id, page)
 	if err != nil {
 		h.Log.Error("failed to get messages by chatId", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
 
-	responseApi.WriteJson(w, r, http.StatusOK, map[string]interface{}{"messages": messages})
+	responseApi.WriteJson(w, r, http.StatusOK, messages)
 }
 
 // SendMessage godoc
@@ -163,119 +203,432 @@ func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	responseApi.WriteJson(w, r, http.StatusOK, "Message sent!")
 }
 
+type markReadRequest struct {
+	MessageID string `json:"message_id"`
+} // @name MarkReadRequest
+
+// MarkRead godoc
+//
+//	@Summary		Mark messages as read
+//	@Description	Moves the read marker of the user to the message, the messages up to it are read. The marker never moves back.
+//	@Description	The other participants get the read frame over the websocket.
+//	@Tags			chats
+//	@Accept			json
+//	@Produce		json
+//	@Param			chatId	path		string				true	"The ID of the chat"
+//	@Param			request	body		markReadRequest		true	"The last read message"
+//	@Success		200		{object}	chat.ReadMarker		"Read marker of the user"
+//	@Failure		400		{object}	response.ResponseError	"Bad Request"
+//	@Failure		401		{object}	response.ResponseError	"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError	"User is not a participant of the chat"
+//	@Failure		404		{object}	response.ResponseError	"Chat or message not found"
+//	@Failure		500		{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/chat/{chatId}/read [post]
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.chat.MarkRead"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("user not logged in")))
+		return
+	}
+
+	var req markReadRequest
+	err := render.DecodeJSON(r.Body, &req)
+	if err != nil {
+		h.Log.Error("failed to decode request body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	if req.MessageID == "" {
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(errors.New("message_id is required")))
+		return
+	}
+
+	marker, err := h.Svc.MarkRead(r.Context(), chi.URLParam(r, "chatId"), userID, req.MessageID)
+	if err != nil && !errors.Is(err, service.ErrMarkerNotDelivered) {
+		h.Log.Error("failed to mark messages as read", slogError.Err(err))
+		writeChatError(w, r, err)
+		return
+	}
+	if err != nil {
+		h.Log.Warn("messages marked as read without notification", slogError.Err(err))
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, marker)
+}
+
+// SendAttachment godoc
+//
+//	@Summary		Send an attachment
+//	@Description	Sends the jpeg or png image or the pdf up to 10MB to the chat with the optional text.
+//	@Description	The message carries the attachment metadata, the other participants get it over the websocket.
+//	@Tags			chats
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			chatId	path		string	true	"The ID of the chat"
+//	@Param			file	formData	file	true	"Image or pdf"
+//	@Param			text	formData	string	false	"Text of the message"
+//	@Success		201		{object}	message.Message			"Sent message"
+//	@Failure		400		{object}	response.ResponseError	"Bad Request"
+//	@Failure		401		{object}	response.ResponseError	"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError	"User is not a participant of the chat"
+//	@Failure		404		{object}	response.ResponseError	"Chat not found"
+//	@Failure		500		{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/chat/{chatId}/attachments [post]
+func (h *Handler) SendAttachment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.chat.SendAttachment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("user not logged in")))
+		return
+	}
+
+	// Restrict request body size, the form adds the boundaries, the part headers and the text to the file
+	r.Body = http.MaxBytesReader(w, r.Body, file.MaxAttachmentMemorySize+attachmentFormOverhead)
+
+	err := r.ParseMultipartForm(file.MaxAttachmentMemorySize)
+	if err != nil {
+		h.Log.Error("failed to parse form", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	attachment, hdl, err := r.FormFile("file")
+	if err != nil {
+		h.Log.Error("failed to parse form", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+	defer attachment.Close()
+
+	if hdl.Size > file.MaxAttachmentMemorySize {
+		h.Log.Error("attachment is too large", slog.Int64("size", hdl.Size))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(service.ErrAttachmentTooLarge))
+		return
+	}
+
+	data, err := io.ReadAll(attachment)
+	if err != nil {
+		h.Log.Error("failed to read attachment", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	msg, err := h.Svc.SendAttachment(r.Context(), chi.URLParam(r, "chatId"), userID, hdl.Filename, r.FormValue("text"), data)
+	if err != nil && !errors.Is(err, service.ErrMessageNotDelivered) {
+		h.Log.Error("failed to send attachment", slogError.Err(err))
+		writeChatError(w, r, err)
+		return
+	}
+	if err != nil {
+		h.Log.Warn("attachment sent without notification", slogError.Err(err))
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, msg)
+}
+
+// GetAttachment godoc
+//
+//	@Summary		Download an attachment
+//	@Description	Downloads the attachment of the chat, only the participants may download it
+//	@Tags			chats
+//	@Produce		image/jpeg,image/png,application/pdf
+//	@Param			chatId			path		string	true	"The ID of the chat"
+//	@Param			attachmentId	path		string	true	"The ID of the attachment"
+//	@Success		200				{file}		binary					"Attachment"
+//	@Failure		401				{object}	response.ResponseError	"Unauthorized"
+//	@Failure		403				{object}	response.ResponseError	"User is not a participant of the chat"
+//	@Failure		404				{object}	response.ResponseError	"Chat or attachment not found"
+//	@Failure		500				{object}	response.ResponseError	"Internal Server Error"
+//	@Router			/chat/{chatId}/attachments/{attachmentId} [get]
+func (h *Handler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.chat.GetAttachment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("user not logged in")))
+		return
+	}
+
+	att, f, err := h.Svc.OpenAttachment(r.Context(), chi.URLParam(r, "chatId"), userID, chi.URLParam(r, "attachmentId"))
+	if err != nil {
+		h.Log.Error("failed to open attachment", slogError.Err(err))
+		writeChatError(w, r, err)
+		return
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		h.Log.Error("failed to stat attachment", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", att.MimeType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": att.Name}))
+
+	http.ServeContent(w, r, att.Name, stat.ModTime(), f)
+}
+
+func writeChatError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidAttachment), errors.Is(err, service.ErrAttachmentTooLarge), errors.Is(err, service.ErrMessageTooLong):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrChatNotFound), errors.Is(err, service.ErrMessageNotFound), errors.Is(err, service.ErrAttachmentNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrNotChatParticipant):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
+
 // HandleWebSocket godoc
 //
 //	@Summary		Establishes a WebSocket connection for chat
-//	@Description	Handles WebSocket connections, retrieves chat history, and supports real-time messaging.
+//	@Description	Handles WebSocket connections of the chat participants, retrieves chat history, and supports real-time messaging.
+//	@Description	A user may connect from several devices, a message is delivered to every other connection in the chat.
+//	@Description	The user is authorized like on the other routes, by the access token cookie or the bearer token.
+//	@Description	Every websocket message is a JSON ChatFrame with the type message, typing, read, history, escalation or error.
+//	@Description	The client sends {"type":"message","text":"..."}, {"type":"typing"} and {"type":"read","message_id":"..."}.
+//	@Description	The server sends one page of the history after the cursor and the read markers of the chat as message and read frames after connecting,
+//	@Description	the history frame with next_cursor follows the page when there are more messages to load with GET /chat/{chatId}.
+//	@Description	Then the frames of the other connections come. A typing indicator lasts 5 seconds unless the typing frame is repeated.
 //	@Tags			webSocket
 //	@Accept			json
 //	@Produce		json
-//	@Param			token	query		string	true	"JWT Token for authentication"
 //	@Param			chatId	path		string	true	"Chat ID to retrieve messages from"
+//	@Param			limit	query		int		false	"history page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string	false	"next_cursor of the history frame or of the messages page"
 //	@Success		101	{string}	string	"WebSocket connection established"
-//	@Failure		400	{object}	response.ResponseError	"Bad Request - Missing or invalid token"
-//	@Failure		401	{object}	response.ResponseError	"Unauthorized - Invalid token or claims"
+//	@Failure		400	{object}	response.ResponseError	"Bad Request - Invalid page request or websocket handshake"
+//	@Failure		401	{object}	response.ResponseError	"Unauthorized"
+//	@Failure		403	{object}	response.ResponseError	"User is not a participant of the chat"
+//	@Failure		404	{object}	response.ResponseError	"Chat not found"
 //	@Failure		500	{object}	response.ResponseError	"Internal Server Error"
 //	@Router			/chat/ws/{chatId} [get]
 func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	// Getting and checking token
-	tokenString := r.URL.Query().Get("token")
-	if tokenString == "" {
-		http.Error(w, "Missing token", http.StatusUnauthorized)
-		return
-	}
+	const op = "handler.chat.HandleWebSocket"
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte("your-secret-key"), nil
-	})
-	if err != nil || !token.Valid {
-		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("invalid token")))
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	ownerID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("user not logged in")))
 		return
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("invalid token claims")))
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		h.Log.Error("invalid user ID", slog.String("owner_id", ownerID), slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("invalid user ID")))
 		return
 	}
 
-	ownerID, ok := claims["user_id"].(string)
-	if !ok {
-		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("invalid user ID in token")))
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
 		return
 	}
 
-	if ownerID == "" {
-		h.Log.Error("ownerId or userId missing", slog.String("owner_id", ownerID))
-		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(errors.New("ownerId or userId missing")))
+	// Only the participants join the room of the chat
+	chatId := chi.URLParam(r, "chatId")
+
+	_, err = h.Svc.GetParticipantChat(r.Context(), chatId, ownerID)
+	if err != nil {
+		h.Log.Error("failed to get chat of the participant", slog.String("chat_id", chatId), slogError.Err(err))
+		writeChatError(w, r, err)
 		return
 	}
 
 	// Upgrading websocket
-	conn, err := upgrader.Upgrade(w, r, nil)
+	wsUpgrader := upgrader
+	wsUpgrader.CheckOrigin = h.checkOrigin
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
 	if err != nil {
 		h.Log.Error("WebSocket upgrade failed", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("connection upgrade failed")))
 		return
 	}
 	defer conn.Close()
 
-	// Connection manager
-	h.Cm.AddConnection(ownerID, conn)
-	h.Log.Info("Owner added to Connection Manager", slog.String("owner_id", ownerID))
-	h.Cm.AllConnections()
+	// Joining the room before the history is sent, the messages arriving meanwhile wait in the queue of the client
+	client := connectionmanager.NewClient(conn, ownerID, chatId)
+	h.Cm.Join(client)
+	defer h.Cm.Leave(client)
+	h.Log.Info("Owner joined the chat", slog.String("owner_id", ownerID), slog.String("chat_id", chatId))
 
-	// Getting message history
-	chatId := chi.URLParam(r, "chatId")
-	h.Log.Info("id", "id", chatId)
-	messages, err := h.Svc.GetMessagesByChatID(context.Background(), chatId)
+	// Getting one page of the message history after the cursor, it is written before the write pump starts.
+	// The client loads the rest with GET /chat/{chatId} from the cursor of the history frame.
+	messages, err := h.Svc.GetMessagesByChatID(r.Context(), chatId, page)
 	if err != nil {
 		h.Log.Error("Error while getting messages by chat id", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("failed to fetch messages")))
+		client.Close()
 		return
 	}
 
-	for _, msg := range messages {
-		msgData := []byte(msg.Text)
-		if err := conn.WriteMessage(websocket.TextMessage, msgData); err != nil {
+	for _, msg := range messages.Items {
+		if err := conn.WriteJSON(chat.MessageFrame(msg)); err != nil {
 			h.Log.Error("Failed to send message history", slogError.Err(err))
-			h.Cm.RemoveConnection(ownerID)
+			client.Close()
 			return
 		}
 	}
 
-	// Infinite loop for websocket
-	for {
-		_, messageData, err := conn.ReadMessage()
-		if err != nil {
-			h.Log.Warn("Owner disconnected", slog.String("owner_id", ownerID), slogError.Err(err))
-			h.Cm.RemoveConnection(ownerID)
-			break
+	if messages.NextCursor != "" {
+		if err := conn.WriteJSON(chat.HistoryFrame(chatId, messages.NextCursor)); err != nil {
+			h.Log.Error("Failed to send message history", slogError.Err(err))
+			client.Close()
+			return
+		}
+	}
+
+	// Getting the read markers of the participants
+	markers, err := h.Svc.GetReadMarkers(r.Context(), chatId)
+	if err != nil {
+		h.Log.Error("Error while getting read markers", slogError.Err(err))
+		client.Close()
+		return
+	}
+
+	for _, marker := range markers {
+		if err := conn.WriteJSON(chat.ReadFrame(marker)); err != nil {
+			h.Log.Error("Failed to send read markers", slogError.Err(err))
+			client.Close()
+			return
 		}
+	}
 
-		messageText := string(messageData)
-		h.Log.Info("Message received", slog.String("owner_id", ownerID), slog.String("message", messageText))
+	go client.WritePump()
 
-		// Creating a message entity
-		msg := message.Entity{
-			UserID: uuid.Must(uuid.Parse(ownerID)),
-			Text:   messageText,
-			Media:  nil,
+	// Reading the frames until the owner disconnects, the frames are delivered to the other connections in the chat on every instance
+	err = client.ReadPump(func(data []byte) {
+		var frame chat.Frame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			h.sendFrame(client, chat.ErrorFrame("invalid frame"))
+			return
+		}
+
+		var err error
+		ctx := chat.WithConnectionID(context.Background(), client.ID)
+
+		switch frame.Type {
+		case chat.FrameMessage:
+			if frame.Text == "" {
+				h.sendFrame(client, chat.ErrorFrame("message text is empty"))
+				return
+			}
+
+			msg := message.Entity{
+				UserID: ownerUUID,
+				Text:   frame.Text,
+				Media:  nil,
+			}
+
+			err = h.Svc.SendMessageInChat(ctx, chatId, ownerID, msg)
+		case chat.FrameTyping:
+			err = h.Svc.SendTyping(ctx, chatId, ownerID)
+		case chat.FrameRead:
+			_, err = h.Svc.MarkRead(ctx, chatId, ownerID, frame.MessageID)
+		default:
+			h.sendFrame(client, chat.ErrorFrame("unknown frame type"))
+			return
 		}
 
-		h.Log.Info("Created message", slog.String("user_id", msg.UserID.String()), slog.String("message_text", msg.Text))
+		if errors.Is(err, service.ErrMessageNotDelivered) || errors.Is(err, service.ErrMarkerNotDelivered) {
+			h.Log.Warn("Frame handled without notification", slog.String("chat_id", chatId), slog.String("type", string(frame.Type)), slogError.Err(err))
+			return
+		}
 
-		// Saving the message to the database
-		err = h.Svc.SendMessageInChat(context.Background(), chatId, ownerID, msg)
 		if err != nil {
-			h.Log.Error("Failed to save message in chat", slog.String("chat_id", chatId), slogError.Err(err))
-			continue
+			h.Log.Error("Failed to handle frame", slog.String("chat_id", chatId), slog.String("type", string(frame.Type)), slogError.Err(err))
+			if errors.Is(err, service.ErrMessageNotFound) {
+				h.sendFrame(client, chat.ErrorFrame(service.ErrMessageNotFound.Error()))
+				return
+			}
+			if errors.Is(err, service.ErrNotChatParticipant) {
+				h.finishFrame(client, chat.ErrorFrame(service.ErrNotChatParticipant.Error()))
+				return
+			}
+			h.sendFrame(client, chat.ErrorFrame("failed to handle frame"))
 		}
+	})
+	h.Log.Warn("Owner disconnected", slog.String("owner_id", ownerID), slogError.Err(err))
+}
+
+// checkOrigin lets through the clients without the Origin header, the same origin and the configured origins,
+// so a foreign page can't open the websocket with the cookie of the user
+func (h *Handler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
 
-		// Broadcasting the message to all clients
-		h.Cm.BroadcastMessage(ownerID, messageData)
+	for _, allowed := range h.Origins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
 	}
+
+	return false
+}
+
+// sendFrame queues the frame for the write pump of the client
+func (h *Handler) sendFrame(client *connectionmanager.Client, frame chat.Frame) {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		h.Log.Error("Failed to encode frame", slogError.Err(err))
+		return
+	}
+
+	client.Send(data)
+}
+
+// finishFrame sends the last frame to the client and closes its connection
+func (h *Handler) finishFrame(client *connectionmanager.Client, frame chat.Frame) {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		h.Log.Error("Failed to encode frame", slogError.Err(err))
+		client.Close()
+		return
+	}
+
+	client.Finish(data)
 }
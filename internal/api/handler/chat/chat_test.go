@@ -1,22 +1,33 @@
 package chat
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/dgrijalva/jwt-go/v4"
 	"github.com/go-chi/chi/v5"
 	"github.com/gofrs/uuid"
+	guuid "github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/connectionmanager"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/repo/chatpubsub"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/net/context"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -153,16 +164,40 @@ func TestChatHandler_GetMessagesByChatID_ChatID_Error(t *testing.T) {
 	}
 	router := chi.NewRouter()
 
-	router.Get("/", hdl.GetMessagesByChatID)
+	router.Get("/{chatId}", hdl.GetMessagesByChatID)
 
-	t.Run("should be chat id error", func(t *testing.T) {
+	testUserID := guuid.New().String()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/chat-id", nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, testUserID))
+	}
+
+	t.Run("should be unauthorized", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		router.ServeHTTP(r, httptest.NewRequest(http.MethodGet, "/chat-id", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("should be not participant error", func(t *testing.T) {
+		r := httptest.NewRecorder()
 
-		svc.On("GetMessagesByChatID", mock.Anything, mock.Anything).Return(nil, errors.New("service error"))
+		svc.On("GetParticipantChat", mock.Anything, "chat-id", testUserID).Return(nil, service.ErrNotChatParticipant).Once()
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be chat id error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetParticipantChat", mock.Anything, "chat-id", testUserID).Return(&chat.Chat{}, nil).Once()
+		svc.On("GetMessagesByChatID", mock.Anything, "chat-id", mock.Anything).Return(api.Page[message.Message]{}, errors.New("service error")).Once()
+
+		router.ServeHTTP(r, newRequest())
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -179,14 +214,18 @@ func TestChatHandler_GetMessagesByChatID_Success(t *testing.T) {
 	}
 	router := chi.NewRouter()
 
-	router.Get("/", hdl.GetMessagesByChatID)
+	router.Get("/{chatId}", hdl.GetMessagesByChatID)
 
-	t.Run("should be chat id error", func(t *testing.T) {
+	testUserID := guuid.New().String()
+
+	t.Run("should be no errors", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat-id", nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, testUserID))
 
-		svc.On("GetMessagesByChatID", mock.Anything, mock.Anything).Return(nil, nil)
+		svc.On("GetParticipantChat", mock.Anything, "chat-id", testUserID).Return(&chat.Chat{}, nil).Once()
+		svc.On("GetMessagesByChatID", mock.Anything, "chat-id", api.PageRequest{Limit: api.DefaultLimit}).Return(api.Page[message.Message]{}, nil).Once()
 
 		router.ServeHTTP(r, req)
 
@@ -310,6 +349,7 @@ func TestChatHandler_SendMessage_Success(t *testing.T) {
 
 func TestHandleWebSocket_(t *testing.T) {
 	config.GlobalEnv = config.LocalEnv
+	t.Setenv("SECRET_KEY_AUTH", "your-secret-key")
 
 	log := logger.New()
 	mockService := mocks.ChatService{}
@@ -321,11 +361,12 @@ func TestHandleWebSocket_(t *testing.T) {
 	}
 	router := chi.NewRouter()
 
-	router.Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
+	router.With(mw.WithAuth).Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
 
 	t.Run("should successfully handle websocket connection", func(t *testing.T) {
 		validToken := "your-valid-token"
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123?token="+validToken, nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123", nil)
+		req.Header.Set("Authorization", "Bearer "+validToken)
 		req.Header.Set("Content-Type", "application/json")
 
 		rr := httptest.NewRecorder()
@@ -338,6 +379,7 @@ func TestHandleWebSocket_(t *testing.T) {
 
 func TestHandleWebSocket_ValidToken(t *testing.T) {
 	config.GlobalEnv = config.LocalEnv
+	t.Setenv("SECRET_KEY_AUTH", "your-secret-key")
 
 	log := logger.New()
 	mockService := mocks.ChatService{}
@@ -353,10 +395,13 @@ func TestHandleWebSocket_ValidToken(t *testing.T) {
 
 	invalidTokenWithoutUserID := generateTokenWithoutUserID()
 
-	router.Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
+	mockService.On("GetParticipantChat", mock.Anything, mock.Anything, mock.Anything).Return(&chat.Chat{}, nil)
+
+	router.With(mw.WithAuth).Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
 
 	t.Run("should successfully upgrade http to websocket", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123?token="+token, nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 
 		rr := httptest.NewRecorder()
@@ -367,7 +412,7 @@ func TestHandleWebSocket_ValidToken(t *testing.T) {
 	})
 
 	t.Run("should return error with empty token", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123?token=", nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123", nil)
 		req.Header.Set("Content-Type", "application/json")
 
 		rr := httptest.NewRecorder()
@@ -378,7 +423,8 @@ func TestHandleWebSocket_ValidToken(t *testing.T) {
 	})
 
 	t.Run("should return error without empty token", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123?token="+invalidTokenWithoutUserID, nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/123", nil)
+		req.Header.Set("Authorization", "Bearer "+invalidTokenWithoutUserID)
 		req.Header.Set("Content-Type", "application/json")
 
 		rr := httptest.NewRecorder()
@@ -392,6 +438,7 @@ func TestHandleWebSocket_ValidToken(t *testing.T) {
 
 func TestHandleWebSocket_Success(t *testing.T) {
 	config.GlobalEnv = config.LocalEnv
+	t.Setenv("SECRET_KEY_AUTH", "your-secret-key")
 
 	log := logger.New()
 	mockService := mocks.ChatService{}
@@ -405,17 +452,22 @@ func TestHandleWebSocket_Success(t *testing.T) {
 
 	token := generateValidToken(t)
 
-	router.Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
+	mockService.On("GetParticipantChat", mock.Anything, mock.Anything, mock.Anything).Return(&chat.Chat{}, nil)
+
+	router.With(mw.WithAuth).Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
 
 	t.Run("should successfully handle websocket connection", func(t *testing.T) {
 
 		chatId := "asdasdasdasd"
-		messages := []*message.Entity{
-			{Text: "Hello World"},
-			{Text: "How are you?"},
+		messages := api.Page[message.Message]{
+			Items: []message.Message{
+				{Text: "Hello World"},
+				{Text: "How are you?"},
+			},
 		}
 
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId+"?token="+token, nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Connection", "Upgrade")
 		req.Header.Set("Upgrade", "websocket")
 
@@ -424,8 +476,8 @@ func TestHandleWebSocket_Success(t *testing.T) {
 		server := httptest.NewServer(router)
 		defer server.Close()
 
-		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId + "?token=" + token
-		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId
+		conn, _, err := websocket.DefaultDialer.Dial(url, bearer(token))
 		if err != nil {
 			t.Fatalf("Failed to upgrade connection: %v", err)
 		}
@@ -433,7 +485,7 @@ func TestHandleWebSocket_Success(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		mockService.On("GetMessagesByChatID", context.Background(), chatId).Return(messages, nil).Once()
+		mockService.On("GetMessagesByChatID", mock.Anything, chatId, api.PageRequest{Limit: api.DefaultLimit}).Return(messages, nil).Once()
 
 	})
 
@@ -441,7 +493,8 @@ func TestHandleWebSocket_Success(t *testing.T) {
 
 		chatId := "asdasdasdasd"
 
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId+"?token="+token, nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Connection", "Upgrade")
 		req.Header.Set("Upgrade", "websocket")
 
@@ -450,8 +503,8 @@ func TestHandleWebSocket_Success(t *testing.T) {
 		server := httptest.NewServer(router)
 		defer server.Close()
 
-		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId + "?token=" + token
-		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId
+		conn, _, err := websocket.DefaultDialer.Dial(url, bearer(token))
 		if err != nil {
 			t.Fatalf("Failed to upgrade connection: %v", err)
 		}
@@ -459,13 +512,14 @@ func TestHandleWebSocket_Success(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 
-		mockService.On("GetMessagesByChatID", context.Background(), chatId).Return(nil, errors.New("messages error")).Once()
+		mockService.On("GetMessagesByChatID", mock.Anything, chatId, api.PageRequest{Limit: api.DefaultLimit}).Return(api.Page[message.Message]{}, errors.New("messages error")).Once()
 
 	})
 }
 
 func TestHandleWebSocket_MessageHandling_RealManager(t *testing.T) {
 	config.GlobalEnv = config.LocalEnv
+	t.Setenv("SECRET_KEY_AUTH", "your-secret-key")
 
 	log := logger.New()
 	mockService := &mocks.ChatService{}
@@ -477,30 +531,32 @@ func TestHandleWebSocket_MessageHandling_RealManager(t *testing.T) {
 	}
 
 	router := chi.NewRouter()
-	router.Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
+	router.With(mw.WithAuth).Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
 
+	mockService.On("GetParticipantChat", mock.Anything, mock.Anything, mock.Anything).Return(&chat.Chat{}, nil)
 	mockService.On("SendMessageInChat", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	mockService.On("SendMessageInChat", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("Error")).Once()
 
 	t.Run("should successfully process a message", func(t *testing.T) {
 		chatId := "chat-id"
 		token := generateValidToken(t)
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId+"?token="+token, nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Connection", "Upgrade")
 		req.Header.Set("Upgrade", "websocket")
 
 		server := httptest.NewServer(router)
 		defer server.Close()
 
-		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId + "?token=" + token
-		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId
+		conn, _, err := websocket.DefaultDialer.Dial(url, bearer(token))
 		if err != nil {
 			t.Fatalf("Failed to upgrade connection: %v", err)
 		}
 		defer conn.Close()
 
-		mockService.On("GetMessagesByChatID", mock.Anything, mock.Anything).
-			Return([]*message.Entity{}, nil).
+		mockService.On("GetMessagesByChatID", mock.Anything, mock.Anything, mock.Anything).
+			Return(api.Page[message.Message]{}, nil).
 			Once()
 
 		message := "Test message"
@@ -511,22 +567,23 @@ func TestHandleWebSocket_MessageHandling_RealManager(t *testing.T) {
 	t.Run("should throw error processing a message", func(t *testing.T) {
 		chatId := "chat-id"
 		token := generateValidToken(t)
-		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId+"?token="+token, nil)
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/"+chatId, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Connection", "Upgrade")
 		req.Header.Set("Upgrade", "websocket")
 
 		server := httptest.NewServer(router)
 		defer server.Close()
 
-		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId + "?token=" + token
-		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatId
+		conn, _, err := websocket.DefaultDialer.Dial(url, bearer(token))
 		if err != nil {
 			t.Fatalf("Failed to upgrade connection: %v", err)
 		}
 		defer conn.Close()
 
-		mockService.On("GetMessagesByChatID", mock.Anything, mock.Anything).
-			Return([]*message.Entity{}, nil).
+		mockService.On("GetMessagesByChatID", mock.Anything, mock.Anything, mock.Anything).
+			Return(api.Page[message.Message]{}, nil).
 			Once()
 
 		message := "Test message"
@@ -538,6 +595,7 @@ func TestHandleWebSocket_MessageHandling_RealManager(t *testing.T) {
 func generateValidToken(t *testing.T) string {
 	claims := jwt.MapClaims{
 		"user_id": "61f0c404-5cb3-11e7-907b-a6006ad3dba0",
+		"role_id": 1,
 		"exp":     time.Now().Add(time.Hour * 1).Unix(),
 	}
 
@@ -551,6 +609,11 @@ func generateValidToken(t *testing.T) string {
 	return tokenString
 }
 
+// bearer is the header of the websocket handshake authorized by the token
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": []string{"Bearer " + token}}
+}
+
 func generateTokenWithoutUserID() string {
 	claims := jwt.MapClaims{
 		"some_other_claim": "value",
@@ -560,3 +623,459 @@ func generateTokenWithoutUserID() string {
 	signedToken, _ := token.SignedString([]byte("your-secret-key"))
 	return signedToken
 }
+
+func TestHandleWebSocket_Participant(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+	t.Setenv("SECRET_KEY_AUTH", "your-secret-key")
+
+	log := logger.New()
+	mockService := &mocks.ChatService{}
+	hdl := Handler{
+		Svc: mockService,
+		Cm:  connectionmanager.NewConnectionManager(),
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.With(mw.WithAuth).Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
+
+	token := generateValidToken(t)
+
+	t.Run("should return forbidden for not participant", func(t *testing.T) {
+		mockService.On("GetParticipantChat", mock.Anything, "chat-id", mock.Anything).Return(nil, service.ErrNotChatParticipant).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/chat-id", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return not found for unknown chat", func(t *testing.T) {
+		mockService.On("GetParticipantChat", mock.Anything, "chat-id", mock.Anything).Return(nil, service.ErrChatNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/ws/chat-id", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestHandleWebSocket_Rooms(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+	t.Setenv("SECRET_KEY_AUTH", "your-secret-key")
+
+	log := logger.New()
+	mockService := &mocks.ChatService{}
+	hdl := Handler{
+		Svc: mockService,
+		Cm:  connectionmanager.NewConnectionManager(),
+		Log: log,
+	}
+
+	pubSub := chatpubsub.NewLocalPubSub()
+	pubSub.Subscribe(hdl.Cm.Deliver)
+
+	// publish stands for the service delivering the frame to the chat on every instance
+	publish := func(ctx context.Context, chatID string, frame chat.Frame) {
+		payload, err := json.Marshal(frame)
+		assert.NoError(t, err)
+
+		_ = pubSub.Publish(ctx, chat.Event{
+			ChatID:           chatID,
+			ExceptConnection: chat.ConnectionIDFromContext(ctx),
+			Payload:          payload,
+		})
+	}
+
+	owner := "61f0c404-5cb3-11e7-907b-a6006ad3dba0"
+	guest := "0e1c1b2a-6f8e-4c1d-9f2a-3b4c5d6e7f80"
+
+	history := api.Page[message.Message]{Items: []message.Message{{Text: "history"}}}
+	marker := chat.ReadMarker{UserID: guuid.MustParse(guest)}
+
+	mockService.On("GetParticipantChat", mock.Anything, mock.Anything, mock.Anything).Return(&chat.Chat{}, nil)
+	mockService.On("GetMessagesByChatID", mock.Anything, mock.Anything, mock.Anything).Return(history, nil)
+	mockService.On("GetReadMarkers", mock.Anything, mock.Anything).Return([]chat.ReadMarker{marker}, nil)
+	mockService.On("SendMessageInChat", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			publish(args.Get(0).(context.Context), args.String(1), chat.Frame{
+				Type:    chat.FrameMessage,
+				UserID:  args.String(2),
+				Message: &message.Message{Text: args.Get(3).(message.Entity).Text},
+			})
+		}).
+		Return(nil)
+	mockService.On("SendTyping", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			publish(args.Get(0).(context.Context), args.String(1), chat.Frame{Type: chat.FrameTyping, UserID: args.String(2)})
+		}).
+		Return(nil)
+
+	server := httptest.NewServer(func() http.Handler {
+		router := chi.NewRouter()
+		router.With(mw.WithAuth).Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
+		return router
+	}())
+	defer server.Close()
+
+	readFrame := func(t *testing.T, conn *websocket.Conn, wait time.Duration) (chat.Frame, error) {
+		conn.SetReadDeadline(time.Now().Add(wait))
+
+		var frame chat.Frame
+		err := conn.ReadJSON(&frame)
+		return frame, err
+	}
+
+	// connect reads the history and the read markers, the connection has joined the room of the chat then
+	connect := func(chatID, userID string) *websocket.Conn {
+		url := "ws://" + server.Listener.Addr().String() + "/chat/ws/" + chatID
+		conn, _, err := websocket.DefaultDialer.Dial(url, bearer(generateTokenForUser(t, userID)))
+		if err != nil {
+			t.Fatalf("Failed to upgrade connection: %v", err)
+		}
+
+		frame, err := readFrame(t, conn, 2*time.Second)
+		if err != nil {
+			t.Fatalf("Failed to read history: %v", err)
+		}
+		assert.Equal(t, chat.FrameMessage, frame.Type)
+		assert.Equal(t, "history", frame.Message.Text)
+
+		frame, err = readFrame(t, conn, 2*time.Second)
+		if err != nil {
+			t.Fatalf("Failed to read markers: %v", err)
+		}
+		assert.Equal(t, chat.FrameRead, frame.Type)
+		assert.Equal(t, guest, frame.UserID)
+
+		return conn
+	}
+
+	phone := connect("room-1", owner)
+	defer phone.Close()
+	laptop := connect("room-1", owner)
+	defer laptop.Close()
+	guestConn := connect("room-1", guest)
+	defer guestConn.Close()
+	otherChat := connect("room-2", guest)
+	defer otherChat.Close()
+
+	t.Run("should deliver to the other devices and participants of the chat", func(t *testing.T) {
+		err := phone.WriteJSON(chat.Frame{Type: chat.FrameMessage, Text: "Hello"})
+		assert.NoError(t, err)
+
+		for _, conn := range []*websocket.Conn{laptop, guestConn} {
+			frame, err := readFrame(t, conn, 2*time.Second)
+			assert.NoError(t, err)
+			assert.Equal(t, chat.FrameMessage, frame.Type)
+			assert.Equal(t, owner, frame.UserID)
+			assert.Equal(t, "Hello", frame.Message.Text)
+		}
+	})
+
+	t.Run("should deliver the typing indicator", func(t *testing.T) {
+		err := guestConn.WriteJSON(chat.Frame{Type: chat.FrameTyping})
+		assert.NoError(t, err)
+
+		for _, conn := range []*websocket.Conn{phone, laptop} {
+			frame, err := readFrame(t, conn, 2*time.Second)
+			assert.NoError(t, err)
+			assert.Equal(t, chat.FrameTyping, frame.Type)
+			assert.Equal(t, guest, frame.UserID)
+		}
+	})
+
+	t.Run("should not deliver to the other chats and the sender", func(t *testing.T) {
+		for _, conn := range []*websocket.Conn{otherChat, phone, guestConn} {
+			_, err := readFrame(t, conn, 200*time.Millisecond)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("should answer the invalid frames with the error frame", func(t *testing.T) {
+		err := laptop.WriteMessage(websocket.TextMessage, []byte("Hello"))
+		assert.NoError(t, err)
+
+		frame, err := readFrame(t, laptop, 2*time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, chat.FrameError, frame.Type)
+	})
+}
+
+func TestHandleWebSocket_OriginAndHistory(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+	t.Setenv("SECRET_KEY_AUTH", "your-secret-key")
+
+	mockService := &mocks.ChatService{}
+	hdl := Handler{
+		Svc:     mockService,
+		Cm:      connectionmanager.NewConnectionManager(),
+		Log:     logger.New(),
+		Origins: []string{"https://app.example.com"},
+	}
+
+	router := chi.NewRouter()
+	router.With(mw.WithAuth).Get("/chat/ws/{chatId}", hdl.HandleWebSocket)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	token := generateValidToken(t)
+	url := "ws://" + server.Listener.Addr().String() + "/chat/ws/chat-id"
+
+	history := api.Page[message.Message]{Items: []message.Message{{Text: "first"}}, NextCursor: "next"}
+
+	mockService.On("GetParticipantChat", mock.Anything, "chat-id", mock.Anything).Return(&chat.Chat{}, nil)
+	mockService.On("GetMessagesByChatID", mock.Anything, "chat-id", api.PageRequest{Limit: 1}).Return(history, nil)
+	mockService.On("GetReadMarkers", mock.Anything, "chat-id").Return([]chat.ReadMarker{}, nil)
+
+	t.Run("should reject the foreign origin", func(t *testing.T) {
+		header := bearer(token)
+		header.Set("Origin", "https://evil.example.com")
+
+		_, resp, err := websocket.DefaultDialer.Dial(url+"?limit=1", header)
+		assert.Error(t, err)
+		if assert.NotNil(t, resp) {
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		}
+	})
+
+	t.Run("should send one page of the history with the next cursor", func(t *testing.T) {
+		header := bearer(token)
+		header.Set("Origin", "https://app.example.com")
+
+		conn, _, err := websocket.DefaultDialer.Dial(url+"?limit=1", header)
+		if err != nil {
+			t.Fatalf("Failed to upgrade connection: %v", err)
+		}
+		defer conn.Close()
+
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+		var frame chat.Frame
+		assert.NoError(t, conn.ReadJSON(&frame))
+		assert.Equal(t, chat.FrameMessage, frame.Type)
+		assert.Equal(t, "first", frame.Message.Text)
+
+		assert.NoError(t, conn.ReadJSON(&frame))
+		assert.Equal(t, chat.FrameHistory, frame.Type)
+		assert.Equal(t, "next", frame.NextCursor)
+	})
+
+	t.Run("should require the user", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		assert.Error(t, err)
+		if assert.NotNil(t, resp) {
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+	})
+}
+
+func TestChatHandler_MarkRead(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	svc := &mocks.ChatService{}
+	hdl := Handler{
+		Svc: svc,
+		Log: logger.New(),
+	}
+	router := chi.NewRouter()
+	router.Post("/chat/{chatId}/read", hdl.MarkRead)
+
+	userID := "61f0c404-5cb3-11e7-907b-a6006ad3dba0"
+	messageID := "0e1c1b2a-6f8e-4c1d-9f2a-3b4c5d6e7f80"
+
+	tests := []struct {
+		name    string
+		chatID  string
+		payload string
+		svcErr  error
+		code    int
+	}{
+		{name: "should mark the messages as read", chatID: "chat-ok", payload: `{"message_id": "` + messageID + `"}`, code: http.StatusOK},
+		{name: "should require the message", chatID: "chat-empty", payload: `{}`, code: http.StatusBadRequest},
+		{name: "should forbid the others", chatID: "chat-other", payload: `{"message_id": "` + messageID + `"}`, svcErr: service.ErrNotChatParticipant, code: http.StatusForbidden},
+		{name: "should return not found for the message of another chat", chatID: "chat-missing", payload: `{"message_id": "` + messageID + `"}`, svcErr: service.ErrMessageNotFound, code: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.svcErr != nil {
+				svc.On("MarkRead", mock.Anything, tt.chatID, userID, messageID).Return(nil, tt.svcErr).Once()
+			} else {
+				svc.On("MarkRead", mock.Anything, tt.chatID, userID, messageID).Return(&chat.ReadMarker{}, nil).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/chat/"+tt.chatID+"/read", strings.NewReader(tt.payload))
+			req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.code, rr.Code)
+		})
+	}
+}
+
+func generateTokenForUser(t *testing.T, userID string) string {
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"role_id": 1,
+		"exp":     time.Now().Add(time.Hour * 1).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte("your-secret-key"))
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	return tokenString
+}
+
+func TestChatHandler_SendAttachment(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	svc := &mocks.ChatService{}
+	hdl := Handler{
+		Svc: svc,
+		Log: logger.New(),
+	}
+	router := chi.NewRouter()
+	router.Post("/chat/{chatId}/attachments", hdl.SendAttachment)
+
+	userID := "61f0c404-5cb3-11e7-907b-a6006ad3dba0"
+	data := []byte("%PDF-1.4 contract")
+
+	newRequest := func(chatID string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+
+		part, err := writer.CreateFormFile("file", "contract.pdf")
+		assert.NoError(t, err)
+		_, err = part.Write(data)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.WriteField("text", "signed"))
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/chat/"+chatID+"/attachments", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID))
+	}
+
+	tests := []struct {
+		name   string
+		chatID string
+		svcErr error
+		code   int
+	}{
+		{name: "should send the attachment", chatID: "chat-ok", code: http.StatusCreated},
+		{name: "should reject the other files", chatID: "chat-invalid", svcErr: service.ErrInvalidAttachment, code: http.StatusBadRequest},
+		{name: "should forbid the others", chatID: "chat-other", svcErr: service.ErrNotChatParticipant, code: http.StatusForbidden},
+		{name: "should reject the long text", chatID: "chat-long", svcErr: service.ErrMessageTooLong, code: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.svcErr != nil {
+				svc.On("SendAttachment", mock.Anything, tt.chatID, userID, "contract.pdf", "signed", data).Return(nil, tt.svcErr).Once()
+			} else {
+				svc.On("SendAttachment", mock.Anything, tt.chatID, userID, "contract.pdf", "signed", data).Return(&message.Message{}, nil).Once()
+			}
+
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, newRequest(tt.chatID))
+
+			assert.Equal(t, tt.code, rr.Code)
+		})
+	}
+
+	t.Run("should send the attachment the chat is not notified about", func(t *testing.T) {
+		svc.On("SendAttachment", mock.Anything, "chat-silent", userID, "contract.pdf", "signed", data).
+			Return(&message.Message{}, fmt.Errorf("service.chat.SendAttachment: %w", service.ErrMessageNotDelivered)).Once()
+
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, newRequest("chat-silent"))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should require the file", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/chat/chat-ok/attachments", strings.NewReader(""))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=none")
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestChatHandler_GetAttachment(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	svc := &mocks.ChatService{}
+	hdl := Handler{
+		Svc: svc,
+		Log: logger.New(),
+	}
+	router := chi.NewRouter()
+	router.Get("/chat/{chatId}/attachments/{attachmentId}", hdl.GetAttachment)
+
+	userID := "61f0c404-5cb3-11e7-907b-a6006ad3dba0"
+
+	t.Run("should download the attachment", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "attachment.pdf")
+		assert.NoError(t, os.WriteFile(path, []byte("%PDF-1.4 contract"), 0o600))
+		f, err := os.Open(path)
+		assert.NoError(t, err)
+
+		att := &message.Attachment{Type: message.AttachmentPDF, MimeType: "application/pdf", Name: "contract.pdf"}
+		svc.On("OpenAttachment", mock.Anything, "chat-ok", userID, "att-ok").Return(att, f, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/chat-ok/attachments/att-ok", nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "contract.pdf")
+		assert.Equal(t, "%PDF-1.4 contract", rr.Body.String())
+	})
+
+	t.Run("should forbid the others", func(t *testing.T) {
+		svc.On("OpenAttachment", mock.Anything, "chat-other", userID, "att-ok").Return(nil, nil, service.ErrNotChatParticipant).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/chat-other/attachments/att-ok", nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return not found for the attachment of another chat", func(t *testing.T) {
+		svc.On("OpenAttachment", mock.Anything, "chat-ok", userID, "att-missing").Return(nil, nil, service.ErrAttachmentNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/chat/chat-ok/attachments/att-missing", nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID))
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
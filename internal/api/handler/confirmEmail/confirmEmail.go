@@ -1,13 +1,14 @@
 package confirmEmail
 
 import (
-	"context"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"github.com/pkg/errors"
@@ -45,6 +46,7 @@ func (h *Handler) NewConfirmEmailHandler(r chi.Router) {
 //	@Produce		json
 //	@Success		200	{string}	string	"success in creating otp for email verification!"
 //	@Failure		401	{object}	response.ResponseError	"Unauthorized - user not logged in"
+//	@Failure		429	{object}	response.ResponseError	"too_many_attempts code, the OTP is requested too often"
 //	@Failure		500	{object}	response.ResponseError	"Internal Server Error - could not generate OTP"
 //	@Router			/email/otp [get]
 func (h *Handler) CreateOTPEmail(w http.ResponseWriter, r *http.Request) {
@@ -65,9 +67,13 @@ func (h *Handler) CreateOTPEmail(w http.ResponseWriter, r *http.Request) {
 	h.Log.Info(userID)
 	fmt.Println(userID)
 
-	err := h.Svc.CreateOTPEmail(context.Background(), userID)
+	err := h.Svc.CreateOTPEmail(r.Context(), userID)
 	if err != nil {
 		h.Log.Error("failed to generate one-time password for email verification", slogError.Err(err))
+		if errors.Is(err, service.ErrTooManyAttempts) {
+			responseApi.WriteTooManyRequests(w, r, attempt.RetryAfter(err), attempt.LockedCode, err)
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.Wrap(err, "could not generate OTP")))
 		return
 	}
@@ -84,6 +90,7 @@ func (h *Handler) CreateOTPEmail(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Param			email	path		string	true	"Email of the user"
 //	@Success		200		{string}	string	"success in creating otp for password reset!"
+//	@Failure		429		{object}	response.ResponseError	"too_many_attempts code, the OTP is requested too often"
 //	@Failure		500		{object}	response.ResponseError	"Internal Server Error - could not generate OTP"
 //	@Router			/password/otp/{email} [get]
 func (h *Handler) CreateOTPPassword(w http.ResponseWriter, r *http.Request) {
@@ -96,9 +103,13 @@ func (h *Handler) CreateOTPPassword(w http.ResponseWriter, r *http.Request) {
 
 	email := chi.URLParam(r, "email")
 
-	err := h.Svc.CreateOTPPassword(context.Background(), email)
+	err := h.Svc.CreateOTPPassword(r.Context(), email)
 	if err != nil {
 		h.Log.Error("failed to generate one-time password for password reset", slogError.Err(err))
+		if errors.Is(err, service.ErrTooManyAttempts) {
+			responseApi.WriteTooManyRequests(w, r, attempt.RetryAfter(err), attempt.LockedCode, err)
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.Wrap(err, "could not generate OTP")))
 		return
 	}
@@ -115,6 +126,7 @@ func (h *Handler) CreateOTPPassword(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Success		200		{string}	string	"otp for email change created!"
 //	@Failure		401		{object}	response.ResponseError	"Unauthorized - invalid user ID in context"
+//	@Failure		429		{object}	response.ResponseError	"too_many_attempts code, the OTP is requested too often"
 //	@Failure		500		{object}	response.ResponseError	"Internal Server Error - failed to send OTP for email change"
 //	@Router			/email/change/otp [get]
 func (h *Handler) SendOtpForEmailChange(w http.ResponseWriter, r *http.Request) {
@@ -131,9 +143,13 @@ func (h *Handler) SendOtpForEmailChange(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.Svc.SendOtpForEmailChange(context.Background(), userId)
+	err := h.Svc.SendOtpForEmailChange(r.Context(), userId)
 	if err != nil {
 		h.Log.Error("failed to send otp for email change", slogError.Err(err))
+		if errors.Is(err, service.ErrTooManyAttempts) {
+			responseApi.WriteTooManyRequests(w, r, attempt.RetryAfter(err), attempt.LockedCode, err)
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
@@ -8,10 +8,10 @@ import (
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/contracts"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"github.com/pkg/errors"
-	"golang.org/x/net/context"
 	"log/slog"
 	"net/http"
 	"time"
@@ -95,9 +95,17 @@ func (h *Handler) UpdateContract(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call the service to add contract with parsed dates
-	contract, err := h.Svc.UpdateContract(context.Background(), userID, stayID, dateStart, dateEnd)
+	contract, err := h.Svc.UpdateContract(r.Context(), userID, stayID, dateStart, dateEnd)
 	if err != nil {
 		h.Log.Error("failed to add contract", slogError.Err(err))
+		if errors.Is(err, service.ErrInvalidQuote) || errors.Is(err, service.ErrValid) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		if errors.Is(err, service.ErrStayNotFound) {
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
@@ -131,7 +139,7 @@ func (h *Handler) GetAllContracts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hist, err := h.Svc.GetAllContracts(context.Background(), userID)
+	hist, err := h.Svc.GetAllContracts(r.Context(), userID)
 	if err != nil {
 		h.Log.Error("failed to fetch history", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(errors.Wrap(err, "could not fetch history")))
@@ -203,9 +211,17 @@ func (h *Handler) AddContract(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call the service to add contract with parsed dates
-	err = h.Svc.AddContract(context.Background(), userID, stayID, dateStart, dateEnd)
+	err = h.Svc.AddContract(r.Context(), userID, stayID, dateStart, dateEnd)
 	if err != nil {
 		h.Log.Error("failed to add contract", slogError.Err(err))
+		if errors.Is(err, service.ErrInvalidQuote) || errors.Is(err, service.ErrValid) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		if errors.Is(err, service.ErrStayNotFound) {
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
@@ -0,0 +1,165 @@
+package currency
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc     interfaces.CurrencyService
+	RoleSvc interfaces.RoleService
+	Log     *slog.Logger
+}
+
+func (h *Handler) NewCurrencyHandler(r chi.Router) {
+	r.Route("/currency", func(r chi.Router) {
+		r.Get("/rates", h.GetRates)
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithAuth)
+			r.Use(mw.WithPermission(h.RoleSvc, "/currency"))
+			r.Put("/rates/{currency}", h.SetRate)
+			r.Delete("/rates/{currency}", h.DeleteRate)
+		})
+	})
+}
+
+// GetRates godoc
+//
+//	@Summary		Get currency rates
+//	@Description	Get rates of all supported currencies in the base currency (RUB)
+//	@Tags			currency
+//	@Accept			application/json
+//	@Produce		json
+//	@Success		200	{object}		[]money.Rate	"ok"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/currency/rates [get]
+func (h *Handler) GetRates(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.currency.GetRates"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	rates, err := h.Svc.GetRates(r.Context())
+	if err != nil {
+		h.Log.Error("failed to get currency rates", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, rates)
+}
+
+// SetRate godoc
+//
+//	@Summary		Set currency rate
+//	@Description	Create or update the rate of the currency in the base currency (RUB), needs the grant of the /currency object
+//	@Tags			currency
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			currency	path		string		true	"currency code"
+//	@Param			request	body		money.RateEntity		true	"currency rate"
+//	@Success		200	{object}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		403		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/currency/rates/{currency} [put]
+func (h *Handler) SetRate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.currency.SetRate"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	currency, err := money.ParseCurrency(chi.URLParam(r, "currency"))
+	if err != nil {
+		h.Log.Error("failed to parse currency", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var rate money.RateEntity
+
+	err = render.DecodeJSON(r.Body, &rate)
+	if err != nil {
+		h.Log.Error("failed to decode request body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.SetRate(r.Context(), currency, rate.Rate)
+	if err != nil {
+		h.Log.Error("failed to set currency rate", slogError.Err(err))
+		if errors.Is(err, service.ErrValid) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully updated")
+}
+
+// DeleteRate godoc
+//
+//	@Summary		Delete currency rate
+//	@Description	Stop supporting the currency, needs the grant of the /currency object. The currency of any stay can't be deleted
+//	@Tags			currency
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			currency	path		string		true	"currency code"
+//	@Success		200	{object}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		403		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/currency/rates/{currency} [delete]
+func (h *Handler) DeleteRate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.currency.DeleteRate"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	currency, err := money.ParseCurrency(chi.URLParam(r, "currency"))
+	if err != nil {
+		h.Log.Error("failed to parse currency", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.DeleteRate(r.Context(), currency)
+	if err != nil {
+		h.Log.Error("failed to delete currency rate", slogError.Err(err))
+		switch {
+		case errors.Is(err, service.ErrValid):
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		case errors.Is(err, service.ErrCurrencyNotFound):
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+		case errors.Is(err, service.ErrCurrencyInUse):
+			responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+		default:
+			responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		}
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully deleted")
+}
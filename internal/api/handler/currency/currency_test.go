@@ -0,0 +1,160 @@
+package currency
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCurrencyHandler_GetRates(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.CurrencyService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/currency/rates", hdl.GetRates)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetRates", mock.Anything).Return([]money.Rate{{Currency: money.BaseCurrency, Rate: 1}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/currency/rates", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be internal error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetRates", mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/currency/rates", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestCurrencyHandler_SetRate(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.CurrencyService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Put("/currency/rates/{currency}", hdl.SetRate)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(money.RateEntity{Rate: 92.5})
+
+		svc.On("SetRate", mock.Anything, money.Currency("USD"), 92.5).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/currency/rates/usd", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be invalid currency code error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(money.RateEntity{Rate: 92.5})
+
+		req := httptest.NewRequest(http.MethodPut, "/currency/rates/dollar", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(money.RateEntity{Rate: -1})
+
+		svc.On("SetRate", mock.Anything, money.Currency("USD"), float64(-1)).Return(service.ErrValid).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/currency/rates/USD", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestCurrencyHandler_DeleteRate(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.CurrencyService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Delete("/currency/rates/{currency}", hdl.DeleteRate)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteRate", mock.Anything, money.Currency("EUR")).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/currency/rates/EUR", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be not found error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteRate", mock.Anything, money.Currency("CNY")).Return(service.ErrCurrencyNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/currency/rates/CNY", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("should be currency in use error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteRate", mock.Anything, money.Currency("USD")).Return(service.ErrCurrencyInUse).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/currency/rates/USD", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
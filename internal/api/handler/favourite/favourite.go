@@ -6,11 +6,12 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
-	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/favourite"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/favourite"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	stays2 "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"github.com/pkg/errors"
 	"log/slog"
@@ -114,11 +115,14 @@ func (h *FavHandler) RemoveFavourite(w http.ResponseWriter, r *http.Request) {
 // GetAllFavourites godoc
 //
 //	@Summary		Get all favourite stays for a user
-//	@Description	Retrieves a list of favourite stays for the user based on the user ID from context.
+//	@Description	Retrieves a page of favourite stays for the user based on the user ID from context, grouped by location ID.
 //	@Tags			favourites
 //	@Accept			application/json
 //	@Produce		json
-//	@Success		200		{array}		map[string]stays2.StayEntityFav	"List of favourite stays"
+//	@Param			limit	query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string		false	"next_cursor of the previous page"
+//	@Success		200		{object}	favourite.Page	"List of favourite stays"
+//	@Failure		400		{object}	response.ResponseError	"Invalid page request"
 //	@Failure		401		{object}	response.ResponseError	"User not logged in"
 //	@Failure		500		{object}	response.ResponseError	"Internal Server Error"
 //	@Router			/favourites [get]
@@ -137,7 +141,14 @@ func (h *FavHandler) GetAllFavourites(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	favourites, err := h.Svc.GetAllFavourites(context.Background(), userID)
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	favourites, err := h.Svc.GetAllFavourites(context.Background(), userID, page)
 	if err != nil {
 		h.Log.Error("failed to get favourites", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
@@ -145,9 +156,9 @@ func (h *FavHandler) GetAllFavourites(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cityMap := make(map[uuid.UUID][]stays2.StayEntityFav)
-	for _, stay := range favourites {
+	for _, stay := range favourites.Items {
 		cityMap[stay.LocationID] = append(cityMap[stay.LocationID], stay)
 	}
 
-	responseApi.WriteJson(w, r, http.StatusOK, cityMap)
+	responseApi.WriteJson(w, r, http.StatusOK, favourite.Page{Items: cityMap, NextCursor: favourites.NextCursor})
 }
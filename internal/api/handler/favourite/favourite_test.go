@@ -8,7 +8,9 @@ import (
 	handler "github.com/imperatorofdwelling/Full-backend/internal/api/handler/user"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -229,7 +231,7 @@ func TestFavHandler_GetAllFavourites(t *testing.T) {
 		ctx := context.WithValue(req.Context(), mw.UserIdKey, testUserID.String())
 		req = req.WithContext(ctx)
 
-		svc.On("GetAllFavourites", mock.Anything, testUserID.String()).Return(nil, errors.New("service error"))
+		svc.On("GetAllFavourites", mock.Anything, testUserID.String(), api.PageRequest{Limit: api.DefaultLimit}).Return(api.Page[stays.StayEntityFav]{}, errors.New("service error"))
 
 		router.ServeHTTP(r, req)
 
@@ -271,7 +273,7 @@ func TestFavHandler_GetAllFavouritesSuccess(t *testing.T) {
 		ctx := context.WithValue(req.Context(), mw.UserIdKey, testUserID.String())
 		req = req.WithContext(ctx)
 
-		svc.On("GetAllFavourites", mock.Anything, testUserID.String()).Return(nil, nil)
+		svc.On("GetAllFavourites", mock.Anything, testUserID.String(), api.PageRequest{Limit: api.DefaultLimit}).Return(api.Page[stays.StayEntityFav]{}, nil)
 
 		router.ServeHTTP(r, req)
 
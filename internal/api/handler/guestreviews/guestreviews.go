@@ -0,0 +1,146 @@
+package guestreviews
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc interfaces.GuestReviewsService
+	Log *slog.Logger
+}
+
+func (h *Handler) NewGuestReviewsHandler(r chi.Router) {
+	r.Route("/guestreviews", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithAuth)
+			r.Post("/", h.CreateGuestReview)
+		})
+
+		r.Get("/{guestId}", h.GetGuestReviews)
+	})
+}
+
+// CreateGuestReview godoc
+//
+//	@Summary		Create guest review
+//	@Description	The stay owner reviews the guest of the completed reservation, one review per reservation, within 14 days after the checkout. The review is hidden until the guest reviews the stay or the review window is closed
+//	@Tags			guestReviews
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			request	body		model.Entity	true	"guest review"
+//	@Success		201	{object}		model.GuestReview	"created"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the owner of the stay"
+//	@Failure		404		{object}	response.ResponseError			"Reservation not found"
+//	@Failure		409		{object}	response.ResponseError			"Reservation is not completed, already reviewed or its review window is closed"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/guestreviews [post]
+func (h *Handler) CreateGuestReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.guestreviews.CreateGuestReview"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	var review model.Entity
+
+	err := render.DecodeJSON(r.Body, &review)
+	if err != nil {
+		h.Log.Error("failed to decode body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	created, err := h.Svc.CreateGuestReview(r.Context(), &review, userID)
+	if err != nil {
+		h.Log.Error("failed to create guest review", slogError.Err(err))
+		h.writeGuestReviewError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, created)
+}
+
+// GetGuestReviews godoc
+//
+//	@Summary		Get guest reviews
+//	@Description	Get the public reviews of the guest page by page, ordered by creation time
+//	@Tags			guestReviews
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			guestId	path		string		true	"guest user id"
+//	@Param			limit	query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		api.Page[model.GuestReview]	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/guestreviews/{guestId} [get]
+func (h *Handler) GetGuestReviews(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.guestreviews.GetGuestReviews"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	guestID, err := uuid.FromString(chi.URLParam(r, "guestId"))
+	if err != nil {
+		h.Log.Error("failed to parse guest id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	reviews, err := h.Svc.GetGuestReviews(r.Context(), guestID, page)
+	if err != nil {
+		h.Log.Error("failed to get guest reviews", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, reviews)
+}
+
+func (h *Handler) writeGuestReviewError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrUserNotOwner):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrNotFoundReservation), errors.Is(err, service.ErrStayNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrStayNotCompleted), errors.Is(err, service.ErrGuestReviewExists), errors.Is(err, service.ErrReviewWindowClosed):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
@@ -0,0 +1,157 @@
+package guestreviews
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGuestReviewsHandler_CreateGuestReview(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.GuestReviewsService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Post("/guestreviews", hdl.CreateGuestReview)
+
+	hostID := "1ef3ba5a-0a3b-4c4e-a8b5-6e2a3c1c2f10"
+
+	review := model.Entity{
+		ReservationID: uuid.Must(uuid.NewV4()),
+		Rating:        5,
+		Description:   "Quiet and tidy guest",
+	}
+	rBytes, _ := json.Marshal(review)
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, hostID))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateGuestReview", mock.Anything, &review, hostID).Return(&model.GuestReview{ID: uuid.Must(uuid.NewV4())}, nil).Once()
+
+		req := withUser(httptest.NewRequest(http.MethodPost, "/guestreviews", bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be error user not logged in", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/guestreviews", bytes.NewBuffer(rBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := withUser(httptest.NewRequest(http.MethodPost, "/guestreviews", bytes.NewBufferString("invalid")))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error not the stay owner", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateGuestReview", mock.Anything, &review, hostID).Return(nil, fmt.Errorf("service.guestreviews.CreateGuestReview: %w", service.ErrUserNotOwner)).Once()
+
+		req := withUser(httptest.NewRequest(http.MethodPost, "/guestreviews", bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error guest already reviewed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateGuestReview", mock.Anything, &review, hostID).Return(nil, fmt.Errorf("service.guestreviews.CreateGuestReview: %w", service.ErrGuestReviewExists)).Once()
+
+		req := withUser(httptest.NewRequest(http.MethodPost, "/guestreviews", bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error review window closed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateGuestReview", mock.Anything, &review, hostID).Return(nil, fmt.Errorf("service.guestreviews.CreateGuestReview: %w", service.ErrReviewWindowClosed)).Once()
+
+		req := withUser(httptest.NewRequest(http.MethodPost, "/guestreviews", bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestGuestReviewsHandler_GetGuestReviews(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.GuestReviewsService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Get("/guestreviews/{guestId}", hdl.GetGuestReviews)
+
+	guestID := uuid.Must(uuid.NewV4())
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetGuestReviews", mock.Anything, guestID, mock.Anything).Return(api.Page[model.GuestReview]{Items: []model.GuestReview{}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/guestreviews/"+guestID.String(), nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error parsing guest id", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/guestreviews/invalid", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -12,14 +12,16 @@ import (
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"log/slog"
 	"net/http"
 )
 
 type Handler struct {
-	Svc interfaces.LocationService
-	Log *slog.Logger
+	Svc     interfaces.LocationService
+	RoleSvc interfaces.RoleService
+	Log     *slog.Logger
 }
 
 func (h *Handler) NewLocationHandler(r chi.Router) {
@@ -27,8 +29,12 @@ func (h *Handler) NewLocationHandler(r chi.Router) {
 		r.Group(func(r chi.Router) {
 			r.Use(mw.WithAuth)
 			r.Get("/{id}", h.GetOneByID)
-			r.Delete("/{id}", h.DeleteByID)
-			r.Put("/{id}", h.UpdateByID)
+
+			r.Group(func(r chi.Router) {
+				r.Use(mw.WithPermission(h.RoleSvc, "/locations"))
+				r.Delete("/{id}", h.DeleteByID)
+				r.Put("/{id}", h.UpdateByID)
+			})
 		})
 
 		r.Group(func(r chi.Router) {
@@ -73,11 +79,13 @@ func (h *Handler) FindByNameMatch(w http.ResponseWriter, r *http.Request) {
 // GetAll godoc
 //
 //	@Summary		Get all locations
-//	@Description	Get all locations
+//	@Description	Get all locations page by page, ordered by creation time
 //	@Tags			locations
 //	@Accept			application/json
 //	@Produce		json
-//	@Success		200	{object}		[]location.Location	"ok"
+//	@Param			limit	query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		api.Page[location.Location]	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/locations [get]
@@ -89,7 +97,14 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
-	locations, err := h.Svc.GetAll(r.Context())
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	locations, err := h.Svc.GetAll(r.Context(), page)
 	if err != nil {
 		h.Log.Error("failed to find locations", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
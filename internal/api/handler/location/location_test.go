@@ -11,6 +11,7 @@ import (
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
 	models "github.com/imperatorofdwelling/Full-backend/internal/domain/models/location"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -124,7 +125,7 @@ func TestLocationHandler_GetAll(t *testing.T) {
 			},
 		}
 
-		svc.On("GetAll", mock.Anything).Return(&expected, nil).Once()
+		svc.On("GetAll", mock.Anything, api.PageRequest{Limit: api.DefaultLimit}).Return(api.Page[models.Location]{Items: expected}, nil).Once()
 
 		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/locations"), nil)
 		if err != nil {
@@ -141,7 +142,7 @@ func TestLocationHandler_GetAll(t *testing.T) {
 	t.Run("should be error response", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("GetAll", mock.Anything).Return(nil, fmt.Errorf("error")).Once()
+		svc.On("GetAll", mock.Anything, mock.Anything).Return(api.Page[models.Location]{}, fmt.Errorf("error")).Once()
 
 		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/locations"), nil)
 		if err != nil {
@@ -154,6 +155,21 @@ func TestLocationHandler_GetAll(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should be invalid limit error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req, err := http.NewRequest(http.MethodGet, "/locations?limit=all", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router.HandleFunc("/locations", hdl.GetAll)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
 
 func TestLocationHandler_GetOneByID(t *testing.T) {
@@ -0,0 +1,349 @@
+package moderation
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/moderation"
+	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc     interfaces.ModerationService
+	RoleSvc interfaces.RoleService
+	Log     *slog.Logger
+}
+
+func (h *Handler) NewModerationHandler(r chi.Router) {
+	r.Route("/moderation/reports", func(r chi.Router) {
+		r.Use(mw.WithAuth)
+		r.Use(mw.WithPermission(h.RoleSvc, "/moderation"))
+
+		r.Get("/", h.GetReports)
+		r.Get("/{type}/{id}", h.GetReport)
+		r.Put("/{type}/{id}/assign", h.AssignReport)
+		r.Post("/{type}/{id}/notes", h.CreateNote)
+		r.Put("/{type}/{id}/resolve", h.ResolveReport)
+	})
+}
+
+// GetReports godoc
+//
+//	@Summary		Get moderation queue
+//	@Description	Get the stay and the user reports page by page, the oldest first. The assignee is a moderator id or "me"
+//	@Tags			moderation
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			status		query		string		false	"open, in_review, resolved or rejected"
+//	@Param			type		query		string		false	"stay or user"
+//	@Param			assignee	query		string		false	"moderator id or me"
+//	@Param			limit		query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor		query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		api.Page[model.Report]	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a moderator"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/moderation/reports [get]
+func (h *Handler) GetReports(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.moderation.GetReports"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	query := r.URL.Query()
+
+	filter := model.Filter{
+		Status: model.Status(query.Get("status")),
+		Type:   model.Type(query.Get("type")),
+	}
+
+	switch assignee := query.Get("assignee"); assignee {
+	case "":
+	case "me":
+		me := uuid.FromStringOrNil(userID)
+		filter.AssigneeID = &me
+	default:
+		assigneeID, err := uuid.FromString(assignee)
+		if err != nil {
+			h.Log.Error("failed to parse assignee", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		filter.AssigneeID = &assigneeID
+	}
+
+	page, err := api.NewPageRequest(query)
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	reports, err := h.Svc.GetReports(r.Context(), filter, page)
+	if err != nil {
+		h.Log.Error("failed to get reports", slogError.Err(err))
+		h.writeModerationError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, reports)
+}
+
+// GetReport godoc
+//
+//	@Summary		Get report
+//	@Description	Get the report with the internal notes of the moderators
+//	@Tags			moderation
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			type	path		string		true	"stay or user"
+//	@Param			id		path		string		true	"report id"
+//	@Success		200	{object}		model.ReportDetails	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a moderator"
+//	@Failure		404		{object}	response.ResponseError			"Report not found"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/moderation/reports/{type}/{id} [get]
+func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.moderation.GetReport"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	report, err := h.Svc.GetReport(r.Context(), model.Type(chi.URLParam(r, "type")), id)
+	if err != nil {
+		h.Log.Error("failed to get report", slogError.Err(err))
+		h.writeModerationError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, report)
+}
+
+// AssignReport godoc
+//
+//	@Summary		Assign report
+//	@Description	Give the report to a moderator, the current user takes it when no assignee is set. The open report goes in review
+//	@Tags			moderation
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			type	path		string		true	"stay or user"
+//	@Param			id		path		string		true	"report id"
+//	@Param			request	body		model.AssignEntity	false	"assignee"
+//	@Success		200	{object}		model.Report	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a moderator"
+//	@Failure		404		{object}	response.ResponseError			"Report or moderator not found"
+//	@Failure		409		{object}	response.ResponseError			"Report is closed"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/moderation/reports/{type}/{id}/assign [put]
+func (h *Handler) AssignReport(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.moderation.AssignReport"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var assign model.AssignEntity
+
+	if r.ContentLength != 0 {
+		err = render.DecodeJSON(r.Body, &assign)
+		if err != nil {
+			h.Log.Error("failed to decode body", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+	}
+
+	report, err := h.Svc.AssignReport(r.Context(), model.Type(chi.URLParam(r, "type")), id, &assign, userID)
+	if err != nil {
+		h.Log.Error("failed to assign report", slogError.Err(err))
+		h.writeModerationError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, report)
+}
+
+// CreateNote godoc
+//
+//	@Summary		Create report note
+//	@Description	Add an internal note to the report, only the moderators see the notes
+//	@Tags			moderation
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			type	path		string		true	"stay or user"
+//	@Param			id		path		string		true	"report id"
+//	@Param			request	body		model.NoteEntity	true	"note"
+//	@Success		201	{object}		model.Note	"created"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a moderator"
+//	@Failure		404		{object}	response.ResponseError			"Report not found"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/moderation/reports/{type}/{id}/notes [post]
+func (h *Handler) CreateNote(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.moderation.CreateNote"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var note model.NoteEntity
+
+	err = render.DecodeJSON(r.Body, &note)
+	if err != nil {
+		h.Log.Error("failed to decode body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	created, err := h.Svc.CreateNote(r.Context(), model.Type(chi.URLParam(r, "type")), id, &note, userID)
+	if err != nil {
+		h.Log.Error("failed to create note", slogError.Err(err))
+		h.writeModerationError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, created)
+}
+
+// ResolveReport godoc
+//
+//	@Summary		Resolve report
+//	@Description	Resolve or reject the report. A resolved report may unpublish the reported stay, suspend the reported user or the stay owner, or warn them by email. The reporter is emailed the resolution
+//	@Tags			moderation
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			type	path		string		true	"stay or user"
+//	@Param			id		path		string		true	"report id"
+//	@Param			request	body		model.ResolutionEntity	true	"resolution"
+//	@Success		200	{object}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a moderator"
+//	@Failure		404		{object}	response.ResponseError			"Report not found"
+//	@Failure		409		{object}	response.ResponseError			"Report is closed"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/moderation/reports/{type}/{id}/resolve [put]
+func (h *Handler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.moderation.ResolveReport"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var resolution model.ResolutionEntity
+
+	err = render.DecodeJSON(r.Body, &resolution)
+	if err != nil {
+		h.Log.Error("failed to decode body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.ResolveReport(r.Context(), model.Type(chi.URLParam(r, "type")), id, &resolution, userID)
+	if err != nil {
+		// the report is closed anyway, the moderator only has to know nobody got the email
+		if errors.Is(err, service.ErrResolutionNotNotified) {
+			h.Log.Warn("report resolved without notification", slogError.Err(err))
+			responseApi.WriteJson(w, r, http.StatusOK, "report is "+string(resolution.Status)+", the users are not notified")
+			return
+		}
+
+		h.Log.Error("failed to resolve report", slogError.Err(err))
+		h.writeModerationError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "report is "+string(resolution.Status))
+}
+
+func (h *Handler) writeModerationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrReportNotFound), errors.Is(err, service.ErrModeratorNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrReportClosed):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
@@ -0,0 +1,282 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/moderation"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const moderatorID = "0b8e3f5c-6a0e-4a3f-9d55-2f8c8a2d4e11"
+
+func withModerator(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, moderatorID))
+}
+
+func TestModerationHandler_GetReports(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ModerationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Get("/moderation/reports", hdl.GetReports)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		me := uuid.FromStringOrNil(moderatorID)
+		filter := model.Filter{Status: model.StatusOpen, AssigneeID: &me}
+
+		svc.On("GetReports", mock.Anything, filter, mock.Anything).Return(api.Page[model.Report]{Items: []model.Report{}}, nil).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodGet, "/moderation/reports?status=open&assignee=me", nil))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error parsing assignee", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := withModerator(httptest.NewRequest(http.MethodGet, "/moderation/reports?assignee=invalid", nil))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetReports", mock.Anything, model.Filter{Status: "unknown"}, mock.Anything).
+			Return(api.Page[model.Report]{}, fmt.Errorf("service.moderation.GetReports: %w: unknown report status", service.ErrValid)).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodGet, "/moderation/reports?status=unknown", nil))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error user not logged in", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/moderation/reports", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestModerationHandler_AssignReport(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ModerationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Put("/moderation/reports/{type}/{id}/assign", hdl.AssignReport)
+
+	reportID := uuid.Must(uuid.NewV4())
+	url := "/moderation/reports/stay/" + reportID.String() + "/assign"
+
+	t.Run("should be no errors taking the report", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("AssignReport", mock.Anything, model.TypeStay, reportID, &model.AssignEntity{}, moderatorID).
+			Return(&model.Report{ID: reportID, Status: model.StatusInReview}, nil).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, nil))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error moderator not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		assigneeID := uuid.Must(uuid.NewV4())
+		assign := model.AssignEntity{AssigneeID: &assigneeID}
+		aBytes, _ := json.Marshal(assign)
+
+		svc.On("AssignReport", mock.Anything, model.TypeStay, reportID, &assign, moderatorID).
+			Return(nil, fmt.Errorf("service.moderation.AssignReport: %w", service.ErrModeratorNotFound)).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(aBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("should be error report closed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("AssignReport", mock.Anything, model.TypeStay, reportID, &model.AssignEntity{}, moderatorID).
+			Return(nil, fmt.Errorf("service.moderation.AssignReport: %w", service.ErrReportClosed)).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, nil))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestModerationHandler_CreateNote(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ModerationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Post("/moderation/reports/{type}/{id}/notes", hdl.CreateNote)
+
+	reportID := uuid.Must(uuid.NewV4())
+	url := "/moderation/reports/user/" + reportID.String() + "/notes"
+
+	note := model.NoteEntity{Text: "The messages confirm the report"}
+	nBytes, _ := json.Marshal(note)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateNote", mock.Anything, model.TypeUser, reportID, &note, moderatorID).
+			Return(&model.Note{ID: uuid.Must(uuid.NewV4()), Text: note.Text}, nil).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPost, url, bytes.NewBuffer(nBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be error report not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateNote", mock.Anything, model.TypeUser, reportID, &note, moderatorID).
+			Return(nil, fmt.Errorf("service.moderation.CreateNote: %w", service.ErrReportNotFound)).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPost, url, bytes.NewBuffer(nBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestModerationHandler_ResolveReport(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ModerationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Put("/moderation/reports/{type}/{id}/resolve", hdl.ResolveReport)
+
+	reportID := uuid.Must(uuid.NewV4())
+	url := "/moderation/reports/stay/" + reportID.String() + "/resolve"
+
+	unpublish := model.ActionUnpublishStay
+	resolution := model.ResolutionEntity{Status: model.StatusResolved, Action: &unpublish, Comment: "The listing is hidden"}
+	rBytes, _ := json.Marshal(resolution)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ResolveReport", mock.Anything, model.TypeStay, reportID, &resolution, moderatorID).Return(nil).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be resolved without notification", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ResolveReport", mock.Anything, model.TypeStay, reportID, &resolution, moderatorID).
+			Return(fmt.Errorf("service.moderation.ResolveReport: %w: smtp is down", service.ErrResolutionNotNotified)).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error report closed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ResolveReport", mock.Anything, model.TypeStay, reportID, &resolution, moderatorID).
+			Return(fmt.Errorf("service.moderation.ResolveReport: %w", service.ErrReportClosed)).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ResolveReport", mock.Anything, model.TypeStay, reportID, &resolution, moderatorID).
+			Return(fmt.Errorf("service.moderation.ResolveReport: %w: only the reported stays can be unpublished", service.ErrValid)).Once()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(rBytes)))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := withModerator(httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString("invalid")))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -6,26 +6,39 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/api/handler"
 	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka"
 	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka/consumer"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/payment"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"log/slog"
 	"net/http"
 	"time"
 )
 
+// responseTimeout is how long MakePayment waits for the provider before it lets the client poll the payment
+const responseTimeout = 10 * time.Second
+
 type Handler struct {
+	Svc interfaces.PaymentService
+	// Kafka consumes the provider responses the requests wait for
 	Kafka           *kafka.Client
 	Log             *slog.Logger
-	WaitForResponse map[string]chan consumer.PaymentResponse
+	WaitForResponse *consumer.Waiters
 }
 
 func (h *Handler) NewPaymentHandler(r chi.Router) {
 	r.Route("/payment", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
+			r.Use(mw.WithAuth)
 			r.Post("/", h.MakePayment)
+			r.Get("/{idempotenceKey}", h.GetPayment)
 		})
 	})
 }
@@ -33,14 +46,23 @@ func (h *Handler) NewPaymentHandler(r chi.Router) {
 // MakePayment godoc
 //
 //	@Summary		Create payment
-//	@Description	Create payment (with yookassa model)
+//	@Description	Pay for the reservation (with yookassa model), the amount must be the quoted price of the reservation.
+//	@Description	Responds with the payment once the provider answers, or with the pending payment after 10 seconds,
+//	@Description	its final status can be polled by the idempotence key. A repeated key returns the recorded payment
 //	@Tags			payment
 //	@Accept			application/json
 //	@Produce		json
 //	@Param	Idempotence-Key header string	true	"Idempotence-Key"
+//	@Param	reservationId query string	true	"reservation id"
 //	@Param	_ body yoomodel.Payment	true	"request yookassa payment"
-//	@Success		200	{object}		yoomodel.PaymentRes	"ok"
+//	@Success		200	{object}		payment.Payment	"ok"
+//	@Success		202	{object}		payment.Payment	"waiting for the provider"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Conflict"
+//	@Failure		503		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/payment [post]
 func (h *Handler) MakePayment(w http.ResponseWriter, r *http.Request) {
@@ -51,6 +73,13 @@ func (h *Handler) MakePayment(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	idempotenceKey := r.Header.Get("Idempotence-Key")
 	if idempotenceKey == "" {
 		h.Log.Error(handler.ErrGettingIdempotenceKey.Error())
@@ -58,33 +87,106 @@ func (h *Handler) MakePayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	reservationID, err := uuid.FromString(r.URL.Query().Get("reservationId"))
+	if err != nil {
+		h.Log.Error("failed to parse reservation id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
 	var payment yoomodel.Payment
 
-	err := render.DecodeJSON(r.Body, &payment)
+	err = render.DecodeJSON(r.Body, &payment)
 	if err != nil {
-		h.Log.Error("error decoding payment json", err.Error())
+		h.Log.Error("error decoding payment json", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	responseChan := make(chan consumer.PaymentResponse)
-	h.WaitForResponse[idempotenceKey] = responseChan
+	// The channel is buffered, so the consumer never blocks on a request that stopped waiting
+	responseChan := make(chan consumer.PaymentResponse, 1)
+	if h.WaitForResponse.Add(idempotenceKey, responseChan) {
+		defer h.WaitForResponse.Remove(idempotenceKey)
+	}
 
-	err = h.Kafka.Producer.SendMessage(kafka.PaymentReqTopic, idempotenceKey, payment)
+	pay, created, err := h.Svc.CreatePayment(r.Context(), idempotenceKey, reservationID, userID, payment)
 	if err != nil {
-		h.Log.Error("failed to send message", "error", err.Error())
-		delete(h.WaitForResponse, idempotenceKey)
-		responseApi.WriteError(w, r, http.StatusServiceUnavailable, err)
+		h.Log.Error("failed to create payment", slogError.Err(err))
+		h.writePaymentError(w, r, err)
+		return
+	}
+
+	if !created {
+		responseApi.WriteJson(w, r, http.StatusOK, pay)
 		return
 	}
 
 	select {
-	case response := <-responseChan:
-		responseApi.WriteJson(w, r, http.StatusOK, response.Result)
-	case <-time.After(10 * time.Second):
-		h.Log.Error("timed out waiting for response")
-		responseApi.WriteError(w, r, http.StatusGatewayTimeout, errors.New("timed out waiting for response"))
+	case <-responseChan:
+		pay, err = h.Svc.GetPayment(r.Context(), idempotenceKey, userID)
+		if err != nil {
+			h.Log.Error("failed to get payment", slogError.Err(err))
+			h.writePaymentError(w, r, err)
+			return
+		}
+		responseApi.WriteJson(w, r, http.StatusOK, pay)
+	case <-time.After(responseTimeout):
+		h.Log.Warn("timed out waiting for response")
+		responseApi.WriteJson(w, r, http.StatusAccepted, pay)
 	}
+}
 
-	delete(h.WaitForResponse, idempotenceKey)
+// GetPayment godoc
+//
+//	@Summary		Get payment
+//	@Description	Get the payment of the user by its idempotence key
+//	@Tags			payment
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			idempotenceKey	path		string		true	"idempotence key of the payment request"
+//	@Success		200	{object}		payment.Payment	"ok"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/payment/{idempotenceKey} [get]
+func (h *Handler) GetPayment(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.payment.GetPayment"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	pay, err := h.Svc.GetPayment(r.Context(), chi.URLParam(r, "idempotenceKey"), userID)
+	if err != nil {
+		h.Log.Error("failed to get payment", slogError.Err(err))
+		h.writePaymentError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, pay)
+}
+
+func (h *Handler) writePaymentError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrNotReservationParticipant):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrPaymentNotFound), errors.Is(err, service.ErrNotFoundReservation), errors.Is(err, service.ErrStayNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrIdempotenceKeyTaken), errors.Is(err, service.ErrInvalidStatusTransition), errors.Is(err, service.ErrReservationPaying):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	case errors.Is(err, service.ErrPaymentUnavailable):
+		responseApi.WriteError(w, r, http.StatusServiceUnavailable, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
 }
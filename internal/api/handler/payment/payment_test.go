@@ -0,0 +1,188 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	yoomodel "github.com/eclipsemode/go-yookassa-sdk/yookassa/model"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka/consumer"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/payment"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaymentHandler_MakePayment(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.PaymentService{}
+	hdl := Handler{
+		Svc:             &svc,
+		Log:             log,
+		WaitForResponse: consumer.NewWaiters(),
+	}
+	router := chi.NewRouter()
+
+	router.Post("/payment", hdl.MakePayment)
+
+	fakeUUID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	pBytes, _ := json.Marshal(yoomodel.Payment{Amount: &yoomodel.Amount{Value: "1500.00", Currency: "RUB"}})
+
+	newRequest := func(key, reservationID string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/payment?reservationId="+reservationID, bytes.NewBuffer(pBytes))
+		if key != "" {
+			req.Header.Set("Idempotence-Key", key)
+		}
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should return recorded payment for repeated key", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pay := &payment.Payment{
+			IdempotenceKey: "key",
+			ReservationID:  &fakeUUID,
+			UserID:         &userID,
+			Status:         payment.StatusSucceeded,
+			Amount:         money.New(150000, money.BaseCurrency),
+		}
+
+		svc.On("CreatePayment", mock.Anything, "key", fakeUUID, userID.String(), mock.Anything).Return(pay, false, nil).Once()
+
+		router.ServeHTTP(r, newRequest("key", fakeUUID.String()))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var res struct {
+			Data payment.Payment `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(r.Body.Bytes(), &res))
+		assert.Equal(t, payment.StatusSucceeded, res.Data.Status)
+		assert.Zero(t, hdl.WaitForResponse.Len())
+	})
+
+	t.Run("should be error idempotence key taken", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreatePayment", mock.Anything, "taken", fakeUUID, userID.String(), mock.Anything).
+			Return(nil, false, fmt.Errorf("service: %w", service.ErrIdempotenceKeyTaken)).Once()
+
+		router.ServeHTTP(r, newRequest("taken", fakeUUID.String()))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error reservation already paying", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreatePayment", mock.Anything, "another", fakeUUID, userID.String(), mock.Anything).
+			Return(nil, false, fmt.Errorf("service: %w", service.ErrReservationPaying)).Once()
+
+		router.ServeHTTP(r, newRequest("another", fakeUUID.String()))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error payment unavailable", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreatePayment", mock.Anything, "down", fakeUUID, userID.String(), mock.Anything).
+			Return(nil, false, fmt.Errorf("service: %w", service.ErrPaymentUnavailable)).Once()
+
+		router.ServeHTTP(r, newRequest("down", fakeUUID.String()))
+
+		assert.Equal(t, http.StatusServiceUnavailable, r.Code)
+	})
+
+	t.Run("should be error missing idempotence key", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("", fakeUUID.String()))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error parsing reservation id", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("key", "invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error unauthorized", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/payment?reservationId="+fakeUUID.String(), bytes.NewBuffer(pBytes))
+		req.Header.Set("Idempotence-Key", "key")
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestPaymentHandler_GetPayment(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.PaymentService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+	router := chi.NewRouter()
+
+	router.Get("/payment/{idempotenceKey}", hdl.GetPayment)
+
+	userID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pay := &payment.Payment{IdempotenceKey: "key", UserID: &userID, Status: payment.StatusPending}
+
+		svc.On("GetPayment", mock.Anything, "key", userID.String()).Return(pay, nil).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodGet, "/payment/key", nil)))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error payment not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetPayment", mock.Anything, "unknown", userID.String()).
+			Return(nil, fmt.Errorf("service: %w", service.ErrPaymentNotFound)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodGet, "/payment/unknown", nil)))
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("should be error unauthorized", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, httptest.NewRequest(http.MethodGet, "/payment/key", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -11,7 +11,9 @@ import (
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"github.com/pkg/errors"
 	"log/slog"
@@ -36,6 +38,13 @@ func (h *Handler) NewReservationHandler(r chi.Router) {
 			r.Delete("/{reservationID}", h.DeleteReservationByID)
 
 			r.Get("/{reservationID}", h.GetReservationByID)
+			r.Get("/{reservationID}/history", h.GetReservationHistory)
+
+			r.Post("/{reservationID}/approve", h.ApproveReservation)
+			r.Post("/{reservationID}/decline", h.DeclineReservation)
+			r.Post("/{reservationID}/no-show", h.MarkNoShow)
+			r.Post("/{reservationID}/cancel", h.CancelReservation)
+			r.Post("/{reservationID}/refund", h.RetryRefund)
 
 			r.Get("/user/userID", h.GetAllReservationsByUser)
 
@@ -48,13 +57,14 @@ func (h *Handler) NewReservationHandler(r chi.Router) {
 // CreateReservation godoc
 //
 //		@Summary		Create Reservation
-//		@Description	Create reservation (arrived and departure should be TIMESTAMP type)
+//		@Description	Create reservation (arrived and departure should be TIMESTAMP type), the guests must fit the stay capacity
 //		@Tags			reservations
 //		@Accept			application/json
 //		@Produce		json
 //	 	@Param			request 	body	reservation.ReservationEntity	true	"Create reservation request"
 //		@Success		201	{object}		string	"created"
 //		@Failure		400		{object}	response.ResponseError			"Error"
+//		@Failure		409		{object}	response.ResponseError			"Dates are taken"
 //		@Failure		default		{object}	response.ResponseError			"Error"
 //		@Router			/reservation [post]
 func (h *Handler) CreateReservation(w http.ResponseWriter, r *http.Request) {
@@ -83,17 +93,17 @@ func (h *Handler) CreateReservation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.CheckReservation(context.Background(), &reserv, userID)
+	err = h.Svc.CheckReservation(r.Context(), &reserv, userID)
 	if err != nil {
 		h.Log.Error("failed to check reservation", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeStatusError(w, r, err)
 		return
 	}
 
-	err = h.Svc.CreateReservation(context.Background(), &reserv, userID)
+	err = h.Svc.CreateReservation(r.Context(), &reserv, userID)
 	if err != nil {
 		h.Log.Error("failed to create reservation", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeStatusError(w, r, err)
 		return
 	}
 
@@ -143,7 +153,7 @@ func (h *Handler) ConfirmCheckInReservation(w http.ResponseWriter, r *http.Reque
 	err = h.Svc.ConfirmCheckInReservation(context.Background(), userID, stayId, reserv)
 	if err != nil {
 		h.Log.Error("failed to confirm reservation", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeStatusError(w, r, err)
 		return
 	}
 
@@ -183,7 +193,7 @@ func (h *Handler) ConfirmCheckOutReservation(w http.ResponseWriter, r *http.Requ
 	err := h.Svc.ConfirmCheckOutReservation(context.Background(), userID, stayId)
 	if err != nil {
 		h.Log.Error("failed to confirm reservation", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeStatusError(w, r, err)
 		return
 	}
 
@@ -201,7 +211,10 @@ func (h *Handler) ConfirmCheckOutReservation(w http.ResponseWriter, r *http.Requ
 //	@Param			request		body	reservation.ReservationUpdateEntity	true	"Details to update the reservation"
 //	@Success		200	{object}	map[string]interface{}	"Successfully updated reservation"
 //	@Failure		400	{object}	response.ResponseError		"Invalid request"
+//	@Failure		401	{object}	response.ResponseError		"Unauthorized"
+//	@Failure		403	{object}	response.ResponseError		"Not the guest of the reservation"
 //	@Failure		404	{object}	response.ResponseError		"Reservation not found"
+//	@Failure		409	{object}	response.ResponseError		"Reservation can't be changed in its status or the dates are taken"
 //	@Failure		500	{object}	response.ResponseError		"Internal server error"
 //	@Router			/reservation/{reservationId} [put]
 func (h *Handler) UpdateReservation(w http.ResponseWriter, r *http.Request) {
@@ -231,14 +244,21 @@ func (h *Handler) UpdateReservation(w http.ResponseWriter, r *http.Request) {
 
 	newReserv.ID = uuID
 
-	err = h.Svc.UpdateReservation(context.Background(), &newReserv)
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	err = h.Svc.UpdateReservation(r.Context(), &newReserv, userID)
 	if err != nil {
 		h.Log.Error("failed to update reservation", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeStatusError(w, r, err)
 		return
 	}
 
-	reserv, err := h.Svc.GetReservationByID(context.Background(), uuID)
+	reserv, err := h.Svc.GetReservationByID(r.Context(), uuID)
 	if err != nil {
 		h.Log.Error("failed to find reservation", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
@@ -251,13 +271,17 @@ func (h *Handler) UpdateReservation(w http.ResponseWriter, r *http.Request) {
 // DeleteReservationByID godoc
 //
 //	@Summary		Delete Reservation
-//	@Description	Delete reservation by id
+//	@Description	Delete the reservation request by id, the approved reservations can only be cancelled
 //	@Tags			reservations
 //	@Accept			application/json
 //	@Produce		json
 //	@Param			reservationID	path		string		true	"reservation id"
 //	@Success		200	{string}		string	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the guest of the reservation"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Reservation is not a request"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/reservation/{reservationID} [delete]
 func (h *Handler) DeleteReservationByID(w http.ResponseWriter, r *http.Request) {
@@ -276,10 +300,17 @@ func (h *Handler) DeleteReservationByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.Svc.DeleteReservationByID(context.Background(), uuID)
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	err = h.Svc.DeleteReservationByID(r.Context(), uuID, userID)
 	if err != nil {
 		h.Log.Error("failed to delete reservation", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeStatusError(w, r, err)
 		return
 	}
 
@@ -332,7 +363,9 @@ func (h *Handler) GetReservationByID(w http.ResponseWriter, r *http.Request) {
 //	@Accept			application/json
 //	@Produce		json
 //	@Param			userID	path		string		true	"user id"
-//	@Success		200	{object}		[]reservation.Reservation	"ok"
+//	@Param			limit	query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		api.Page[reservation.Reservation]	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/reservation/user/{userID} [get]
@@ -352,7 +385,14 @@ func (h *Handler) GetAllReservationsByUser(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	reservs, err := h.Svc.GetAllReservationsByUser(context.Background(), uuID)
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	reservs, err := h.Svc.GetAllReservationsByUser(context.Background(), uuID, page)
 	if err != nil {
 		h.Log.Error("failed to fetch reservations", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
@@ -437,3 +477,341 @@ func (h *Handler) GetOccupiedReservationsByUserID(w http.ResponseWriter, r *http
 
 	responseApi.WriteJson(w, r, http.StatusOK, occupiedReservations)
 }
+
+// ApproveReservation godoc
+//
+//	@Summary		Approve reservation
+//	@Description	Accept a requested reservation. Only the stay owner can do it
+//	@Tags			reservations
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			reservationID	path		string		true	"reservation id"
+//	@Success		200	{string}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Invalid status transition"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/reservation/{reservationID}/approve [post]
+func (h *Handler) ApproveReservation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reservation.ApproveReservation"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "reservationID"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.ApproveReservation(r.Context(), id, userID)
+	if err != nil {
+		h.Log.Error("failed to approve reservation", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully approved reservation")
+}
+
+// DeclineReservation godoc
+//
+//	@Summary		Decline reservation
+//	@Description	Reject a requested reservation, the reason is optional. Only the stay owner can do it
+//	@Tags			reservations
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			reservationID	path		string		true	"reservation id"
+//	@Param			request	body		reservation.StatusReasonEntity	false	"Decline reason"
+//	@Success		200	{string}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Invalid status transition"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/reservation/{reservationID}/decline [post]
+func (h *Handler) DeclineReservation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reservation.DeclineReservation"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "reservationID"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var reason reservation.StatusReasonEntity
+
+	if r.ContentLength != 0 {
+		err = render.DecodeJSON(r.Body, &reason)
+		if err != nil {
+			h.Log.Error("failed to decode JSON", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+	}
+
+	err = h.Svc.DeclineReservation(r.Context(), id, userID, reason.Reason)
+	if err != nil {
+		h.Log.Error("failed to decline reservation", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully declined reservation")
+}
+
+// MarkNoShow godoc
+//
+//	@Summary		Mark reservation as no-show
+//	@Description	Close the reservation whose guest didn't come, possible from the arrival day. Only the stay owner can do it
+//	@Tags			reservations
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			reservationID	path		string		true	"reservation id"
+//	@Success		200	{string}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Invalid status transition"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/reservation/{reservationID}/no-show [post]
+func (h *Handler) MarkNoShow(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reservation.MarkNoShow"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "reservationID"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.MarkNoShow(r.Context(), id, userID)
+	if err != nil {
+		h.Log.Error("failed to mark reservation as no-show", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully marked reservation as no-show")
+}
+
+// CancelReservation godoc
+//
+//	@Summary		Cancel reservation
+//	@Description	Cancel the reservation by the guest or the stay owner, the reason is optional.
+//	@Description	A paid reservation is refunded by the stay cancellation policy, or in full when the owner cancels it
+//	@Tags			reservations
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			reservationID	path		string		true	"reservation id"
+//	@Param			request	body		reservation.StatusReasonEntity	false	"Cancellation reason"
+//	@Success		200	{object}		reservation.Refund	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Invalid status transition"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/reservation/{reservationID}/cancel [post]
+func (h *Handler) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reservation.CancelReservation"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "reservationID"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var reason reservation.StatusReasonEntity
+
+	if r.ContentLength != 0 {
+		err = render.DecodeJSON(r.Body, &reason)
+		if err != nil {
+			h.Log.Error("failed to decode JSON", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+	}
+
+	refund, err := h.Svc.CancelReservation(r.Context(), id, userID, reason.Reason)
+	if err != nil {
+		h.Log.Error("failed to cancel reservation", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	if refund.Status == reservation.RefundFailed {
+		h.Log.Error("failed to request refund", slog.String("reservation_id", id.String()))
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, refund)
+}
+
+// RetryRefund godoc
+//
+//	@Summary		Retry reservation refund
+//	@Description	Request the failed refund of the cancelled reservation again. Only the guest and the stay owner can retry it
+//	@Tags			reservations
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			reservationID	path		string		true	"reservation id"
+//	@Success		200	{object}		reservation.Refund	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"No failed refund"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/reservation/{reservationID}/refund [post]
+func (h *Handler) RetryRefund(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reservation.RetryRefund"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "reservationID"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	refund, err := h.Svc.RetryRefund(r.Context(), id, userID)
+	if err != nil {
+		h.Log.Error("failed to retry refund", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	if refund.Status == reservation.RefundFailed {
+		h.Log.Error("failed to request refund", slog.String("reservation_id", id.String()))
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, refund)
+}
+
+// GetReservationHistory godoc
+//
+//	@Summary		Get reservation status history
+//	@Description	Get the status changes of the reservation. Only the guest and the stay owner can see them
+//	@Tags			reservations
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			reservationID	path		string		true	"reservation id"
+//	@Success		200	{object}		[]reservation.StatusChange	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/reservation/{reservationID}/history [get]
+func (h *Handler) GetReservationHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reservation.GetReservationHistory"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "reservationID"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	history, err := h.Svc.GetReservationHistory(r.Context(), id, userID)
+	if err != nil {
+		h.Log.Error("failed to get reservation history", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, history)
+}
+
+func (h *Handler) writeStatusError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrTimeNotCome), errors.Is(err, service.ErrTimeHasNotCome),
+		errors.Is(err, service.ErrInvalidArrivalDate), errors.Is(err, service.ErrInvalidDepartureDate),
+		errors.Is(err, service.ErrInvalidGuests), errors.Is(err, service.ErrTooManyGuests):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrUserNotOwner), errors.Is(err, service.ErrNotReservationParticipant), errors.Is(err, service.ErrNotReservationGuest):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrNotFoundReservation), errors.Is(err, service.ErrNoReservations), errors.Is(err, service.ErrStayNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrInvalidStatusTransition), errors.Is(err, service.ErrReservationNotEditable), errors.Is(err, service.ErrRefundNotFailed),
+		errors.Is(err, service.ErrAlreadyReservedDate), errors.Is(err, service.ErrDatesBlocked):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
@@ -2,13 +2,18 @@ package reservation
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -36,6 +41,12 @@ func TestReservationHandler_NewReservationHandler(t *testing.T) {
 }
 
 func TestReservationHandler_CreateReservation(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -45,6 +56,7 @@ func TestReservationHandler_CreateReservation(t *testing.T) {
 		Log: log,
 	}
 	router := chi.NewRouter()
+	router.HandleFunc("/reservation/create", hdl.CreateReservation)
 
 	fakeUUID, _ := uuid.NewV4()
 
@@ -61,15 +73,24 @@ func TestReservationHandler_CreateReservation(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("CreateReservation", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("CheckReservation", mock.Anything, mock.Anything, fakeUserID.String()).Return(nil).Once()
+		svc.On("CreateReservation", mock.Anything, mock.Anything, fakeUserID.String()).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/reservation/create", pBuf)
 
-		router.HandleFunc("/reservation/create", hdl.CreateReservation)
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be error unauthorized", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/reservation/create", bytes.NewBuffer(pBytes))
 
 		router.ServeHTTP(r, req)
 
-		assert.Equal(t, http.StatusCreated, r.Code)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
 	})
 
 	t.Run("should be error decoding body", func(t *testing.T) {
@@ -77,9 +98,32 @@ func TestReservationHandler_CreateReservation(t *testing.T) {
 
 		req := httptest.NewRequest(http.MethodPost, "/reservation/create", strings.NewReader(""))
 
-		router.HandleFunc("/reservation/create", hdl.CreateReservation)
+		router.ServeHTTP(r, withUser(req))
 
-		router.ServeHTTP(r, req)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error dates are taken", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CheckReservation", mock.Anything, mock.Anything, fakeUserID.String()).Return(service.ErrAlreadyReservedDate).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/reservation/create", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error too many guests", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CheckReservation", mock.Anything, mock.Anything, fakeUserID.String()).Return(nil).Once()
+		svc.On("CreateReservation", mock.Anything, mock.Anything, fakeUserID.String()).Return(service.ErrTooManyGuests).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/reservation/create", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -89,19 +133,24 @@ func TestReservationHandler_CreateReservation(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("CreateReservation", mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
+		svc.On("CheckReservation", mock.Anything, mock.Anything, fakeUserID.String()).Return(nil).Once()
+		svc.On("CreateReservation", mock.Anything, mock.Anything, fakeUserID.String()).Return(errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/reservation/create", pBuf)
 
-		router.HandleFunc("/reservation/create", hdl.CreateReservation)
-
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
 }
 
 func TestReservationHandler_UpdateReservation(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -127,13 +176,13 @@ func TestReservationHandler_UpdateReservation(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("UpdateReservation", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("UpdateReservation", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/reservation/"+fakeUUID.String(), pBuf)
 
 		router.HandleFunc("/reservation/{reservationId}", hdl.UpdateReservation)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -145,7 +194,7 @@ func TestReservationHandler_UpdateReservation(t *testing.T) {
 
 		router.HandleFunc("/reservation/{reservationId}", hdl.UpdateReservation)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -155,19 +204,25 @@ func TestReservationHandler_UpdateReservation(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("UpdateReservation", mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
+		svc.On("UpdateReservation", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/reservation/"+fakeUUID.String(), pBuf)
 
 		router.HandleFunc("/reservation/{reservationId}", hdl.UpdateReservation)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
 
 func TestReservationHandler_DeleteReservationByID(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -183,13 +238,13 @@ func TestReservationHandler_DeleteReservationByID(t *testing.T) {
 	t.Run("should be no errors", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteReservationByID", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("DeleteReservationByID", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/reservation/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/reservation/{reservationID}", hdl.DeleteReservationByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
@@ -203,7 +258,7 @@ func TestReservationHandler_DeleteReservationByID(t *testing.T) {
 
 		router.HandleFunc("/reservation/{reservationID}", hdl.DeleteReservationByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -211,16 +266,30 @@ func TestReservationHandler_DeleteReservationByID(t *testing.T) {
 	t.Run("should be error deleting reservation", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteReservationByID", mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
+		svc.On("DeleteReservationByID", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/reservation/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/reservation/{reservationID}", hdl.DeleteReservationByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should be error reservation is not a request", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteReservationByID", mock.Anything, fakeUUID, fakeUserID.String()).Return(service.ErrReservationNotEditable).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/reservation/"+fakeUUID.String(), nil)
+
+		router.HandleFunc("/reservation/{reservationID}", hdl.DeleteReservationByID)
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
 }
 
 func TestReservationHandler_GetReservationByID(t *testing.T) {
@@ -317,7 +386,7 @@ func TestReservationHandler_GetAllReservationsByUser(t *testing.T) {
 	t.Run("should be no errors", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("GetAllReservationsByUser", mock.Anything, mock.Anything).Return(&expected, nil).Once()
+		svc.On("GetAllReservationsByUser", mock.Anything, fakeUUID, api.PageRequest{Limit: api.DefaultLimit}).Return(api.Page[reservation.Reservation]{Items: expected}, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/reservation/user/"+fakeUUID.String(), nil)
 
@@ -345,7 +414,7 @@ func TestReservationHandler_GetAllReservationsByUser(t *testing.T) {
 	t.Run("should be error getting reservation", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("GetAllReservationsByUser", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+		svc.On("GetAllReservationsByUser", mock.Anything, mock.Anything, mock.Anything).Return(api.Page[reservation.Reservation]{}, errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/reservation/user/"+fakeUUID.String(), nil)
 
@@ -358,6 +427,12 @@ func TestReservationHandler_GetAllReservationsByUser(t *testing.T) {
 }
 
 func TestReservationHandler_UpdateReservation_2(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -386,7 +461,7 @@ func TestReservationHandler_UpdateReservation_2(t *testing.T) {
 
 		router.HandleFunc("/reservation/update/{reservationID}", hdl.UpdateReservation)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -398,9 +473,9 @@ func TestReservationHandler_UpdateReservation_2(t *testing.T) {
 
 		router.HandleFunc("/reservation/update/{reservationID}", hdl.UpdateReservation)
 
-		svc.On("UpdateReservation", mock.Anything, mock.Anything).Return(errors.New("failed to update reservation")).Once()
+		svc.On("UpdateReservation", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed to update reservation")).Once()
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -412,11 +487,11 @@ func TestReservationHandler_UpdateReservation_2(t *testing.T) {
 
 		router.HandleFunc("/reservation/update/{reservationID}", hdl.UpdateReservation)
 
-		svc.On("UpdateReservation", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("UpdateReservation", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		svc.On("GetReservationByID", mock.Anything, fakeUUID).Return(nil, errors.New("failed to find reservation")).Once()
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -428,12 +503,310 @@ func TestReservationHandler_UpdateReservation_2(t *testing.T) {
 
 		router.HandleFunc("/reservation/update/{reservationID}", hdl.UpdateReservation)
 
-		svc.On("UpdateReservation", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("UpdateReservation", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		svc.On("GetReservationByID", mock.Anything, fakeUUID).Return(nil, nil).Once()
 
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestReservationHandler_ApproveReservation(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ReservationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+	router := chi.NewRouter()
+	router.Post("/reservation/{reservationID}/approve", hdl.ApproveReservation)
+
+	fakeUUID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/reservation/"+id+"/approve", nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ApproveReservation", mock.Anything, fakeUUID, userID.String()).Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(fakeUUID.String()))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be unauthorized", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/reservation/"+fakeUUID.String()+"/approve", nil)
+
 		router.ServeHTTP(r, req)
 
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("should be error parsing uuid", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error user not owner", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ApproveReservation", mock.Anything, fakeUUID, userID.String()).Return(service.ErrUserNotOwner).Once()
+
+		router.ServeHTTP(r, newRequest(fakeUUID.String()))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error invalid status transition", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ApproveReservation", mock.Anything, fakeUUID, userID.String()).Return(service.ErrInvalidStatusTransition).Once()
+
+		router.ServeHTTP(r, newRequest(fakeUUID.String()))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestReservationHandler_DeclineReservation(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ReservationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+	router := chi.NewRouter()
+	router.Post("/reservation/{reservationID}/decline", hdl.DeclineReservation)
+
+	fakeUUID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/reservation/"+fakeUUID.String()+"/decline", strings.NewReader(body))
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeclineReservation", mock.Anything, fakeUUID, userID.String(), "dates are taken").Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(`{"reason": "dates are taken"}`))
+
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+
+	t.Run("should be no errors without reason", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeclineReservation", mock.Anything, fakeUUID, userID.String(), "").Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(""))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("{"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error reservation not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeclineReservation", mock.Anything, fakeUUID, userID.String(), "").Return(service.ErrNotFoundReservation).Once()
+
+		router.ServeHTTP(r, newRequest(""))
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestReservationHandler_GetReservationHistory(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ReservationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+	router := chi.NewRouter()
+	router.Get("/reservation/{reservationID}/history", hdl.GetReservationHistory)
+
+	fakeUUID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/reservation/"+fakeUUID.String()+"/history", nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		history := []reservation.StatusChange{
+			{ReservationID: fakeUUID, To: reservation.StatusRequested},
+		}
+
+		svc.On("GetReservationHistory", mock.Anything, fakeUUID, userID.String()).Return(history, nil).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"to_status":"requested"`)
+	})
+
+	t.Run("should be error not participant", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetReservationHistory", mock.Anything, fakeUUID, userID.String()).Return(nil, service.ErrNotReservationParticipant).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestReservationHandler_CancelReservation(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ReservationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+	router := chi.NewRouter()
+	router.Post("/reservation/{reservationID}/cancel", hdl.CancelReservation)
+
+	fakeUUID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/reservation/"+fakeUUID.String()+"/cancel", strings.NewReader(body))
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		refund := &reservation.Refund{
+			Percent: 50,
+			Amount:  money.New(150000, money.BaseCurrency),
+			Status:  reservation.RefundRequested,
+		}
+
+		svc.On("CancelReservation", mock.Anything, fakeUUID, userID.String(), "plans changed").Return(refund, nil).Once()
+
+		router.ServeHTTP(r, newRequest(`{"reason": "plans changed"}`))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"status":"requested"`)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("{"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error not participant", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CancelReservation", mock.Anything, fakeUUID, userID.String(), "").Return(nil, service.ErrNotReservationParticipant).Once()
+
+		router.ServeHTTP(r, newRequest(""))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error already completed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CancelReservation", mock.Anything, fakeUUID, userID.String(), "").Return(nil, service.ErrInvalidStatusTransition).Once()
+
+		router.ServeHTTP(r, newRequest(""))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestReservationHandler_RetryRefund(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.ReservationService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+	router := chi.NewRouter()
+	router.Post("/reservation/{reservationID}/refund", hdl.RetryRefund)
+
+	fakeUUID, _ := uuid.NewV4()
+	userID, _ := uuid.NewV4()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/reservation/"+fakeUUID.String()+"/refund", nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		refund := &reservation.Refund{
+			Percent: 100,
+			Amount:  money.New(300000, money.BaseCurrency),
+			Status:  reservation.RefundRequested,
+		}
+
+		svc.On("RetryRefund", mock.Anything, fakeUUID, userID.String()).Return(refund, nil).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `"status":"requested"`)
+	})
+
+	t.Run("should be error refund not failed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RetryRefund", mock.Anything, fakeUUID, userID.String()).Return(nil, service.ErrRefundNotFailed).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error not participant", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("RetryRefund", mock.Anything, fakeUUID, userID.String()).Return(nil, service.ErrNotReservationParticipant).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
 }
@@ -0,0 +1,242 @@
+package role
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	Svc interfaces.RoleService
+	Log *slog.Logger
+}
+
+// NewRoleHandler registers the grant management, it stays admin only whatever the grants are
+// so that the admins can't lock themselves out
+func (h *Handler) NewRoleHandler(r chi.Router) {
+	r.Route("/roles", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithAuth)
+			r.Use(mw.WithAdmin)
+			r.Get("/", h.GetRoles)
+			r.Get("/objects", h.GetObjects)
+			r.Post("/objects", h.CreateObject)
+			r.Put("/{roleId}/objects/{objectId}", h.GrantObject)
+			r.Delete("/{roleId}/objects/{objectId}", h.RevokeObject)
+		})
+	})
+}
+
+// GetRoles godoc
+//
+//	@Summary		Get roles
+//	@Description	Get the roles with the objects granted to them, admin only
+//	@Tags			roles
+//	@Accept			application/json
+//	@Produce		json
+//	@Success		200	{object}		[]role.Role	"ok"
+//	@Failure		403		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/roles [get]
+func (h *Handler) GetRoles(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.role.GetRoles"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	roles, err := h.Svc.GetRoles(r.Context())
+	if err != nil {
+		h.Log.Error("failed to get roles", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, roles)
+}
+
+// GetObjects godoc
+//
+//	@Summary		Get adm objects
+//	@Description	Get the routes with the actions the roles can be granted, admin only
+//	@Tags			roles
+//	@Accept			application/json
+//	@Produce		json
+//	@Success		200	{object}		[]role.Object	"ok"
+//	@Failure		403		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/roles/objects [get]
+func (h *Handler) GetObjects(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.role.GetObjects"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	objects, err := h.Svc.GetObjects(r.Context())
+	if err != nil {
+		h.Log.Error("failed to get adm objects", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, objects)
+}
+
+// CreateObject godoc
+//
+//	@Summary		Create adm object
+//	@Description	Create the route with the actions the roles can be granted, the actions are read, create, update and delete. Admin only
+//	@Tags			roles
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			request	body		role.ObjectEntity		true	"adm object"
+//	@Success		201	{object}		role.Object	"created"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		403		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/roles/objects [post]
+func (h *Handler) CreateObject(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.role.CreateObject"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var object role.ObjectEntity
+
+	err := render.DecodeJSON(r.Body, &object)
+	if err != nil {
+		h.Log.Error("failed to decode request body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	created, err := h.Svc.CreateObject(r.Context(), &object)
+	if err != nil {
+		h.Log.Error("failed to create adm object", slogError.Err(err))
+		h.writeRoleError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, created)
+}
+
+// GrantObject godoc
+//
+//	@Summary		Grant adm object
+//	@Description	Allow the role the actions of the object, admin only
+//	@Tags			roles
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			roleId	path		int		true	"role id"
+//	@Param			objectId	path		int		true	"adm object id"
+//	@Success		200	{object}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		403		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/roles/{roleId}/objects/{objectId} [put]
+func (h *Handler) GrantObject(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.role.GrantObject"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	roleID, objectID, err := grantParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse grant", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.GrantObject(r.Context(), roleID, objectID)
+	if err != nil {
+		h.Log.Error("failed to grant adm object", slogError.Err(err))
+		h.writeRoleError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully granted")
+}
+
+// RevokeObject godoc
+//
+//	@Summary		Revoke adm object
+//	@Description	Take the actions of the object away from the role, admin only
+//	@Tags			roles
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			roleId	path		int		true	"role id"
+//	@Param			objectId	path		int		true	"adm object id"
+//	@Success		200	{object}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		403		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/roles/{roleId}/objects/{objectId} [delete]
+func (h *Handler) RevokeObject(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.role.RevokeObject"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	roleID, objectID, err := grantParams(r)
+	if err != nil {
+		h.Log.Error("failed to parse grant", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.RevokeObject(r.Context(), roleID, objectID)
+	if err != nil {
+		h.Log.Error("failed to revoke adm object", slogError.Err(err))
+		h.writeRoleError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully revoked")
+}
+
+func grantParams(r *http.Request) (int, int, error) {
+	roleID, err := strconv.Atoi(chi.URLParam(r, "roleId"))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid role id")
+	}
+
+	objectID, err := strconv.Atoi(chi.URLParam(r, "objectId"))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid object id")
+	}
+
+	return roleID, objectID, nil
+}
+
+func (h *Handler) writeRoleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrRoleNotFound), errors.Is(err, service.ErrAdmObjectNotFound), errors.Is(err, service.ErrGrantNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
@@ -0,0 +1,161 @@
+package role
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoleHandler_GetRoles(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.RoleService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Get("/roles", hdl.GetRoles)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		roles := []role.Role{{ID: role.AdminID, Name: "admin", Objects: []role.Object{}}}
+
+		svc.On("GetRoles", mock.Anything).Return(roles, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/roles", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error getting roles", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetRoles", mock.Anything).Return(nil, errors.New("failed to get roles")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/roles", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestRoleHandler_CreateObject(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.RoleService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Post("/roles/objects", hdl.CreateObject)
+
+	object := role.ObjectEntity{Route: "/locations", Actions: []role.Action{role.ActionCreate}}
+	oBytes, _ := json.Marshal(object)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateObject", mock.Anything, &object).Return(&role.Object{ID: 1, Route: object.Route, Actions: object.Actions}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/roles/objects", bytes.NewBuffer(oBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/roles/objects", bytes.NewBufferString("invalid"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateObject", mock.Anything, &object).Return(nil, fmt.Errorf("%w: unknown action", service.ErrValid)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/roles/objects", bytes.NewBuffer(oBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRoleHandler_GrantObject(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.RoleService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+
+	router.Put("/roles/{roleId}/objects/{objectId}", hdl.GrantObject)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GrantObject", mock.Anything, role.UserID, 3).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/roles/1/objects/3", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error parsing role id", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPut, "/roles/invalid/objects/3", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error role not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GrantObject", mock.Anything, 42, 3).Return(fmt.Errorf("service.role.GrantObject: %w", service.ErrRoleNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/roles/42/objects/3", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
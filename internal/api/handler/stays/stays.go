@@ -6,16 +6,24 @@ import (
 	"github.com/go-chi/render"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/amenity"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/geo"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/sort"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 const (
@@ -23,8 +31,9 @@ const (
 )
 
 type Handler struct {
-	Svc interfaces.StaysService
-	Log *slog.Logger
+	Svc     interfaces.StaysService
+	RoleSvc interfaces.RoleService
+	Log     *slog.Logger
 }
 
 func (h *Handler) NewStaysHandler(r chi.Router) {
@@ -38,12 +47,39 @@ func (h *Handler) NewStaysHandler(r chi.Router) {
 			r.Post("/images", h.CreateImages)
 			r.Post("/images/main", h.CreateMainImage)
 			r.Delete("/images/{imageId}", h.DeleteStayImage)
+			r.Get("/{stayId}/calendar/blocks", h.GetCalendarBlocks)
+			r.Post("/{stayId}/calendar/blocks", h.CreateCalendarBlock)
+			r.Delete("/{stayId}/calendar/blocks/{blockId}", h.DeleteCalendarBlock)
+			r.Put("/{stayId}/pricing", h.UpdatePricing)
+			r.Post("/{stayId}/pricing/seasons", h.CreateSeasonalPrice)
+			r.Delete("/{stayId}/pricing/seasons/{seasonId}", h.DeleteSeasonalPrice)
+			r.Get("/{stayId}/completeness", h.GetCompleteness)
+			r.Put("/{stayId}/submit", h.SubmitStay)
+			r.Put("/{stayId}/archive", h.ArchiveStay)
+			r.Put("/{stayId}/unarchive", h.UnarchiveStay)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithAuth)
+			r.Use(mw.WithPermission(h.RoleSvc, "/stays/review"))
+			r.Get("/review", h.GetStaysForReview)
+			r.Put("/{stayId}/review", h.ReviewStay)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithOptionalAuth)
+			r.Get("/search", h.SearchStays)
+			r.Get("/{stayId}", h.GetStayByID)
 		})
 
 		r.Group(func(r chi.Router) {
 			r.Get("/", h.GetStays)
 			r.Get("/statistics/{userId}", h.GetStatistics)
-			r.Get("/{stayId}", h.GetStayByID)
+			r.Get("/nearby", h.GetNearbyStays)
+			r.Get("/bbox", h.GetStaysInBoundingBox)
+			r.Get("/{stayId}/calendar", h.GetCalendar)
+			r.Get("/{stayId}/quote", h.GetQuote)
+			r.Get("/{stayId}/pricing", h.GetPricing)
 			r.Get("/user/{userId}", h.GetStaysByUserID)
 			r.Get("/images/{stayId}", h.GetStayImagesByStayID)
 			r.Get("/images/main/{stayId}", h.GetMainImageByStayID)
@@ -90,13 +126,14 @@ func (h *Handler) GetStatistics(w http.ResponseWriter, r *http.Request) {
 // CreateStay godoc
 //
 //	@Summary		Create Stay
-//	@Description	Create stay
+//	@Description	Create stay of the logged in user, the user_id of the request is ignored. The stay is a draft until it is submitted for review and approved
 //	@Tags			stays
 //	@Accept			application/json
 //	@Produce		json
 //	@Param	request body model.StayEntity	true	"request stay data"
 //	@Success		201	{string}		string		"created"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays [post]
 func (h *Handler) CreateStay(w http.ResponseWriter, r *http.Request) {
@@ -107,6 +144,13 @@ func (h *Handler) CreateStay(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	var newStay model.StayEntity
 
 	err := render.DecodeJSON(r.Body, &newStay)
@@ -116,9 +160,15 @@ func (h *Handler) CreateStay(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	newStay.UserID = uuid.FromStringOrNil(userID)
+
 	err = h.Svc.CreateStay(r.Context(), &newStay)
 	if err != nil {
 		h.Log.Error("failed to create stay: ", slogError.Err(err))
+		if errors.Is(err, service.ErrValid) || errors.Is(err, service.ErrCurrencyNotFound) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
@@ -129,13 +179,14 @@ func (h *Handler) CreateStay(w http.ResponseWriter, r *http.Request) {
 // GetStayByID godoc
 //
 //	@Summary		Get Stay by id
-//	@Description	get stay
+//	@Description	get the published stay, the owner gets the stay in any status
 //	@Tags			stays
 //	@Accept			application/json
 //	@Produce		json
 //	@Param			stayId	path		string		true	"stay id"
 //	@Success		200	{object}		model.Stay		"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays/{stayId} [get]
 func (h *Handler) GetStayByID(w http.ResponseWriter, r *http.Request) {
@@ -154,9 +205,15 @@ func (h *Handler) GetStayByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stay, err := h.Svc.GetStayByID(r.Context(), idUuid)
+	userID, _ := r.Context().Value(mw.UserIdKey).(string)
+
+	stay, err := h.Svc.GetPublicStayByID(r.Context(), idUuid, userID)
 	if err != nil {
 		h.Log.Error("failed to fetch stay by id %s: %v", slogError.Err(err))
+		if errors.Is(err, service.ErrStayNotFound) {
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
@@ -167,11 +224,13 @@ func (h *Handler) GetStayByID(w http.ResponseWriter, r *http.Request) {
 // GetStays godoc
 //
 //	@Summary		Get all stays
-//	@Description	Get all stays
+//	@Description	Get all stays page by page, ordered by creation time
 //	@Tags			stays
 //	@Accept			application/json
 //	@Produce		json
-//	@Success		200	{object}		[]model.StayResponse	"ok"
+//	@Param			limit	query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		api.Page[model.StayResponse]	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays [get]
@@ -183,7 +242,14 @@ func (h *Handler) GetStays(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
-	stays, err := h.Svc.GetStays(r.Context())
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	stays, err := h.Svc.GetStays(r.Context(), page)
 	if err != nil {
 		h.Log.Error("failed to fetch stays: ", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
@@ -203,6 +269,9 @@ func (h *Handler) GetStays(w http.ResponseWriter, r *http.Request) {
 //	@Param			stayId	path		string		true	"stay id"
 //	@Success		204	{string}		string	"no content"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays/{stayId} [delete]
 func (h *Handler) DeleteStayByID(w http.ResponseWriter, r *http.Request) {
@@ -213,6 +282,13 @@ func (h *Handler) DeleteStayByID(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	stayId := chi.URLParam(r, "stayId")
 	idUuid, err := uuid.FromString(stayId)
 	if err != nil {
@@ -221,10 +297,10 @@ func (h *Handler) DeleteStayByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.DeleteStayByID(r.Context(), idUuid)
+	err = h.Svc.DeleteStayByID(r.Context(), idUuid, userID)
 	if err != nil {
 		h.Log.Error("failed to delete stay by id %s: %v", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeOwnerError(w, r, err)
 		return
 	}
 
@@ -234,13 +310,16 @@ func (h *Handler) DeleteStayByID(w http.ResponseWriter, r *http.Request) {
 // UpdateStayByID godoc
 //
 //	@Summary		Update Stay
-//	@Description	Update stay by id
+//	@Description	Update stay by id, the published stay goes back to the review
 //	@Tags			stays
 //	@Accept			application/json
 //	@Produce		json
 //	@Param	request body model.StayEntity	true	"request stay data"
 //	@Success		200	{object}		model.Stay	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays/{stayId} [put]
 func (h *Handler) UpdateStayByID(w http.ResponseWriter, r *http.Request) {
@@ -251,6 +330,13 @@ func (h *Handler) UpdateStayByID(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	stayId := chi.URLParam(r, "stayId")
 	idUuid, err := uuid.FromString(stayId)
 	if err != nil {
@@ -268,10 +354,10 @@ func (h *Handler) UpdateStayByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedStay, err := h.Svc.UpdateStayByID(r.Context(), &newStay, idUuid)
+	updatedStay, err := h.Svc.UpdateStayByID(r.Context(), &newStay, idUuid, userID)
 	if err != nil {
 		h.Log.Error("failed to update stay by id %s: %v", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeOwnerError(w, r, err)
 		return
 	}
 
@@ -403,6 +489,9 @@ func (h *Handler) GetMainImageByStayID(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Success		200	{object}		string	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays/images [post]
 func (h *Handler) CreateImages(w http.ResponseWriter, r *http.Request) {
@@ -413,6 +502,13 @@ func (h *Handler) CreateImages(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	err := r.ParseMultipartForm(MaxImageMemorySize)
 	if err != nil {
 		h.Log.Error("%s: %v", op, err)
@@ -434,10 +530,10 @@ func (h *Handler) CreateImages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.CreateImages(r.Context(), images, stayIDUuid)
+	err = h.Svc.CreateImages(r.Context(), images, stayIDUuid, userID)
 	if err != nil {
 		h.Log.Error("%s: %v", op, err)
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeOwnerError(w, r, err)
 		return
 	}
 
@@ -455,6 +551,9 @@ func (h *Handler) CreateImages(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Success		200	{object}		string	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays/images/main [post]
 func (h *Handler) CreateMainImage(w http.ResponseWriter, r *http.Request) {
@@ -465,6 +564,13 @@ func (h *Handler) CreateMainImage(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	err := r.ParseMultipartForm(MaxImageMemorySize)
 	if err != nil {
 		h.Log.Error("%s: %v", op, err)
@@ -485,10 +591,10 @@ func (h *Handler) CreateMainImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.CreateMainImage(r.Context(), mainImage, stayIDUuid)
+	err = h.Svc.CreateMainImage(r.Context(), mainImage, stayIDUuid, userID)
 	if err != nil {
 		h.Log.Error("%s: %v", op, err)
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeOwnerError(w, r, err)
 		return
 	}
 
@@ -505,6 +611,9 @@ func (h *Handler) CreateMainImage(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Success		200	{object}		string	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/stays/images/{imageId} [delete]
 func (h *Handler) DeleteStayImage(w http.ResponseWriter, r *http.Request) {
@@ -515,6 +624,13 @@ func (h *Handler) DeleteStayImage(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	imageID := chi.URLParam(r, "imageId")
 	imageIDUuid, err := uuid.FromString(imageID)
 	if err != nil {
@@ -523,10 +639,10 @@ func (h *Handler) DeleteStayImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.DeleteStayImage(r.Context(), imageIDUuid)
+	err = h.Svc.DeleteStayImage(r.Context(), imageIDUuid, userID)
 	if err != nil {
 		h.Log.Error("%s: %v", op, err)
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeOwnerError(w, r, err)
 		return
 	}
 
@@ -616,6 +732,10 @@ func (h *Handler) Filtration(w http.ResponseWriter, r *http.Request) {
 	result, err := h.Svc.Filtration(r.Context(), searchValues)
 	if err != nil {
 		h.Log.Error("failed to search", slogError.Err(err))
+		if errors.Is(err, service.ErrCurrencyNotFound) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
@@ -677,3 +797,964 @@ func (h *Handler) GetSorts(w http.ResponseWriter, r *http.Request) {
 
 	responseApi.WriteJson(w, r, http.StatusOK, result)
 }
+
+// GetCalendar godoc
+//
+//	@Summary		Get stay calendar
+//	@Description	Per-day availability of the stay (free, reserved, checked_in, blocked). Dates are in YYYY-MM-DD format, "to" is exclusive
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Param			from	query		string		true	"first day"	Example(2025-01-01)
+//	@Param			to		query		string		true	"day after the last one"	Example(2025-02-01)
+//	@Success		200	{object}		[]reservation.CalendarDay	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/calendar [get]
+func (h *Handler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetCalendar"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	from, err := time.Parse(time.DateOnly, r.URL.Query().Get("from"))
+	if err != nil {
+		h.Log.Error("failed to parse from date", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	to, err := time.Parse(time.DateOnly, r.URL.Query().Get("to"))
+	if err != nil {
+		h.Log.Error("failed to parse to date", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	days, err := h.Svc.GetCalendar(r.Context(), stayID, from, to)
+	if err != nil {
+		h.Log.Error("failed to get calendar", slogError.Err(err))
+		switch {
+		case errors.Is(err, service.ErrInvalidCalendarRange):
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		case errors.Is(err, service.ErrStayNotFound):
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+		default:
+			responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		}
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, days)
+}
+
+// GetCalendarBlocks godoc
+//
+//	@Summary		Get stay date blocks
+//	@Description	The manual blocks of the stay calendar by the first day. Only the stay owner can see them
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Success		200	{object}		[]reservation.StayBlock	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Stay not found"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/calendar/blocks [get]
+func (h *Handler) GetCalendarBlocks(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetCalendarBlocks"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	blocks, err := h.Svc.GetCalendarBlocks(r.Context(), stayID, userID)
+	if err != nil {
+		h.Log.Error("failed to get blocked dates", slogError.Err(err))
+		switch {
+		case errors.Is(err, service.ErrUserNotOwner):
+			responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+		case errors.Is(err, service.ErrStayNotFound):
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+		default:
+			responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		}
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, blocks)
+}
+
+// CreateCalendarBlock godoc
+//
+//	@Summary		Block stay dates
+//	@Description	Close a date range for booking. Only the stay owner can do it, "date_end" is exclusive
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Param			request	body		reservation.StayBlockEntity	true	"blocked dates"
+//	@Success		201	{object}		reservation.StayBlock	"created"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Stay not found"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/calendar/blocks [post]
+func (h *Handler) CreateCalendarBlock(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.CreateCalendarBlock"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var block reservation.StayBlockEntity
+
+	err = render.DecodeJSON(r.Body, &block)
+	if err != nil {
+		h.Log.Error("failed to decode JSON", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	block.StayID = stayID
+
+	created, err := h.Svc.CreateCalendarBlock(r.Context(), &block, userID)
+	if err != nil {
+		h.Log.Error("failed to block dates", slogError.Err(err))
+		switch {
+		case errors.Is(err, service.ErrInvalidCalendarRange), errors.Is(err, service.ErrAlreadyReservedDate):
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		case errors.Is(err, service.ErrUserNotOwner):
+			responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+		case errors.Is(err, service.ErrStayNotFound):
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+		default:
+			responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		}
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, created)
+}
+
+// DeleteCalendarBlock godoc
+//
+//	@Summary		Unblock stay dates
+//	@Description	Remove a manual block from the stay calendar. Only the stay owner can do it
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Param			blockId	path		string		true	"block id"
+//	@Success		200	{string}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Block of the stay not found"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/calendar/blocks/{blockId} [delete]
+func (h *Handler) DeleteCalendarBlock(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.DeleteCalendarBlock"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	blockID, err := uuid.FromString(chi.URLParam(r, "blockId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.DeleteCalendarBlock(r.Context(), stayID, blockID, userID)
+	if err != nil {
+		h.Log.Error("failed to unblock dates", slogError.Err(err))
+		switch {
+		case errors.Is(err, service.ErrUserNotOwner):
+			responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+		case errors.Is(err, service.ErrStayBlockNotFound):
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+		default:
+			responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		}
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully unblocked dates")
+}
+
+// GetNearbyStays godoc
+//
+//	@Summary		Get stays near a point
+//	@Description	Stays within radius_km of the given point, nearest first. Radius is limited to 500 km
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			lat			query		number		true	"latitude"	Example(55.7558)
+//	@Param			lon			query		number		true	"longitude"	Example(37.6173)
+//	@Param			radius_km	query		number		true	"search radius in kilometers"	Example(10)
+//	@Success		200	{object}		[]model.StayNearby	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/nearby [get]
+func (h *Handler) GetNearbyStays(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetNearbyStays"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	values, err := parseFloatParams(r, "lat", "lon", "radius_km")
+	if err != nil {
+		h.Log.Error("failed to parse query params", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	point := geo.Point{Lat: values[0], Lon: values[1]}
+
+	result, err := h.Svc.GetNearbyStays(r.Context(), point, values[2])
+	if err != nil {
+		h.Log.Error("failed to get nearby stays", slogError.Err(err))
+		if errors.Is(err, service.ErrInvalidGeoQuery) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, result)
+}
+
+// GetStaysInBoundingBox godoc
+//
+//	@Summary		Get stays in a map viewport
+//	@Description	Stays inside the bounding box, ordered by distance from its center. The box with min_lon greater than max_lon spans the antimeridian
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			min_lat	query		number		true	"south latitude"	Example(55.5)
+//	@Param			min_lon	query		number		true	"west longitude"	Example(37.3)
+//	@Param			max_lat	query		number		true	"north latitude"	Example(56.0)
+//	@Param			max_lon	query		number		true	"east longitude"	Example(37.9)
+//	@Success		200	{object}		[]model.StayNearby	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/bbox [get]
+func (h *Handler) GetStaysInBoundingBox(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetStaysInBoundingBox"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	values, err := parseFloatParams(r, "min_lat", "min_lon", "max_lat", "max_lon")
+	if err != nil {
+		h.Log.Error("failed to parse query params", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	box := geo.BoundingBox{MinLat: values[0], MinLon: values[1], MaxLat: values[2], MaxLon: values[3]}
+
+	result, err := h.Svc.GetStaysInBoundingBox(r.Context(), box)
+	if err != nil {
+		h.Log.Error("failed to get stays in bounding box", slogError.Err(err))
+		if errors.Is(err, service.ErrInvalidGeoQuery) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, result)
+}
+
+// SearchStays godoc
+//
+//	@Summary		Search stays
+//	@Description	Ranked full-text search over stay names, descriptions, addresses and owner rules. Queries of a logged-in user are saved to the search history
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			q		query		string		true	"search query"	Example(квартира у метро)
+//	@Param			limit	query		int			false	"max results, 20 by default and 100 at most"
+//	@Success		200	{object}		[]model.StaySearchResult	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/search [get]
+func (h *Handler) SearchStays(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.SearchStays"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var limit int
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil {
+			h.Log.Error("failed to parse limit", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+	}
+
+	userID, _ := r.Context().Value(mw.UserIdKey).(string)
+
+	result, err := h.Svc.SearchStays(r.Context(), r.URL.Query().Get("q"), limit, userID)
+	if errors.Is(err, service.ErrHistoryNotSaved) {
+		h.Log.Warn("stays searched without saving the history", slogError.Err(err))
+		err = nil
+	}
+	if err != nil {
+		h.Log.Error("failed to search stays", slogError.Err(err))
+		if errors.Is(err, service.ErrInvalidSearch) {
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, result)
+}
+
+func parseFloatParams(r *http.Request, names ...string) ([]float64, error) {
+	values := make([]float64, len(names))
+	for i, name := range names {
+		v, err := strconv.ParseFloat(r.URL.Query().Get(name), 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", name)
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+// GetQuote godoc
+//
+//	@Summary		Get stay price quote
+//	@Description	Itemised price of the stay for the dates and guests: nights by the nightly, weekend and seasonal prices, length of stay discounts, extra guests and cleaning fees. Dates are in YYYY-MM-DD format, "departure" is exclusive
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId		path		string		true	"stay id"
+//	@Param			arrival		query		string		true	"arrival day"	Example(2025-01-01)
+//	@Param			departure	query		string		true	"departure day"	Example(2025-01-08)
+//	@Param			guests		query		int			false	"number of guests, 1 by default"	Example(2)
+//	@Success		200	{object}		pricing.Quote	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/quote [get]
+func (h *Handler) GetQuote(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetQuote"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	arrival, err := time.Parse(time.DateOnly, r.URL.Query().Get("arrival"))
+	if err != nil {
+		h.Log.Error("failed to parse arrival date", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	departure, err := time.Parse(time.DateOnly, r.URL.Query().Get("departure"))
+	if err != nil {
+		h.Log.Error("failed to parse departure date", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	guests := 1
+	if g := r.URL.Query().Get("guests"); g != "" {
+		guests, err = strconv.Atoi(g)
+		if err != nil {
+			h.Log.Error("failed to parse guests", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+	}
+
+	quote, err := h.Svc.GetQuote(r.Context(), stayID, arrival, departure, guests)
+	if err != nil {
+		h.Log.Error("failed to get quote", slogError.Err(err))
+		switch {
+		case errors.Is(err, service.ErrInvalidQuote):
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		case errors.Is(err, service.ErrStayNotFound):
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+		default:
+			responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		}
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, quote)
+}
+
+// GetPricing godoc
+//
+//	@Summary		Get stay pricing rules
+//	@Description	Weekend surcharge, length of stay discounts, fees and seasonal prices the stay quotes are calculated with
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Success		200	{object}		pricing.Rules	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/pricing [get]
+func (h *Handler) GetPricing(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetPricing"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	rules, err := h.Svc.GetPricing(r.Context(), stayID)
+	if err != nil {
+		h.Log.Error("failed to get pricing", slogError.Err(err))
+		if errors.Is(err, service.ErrStayNotFound) {
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+			return
+		}
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, rules)
+}
+
+// UpdatePricing godoc
+//
+//	@Summary		Update stay pricing rules
+//	@Description	Set weekend surcharge, length of stay discounts and fees of the stay. Only the stay owner can do it
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Param			request	body		pricing.RulesEntity	true	"pricing rules"
+//	@Success		200	{string}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/pricing [put]
+func (h *Handler) UpdatePricing(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.UpdatePricing"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var rules pricing.RulesEntity
+
+	err = render.DecodeJSON(r.Body, &rules)
+	if err != nil {
+		h.Log.Error("failed to decode JSON", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.UpdatePricing(r.Context(), stayID, &rules, userID)
+	if err != nil {
+		h.Log.Error("failed to update pricing", slogError.Err(err))
+		h.writePricingError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully updated")
+}
+
+// CreateSeasonalPrice godoc
+//
+//	@Summary		Add stay seasonal price
+//	@Description	Replace the nightly price of the stay for a date range, seasons of a stay can't overlap. Only the stay owner can do it, "date_end" is exclusive
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Param			request	body		pricing.SeasonEntity	true	"seasonal price"
+//	@Success		201	{string}		string	"created"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		409		{object}	response.ResponseError			"Conflict"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/pricing/seasons [post]
+func (h *Handler) CreateSeasonalPrice(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.CreateSeasonalPrice"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var season pricing.SeasonEntity
+
+	err = render.DecodeJSON(r.Body, &season)
+	if err != nil {
+		h.Log.Error("failed to decode JSON", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.CreateSeasonalPrice(r.Context(), stayID, &season, userID)
+	if err != nil {
+		h.Log.Error("failed to create seasonal price", slogError.Err(err))
+		h.writePricingError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, "successfully created")
+}
+
+// DeleteSeasonalPrice godoc
+//
+//	@Summary		Delete stay seasonal price
+//	@Description	Remove a seasonal price of the stay. Only the stay owner can do it
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId		path		string		true	"stay id"
+//	@Param			seasonId	path		string		true	"seasonal price id"
+//	@Success		200	{string}		string	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Forbidden"
+//	@Failure		404		{object}	response.ResponseError			"Error"
+//	@Failure		500		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/pricing/seasons/{seasonId} [delete]
+func (h *Handler) DeleteSeasonalPrice(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.DeleteSeasonalPrice"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	seasonID, err := uuid.FromString(chi.URLParam(r, "seasonId"))
+	if err != nil {
+		h.Log.Error("failed to parse UUID", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	err = h.Svc.DeleteSeasonalPrice(r.Context(), stayID, seasonID, userID)
+	if err != nil {
+		h.Log.Error("failed to delete seasonal price", slogError.Err(err))
+		h.writePricingError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "successfully deleted")
+}
+
+// writeOwnerError maps the errors of the changes only the stay owner can make
+// GetCompleteness godoc
+//
+//	@Summary		Get stay completeness
+//	@Description	List the required fields and the main image the stay of the logged in user misses to be submitted for review
+//	@Tags			stays
+//	@Accept			json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Success		200	{object}		model.Completeness	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Stay not found"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/completeness [get]
+func (h *Handler) GetCompleteness(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetCompleteness"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse stay id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	completeness, err := h.Svc.GetCompleteness(r.Context(), stayID, userID)
+	if err != nil {
+		h.Log.Error("failed to get stay completeness", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, completeness)
+}
+
+// SubmitStay godoc
+//
+//	@Summary		Submit stay for review
+//	@Description	Send the draft of the logged in user for review. The stay needs all the required fields and the main image
+//	@Tags			stays
+//	@Accept			json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Success		200	{object}		model.Stay	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Stay not found"
+//	@Failure		409		{object}	response.ResponseError			"Stay is not a draft"
+//	@Failure		422		{object}	response.ResponseError			"Stay is incomplete"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/submit [put]
+func (h *Handler) SubmitStay(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "handler.stays.SubmitStay", h.Svc.SubmitStay)
+}
+
+// ArchiveStay godoc
+//
+//	@Summary		Archive stay
+//	@Description	Hide the draft or the published stay of the logged in user
+//	@Tags			stays
+//	@Accept			json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Success		200	{object}		model.Stay	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Stay not found"
+//	@Failure		409		{object}	response.ResponseError			"Stay is pending review or already archived"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/archive [put]
+func (h *Handler) ArchiveStay(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "handler.stays.ArchiveStay", h.Svc.ArchiveStay)
+}
+
+// UnarchiveStay godoc
+//
+//	@Summary		Unarchive stay
+//	@Description	Turn the archived stay of the logged in user into a draft, it is submitted for review again to be published
+//	@Tags			stays
+//	@Accept			json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Success		200	{object}		model.Stay	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Stay not found"
+//	@Failure		409		{object}	response.ResponseError			"Stay is not archived"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/unarchive [put]
+func (h *Handler) UnarchiveStay(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "handler.stays.UnarchiveStay", h.Svc.UnarchiveStay)
+}
+
+// changeStatus runs the status change of the stay requested by its owner
+func (h *Handler) changeStatus(w http.ResponseWriter, r *http.Request, op string, change func(context.Context, uuid.UUID, string) (*model.Stay, error)) {
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse stay id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	stay, err := change(r.Context(), stayID, userID)
+	if err != nil {
+		h.Log.Error("failed to change stay status", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, stay)
+}
+
+// GetStaysForReview godoc
+//
+//	@Summary		Get stays for review
+//	@Description	Get the stays pending review page by page, the oldest stays first
+//	@Tags			stays
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		api.Page[model.StayResponse]	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Permission denied"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/stays/review [get]
+func (h *Handler) GetStaysForReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.GetStaysForReview"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	stays, err := h.Svc.GetStaysForReview(r.Context(), page)
+	if err != nil {
+		h.Log.Error("failed to get stays for review", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, stays)
+}
+
+// ReviewStay godoc
+//
+//	@Summary		Review stay
+//	@Description	Publish the approved stay or send the rejected one back to its host with the comment
+//	@Tags			stays
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			stayId	path		string		true	"stay id"
+//	@Param			request	body		model.ReviewEntity	true	"review decision"
+//	@Success		200	{object}		model.Stay	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Permission denied"
+//	@Failure		404		{object}	response.ResponseError			"Stay not found"
+//	@Failure		409		{object}	response.ResponseError			"Stay is not pending review"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/stays/{stayId}/review [put]
+func (h *Handler) ReviewStay(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.stays.ReviewStay"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	stayID, err := uuid.FromString(chi.URLParam(r, "stayId"))
+	if err != nil {
+		h.Log.Error("failed to parse stay id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var review model.ReviewEntity
+
+	err = render.DecodeJSON(r.Body, &review)
+	if err != nil {
+		h.Log.Error("failed to decode body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	stay, err := h.Svc.ReviewStay(r.Context(), stayID, &review)
+	if err != nil {
+		h.Log.Error("failed to review stay", slogError.Err(err))
+		h.writeStatusError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, stay)
+}
+
+func (h *Handler) writeOwnerError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid), errors.Is(err, service.ErrCurrencyNotFound):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrUserNotOwner):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrStayNotFound), errors.Is(err, service.ErrStayImageNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
+
+func (h *Handler) writePricingError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidPricing):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrUserNotOwner):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrStayNotFound), errors.Is(err, service.ErrSeasonNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrSeasonOverlap):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
+
+func (h *Handler) writeStatusError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrUserNotOwner):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrStayNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrInvalidStayStatus):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	case errors.Is(err, service.ErrStayIncomplete):
+		responseApi.WriteError(w, r, http.StatusUnprocessableEntity, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
@@ -2,20 +2,30 @@ package stays
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/amenity"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/geo"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
 	"github.com/imperatorofdwelling/Full-backend/pkg/testhelper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"image/png"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -42,6 +52,12 @@ func TestStaysHandler_NewStaysHandler(t *testing.T) {
 }
 
 func TestStaysHandler_CreateStay(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -70,9 +86,9 @@ func TestStaysHandler_CreateStay(t *testing.T) {
 		House:              "12B",
 		Entrance:           "South",
 		Address:            "123 Main Street",
-		RoomsCount:         "3",
-		BedsCount:          "2",
-		Price:              "150",
+		RoomsCount:         3,
+		BedsCount:          2,
+		Price:              money.New(15000, money.BaseCurrency),
 		Period:             "day",
 		OwnersRules:        "No smoking, no parties",
 		CancellationPolicy: "Flexible",
@@ -92,7 +108,7 @@ func TestStaysHandler_CreateStay(t *testing.T) {
 
 		router.HandleFunc("/stays/create", hdl.CreateStay)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusCreated, r.Code)
 	})
@@ -106,7 +122,7 @@ func TestStaysHandler_CreateStay(t *testing.T) {
 
 		router.HandleFunc("/stays/create", hdl.CreateStay)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -122,7 +138,7 @@ func TestStaysHandler_CreateStay(t *testing.T) {
 
 		router.HandleFunc("/stays/create", hdl.CreateStay)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -159,9 +175,9 @@ func TestStaysHandler_GetStayByID(t *testing.T) {
 		House:              "12B",
 		Entrance:           "South",
 		Address:            "123 Main Street",
-		RoomsCount:         "3",
-		BedsCount:          "2",
-		Price:              "150",
+		RoomsCount:         3,
+		BedsCount:          2,
+		Price:              money.New(15000, money.BaseCurrency),
 		Period:             "day",
 		OwnersRules:        "No smoking, no parties",
 		CancellationPolicy: "Flexible",
@@ -173,7 +189,7 @@ func TestStaysHandler_GetStayByID(t *testing.T) {
 	t.Run("should be no errors", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("GetStayByID", mock.Anything, mock.Anything).Return(&expected, nil).Once()
+		svc.On("GetPublicStayByID", mock.Anything, fakeUUID, "").Return(&expected, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String(), nil)
 
@@ -204,10 +220,39 @@ func TestStaysHandler_GetStayByID(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 
+	t.Run("should pass the owner to the service", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetPublicStayByID", mock.Anything, fakeUUID, fakeUUID.String()).Return(&expected, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String(), nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+
+		router.HandleFunc("/stays/{stayId}", hdl.GetStayByID)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be not found error for not published stay", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetPublicStayByID", mock.Anything, fakeUUID, "").Return(nil, service.ErrStayNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String(), nil)
+
+		router.HandleFunc("/stays/{stayId}", hdl.GetStayByID)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
 	t.Run("should be error getting stay", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("GetStayByID", mock.Anything, mock.Anything).Return(nil, errors.New("failed to get stay")).Once()
+		svc.On("GetPublicStayByID", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed to get stay")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String(), nil)
 
@@ -252,9 +297,9 @@ func TestStaysHandler_GetStays(t *testing.T) {
 				House:              "12B",
 				Entrance:           "South",
 				Address:            "123 Main Street",
-				RoomsCount:         "3",
-				BedsCount:          "2",
-				Price:              "150",
+				RoomsCount:         3,
+				BedsCount:          2,
+				Price:              money.New(15000, money.BaseCurrency),
 				Period:             "day",
 				OwnersRules:        "No smoking, no parties",
 				CancellationPolicy: "Flexible",
@@ -271,14 +316,14 @@ func TestStaysHandler_GetStays(t *testing.T) {
 	t.Run("should be no errors", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("GetStays", mock.Anything).Return(expected, nil).Once()
+		svc.On("GetStays", mock.Anything, api.PageRequest{Limit: api.DefaultLimit}).Return(api.Page[stays.StayResponse]{Items: expected}, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/stays", nil)
 
 		router.ServeHTTP(r, req)
 
 		var actual struct {
-			Data []stays.StayResponse `json:"data"`
+			Data api.Page[stays.StayResponse] `json:"data"`
 		}
 
 		err := render.DecodeJSON(r.Body, &actual)
@@ -287,13 +332,36 @@ func TestStaysHandler_GetStays(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusOK, r.Code)
-		assert.Equal(t, expected[0].ID, actual.Data[0].ID)
+		assert.Equal(t, expected[0].ID, actual.Data.Items[0].ID)
+	})
+
+	t.Run("should pass cursor of the previous page", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		cursor := api.Cursor{CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), ID: fakeUUID}
+		svc.On("GetStays", mock.Anything, api.PageRequest{Limit: 5, After: &cursor}).Return(api.Page[stays.StayResponse]{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays?limit=5&cursor="+cursor.String(), nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be invalid cursor error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays?cursor=broken", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 
 	t.Run("should be error getting stays", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("GetStays", mock.Anything).Return(nil, errors.New("failed to get stays")).Once()
+		svc.On("GetStays", mock.Anything, mock.Anything).Return(api.Page[stays.StayResponse]{}, errors.New("failed to get stays")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/stays", nil)
 
@@ -304,6 +372,12 @@ func TestStaysHandler_GetStays(t *testing.T) {
 }
 
 func TestStaysHandler_DeleteStayByID(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -320,13 +394,13 @@ func TestStaysHandler_DeleteStayByID(t *testing.T) {
 	t.Run("should be no errors", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteStayByID", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("DeleteStayByID", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/stays/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/stays/{stayId}", hdl.DeleteStayByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusNoContent, r.Code)
 	})
@@ -340,7 +414,7 @@ func TestStaysHandler_DeleteStayByID(t *testing.T) {
 
 		router.HandleFunc("/stays/{stayId}", hdl.DeleteStayByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -348,19 +422,51 @@ func TestStaysHandler_DeleteStayByID(t *testing.T) {
 	t.Run("should be error deleting stay", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteStayByID", mock.Anything, mock.Anything).Return(errors.New("failed to delete stay")).Once()
+		svc.On("DeleteStayByID", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed to delete stay")).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/stays/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/stays/{stayId}", hdl.DeleteStayByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should be error user not owner", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteStayByID", mock.Anything, fakeUUID, fakeUserID.String()).Return(service.ErrUserNotOwner).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/stays/"+fakeUUID.String(), nil)
+
+		router.HandleFunc("/stays/{stayId}", hdl.DeleteStayByID)
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error without user", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodDelete, "/stays/"+fakeUUID.String(), nil)
+
+		router.HandleFunc("/stays/{stayId}", hdl.DeleteStayByID)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
 }
 
 func TestStaysHandler_UpdateStayByID(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -389,9 +495,9 @@ func TestStaysHandler_UpdateStayByID(t *testing.T) {
 		House:              "12B",
 		Entrance:           "South",
 		Address:            "123 Main Street",
-		RoomsCount:         "3",
-		BedsCount:          "2",
-		Price:              "170",
+		RoomsCount:         3,
+		BedsCount:          2,
+		Price:              money.New(17000, money.BaseCurrency),
 		Period:             "day",
 		OwnersRules:        "No smoking, no parties",
 		CancellationPolicy: "Flexible",
@@ -410,9 +516,9 @@ func TestStaysHandler_UpdateStayByID(t *testing.T) {
 		House:              "12B",
 		Entrance:           "South",
 		Address:            "123 Main Street",
-		RoomsCount:         "3",
-		BedsCount:          "2",
-		Price:              "170",
+		RoomsCount:         3,
+		BedsCount:          2,
+		Price:              money.New(17000, money.BaseCurrency),
 		Period:             "day",
 		OwnersRules:        "No smoking, no parties",
 		CancellationPolicy: "Flexible",
@@ -428,13 +534,13 @@ func TestStaysHandler_UpdateStayByID(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pMarshalled)
 
-		svc.On("UpdateStayByID", mock.Anything, mock.Anything, mock.Anything).Return(&expected, nil).Once()
+		svc.On("UpdateStayByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&expected, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/stays/"+fakeUUID.String(), pBuf)
 
 		router.HandleFunc("/stays/{stayId}", hdl.UpdateStayByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
@@ -448,7 +554,7 @@ func TestStaysHandler_UpdateStayByID(t *testing.T) {
 
 		router.HandleFunc("/stays/{stayId}", hdl.UpdateStayByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -460,7 +566,7 @@ func TestStaysHandler_UpdateStayByID(t *testing.T) {
 
 		router.HandleFunc("/stays/{stayId}", hdl.UpdateStayByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -470,13 +576,13 @@ func TestStaysHandler_UpdateStayByID(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pMarshalled)
 
-		svc.On("UpdateStayByID", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+		svc.On("UpdateStayByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String(), pBuf)
 
 		router.HandleFunc("/stays/{stayId}", hdl.UpdateStayByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -514,9 +620,9 @@ func TestStaysHandler_GetStaysByUserID(t *testing.T) {
 			House:              "12B",
 			Entrance:           "South",
 			Address:            "123 Main Street",
-			RoomsCount:         "3",
-			BedsCount:          "2",
-			Price:              "150",
+			RoomsCount:         3,
+			BedsCount:          2,
+			Price:              money.New(15000, money.BaseCurrency),
 			Period:             "day",
 			OwnersRules:        "No smoking, no parties",
 			CancellationPolicy: "Flexible",
@@ -726,6 +832,12 @@ func TestStaysHandler_GetMainImageByStayID(t *testing.T) {
 }
 
 func TestStaysHandler_CreateImages(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -763,14 +875,14 @@ func TestStaysHandler_CreateImages(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/stays/images", &buf)
 		req.Header.Add("Content-Type", writer.FormDataContentType())
 
 		router.HandleFunc("/stays/images", hdl.CreateImages)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusCreated, r.Code)
 	})
@@ -778,7 +890,7 @@ func TestStaysHandler_CreateImages(t *testing.T) {
 	t.Run("should be error creating images", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
+		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
 
 		var buf bytes.Buffer
 		writer := multipart.NewWriter(&buf)
@@ -807,7 +919,7 @@ func TestStaysHandler_CreateImages(t *testing.T) {
 
 		router.HandleFunc("/stays/images", hdl.CreateImages)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -839,14 +951,14 @@ func TestStaysHandler_CreateImages(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/stays/images", &buf)
 		req.Header.Set("Content-Type", writer.FormDataContentType())
 
 		router.HandleFunc("/stays/images", hdl.CreateImages)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -856,19 +968,25 @@ func TestStaysHandler_CreateImages(t *testing.T) {
 
 		var buf bytes.Buffer
 
-		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("CreateImages", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/stays/images", &buf)
 
 		router.HandleFunc("/stays/images", hdl.CreateImages)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
 
 func TestStaysHandler_DeleteStayImage(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -884,13 +1002,13 @@ func TestStaysHandler_DeleteStayImage(t *testing.T) {
 	t.Run("should be no errors", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteStayImage", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("DeleteStayImage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/stays/images/delete/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/stays/images/delete/{imageId}", hdl.DeleteStayImage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusNoContent, r.Code)
 	})
@@ -904,7 +1022,7 @@ func TestStaysHandler_DeleteStayImage(t *testing.T) {
 
 		router.HandleFunc("/stays/images/delete/{imageId}", hdl.DeleteStayImage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -912,19 +1030,25 @@ func TestStaysHandler_DeleteStayImage(t *testing.T) {
 	t.Run("should be error deleting stay image", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteStayImage", mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
+		svc.On("DeleteStayImage", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/stays/images/delete/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/stays/images/delete/{imageId}", hdl.DeleteStayImage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
 }
 
 func TestStaysHandler_CreateMainImage(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -962,14 +1086,14 @@ func TestStaysHandler_CreateMainImage(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		svc.On("CreateMainImage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("CreateMainImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/stays/images/main", &buf)
 		req.Header.Add("Content-Type", writer.FormDataContentType())
 
 		router.HandleFunc("/stays/images/main", hdl.CreateMainImage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusCreated, r.Code)
 	})
@@ -1006,7 +1130,7 @@ func TestStaysHandler_CreateMainImage(t *testing.T) {
 
 		router.HandleFunc("/stays/images/main", hdl.CreateMainImage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -1014,7 +1138,7 @@ func TestStaysHandler_CreateMainImage(t *testing.T) {
 	t.Run("should be error creating main image", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("CreateMainImage", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
+		svc.On("CreateMainImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
 
 		var buf bytes.Buffer
 		writer := multipart.NewWriter(&buf)
@@ -1043,7 +1167,7 @@ func TestStaysHandler_CreateMainImage(t *testing.T) {
 
 		router.HandleFunc("/stays/images/main", hdl.CreateMainImage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -1051,13 +1175,13 @@ func TestStaysHandler_CreateMainImage(t *testing.T) {
 	t.Run("should be error parsing multipart form data", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("CreateMainImage", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("CreateMainImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/stays/images/main", bytes.NewReader([]byte{}))
 
 		router.HandleFunc("/stays/images/main", hdl.CreateMainImage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -1090,9 +1214,9 @@ func TestStaysHandler_GetStaysByLocationID(t *testing.T) {
 			House:              "22A",
 			Entrance:           "North Entrance",
 			Address:            "Main Street",
-			RoomsCount:         "2",
-			BedsCount:          "3",
-			Price:              "120.0",
+			RoomsCount:         2,
+			BedsCount:          3,
+			Price:              money.New(12000, money.BaseCurrency),
 			Period:             "day",
 			OwnersRules:        "No smoking, no pets",
 			CancellationPolicy: "Free cancellation 24 hours before check-in",
@@ -1131,3 +1255,971 @@ func TestStaysHandler_GetStaysByLocationID(t *testing.T) {
 
 	})
 }
+
+func TestStaysHandler_GetCalendar(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/stays/{stayId}/calendar", hdl.GetCalendar)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	expected := []reservation.CalendarDay{
+		{Date: "2025-01-01", Status: reservation.CalendarDayFree},
+		{Date: "2025-01-02", Status: reservation.CalendarDayReserved},
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetCalendar", mock.Anything, fakeUUID, mock.Anything, mock.Anything).Return(expected, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String()+"/calendar?from=2025-01-01&to=2025-01-03", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be uuid parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/invalid/calendar?from=2025-01-01&to=2025-01-03", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be date parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String()+"/calendar?from=01.01.2025&to=2025-01-03", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be invalid range error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetCalendar", mock.Anything, fakeUUID, mock.Anything, mock.Anything).Return(nil, service.ErrInvalidCalendarRange).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String()+"/calendar?from=2025-01-03&to=2025-01-01", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error getting calendar", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetCalendar", mock.Anything, fakeUUID, mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String()+"/calendar?from=2025-01-01&to=2025-01-03", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestStaysHandler_CreateCalendarBlock(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/stays/{stayId}/calendar/blocks", hdl.CreateCalendarBlock)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	payload := reservation.StayBlockEntity{
+		DateStart: time.Now(),
+		DateEnd:   time.Now().Add(48 * time.Hour),
+		Reason:    "repairs",
+	}
+
+	pBytes, _ := json.Marshal(payload)
+
+	newRequest := func(body io.Reader) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/stays/"+fakeUUID.String()+"/calendar/blocks", body)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateCalendarBlock", mock.Anything, mock.Anything, fakeUUID.String()).
+			Return(&reservation.StayBlock{ID: fakeUUID, StayID: fakeUUID, Reason: payload.Reason}, nil).Once()
+
+		router.ServeHTTP(r, newRequest(bytes.NewBuffer(pBytes)))
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+
+		var resp struct {
+			Data reservation.StayBlock `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(r.Body.Bytes(), &resp))
+		assert.Equal(t, fakeUUID, resp.Data.ID)
+	})
+
+	t.Run("should be unauthorized error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPost, "/stays/"+fakeUUID.String()+"/calendar/blocks", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest(strings.NewReader("")))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be not owner error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateCalendarBlock", mock.Anything, mock.Anything, fakeUUID.String()).Return(nil, service.ErrUserNotOwner).Once()
+
+		router.ServeHTTP(r, newRequest(bytes.NewBuffer(pBytes)))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestStaysHandler_DeleteCalendarBlock(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Delete("/stays/{stayId}/calendar/blocks/{blockId}", hdl.DeleteCalendarBlock)
+
+	fakeUUID, _ := uuid.NewV4()
+	blockUUID, _ := uuid.NewV4()
+
+	newRequest := func(blockID string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/stays/"+fakeUUID.String()+"/calendar/blocks/"+blockID, nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteCalendarBlock", mock.Anything, fakeUUID, blockUUID, fakeUUID.String()).Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(blockUUID.String()))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be uuid parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be not found error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteCalendarBlock", mock.Anything, fakeUUID, blockUUID, fakeUUID.String()).Return(service.ErrStayBlockNotFound).Once()
+
+		router.ServeHTTP(r, newRequest(blockUUID.String()))
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestStaysHandler_GetCalendarBlocks(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/stays/{stayId}/calendar/blocks", hdl.GetCalendarBlocks)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	newRequest := func(stayID string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+stayID+"/calendar/blocks", nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetCalendarBlocks", mock.Anything, fakeUUID, fakeUUID.String()).
+			Return([]reservation.StayBlock{{ID: fakeUUID, StayID: fakeUUID}}, nil).Once()
+
+		router.ServeHTTP(r, newRequest(fakeUUID.String()))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be unauthorized error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String()+"/calendar/blocks", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("should be uuid parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest("invalid"))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be not owner error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetCalendarBlocks", mock.Anything, fakeUUID, fakeUUID.String()).Return(nil, service.ErrUserNotOwner).Once()
+
+		router.ServeHTTP(r, newRequest(fakeUUID.String()))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestStaysHandler_Filtration(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/stays/filtration", hdl.Filtration)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	arrival := time.Now().Add(24 * time.Hour)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(stays.Filtration{
+			LocationID: fakeUUID,
+			Arrival:    arrival,
+			Departure:  arrival.Add(72 * time.Hour),
+			Guests:     2,
+		})
+
+		svc.On("Filtration", mock.Anything, mock.MatchedBy(func(f stays.Filtration) bool {
+			return f.HasDates() && f.Guests == 2
+		})).Return([]stays.Stay{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/filtration", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be error only arrival given", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(stays.Filtration{
+			LocationID: fakeUUID,
+			Arrival:    arrival,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/filtration", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error departure before arrival", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(stays.Filtration{
+			LocationID: fakeUUID,
+			Arrival:    arrival,
+			Departure:  arrival.Add(-24 * time.Hour),
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/filtration", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error invalid currency code", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(stays.Filtration{
+			LocationID: fakeUUID,
+			Currency:   "dollars",
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/filtration", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error unsupported currency", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		pBytes, _ := json.Marshal(stays.Filtration{
+			LocationID: fakeUUID,
+			Currency:   "usd",
+		})
+
+		svc.On("Filtration", mock.Anything, mock.MatchedBy(func(f stays.Filtration) bool {
+			return f.Currency == "USD"
+		})).Return(nil, service.ErrCurrencyNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/filtration", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestStaysHandler_GetNearbyStays(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/stays/nearby", hdl.GetNearbyStays)
+
+	point := geo.Point{Lat: 55.7558, Lon: 37.6173}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetNearbyStays", mock.Anything, point, float64(10)).Return([]stays.StayNearby{{DistanceKm: 1.5}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/nearby?lat=55.7558&lon=37.6173&radius_km=10", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be query parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/nearby?lat=north&lon=37.6173&radius_km=10", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be invalid geo query error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetNearbyStays", mock.Anything, point, float64(1000)).Return(nil, service.ErrInvalidGeoQuery).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/nearby?lat=55.7558&lon=37.6173&radius_km=1000", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be internal error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetNearbyStays", mock.Anything, point, float64(10)).Return(nil, errors.New("db error")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/nearby?lat=55.7558&lon=37.6173&radius_km=10", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestStaysHandler_GetStaysInBoundingBox(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/stays/bbox", hdl.GetStaysInBoundingBox)
+
+	box := geo.BoundingBox{MinLat: 55.5, MinLon: 37.3, MaxLat: 56, MaxLon: 37.9}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetStaysInBoundingBox", mock.Anything, box).Return([]stays.StayNearby{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/bbox?min_lat=55.5&min_lon=37.3&max_lat=56&max_lon=37.9", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be query parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/bbox?min_lat=55.5&min_lon=37.3&max_lat=56", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should pass box across antimeridian", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		across := geo.BoundingBox{MinLat: -20, MinLon: 175, MaxLat: -15, MaxLon: -178}
+		svc.On("GetStaysInBoundingBox", mock.Anything, across).Return([]stays.StayNearby{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/bbox?min_lat=-20&min_lon=175&max_lat=-15&max_lon=-178", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be invalid geo query error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		inverted := geo.BoundingBox{MinLat: 56, MinLon: 37.3, MaxLat: 55.5, MaxLon: 37.9}
+		svc.On("GetStaysInBoundingBox", mock.Anything, inverted).Return(nil, service.ErrInvalidGeoQuery).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/bbox?min_lat=56&min_lon=37.3&max_lat=55.5&max_lon=37.9", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestStaysHandler_SearchStays(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/stays/search", hdl.SearchStays)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	t.Run("should be no errors for anonymous user", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SearchStays", mock.Anything, "квартира", 0, "").Return([]stays.StaySearchResult{{Rank: 0.6}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/search?q=%D0%BA%D0%B2%D0%B0%D1%80%D1%82%D0%B8%D1%80%D0%B0", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should pass user id to be saved in history", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SearchStays", mock.Anything, "loft", 10, fakeUUID.String()).Return([]stays.StaySearchResult{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/search?q=loft&limit=10", nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should search even if history is not saved", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SearchStays", mock.Anything, "loft", 10, fakeUUID.String()).
+			Return([]stays.StaySearchResult{}, fmt.Errorf("stays.SearchStays: %w: db error", service.ErrHistoryNotSaved)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/search?q=loft&limit=10", nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be limit parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/search?q=loft&limit=many", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be invalid search error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SearchStays", mock.Anything, "", 0, "").Return(nil, service.ErrInvalidSearch).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/search", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be internal error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SearchStays", mock.Anything, "loft", 0, "").Return(nil, errors.New("db error")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/search?q=loft", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusInternalServerError, r.Code)
+	})
+}
+
+func TestStaysHandler_GetQuote(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Get("/stays/{stayId}/quote", hdl.GetQuote)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	arrival := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	departure := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	url := "/stays/" + fakeUUID.String() + "/quote?arrival=2025-01-01&departure=2025-01-08"
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		quote := &pricing.Quote{
+			StayID: fakeUUID,
+			Nights: 7,
+			Guests: 2,
+			Lines: []pricing.QuoteLine{
+				{Kind: pricing.LineNights, Quantity: 7, UnitPrice: money.New(10000, money.BaseCurrency), Amount: money.New(70000, money.BaseCurrency)},
+			},
+			Total: money.New(70000, money.BaseCurrency),
+		}
+
+		svc.On("GetQuote", mock.Anything, fakeUUID, arrival, departure, 2).Return(quote, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, url+"&guests=2", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be one guest by default", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetQuote", mock.Anything, fakeUUID, arrival, departure, 1).Return(&pricing.Quote{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be date parsing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/stays/"+fakeUUID.String()+"/quote?arrival=tomorrow&departure=2025-01-08", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be invalid quote error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetQuote", mock.Anything, fakeUUID, arrival, departure, 20).Return(nil, service.ErrInvalidQuote).Once()
+
+		req := httptest.NewRequest(http.MethodGet, url+"&guests=20", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be stay not found error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetQuote", mock.Anything, fakeUUID, arrival, departure, 3).Return(nil, service.ErrStayNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, url+"&guests=3", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestStaysHandler_UpdatePricing(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Put("/stays/{stayId}/pricing", hdl.UpdatePricing)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	pBytes, _ := json.Marshal(pricing.RulesEntity{
+		WeekendSurchargePercent: 20,
+		WeeklyDiscountPercent:   10,
+		CleaningFee:             50000,
+		IncludedGuests:          2,
+	})
+
+	newRequest := func(body io.Reader) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/pricing", body)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("UpdatePricing", mock.Anything, fakeUUID, mock.Anything, fakeUUID.String()).Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(bytes.NewBuffer(pBytes)))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be unauthorized error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/pricing", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+
+	t.Run("should be invalid pricing error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("UpdatePricing", mock.Anything, fakeUUID, mock.Anything, fakeUUID.String()).Return(service.ErrInvalidPricing).Once()
+
+		router.ServeHTTP(r, newRequest(bytes.NewBuffer(pBytes)))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be not owner error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("UpdatePricing", mock.Anything, fakeUUID, mock.Anything, fakeUUID.String()).Return(service.ErrUserNotOwner).Once()
+
+		router.ServeHTTP(r, newRequest(bytes.NewBuffer(pBytes)))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestStaysHandler_CreateSeasonalPrice(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Post("/stays/{stayId}/pricing/seasons", hdl.CreateSeasonalPrice)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	pBytes, _ := json.Marshal(pricing.SeasonEntity{
+		Name:      "New Year",
+		DateStart: time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC),
+		DateEnd:   time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC),
+		Price:     900000,
+	})
+
+	newRequest := func(body io.Reader) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/stays/"+fakeUUID.String()+"/pricing/seasons", body)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateSeasonalPrice", mock.Anything, fakeUUID, mock.Anything, fakeUUID.String()).Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest(bytes.NewBuffer(pBytes)))
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, newRequest(strings.NewReader("")))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be overlap error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateSeasonalPrice", mock.Anything, fakeUUID, mock.Anything, fakeUUID.String()).Return(service.ErrSeasonOverlap).Once()
+
+		router.ServeHTTP(r, newRequest(bytes.NewBuffer(pBytes)))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestStaysHandler_DeleteSeasonalPrice(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Delete("/stays/{stayId}/pricing/seasons/{seasonId}", hdl.DeleteSeasonalPrice)
+
+	fakeUUID, _ := uuid.NewV4()
+	seasonUUID, _ := uuid.NewV4()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/stays/"+fakeUUID.String()+"/pricing/seasons/"+seasonUUID.String(), nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteSeasonalPrice", mock.Anything, fakeUUID, seasonUUID, fakeUUID.String()).Return(nil).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be not found error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteSeasonalPrice", mock.Anything, fakeUUID, seasonUUID, fakeUUID.String()).Return(service.ErrSeasonNotFound).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestStaysHandler_SubmitStay(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Put("/stays/{stayId}/submit", hdl.SubmitStay)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/submit", nil)
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUUID.String()))
+	}
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SubmitStay", mock.Anything, fakeUUID, fakeUUID.String()).Return(&stays.Stay{ID: fakeUUID, Status: stays.StatusPendingReview}, nil).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be incomplete error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SubmitStay", mock.Anything, fakeUUID, fakeUUID.String()).Return(nil, fmt.Errorf("service.stays.SubmitStay: %w: missing main_image", service.ErrStayIncomplete)).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusUnprocessableEntity, r.Code)
+	})
+
+	t.Run("should be invalid status error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SubmitStay", mock.Anything, fakeUUID, fakeUUID.String()).Return(nil, service.ErrInvalidStayStatus).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be not owner error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SubmitStay", mock.Anything, fakeUUID, fakeUUID.String()).Return(nil, service.ErrUserNotOwner).Once()
+
+		router.ServeHTTP(r, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be unauthorized error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/submit", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestStaysHandler_ReviewStay(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+
+	router := chi.NewRouter()
+	router.Put("/stays/{stayId}/review", hdl.ReviewStay)
+
+	fakeUUID, _ := uuid.NewV4()
+
+	rBytes, _ := json.Marshal(stays.ReviewEntity{Decision: stays.DecisionReject, Comment: "Add the photos of the bedroom"})
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ReviewStay", mock.Anything, fakeUUID, mock.Anything).Return(&stays.Stay{ID: fakeUUID, Status: stays.StatusDraft}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/review", bytes.NewBuffer(rBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/review", strings.NewReader("invalid"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ReviewStay", mock.Anything, fakeUUID, mock.Anything).Return(nil, fmt.Errorf("service.stays.ReviewStay: %w: rejected stay needs a comment for its host", service.ErrValid)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/review", bytes.NewBuffer(rBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be not pending review error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ReviewStay", mock.Anything, fakeUUID, mock.Anything).Return(nil, service.ErrInvalidStayStatus).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/stays/"+fakeUUID.String()+"/review", bytes.NewBuffer(rBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
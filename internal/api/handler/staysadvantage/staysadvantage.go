@@ -1,7 +1,7 @@
 package staysadvantage
 
 import (
-	"context"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -10,6 +10,7 @@ import (
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysadvantage"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"log/slog"
@@ -34,13 +35,16 @@ func (h *Handler) NewStaysAdvantageHandler(r chi.Router) {
 // CreateStaysAdvantage godoc
 //
 //		@Summary		Create StaysAdvantage
-//		@Description	Create staysAdvantage
+//		@Description	Create staysAdvantage, only the stay owner can add the advantages
 //		@Tags			staysAdvantage
 //		@Accept			application/json
 //		@Produce		json
 //	 	@Param			request		body	model.StayAdvantageCreateReq	true	"staysAdvantage request"
 //		@Success		201	{object}		string	"created"
 //		@Failure		400		{object}	response.ResponseError			"Error"
+//		@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//		@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//		@Failure		404		{object}	response.ResponseError			"Error"
 //		@Failure		default		{object}	response.ResponseError			"Error"
 //		@Router			/staysadvantage [post]
 func (h *Handler) CreateStaysAdvantage(w http.ResponseWriter, r *http.Request) {
@@ -51,6 +55,13 @@ func (h *Handler) CreateStaysAdvantage(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	var req model.StayAdvantageCreateReq
 
 	err := render.DecodeJSON(r.Body, &req)
@@ -60,10 +71,10 @@ func (h *Handler) CreateStaysAdvantage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.CreateStaysAdvantage(r.Context(), &req)
+	err = h.Svc.CreateStaysAdvantage(r.Context(), &req, userID)
 	if err != nil {
 		h.Log.Error("failed to create stay advantage", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeOwnerError(w, r, err)
 		return
 	}
 
@@ -72,14 +83,17 @@ func (h *Handler) CreateStaysAdvantage(w http.ResponseWriter, r *http.Request) {
 
 // DeleteStaysAdvantageByID godoc
 //
-//	@Summary		Create StaysAdvantage
-//	@Description	Create staysAdvantage
+//	@Summary		Delete StaysAdvantage
+//	@Description	Delete staysAdvantage, only the stay owner can remove the advantages
 //	@Tags			staysAdvantage
 //	@Accept			application/json
 //	@Param			id	path		string		true	"stay advantage id"
 //	@Produce		json
 //	@Success		204	{object}		string	"no content"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the stay owner"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/staysadvantage/{id} [delete]
 func (h *Handler) DeleteStaysAdvantageByID(w http.ResponseWriter, r *http.Request) {
@@ -90,6 +104,13 @@ func (h *Handler) DeleteStaysAdvantageByID(w http.ResponseWriter, r *http.Reques
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	id := chi.URLParam(r, "id")
 	uuID, err := uuid.FromString(id)
 	if err != nil {
@@ -98,12 +119,24 @@ func (h *Handler) DeleteStaysAdvantageByID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = h.Svc.DeleteStaysAdvantageByID(context.Background(), uuID)
+	err = h.Svc.DeleteStaysAdvantageByID(r.Context(), uuID, userID)
 	if err != nil {
 		h.Log.Error("failed to delete stay advantage", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeOwnerError(w, r, err)
 		return
 	}
 
 	responseApi.WriteJson(w, r, http.StatusNoContent, "successfully deleted stay advantage")
 }
+
+// writeOwnerError maps the errors of the changes only the stay owner can make
+func (h *Handler) writeOwnerError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotOwner):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrStayNotFound), errors.Is(err, service.ErrAdvantageNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
@@ -2,6 +2,7 @@ package staysadvantage
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/go-chi/chi/v5"
@@ -9,6 +10,8 @@ import (
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysadvantage"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -35,6 +38,12 @@ func TestStaysAdvantagesHandler_NewStaysAdvantageHandler(t *testing.T) {
 }
 
 func TestStaysAdvantagesHandler_CreateStaysAdvantage(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -60,13 +69,13 @@ func TestStaysAdvantagesHandler_CreateStaysAdvantage(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("CreateStaysAdvantage", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("CreateStaysAdvantage", mock.Anything, mock.Anything, fakeUserID.String()).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/staysadvantage/create", pBuf)
 
 		router.HandleFunc("/staysadvantage/create", hdl.CreateStaysAdvantage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusCreated, r.Code)
 	})
@@ -78,7 +87,7 @@ func TestStaysAdvantagesHandler_CreateStaysAdvantage(t *testing.T) {
 
 		router.HandleFunc("/staysadvantage/create", hdl.CreateStaysAdvantage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -88,19 +97,39 @@ func TestStaysAdvantagesHandler_CreateStaysAdvantage(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("CreateStaysAdvantage", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
+		svc.On("CreateStaysAdvantage", mock.Anything, mock.Anything, fakeUserID.String()).Return(errors.New("error")).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/staysadvantage/create", pBuf)
 
 		router.HandleFunc("/staysadvantage/create", hdl.CreateStaysAdvantage)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should be error not stay owner", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CreateStaysAdvantage", mock.Anything, mock.Anything, fakeUserID.String()).Return(service.ErrUserNotOwner).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/staysadvantage/create", bytes.NewBuffer(pBytes))
+
+		router.HandleFunc("/staysadvantage/create", hdl.CreateStaysAdvantage)
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
 }
 
 func TestStaysAdvantagesHandler_DeleteStaysAdvantageByID(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -117,13 +146,13 @@ func TestStaysAdvantagesHandler_DeleteStaysAdvantageByID(t *testing.T) {
 	t.Run("should be no error", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteStaysAdvantageByID", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("DeleteStaysAdvantageByID", mock.Anything, fakeUUID, fakeUserID.String()).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/staysadvantage/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/staysadvantage/{id}", hdl.DeleteStaysAdvantageByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusNoContent, r.Code)
 	})
@@ -137,7 +166,7 @@ func TestStaysAdvantagesHandler_DeleteStaysAdvantageByID(t *testing.T) {
 
 		router.HandleFunc("/staysadvantage/{id}", hdl.DeleteStaysAdvantageByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -145,14 +174,40 @@ func TestStaysAdvantagesHandler_DeleteStaysAdvantageByID(t *testing.T) {
 	t.Run("should be error deleting stays advantage", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("DeleteStaysAdvantageByID", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
+		svc.On("DeleteStaysAdvantageByID", mock.Anything, fakeUUID, fakeUserID.String()).Return(errors.New("error")).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/staysadvantage/"+fakeUUID.String(), nil)
 
 		router.HandleFunc("/staysadvantage/{id}", hdl.DeleteStaysAdvantageByID)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should be error not stay owner", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("DeleteStaysAdvantageByID", mock.Anything, fakeUUID, fakeUserID.String()).Return(service.ErrUserNotOwner).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/staysadvantage/"+fakeUUID.String(), nil)
+
+		router.HandleFunc("/staysadvantage/{id}", hdl.DeleteStaysAdvantageByID)
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error unauthorized", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodDelete, "/staysadvantage/"+fakeUUID.String(), nil)
+
+		router.HandleFunc("/staysadvantage/{id}", hdl.DeleteStaysAdvantageByID)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
 }
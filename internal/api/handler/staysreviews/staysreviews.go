@@ -9,8 +9,11 @@ import (
 	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
 	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysreviews"
 	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/pkg/errors"
 	"log/slog"
 	"net/http"
 )
@@ -27,10 +30,15 @@ func (h *Handler) NewStaysReviewsHandler(r chi.Router) {
 			r.Post("/", h.CreateStaysReview)
 			r.Put("/{id}", h.UpdateStaysReview)
 			r.Delete("/{id}", h.DeleteStaysReview)
+			r.Put("/{id}/reply", h.ReplyToStaysReview)
 		})
 
 		r.Group(func(r chi.Router) {
+			r.Use(mw.WithOptionalAuth)
 			r.Get("/{id}", h.FindOneStaysReview)
+		})
+
+		r.Group(func(r chi.Router) {
 			r.Get("/", h.FindAllStaysReviews)
 		})
 	})
@@ -39,13 +47,17 @@ func (h *Handler) NewStaysReviewsHandler(r chi.Router) {
 // CreateStaysReview godoc
 //
 //		@Summary		Create Stays_review
-//		@Description	Create stays_review
+//		@Description	Create stays_review of the completed reservation by its guest, one review per reservation, within 14 days after the checkout. Every criterion is rated from 1 to 5 and the overall rating is their average. The review is hidden until the host reviews the guest or the review window is closed
 //		@Tags			staysReviews
 //		@Accept			application/json
 //		@Produce		json
 //	 	@Param			request	body	model.StaysReviewEntity			true	"stays review request"
 //		@Success		201	{string}		string	"created"
 //		@Failure		400		{object}	response.ResponseError			"Error"
+//		@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//		@Failure		403		{object}	response.ResponseError			"Not the guest of the reservation"
+//		@Failure		404		{object}	response.ResponseError			"Reservation not found"
+//		@Failure		409		{object}	response.ResponseError			"Reservation is not completed, already reviewed or its review window is closed"
 //		@Failure		default		{object}	response.ResponseError			"Error"
 //		@Router			/staysreviews [post]
 func (h *Handler) CreateStaysReview(w http.ResponseWriter, r *http.Request) {
@@ -56,6 +68,13 @@ func (h *Handler) CreateStaysReview(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	var newStaysReview model.StaysReviewEntity
 
 	err := render.DecodeJSON(r.Body, &newStaysReview)
@@ -65,10 +84,12 @@ func (h *Handler) CreateStaysReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	newStaysReview.UserID = uuid.FromStringOrNil(userID)
+
 	err = h.Svc.CreateStaysReview(r.Context(), &newStaysReview)
 	if err != nil {
 		h.Log.Error("failed to create stay review", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeReviewError(w, r, err)
 		return
 	}
 
@@ -78,7 +99,7 @@ func (h *Handler) CreateStaysReview(w http.ResponseWriter, r *http.Request) {
 // UpdateStaysReview godoc
 //
 //	@Summary		Update Stays Review
-//	@Description	Update a stays review by its ID
+//	@Description	Update a stays review by its ID, the review can be changed while it is hidden
 //	@Tags			staysReviews
 //	@Accept			application/json
 //	@Produce		json
@@ -86,7 +107,10 @@ func (h *Handler) CreateStaysReview(w http.ResponseWriter, r *http.Request) {
 //	@Param			request	body	model.StaysReviewEntity	true	"Details to update the stays review"
 //	@Success		200	{object}	map[string]interface{}	"Successfully updated stays review"
 //	@Failure		400	{object}	response.ResponseError		"Invalid request"
+//	@Failure		401	{object}	response.ResponseError		"Unauthorized"
+//	@Failure		403	{object}	response.ResponseError		"Not the author of the review"
 //	@Failure		404	{object}	response.ResponseError		"Stays review not found"
+//	@Failure		409	{object}	response.ResponseError		"Review is public"
 //	@Failure		500	{object}	response.ResponseError		"Internal server error"
 //	@Router			/staysreviews/{id} [put]
 func (h *Handler) UpdateStaysReview(w http.ResponseWriter, r *http.Request) {
@@ -97,6 +121,13 @@ func (h *Handler) UpdateStaysReview(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	id := chi.URLParam(r, "id")
 	uuID, err := uuid.FromString(id)
 	if err != nil {
@@ -114,10 +145,10 @@ func (h *Handler) UpdateStaysReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stayRev, err := h.Svc.UpdateStaysReview(r.Context(), &newStaysReview, uuID)
+	stayRev, err := h.Svc.UpdateStaysReview(r.Context(), &newStaysReview, uuID, userID)
 	if err != nil {
 		h.Log.Error("failed to update stay review", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeReviewError(w, r, err)
 		return
 	}
 
@@ -134,6 +165,9 @@ func (h *Handler) UpdateStaysReview(w http.ResponseWriter, r *http.Request) {
 //	@Param			id	path		string		true	"stays review id"
 //	@Success		200	{string}		string	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the author of the review"
+//	@Failure		404		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/staysreviews/{id} [delete]
 func (h *Handler) DeleteStaysReview(w http.ResponseWriter, r *http.Request) {
@@ -144,6 +178,13 @@ func (h *Handler) DeleteStaysReview(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
 	id := chi.URLParam(r, "id")
 	uuID, err := uuid.FromString(id)
 	if err != nil {
@@ -152,10 +193,10 @@ func (h *Handler) DeleteStaysReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Svc.DeleteStaysReview(r.Context(), uuID)
+	err = h.Svc.DeleteStaysReview(r.Context(), uuID, userID)
 	if err != nil {
 		h.Log.Error("failed to delete stay review", slogError.Err(err))
-		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		h.writeReviewError(w, r, err)
 		return
 	}
 
@@ -165,13 +206,14 @@ func (h *Handler) DeleteStaysReview(w http.ResponseWriter, r *http.Request) {
 // FindOneStaysReview godoc
 //
 //	@Summary		Get Stays review
-//	@Description	Get Stays review by id
+//	@Description	Get Stays review by id, the hidden review is shown only to its author
 //	@Tags			staysReviews
 //	@Accept			application/json
 //	@Produce		json
 //	@Param			id	path		string		true	"stays review id"
 //	@Success		200	{object}		model.StaysReview	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		404		{object}	response.ResponseError			"Review not found"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/staysreviews/{id} [get]
 func (h *Handler) FindOneStaysReview(w http.ResponseWriter, r *http.Request) {
@@ -190,9 +232,16 @@ func (h *Handler) FindOneStaysReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	foundStayReview, err := h.Svc.FindOneStaysReview(r.Context(), uuID)
+	// the user is known when the request is authorized
+	userID, _ := r.Context().Value(mw.UserIdKey).(string)
+
+	foundStayReview, err := h.Svc.FindOneStaysReview(r.Context(), uuID, userID)
 	if err != nil {
 		h.Log.Error("failed to find stay review", slogError.Err(err))
+		if errors.Is(err, service.ErrStaysReviewNotFound) {
+			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+			return
+		}
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
 		return
 	}
@@ -203,11 +252,14 @@ func (h *Handler) FindOneStaysReview(w http.ResponseWriter, r *http.Request) {
 // FindAllStaysReviews godoc
 //
 //	@Summary		Get all Stays review
-//	@Description	Get all Stays reviews
+//	@Description	Get all Stays reviews page by page, ordered by creation time. The reviews of a single stay come with the averages of the criteria
 //	@Tags			staysReviews
 //	@Accept			application/json
 //	@Produce		json
-//	@Success		200	{object}		[]model.StaysReview	"ok"
+//	@Param			stayId	query		string		false	"stay id"
+//	@Param			limit	query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor	query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		model.StaysReviewsPage	"ok"
 //	@Failure		400		{object}	response.ResponseError			"Error"
 //	@Failure		default		{object}	response.ResponseError			"Error"
 //	@Router			/staysreviews [get]
@@ -219,7 +271,26 @@ func (h *Handler) FindAllStaysReviews(w http.ResponseWriter, r *http.Request) {
 		slog.String("request_id", middleware.GetReqID(r.Context())),
 	)
 
-	foundStayReviews, err := h.Svc.FindAllStaysReviews(r.Context())
+	page, err := api.NewPageRequest(r.URL.Query())
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var stayID *uuid.UUID
+
+	if s := r.URL.Query().Get("stayId"); s != "" {
+		id, err := uuid.FromString(s)
+		if err != nil {
+			h.Log.Error("failed to parse stay id", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		stayID = &id
+	}
+
+	foundStayReviews, err := h.Svc.FindAllStaysReviews(r.Context(), stayID, page)
 	if err != nil {
 		h.Log.Error("failed to find all stay reviews", slogError.Err(err))
 		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
@@ -228,3 +299,79 @@ func (h *Handler) FindAllStaysReviews(w http.ResponseWriter, r *http.Request) {
 
 	responseApi.WriteJson(w, r, http.StatusOK, foundStayReviews)
 }
+
+// ReplyToStaysReview godoc
+//
+//	@Summary		Reply to Stays review
+//	@Description	Post the public reply of the stay owner to the review, a review can be replied once
+//	@Tags			staysReviews
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			id	path		string		true	"stays review id"
+//	@Param			request	body	model.ReplyEntity	true	"reply"
+//	@Success		200	{object}		model.StaysReview	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not the owner of the stay"
+//	@Failure		404		{object}	response.ResponseError			"Stays review not found"
+//	@Failure		409		{object}	response.ResponseError			"Review is already replied"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/staysreviews/{id}/reply [put]
+func (h *Handler) ReplyToStaysReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.staysreviews.ReplyToStaysReview"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	uuID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var reply model.ReplyEntity
+
+	err = render.DecodeJSON(r.Body, &reply)
+	if err != nil {
+		h.Log.Error("failed to decode body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	stayRev, err := h.Svc.ReplyToStaysReview(r.Context(), uuID, userID, &reply)
+	if err != nil {
+		h.Log.Error("failed to reply to stay review", slogError.Err(err))
+		h.writeReviewError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, stayRev)
+}
+
+// writeReviewError maps the errors of the review changes, only the review author can change the review
+// and only the stay owner can reply to it
+func (h *Handler) writeReviewError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrUserNotOwner), errors.Is(err, service.ErrNotReservationGuest):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrStaysReviewNotFound), errors.Is(err, service.ErrStayNotFound), errors.Is(err, service.ErrNotFoundReservation):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrStayNotCompleted), errors.Is(err, service.ErrStaysReviewExists), errors.Is(err, service.ErrReplyExists),
+		errors.Is(err, service.ErrReviewWindowClosed), errors.Is(err, service.ErrReviewRevealed):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
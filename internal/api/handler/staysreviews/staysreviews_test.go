@@ -2,13 +2,18 @@ package staysreviews
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysreviews"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -35,6 +40,12 @@ func TestStaysReviewsHandler_NewStaysReviewsHandler(t *testing.T) {
 }
 
 func TestStaysReviewsHandler_CreateStaysReviewHandler(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -70,7 +81,7 @@ func TestStaysReviewsHandler_CreateStaysReviewHandler(t *testing.T) {
 
 		router.HandleFunc("/staysreviews/create", hdl.CreateStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusCreated, r.Code)
 	})
@@ -82,7 +93,7 @@ func TestStaysReviewsHandler_CreateStaysReviewHandler(t *testing.T) {
 
 		router.HandleFunc("/staysreviews/create", hdl.CreateStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 
@@ -111,13 +122,70 @@ func TestStaysReviewsHandler_CreateStaysReviewHandler(t *testing.T) {
 
 		router.HandleFunc("/staysreviews/create", hdl.CreateStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should be error rating out of range", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		uuidStay, _ := uuid.NewV4()
+
+		payload := staysreviews.StaysReviewEntity{
+			StayID:      uuidStay,
+			Title:       "test",
+			Description: "test",
+			Rating:      6,
+		}
+
+		pBytes, _ := json.Marshal(payload)
+
+		pBuf := bytes.NewBuffer(pBytes)
+
+		svc.On("CreateStaysReview", mock.Anything, mock.Anything).Return(fmt.Errorf("%w: rating must be between 1 and 5", service.ErrValid)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/staysreviews/create", pBuf)
+
+		router.HandleFunc("/staysreviews/create", hdl.CreateStaysReview)
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error reservation not completed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		reservationID, _ := uuid.NewV4()
+
+		payload := staysreviews.StaysReviewEntity{
+			ReservationID: reservationID,
+			Title:         "test",
+			Criteria:      staysreviews.Criteria{Cleanliness: 5, Accuracy: 5, Location: 5, Communication: 5, Value: 5},
+		}
+
+		pBytes, _ := json.Marshal(payload)
+
+		svc.On("CreateStaysReview", mock.Anything, mock.Anything).Return(service.ErrStayNotCompleted).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/staysreviews/create", bytes.NewBuffer(pBytes))
+
+		router.HandleFunc("/staysreviews/create", hdl.CreateStaysReview)
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
 }
 
 func TestStaysReviewsHandler_UpdateStaysReviewHandler(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -149,13 +217,13 @@ func TestStaysReviewsHandler_UpdateStaysReviewHandler(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("UpdateStaysReview", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+		svc.On("UpdateStaysReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/staysreviews/update/"+uuidStayReview.String(), pBuf)
 
 		router.HandleFunc("/staysreviews/update/{id}", hdl.UpdateStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
@@ -169,7 +237,7 @@ func TestStaysReviewsHandler_UpdateStaysReviewHandler(t *testing.T) {
 
 		router.HandleFunc("/staysreviews/update/{id}", hdl.UpdateStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -183,7 +251,7 @@ func TestStaysReviewsHandler_UpdateStaysReviewHandler(t *testing.T) {
 
 		router.HandleFunc("/staysreviews/update/{id}", hdl.UpdateStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -209,19 +277,25 @@ func TestStaysReviewsHandler_UpdateStaysReviewHandler(t *testing.T) {
 
 		pBuf := bytes.NewBuffer(pBytes)
 
-		svc.On("UpdateStaysReview", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+		svc.On("UpdateStaysReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/staysreviews/update/"+uuidStayReview.String(), pBuf)
 
 		router.HandleFunc("/staysreviews/update/{id}", hdl.UpdateStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
 }
 
 func TestStaysReviewsHandler_DeleteStayReviewHandler(t *testing.T) {
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
 	config.GlobalEnv = config.LocalEnv
 
 	log := logger.New()
@@ -237,13 +311,13 @@ func TestStaysReviewsHandler_DeleteStayReviewHandler(t *testing.T) {
 
 		uuidStayReview, _ := uuid.NewV4()
 
-		svc.On("DeleteStaysReview", mock.Anything, mock.Anything).Return(nil).Once()
+		svc.On("DeleteStaysReview", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/staysreviews/delete/"+uuidStayReview.String(), nil)
 
 		router.HandleFunc("/staysreviews/delete/{id}", hdl.DeleteStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
@@ -256,7 +330,7 @@ func TestStaysReviewsHandler_DeleteStayReviewHandler(t *testing.T) {
 		req := httptest.NewRequest(http.MethodDelete, "/staysreviews/delete/"+fakeID, nil)
 
 		router.HandleFunc("/staysreviews/delete/{id}", hdl.DeleteStaysReview)
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
@@ -266,13 +340,13 @@ func TestStaysReviewsHandler_DeleteStayReviewHandler(t *testing.T) {
 
 		uuidStayReview, _ := uuid.NewV4()
 
-		svc.On("DeleteStaysReview", mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
+		svc.On("DeleteStaysReview", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/staysreviews/delete/"+uuidStayReview.String(), nil)
 
 		router.HandleFunc("/staysreviews/delete/{id}", hdl.DeleteStaysReview)
 
-		router.ServeHTTP(r, req)
+		router.ServeHTTP(r, withUser(req))
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
@@ -303,7 +377,7 @@ func TestStaysReviewsHandler_FindOneStayReviewHandler(t *testing.T) {
 			Rating:      1.2,
 		}
 
-		svc.On("FindOneStaysReview", mock.Anything, mock.Anything).Return(&expected, nil).Once()
+		svc.On("FindOneStaysReview", mock.Anything, uuID, "").Return(&expected, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/staysreviews/"+uuID.String(), nil)
 
@@ -334,7 +408,7 @@ func TestStaysReviewsHandler_FindOneStayReviewHandler(t *testing.T) {
 
 		uuID, _ := uuid.NewV4()
 
-		svc.On("FindOneStaysReview", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+		svc.On("FindOneStaysReview", mock.Anything, uuID, "").Return(nil, errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/staysreviews/"+uuID.String(), nil)
 
@@ -344,6 +418,40 @@ func TestStaysReviewsHandler_FindOneStayReviewHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should show the hidden review to its author", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		uuID, _ := uuid.NewV4()
+
+		svc.On("FindOneStaysReview", mock.Anything, uuID, uuID.String()).
+			Return(&staysreviews.StaysReview{ID: uuID, UserID: uuID}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/staysreviews/"+uuID.String(), nil)
+		req = req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, uuID.String()))
+
+		router.HandleFunc("/staysreviews/{id}", hdl.FindOneStaysReview)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be not found error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		uuID, _ := uuid.NewV4()
+
+		svc.On("FindOneStaysReview", mock.Anything, uuID, "").Return(nil, service.ErrStaysReviewNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/staysreviews/"+uuID.String(), nil)
+
+		router.HandleFunc("/staysreviews/{id}", hdl.FindOneStaysReview)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
 }
 
 func TestStaysReviewsHandler_FindAllStayReviews(t *testing.T) {
@@ -373,7 +481,8 @@ func TestStaysReviewsHandler_FindAllStayReviews(t *testing.T) {
 			},
 		}
 
-		svc.On("FindAllStaysReviews", mock.Anything).Return(expected, nil).Once()
+		svc.On("FindAllStaysReviews", mock.Anything, (*uuid.UUID)(nil), api.PageRequest{Limit: api.DefaultLimit}).
+			Return(&staysreviews.StaysReviewsPage{Page: api.Page[staysreviews.StaysReview]{Items: expected}}, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/staysreviews", nil)
 
@@ -387,7 +496,7 @@ func TestStaysReviewsHandler_FindAllStayReviews(t *testing.T) {
 	t.Run("should be getting stays reviews", func(t *testing.T) {
 		r := httptest.NewRecorder()
 
-		svc.On("FindAllStaysReviews", mock.Anything).Return(nil, errors.New("failed")).Once()
+		svc.On("FindAllStaysReviews", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/staysreviews", nil)
 
@@ -397,4 +506,125 @@ func TestStaysReviewsHandler_FindAllStayReviews(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, r.Code)
 	})
+
+	t.Run("should be invalid limit error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/staysreviews?limit=-1", nil)
+
+		router.HandleFunc("/staysreviews", hdl.FindAllStaysReviews)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be reviews of stay with summary", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		stayID, _ := uuid.NewV4()
+
+		summary := &staysreviews.RatingSummary{StayID: stayID, ReviewsCount: 2, Rating: 4.5, Cleanliness: 5}
+
+		svc.On("FindAllStaysReviews", mock.Anything, &stayID, api.PageRequest{Limit: api.DefaultLimit}).
+			Return(&staysreviews.StaysReviewsPage{Page: api.Page[staysreviews.StaysReview]{Items: []staysreviews.StaysReview{}}, Summary: summary}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/staysreviews?stayId="+stayID.String(), nil)
+
+		router.HandleFunc("/staysreviews", hdl.FindAllStaysReviews)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var res struct {
+			Data staysreviews.StaysReviewsPage `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(r.Body.Bytes(), &res))
+		assert.Equal(t, summary, res.Data.Summary)
+	})
+
+	t.Run("should be invalid stay id error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodGet, "/staysreviews?stayId=invalid", nil)
+
+		router.HandleFunc("/staysreviews", hdl.FindAllStaysReviews)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestStaysReviewsHandler_ReplyToStaysReview(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.StaysReviewsService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: log,
+	}
+	router := chi.NewRouter()
+
+	router.Put("/staysreviews/{id}/reply", hdl.ReplyToStaysReview)
+
+	fakeUserID, _ := uuid.NewV4()
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, fakeUserID.String()))
+	}
+
+	reviewID, _ := uuid.NewV4()
+
+	pBytes, _ := json.Marshal(staysreviews.ReplyEntity{Text: "Thank you!"})
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		replied := &staysreviews.StaysReview{ID: reviewID, Reply: &staysreviews.Reply{Text: "Thank you!"}}
+
+		svc.On("ReplyToStaysReview", mock.Anything, reviewID, fakeUserID.String(), &staysreviews.ReplyEntity{Text: "Thank you!"}).Return(replied, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/staysreviews/"+reviewID.String()+"/reply", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error not stay owner", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ReplyToStaysReview", mock.Anything, reviewID, fakeUserID.String(), mock.Anything).Return(nil, service.ErrUserNotOwner).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/staysreviews/"+reviewID.String()+"/reply", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error already replied", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ReplyToStaysReview", mock.Anything, reviewID, fakeUserID.String(), mock.Anything).Return(nil, service.ErrReplyExists).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/staysreviews/"+reviewID.String()+"/reply", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, withUser(req))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error without user", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPut, "/staysreviews/"+reviewID.String()+"/reply", bytes.NewBuffer(pBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
 }
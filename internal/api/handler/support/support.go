@@ -0,0 +1,394 @@
+package support
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	_ "github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	supportSvc "github.com/imperatorofdwelling/Full-backend/internal/service/support"
+	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/pkg/errors"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	Svc     interfaces.SupportService
+	RoleSvc interfaces.RoleService
+	Log     *slog.Logger
+}
+
+func (h *Handler) NewSupportHandler(r chi.Router) {
+	r.Route("/support", func(r chi.Router) {
+		r.Use(mw.WithAuth)
+
+		r.Post("/chats/{chatId}/escalations", h.Escalate)
+		r.Get("/chats/{chatId}/escalations", h.GetChatEscalations)
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithPermission(h.RoleSvc, supportSvc.Route))
+
+			r.Get("/escalations", h.GetEscalations)
+			r.Get("/escalations/{id}", h.GetEscalation)
+			r.Put("/escalations/{id}/assign", h.AssignEscalation)
+			r.Put("/escalations/{id}/close", h.CloseEscalation)
+		})
+	})
+}
+
+// Escalate godoc
+//
+//	@Summary		Escalate chat
+//	@Description	The guest or the host asks the support to step into the chat. The escalation waits in the operator queue,
+//	@Description	an operator has 1 hour to take it and 24 hours to close it. The chat connections get the escalation frame
+//	@Tags			support
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			chatId	path		string		true	"chat id"
+//	@Param			request	body		model.EscalationEntity	false	"reason"
+//	@Success		201	{object}		model.Escalation	"created"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a participant of the chat"
+//	@Failure		404		{object}	response.ResponseError			"Chat not found"
+//	@Failure		409		{object}	response.ResponseError			"Chat is already escalated"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/support/chats/{chatId}/escalations [post]
+func (h *Handler) Escalate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.support.Escalate"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	var entity model.EscalationEntity
+
+	if r.ContentLength != 0 {
+		err := render.DecodeJSON(r.Body, &entity)
+		if err != nil {
+			h.Log.Error("failed to decode body", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+	}
+
+	escalation, err := h.Svc.Escalate(r.Context(), chi.URLParam(r, "chatId"), userID, &entity)
+	if err != nil && !errors.Is(err, service.ErrEscalationNotDelivered) {
+		h.Log.Error("failed to escalate chat", slogError.Err(err))
+		h.writeSupportError(w, r, err)
+		return
+	}
+	if err != nil {
+		h.Log.Warn("chat escalated without notification", slogError.Err(err))
+	}
+
+	responseApi.WriteJson(w, r, http.StatusCreated, escalation)
+}
+
+// GetChatEscalations godoc
+//
+//	@Summary		Get chat escalations
+//	@Description	Get the escalations of the chat with the resolution notes, the latest first
+//	@Tags			support
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			chatId	path		string		true	"chat id"
+//	@Success		200	{array}		model.Escalation	"ok"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a participant of the chat"
+//	@Failure		404		{object}	response.ResponseError			"Chat not found"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/support/chats/{chatId}/escalations [get]
+func (h *Handler) GetChatEscalations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.support.GetChatEscalations"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	escalations, err := h.Svc.GetChatEscalations(r.Context(), chi.URLParam(r, "chatId"), userID)
+	if err != nil {
+		h.Log.Error("failed to get chat escalations", slogError.Err(err))
+		h.writeSupportError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, escalations)
+}
+
+// GetEscalations godoc
+//
+//	@Summary		Get escalation queue
+//	@Description	Get the escalations page by page, the oldest first. The operator is an operator id or "me",
+//	@Description	overdue keeps the escalations past their SLA which are not closed
+//	@Tags			support
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			status		query		string		false	"open, assigned or closed"
+//	@Param			operator	query		string		false	"operator id or me"
+//	@Param			overdue		query		bool		false	"past the SLA only"
+//	@Param			limit		query		int			false	"page size, 20 by default and 100 at most"
+//	@Param			cursor		query		string		false	"next_cursor of the previous page"
+//	@Success		200	{object}		api.Page[model.Escalation]	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a support operator"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/support/escalations [get]
+func (h *Handler) GetEscalations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.support.GetEscalations"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	query := r.URL.Query()
+
+	filter := model.Filter{
+		Status:  model.Status(query.Get("status")),
+		Overdue: query.Get("overdue") == "true",
+	}
+
+	switch operator := query.Get("operator"); operator {
+	case "":
+	case "me":
+		me := uuid.FromStringOrNil(userID)
+		filter.OperatorID = &me
+	default:
+		operatorID, err := uuid.FromString(operator)
+		if err != nil {
+			h.Log.Error("failed to parse operator", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+		filter.OperatorID = &operatorID
+	}
+
+	page, err := api.NewPageRequest(query)
+	if err != nil {
+		h.Log.Error("failed to parse page request", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	escalations, err := h.Svc.GetEscalations(r.Context(), filter, page)
+	if err != nil {
+		h.Log.Error("failed to get escalations", slogError.Err(err))
+		h.writeSupportError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, escalations)
+}
+
+// GetEscalation godoc
+//
+//	@Summary		Get escalation
+//	@Description	Get the escalation with its SLA timers
+//	@Tags			support
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			id		path		string		true	"escalation id"
+//	@Success		200	{object}		model.Escalation	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a support operator"
+//	@Failure		404		{object}	response.ResponseError			"Escalation not found"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/support/escalations/{id} [get]
+func (h *Handler) GetEscalation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.support.GetEscalation"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	escalation, err := h.Svc.GetEscalation(r.Context(), id)
+	if err != nil {
+		h.Log.Error("failed to get escalation", slogError.Err(err))
+		h.writeSupportError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, escalation)
+}
+
+// AssignEscalation godoc
+//
+//	@Summary		Assign escalation
+//	@Description	Give the escalation to an operator, the current user takes it when no operator is set.
+//	@Description	The operator reads and posts in the chat as its third participant until the escalation is closed
+//	@Tags			support
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			id		path		string		true	"escalation id"
+//	@Param			request	body		model.AssignEntity	false	"operator"
+//	@Success		200	{object}		model.Escalation	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a support operator"
+//	@Failure		404		{object}	response.ResponseError			"Escalation or operator not found"
+//	@Failure		409		{object}	response.ResponseError			"Escalation is closed, assigned to another operator or changed"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/support/escalations/{id}/assign [put]
+func (h *Handler) AssignEscalation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.support.AssignEscalation"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var assign model.AssignEntity
+
+	if r.ContentLength != 0 {
+		err = render.DecodeJSON(r.Body, &assign)
+		if err != nil {
+			h.Log.Error("failed to decode body", slogError.Err(err))
+			responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+			return
+		}
+	}
+
+	escalation, err := h.Svc.AssignEscalation(r.Context(), id, &assign, userID)
+	if err != nil && !errors.Is(err, service.ErrEscalationNotDelivered) {
+		h.Log.Error("failed to assign escalation", slogError.Err(err))
+		h.writeSupportError(w, r, err)
+		return
+	}
+	if err != nil {
+		h.Log.Warn("escalation assigned without notification", slogError.Err(err))
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, escalation)
+}
+
+// CloseEscalation godoc
+//
+//	@Summary		Close escalation
+//	@Description	Close the escalation with the resolution note both sides of the chat see, the operator leaves the chat
+//	@Tags			support
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			id		path		string		true	"escalation id"
+//	@Param			request	body		model.CloseEntity	true	"resolution note"
+//	@Success		200	{object}		model.Escalation	"ok"
+//	@Failure		400		{object}	response.ResponseError			"Error"
+//	@Failure		401		{object}	response.ResponseError			"Unauthorized"
+//	@Failure		403		{object}	response.ResponseError			"Not a support operator or the operator of the escalation"
+//	@Failure		404		{object}	response.ResponseError			"Escalation not found"
+//	@Failure		409		{object}	response.ResponseError			"Escalation is closed or changed"
+//	@Failure		default		{object}	response.ResponseError			"Error"
+//	@Router			/support/escalations/{id}/close [put]
+func (h *Handler) CloseEscalation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.support.CloseEscalation"
+
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, ok := r.Context().Value(mw.UserIdKey).(string)
+	if !ok {
+		h.Log.Error("user ID not found in context")
+		responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("unauthorized: user not logged in")))
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to parse id", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	var entity model.CloseEntity
+
+	err = render.DecodeJSON(r.Body, &entity)
+	if err != nil {
+		h.Log.Error("failed to decode body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	escalation, err := h.Svc.CloseEscalation(r.Context(), id, &entity, userID)
+	if err != nil && !errors.Is(err, service.ErrEscalationNotDelivered) {
+		h.Log.Error("failed to close escalation", slogError.Err(err))
+		h.writeSupportError(w, r, err)
+		return
+	}
+	if err != nil {
+		h.Log.Warn("escalation closed without notification", slogError.Err(err))
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, escalation)
+}
+
+func (h *Handler) writeSupportError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrNotChatParticipant), errors.Is(err, service.ErrNotEscalationOperator):
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(err))
+	case errors.Is(err, service.ErrChatNotFound), errors.Is(err, service.ErrEscalationNotFound), errors.Is(err, service.ErrOperatorNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	case errors.Is(err, service.ErrChatEscalated), errors.Is(err, service.ErrEscalationClosed),
+		errors.Is(err, service.ErrEscalationAssigned), errors.Is(err, service.ErrEscalationChanged):
+		responseApi.WriteError(w, r, http.StatusConflict, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
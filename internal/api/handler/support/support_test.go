@@ -0,0 +1,304 @@
+package support
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces/mocks"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	guestID    = "61f0c404-5cb3-11e7-907b-a6006ad3dba0"
+	operatorID = "0b8e3f5c-6a0e-4a3f-9d55-2f8c8a2d4e11"
+	chatID     = "3c2a1b4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+)
+
+func withUser(req *http.Request, userID string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), mw.UserIdKey, userID))
+}
+
+func TestSupportHandler_Escalate(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	svc := mocks.SupportService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: logger.New(),
+	}
+
+	router := chi.NewRouter()
+
+	router.Post("/support/chats/{chatId}/escalations", hdl.Escalate)
+
+	url := "/support/chats/" + chatID + "/escalations"
+	body := `{"reason": "The host asks to pay outside the platform"}`
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		entity := &model.EscalationEntity{Reason: "The host asks to pay outside the platform"}
+		svc.On("Escalate", mock.Anything, chatID, guestID, entity).Return(&model.Escalation{Status: model.StatusOpen}, nil).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(body)), guestID))
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be created when the chat is not notified", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("Escalate", mock.Anything, chatID, guestID, mock.Anything).
+			Return(&model.Escalation{Status: model.StatusOpen}, fmt.Errorf("service.support.Escalate: %w", service.ErrEscalationNotDelivered)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(body)), guestID))
+
+		assert.Equal(t, http.StatusCreated, r.Code)
+	})
+
+	t.Run("should be error not a participant", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("Escalate", mock.Anything, chatID, operatorID, mock.Anything).
+			Return(nil, fmt.Errorf("service.support.Escalate: %w", service.ErrNotChatParticipant)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(body)), operatorID))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error already escalated", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("Escalate", mock.Anything, chatID, guestID, mock.Anything).
+			Return(nil, fmt.Errorf("repo.support.CreateEscalation: %w", service.ErrChatEscalated)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(body)), guestID))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error user not logged in", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(body)))
+
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestSupportHandler_GetEscalations(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	svc := mocks.SupportService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: logger.New(),
+	}
+
+	router := chi.NewRouter()
+
+	router.Get("/support/escalations", hdl.GetEscalations)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		me := uuid.FromStringOrNil(operatorID)
+		filter := model.Filter{Status: model.StatusAssigned, OperatorID: &me, Overdue: true}
+
+		svc.On("GetEscalations", mock.Anything, filter, mock.Anything).Return(api.Page[model.Escalation]{Items: []model.Escalation{}}, nil).Once()
+
+		req := withUser(httptest.NewRequest(http.MethodGet, "/support/escalations?status=assigned&operator=me&overdue=true", nil), operatorID)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error parsing operator", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := withUser(httptest.NewRequest(http.MethodGet, "/support/escalations?operator=invalid", nil), operatorID)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("GetEscalations", mock.Anything, model.Filter{Status: "unknown"}, mock.Anything).
+			Return(api.Page[model.Escalation]{}, fmt.Errorf("service.support.GetEscalations: %w: unknown escalation status", service.ErrValid)).Once()
+
+		req := withUser(httptest.NewRequest(http.MethodGet, "/support/escalations?status=unknown", nil), operatorID)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestSupportHandler_AssignEscalation(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	svc := mocks.SupportService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: logger.New(),
+	}
+
+	router := chi.NewRouter()
+
+	router.Put("/support/escalations/{id}/assign", hdl.AssignEscalation)
+
+	id := uuid.Must(uuid.NewV4())
+	url := "/support/escalations/" + id.String() + "/assign"
+
+	t.Run("should take the escalation without body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("AssignEscalation", mock.Anything, id, &model.AssignEntity{}, operatorID).
+			Return(&model.Escalation{Status: model.StatusAssigned}, nil).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, nil), operatorID))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error operator not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		other := uuid.Must(uuid.NewV4())
+		body, _ := json.Marshal(model.AssignEntity{OperatorID: &other})
+
+		svc.On("AssignEscalation", mock.Anything, id, &model.AssignEntity{OperatorID: &other}, operatorID).
+			Return(nil, fmt.Errorf("service.support.AssignEscalation: %w", service.ErrOperatorNotFound)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(body)), operatorID))
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("should be error escalation closed", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("AssignEscalation", mock.Anything, id, mock.Anything, operatorID).
+			Return(nil, fmt.Errorf("service.support.AssignEscalation: %w", service.ErrEscalationClosed)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, nil), operatorID))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should be error escalation assigned to another operator", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("AssignEscalation", mock.Anything, id, mock.Anything, operatorID).
+			Return(nil, fmt.Errorf("service.support.AssignEscalation: %w", service.ErrEscalationAssigned)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, nil), operatorID))
+
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+
+	t.Run("should reassign when asked for", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("AssignEscalation", mock.Anything, id, &model.AssignEntity{Reassign: true}, operatorID).
+			Return(&model.Escalation{Status: model.StatusAssigned}, nil).Once()
+
+		body := `{"reassign": true}`
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(body)), operatorID))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error parsing id", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, "/support/escalations/invalid/assign", nil), operatorID))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestSupportHandler_CloseEscalation(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	svc := mocks.SupportService{}
+	hdl := Handler{
+		Svc: &svc,
+		Log: logger.New(),
+	}
+
+	router := chi.NewRouter()
+
+	router.Put("/support/escalations/{id}/close", hdl.CloseEscalation)
+
+	id := uuid.Must(uuid.NewV4())
+	url := "/support/escalations/" + id.String() + "/close"
+
+	t.Run("should close with the resolution note", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		entity := &model.CloseEntity{Note: "The reservation is refunded to the guest"}
+		svc.On("CloseEscalation", mock.Anything, id, entity, operatorID).
+			Return(&model.Escalation{Status: model.StatusClosed, ResolutionNote: entity.Note}, nil).Once()
+
+		body := `{"note": "The reservation is refunded to the guest"}`
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(body)), operatorID))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		var resp struct {
+			Data model.Escalation `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(r.Body.Bytes(), &resp))
+		assert.Equal(t, entity.Note, resp.Data.ResolutionNote)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CloseEscalation", mock.Anything, id, &model.CloseEntity{}, operatorID).
+			Return(nil, fmt.Errorf("service.support.CloseEscalation: %w: resolution note is required", service.ErrValid)).Once()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(`{}`)), operatorID))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error not the operator of the escalation", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("CloseEscalation", mock.Anything, id, mock.Anything, operatorID).
+			Return(nil, fmt.Errorf("service.support.CloseEscalation: %w", service.ErrNotEscalationOperator)).Once()
+
+		body := `{"note": "Closed by someone else"}`
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(body)), operatorID))
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		router.ServeHTTP(r, withUser(httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(`{`)), operatorID))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -24,27 +24,45 @@ import (
 )
 
 type UserHandler struct {
-	Svc interfaces.UserService
-	Log *slog.Logger
+	Svc     interfaces.UserService
+	RoleSvc interfaces.RoleService
+	Log     *slog.Logger
 }
 
 func (h *UserHandler) NewUserHandler(r chi.Router) {
 	r.Route("/user", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
 			r.Use(mw.WithAuth)
-			r.Put("/{id}", h.UpdateUserByID)
-			r.Delete("/{id}", h.DeleteUserByID)
 			r.Post("/profile/picture", h.CreateUserPfp)
 			r.Get("/profile/picture", h.GetUserPfp)
 			r.Put("/email/change", h.UpdateUserEmailById)
+
+			// The users manage themselves, managing the others needs the grant
+			r.Group(func(r chi.Router) {
+				r.Use(mw.WithSelfOrPermission(h.RoleSvc, "/user", "id"))
+				r.Put("/{id}", h.UpdateUserByID)
+				r.Delete("/{id}", h.DeleteUserByID)
+				r.Patch("/profile/picture/{id}", h.UpdateUserPfp)
+				r.Delete("/profile/picture/{id}", h.DeleteUserPfp)
+			})
+
+			// The moderators block the users
+			r.Group(func(r chi.Router) {
+				r.Use(mw.WithPermission(h.RoleSvc, "/moderation"))
+				r.Put("/{id}/suspension", h.SuspendUser)
+				r.Delete("/{id}/suspension", h.ReinstateUser)
+			})
 		})
 
 		r.Group(func(r chi.Router) {
 			r.Get("/profile/picture/{id}", h.GetUserPfpByUserID)
 			r.Get("/{id}", h.GetUserByID)
+		})
+
+		// The password reset keeps the session of the logged in user and ends the other ones
+		r.Group(func(r chi.Router) {
+			r.Use(mw.WithOptionalAuth)
 			r.Put("/password", h.UpdateUserPasswordByEmail)
-			r.Patch("/profile/picture/{id}", h.UpdateUserPfp)
-			r.Delete("/profile/picture/{id}", h.DeleteUserPfp)
 		})
 	})
 }
@@ -63,6 +81,7 @@ func (h *UserHandler) NewUserHandler(r chi.Router) {
 // @Success 200 {object} string "Successfully updated"
 // @Failure 400 {object} response.ResponseError "Invalid request"
 // @Failure 404 {object} response.ResponseError "User  not found"
+// @Failure 403 {object} response.ResponseError "Another user without the grant"
 // @Router /user/profile/picture/{id} [patch]
 func (h *UserHandler) UpdateUserPfp(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.user.UpdateUserPfp"
@@ -117,6 +136,7 @@ func (h *UserHandler) UpdateUserPfp(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} response.ResponseError "Unauthorized"
 // @Failure 404 {object} response.ResponseError "User not found"
 // @Failure 500 {object} response.ResponseError "Internal server error"
+// @Failure 403 {object} response.ResponseError "Another user without the grant"
 // @Router /user/profile/picture/{id} [delete]
 func (h *UserHandler) DeleteUserPfp(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.user.DeleteUserPfp"
@@ -148,7 +168,7 @@ func (h *UserHandler) DeleteUserPfp(w http.ResponseWriter, r *http.Request) {
 // GetUserByID
 //
 // @Summary Get a user by ID
-// @Description Retrieves a user by the provided ID
+// @Description Retrieves a user by the provided ID with the rating the hosts gave them as a guest
 // @ID getUserByID
 // @Tags users
 // @Accept  json
@@ -202,6 +222,7 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} response.ResponseError "Invalid request"
 // @Failure 404 {object} response.ResponseError "User not found"
 // @Failure 500 {object} response.ResponseError "Internal server error"
+// @Failure 403 {object} response.ResponseError "Another user without the grant"
 // @Router /user/{id} [put]
 func (h *UserHandler) UpdateUserByID(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.user.LoginUser"
@@ -269,6 +290,7 @@ func (h *UserHandler) UpdateUserByID(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} response.ResponseError "Unauthorized"
 // @Failure 404 {object} response.ResponseError "User not found"
 // @Failure 500 {object} response.ResponseError "Internal server error"
+// @Failure 403 {object} response.ResponseError "Another user without the grant"
 // @Router /user/{id} [delete]
 func (h *UserHandler) DeleteUserByID(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.user.DeleteUserByID"
@@ -296,10 +318,98 @@ func (h *UserHandler) DeleteUserByID(w http.ResponseWriter, r *http.Request) {
 	responseApi.WriteJson(w, r, http.StatusNoContent, nil)
 }
 
+// SuspendUser
+//
+// @Summary Suspend or ban a user
+// @Description Suspends the user for the days or bans them for good, the suspension the user has is replaced. The blocked user can't log in and their stays are hidden
+// @ID suspendUser
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body model.SuspensionEntity true "Suspension"
+// @Security ApiKeyAuth
+// @Success 200 {object} model.Suspension "User suspended"
+// @Failure 400 {object} response.ResponseError "Invalid request"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Not a moderator"
+// @Failure 404 {object} response.ResponseError "User not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /user/{id}/suspension [put]
+func (h *UserHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.user.SuspendUser"
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var suspension model.SuspensionEntity
+
+	err := render.DecodeJSON(r.Body, &suspension)
+	if err != nil {
+		h.Log.Error("failed to decode body", slogError.Err(err))
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+		return
+	}
+
+	suspended, err := h.Svc.SuspendUser(r.Context(), chi.URLParam(r, "id"), &suspension)
+	if err != nil {
+		h.Log.Error("failed to suspend user", slogError.Err(err))
+		h.writeSuspensionError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, suspended)
+}
+
+// ReinstateUser
+//
+// @Summary Reinstate a user
+// @Description Lifts the suspension or the ban of the user
+// @ID reinstateUser
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID"
+// @Security ApiKeyAuth
+// @Success 200 {string} string "User reinstated"
+// @Failure 400 {object} response.ResponseError "Invalid request"
+// @Failure 401 {object} response.ResponseError "Unauthorized"
+// @Failure 403 {object} response.ResponseError "Not a moderator"
+// @Failure 404 {object} response.ResponseError "User not found"
+// @Failure 500 {object} response.ResponseError "Internal server error"
+// @Router /user/{id}/suspension [delete]
+func (h *UserHandler) ReinstateUser(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.user.ReinstateUser"
+	h.Log = h.Log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	err := h.Svc.ReinstateUser(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.Log.Error("failed to reinstate user", slogError.Err(err))
+		h.writeSuspensionError(w, r, err)
+		return
+	}
+
+	responseApi.WriteJson(w, r, http.StatusOK, "user reinstated")
+}
+
+func (h *UserHandler) writeSuspensionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrValid):
+		responseApi.WriteError(w, r, http.StatusBadRequest, slogError.Err(err))
+	case errors.Is(err, service.ErrUserNotFound):
+		responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
+	default:
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+	}
+}
+
 // UpdateUserPasswordByEmail
 //
 // @Summary Update user password by email
-// @Description Updates the user's password after verifying the OTP and checking its expiration
+// @Description Updates the user's password after verifying the OTP and checking its expiration. Every other session of the user is ended
 // @ID updateUserPasswordByEmail
 // @Tags users
 // @Accept json
@@ -338,7 +448,7 @@ func (h *UserHandler) UpdateUserPasswordByEmail(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	err = h.Svc.UpdateUserPasswordByEmail(context.Background(), userNewPassword)
+	err = h.Svc.UpdateUserPasswordByEmail(r.Context(), userNewPassword)
 	if err != nil {
 		if errors.Is(err, service.ErrNotFound) {
 			responseApi.WriteError(w, r, http.StatusNotFound, slogError.Err(err))
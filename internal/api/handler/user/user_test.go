@@ -1588,3 +1588,110 @@ func TestUserHandler_UpdateUserEmailById_EmailAlreadyExists(t *testing.T) {
 	})
 
 }
+
+func TestUserHandler_SuspendUser(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.UserService{}
+	hdl := UserHandler{
+		Log: log,
+		Svc: &svc,
+	}
+
+	router := chi.NewRouter()
+	router.Put("/user/{id}/suspension", hdl.SuspendUser)
+
+	testUserID, _ := uuid.NewV4()
+
+	suspension := user.SuspensionEntity{Reason: "Spam in the chats", Days: 7}
+	sBytes, _ := json.Marshal(suspension)
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		until := time.Now().AddDate(0, 0, 7)
+
+		svc.On("SuspendUser", mock.Anything, testUserID.String(), &suspension).Return(&user.Suspension{Reason: suspension.Reason, Until: &until}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/user/"+testUserID.String()+"/suspension", bytes.NewBuffer(sBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be validation error", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SuspendUser", mock.Anything, testUserID.String(), &suspension).Return(nil, fmt.Errorf("service.user.SuspendUser: %w: reason is required", service.ErrValid)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/user/"+testUserID.String()+"/suspension", bytes.NewBuffer(sBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("should be error user not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("SuspendUser", mock.Anything, testUserID.String(), &suspension).Return(nil, fmt.Errorf("service.user.SuspendUser: %w", service.ErrUserNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/user/"+testUserID.String()+"/suspension", bytes.NewBuffer(sBytes))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("should be error decoding body", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPut, "/user/"+testUserID.String()+"/suspension", bytes.NewBufferString("invalid"))
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestUserHandler_ReinstateUser(t *testing.T) {
+	config.GlobalEnv = config.LocalEnv
+
+	log := logger.New()
+	svc := mocks.UserService{}
+	hdl := UserHandler{
+		Log: log,
+		Svc: &svc,
+	}
+
+	router := chi.NewRouter()
+	router.Delete("/user/{id}/suspension", hdl.ReinstateUser)
+
+	testUserID, _ := uuid.NewV4()
+
+	t.Run("should be no errors", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ReinstateUser", mock.Anything, testUserID.String()).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/user/"+testUserID.String()+"/suspension", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("should be error user not found", func(t *testing.T) {
+		r := httptest.NewRecorder()
+
+		svc.On("ReinstateUser", mock.Anything, testUserID.String()).Return(fmt.Errorf("service.user.ReinstateUser: %w", service.ErrUserNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/user/"+testUserID.String()+"/suspension", nil)
+
+		router.ServeHTTP(r, req)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -43,7 +43,8 @@ func NewKafkaConsumer(paymentConsumer *consumer.PaymentConsumerHdl) *Consumer {
 
 func (c *Consumer) Setup(ctx context.Context, group sarama.ConsumerGroup, hdl sarama.ConsumerGroupHandler) error {
 	for {
-		err := group.Consume(ctx, []string{PaymentReqTopic, PayoutReqTopic, PaymentResponseTopic}, hdl)
+		// The request topics are consumed by the payment service, only its responses are ours
+		err := group.Consume(ctx, []string{PaymentResponseTopic}, hdl)
 		if err != nil {
 			log.Printf("Error from consumer: %v", err)
 		}
@@ -1,16 +1,25 @@
 package consumer
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"github.com/IBM/sarama"
 	yoomodel "github.com/eclipsemode/go-yookassa-sdk/yookassa/model"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"log/slog"
+	"time"
 )
 
+// retryDelay is the pause before a payment response that failed to be stored is handled again
+const retryDelay = 5 * time.Second
+
 type PaymentConsumerHdl struct {
+	Svc             interfaces.PaymentService
 	Log             *slog.Logger
-	WaitForResponse map[string]chan PaymentResponse
+	WaitForResponse *Waiters
 }
 
 type PaymentResponse struct {
@@ -21,9 +30,16 @@ type PaymentResponse struct {
 func (*PaymentConsumerHdl) Setup(sarama.ConsumerGroupSession) error   { return nil }
 func (*PaymentConsumerHdl) Cleanup(sarama.ConsumerGroupSession) error { return nil }
 
+// ConsumeClaim stores every payment response, the waiting requests are notified if there are any.
+// Malformed messages are logged and skipped so that they don't block the ones after them.
+// A response is marked consumed only after it is stored, a failing one is retried until the session ends
+// and the next session consumes it again then.
 func (c *PaymentConsumerHdl) ConsumeClaim(
 	sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	const op = "kafka.consumer.paymentresponse.ConsumeClaim"
+
+	log := c.Log.With(slog.String("op", op))
+
 	for msg := range claim.Messages() {
 
 		requestID := string(msg.Key)
@@ -31,29 +47,57 @@ func (c *PaymentConsumerHdl) ConsumeClaim(
 		var payment yoomodel.Payment
 
 		if err := json.Unmarshal(msg.Value, &payment); err != nil {
-			return fmt.Errorf("%s: %s", op, err.Error())
+			log.Error("failed to decode payment response", slog.String("request_id", requestID), slogError.Err(err))
+			sess.MarkMessage(msg, "")
+			continue
 		}
 
 		if payment.Status == "" {
-			return fmt.Errorf("%s: %s", op, "internal status error")
+			log.Error("payment response without status", slog.String("request_id", requestID))
+			sess.MarkMessage(msg, "")
+			continue
 		}
 
-		if responseChan, ok := c.WaitForResponse[requestID]; ok {
-			responseChan <- PaymentResponse{
-				RequestID: requestID,
-				Result:    payment,
-			}
+		if !c.handle(sess.Context(), log, requestID, payment) {
+			return nil
 		}
 
+		c.WaitForResponse.Notify(PaymentResponse{RequestID: requestID, Result: payment})
+
 		sess.MarkMessage(msg, "")
 	}
 
 	return nil
 }
 
+// handle stores the response retrying the failures, it returns false when the session ends before the response is stored.
+// The responses of the payments unknown here are skipped.
+func (c *PaymentConsumerHdl) handle(ctx context.Context, log *slog.Logger, requestID string, payment yoomodel.Payment) bool {
+	for {
+		err := c.Svc.HandleProviderResponse(ctx, requestID, payment)
+		if err == nil {
+			return true
+		}
+
+		if errors.Is(err, service.ErrPaymentNotFound) {
+			log.Error("payment response of unknown payment", slog.String("request_id", requestID), slogError.Err(err))
+			return true
+		}
+
+		log.Error("failed to handle payment response, retrying", slog.String("request_id", requestID), slogError.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
 func (c *PaymentConsumerHdl) NewPaymentConsumerHdl() *PaymentConsumerHdl {
 
 	return &PaymentConsumerHdl{
+		Svc:             c.Svc,
 		Log:             c.Log,
 		WaitForResponse: c.WaitForResponse,
 	}
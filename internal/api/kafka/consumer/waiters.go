@@ -0,0 +1,58 @@
+package consumer
+
+import "sync"
+
+// Waiters are the payment requests waiting for the provider responses by the idempotence key.
+// The HTTP handlers add and remove them while the consumer notifies them, so the map is guarded.
+type Waiters struct {
+	mu    sync.Mutex
+	chans map[string]chan PaymentResponse
+}
+
+func NewWaiters() *Waiters {
+	return &Waiters{chans: make(map[string]chan PaymentResponse)}
+}
+
+// Add registers the channel for the key, it returns false when another request already waits for it
+func (w *Waiters) Add(key string, ch chan PaymentResponse) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, waiting := w.chans[key]; waiting {
+		return false
+	}
+
+	w.chans[key] = ch
+
+	return true
+}
+
+func (w *Waiters) Remove(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.chans, key)
+}
+
+// Notify passes the response to the request waiting for it, the request may have stopped waiting already
+func (w *Waiters) Notify(res PaymentResponse) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch, ok := w.chans[res.RequestID]
+	if !ok {
+		return
+	}
+
+	select {
+	case ch <- res:
+	default:
+	}
+}
+
+func (w *Waiters) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.chans)
+}
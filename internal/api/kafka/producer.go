@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/IBM/sarama"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 )
 
 const (
@@ -52,3 +53,8 @@ func (p *Producer) SendMessage(topic string, key string, message interface{}) er
 
 	return err
 }
+
+// RequestRefund sends the refund to the payout service, keyed by its idempotence key
+func (p *Producer) RequestRefund(req reservation.RefundRequest) error {
+	return p.SendMessage(PayoutReqTopic, req.IdempotenceKey, req)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"log/slog"
+	"time"
+)
+
+const (
+	// revealInterval is how often the reviews revealed by the closed review windows are counted in the ratings
+	revealInterval = 10 * time.Minute
+	// revealLookback covers the review windows closed while the server was down,
+	// the longer downtime needs the ratings backfill
+	revealLookback = 24 * time.Hour
+)
+
+// revealRatings recomputes the ratings of the stays whose review windows closed since the previous run
+// until ctx is cancelled, the failed run is retried from the same time
+func revealRatings(ctx context.Context, svc interfaces.StaysReviewsService, log *slog.Logger) {
+	const op = "api.revealRatings"
+
+	from := time.Now().Add(-revealLookback)
+
+	ticker := time.NewTicker(revealInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		to := time.Now()
+
+		count, err := svc.RecomputeRevealedRatings(ctx, from, to)
+		if err != nil {
+			log.Error("failed to recompute revealed ratings", slog.String("op", op), slogError.Err(err))
+			continue
+		}
+
+		if count > 0 {
+			log.Info("revealed ratings recomputed", slog.String("op", op), slog.Int("stays", count))
+		}
+
+		from = to
+	}
+}
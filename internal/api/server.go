@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -9,29 +10,41 @@ import (
 	chatHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/chat"
 	confirmEmailHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/confirmEmail"
 	ctrctHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/contracts"
+	curHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/currency"
 	fvrtHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/favourite"
 	fileHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/file"
+	guestRevHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/guestreviews"
 	locHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/location"
 	msgHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/message"
+	modHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/moderation"
 	paymentHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/payment"
 	reservationHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/reservation"
+	roleHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/role"
 	srchRevHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/searchhistory"
 	staysHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/stays"
 	staysAdvHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/staysadvantage"
 	staysReportRevHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/staysreports"
 	staysRevHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/staysreviews"
+	supportHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/support"
 	usrHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/user"
 	usersReportHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/usersreports"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	mw "github.com/imperatorofdwelling/Full-backend/internal/middleware"
 	"github.com/rs/cors"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
+// shutdownTimeout is how long the requests in flight are waited for on shutdown
+const shutdownTimeout = 10 * time.Second
+
 type ServerHTTP struct {
-	router http.Handler
+	router     http.Handler
+	ratingsSvc interfaces.StaysReviewsService
 }
 
 func NewServerHTTP(
@@ -54,15 +67,26 @@ func NewServerHTTP(
 	fileHandler *fileHdl.Handler,
 	confirmEmailHandler *confirmEmailHdl.Handler,
 	paymentHandler *paymentHdl.Handler,
+	currencyHandler *curHdl.Handler,
+	roleHandler *roleHdl.Handler,
+	guestReviewsHandler *guestRevHdl.Handler,
+	moderationHandler *modHdl.Handler,
+	supportHandler *supportHdl.Handler,
 ) *ServerHTTP {
+	// the blocked users are rejected by every authorized route
+	mw.SetSuspensionChecker(userHandler.Svc)
+	mw.SetAPIKeyAuthenticator(authHandler.Svc)
+
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
+	// the server runs behind nginx, it passes the client address in X-Real-IP
+	r.Use(mw.WithClientIP(cfg.Server.TrustedProxies...))
 	r.Use(middleware.DefaultLogger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(10 * time.Second))
 
-	r.Route("/api/v1", func(r chi.Router) {
+	r.Route(mw.APIPrefix, func(r chi.Router) {
 		authHandler.NewAuthHandler(r)
 		advantageHandler.NewAdvantageHandler(r)
 		staysAdvHandler.NewStaysAdvantageHandler(r)
@@ -81,6 +105,11 @@ func NewServerHTTP(
 		fileHandler.NewFileHandler(r)
 		confirmEmailHandler.NewConfirmEmailHandler(r)
 		paymentHandler.NewPaymentHandler(r)
+		currencyHandler.NewCurrencyHandler(r)
+		roleHandler.NewRoleHandler(r)
+		guestReviewsHandler.NewGuestReviewsHandler(r)
+		moderationHandler.NewModerationHandler(r)
+		supportHandler.NewSupportHandler(r)
 
 		r.Get("/swagger/*", httpSwagger.Handler(
 			httpSwagger.URL(fmt.Sprintf("http://%s/api/v1/swagger/doc.json", cfg.Server.Host)),
@@ -90,16 +119,48 @@ func NewServerHTTP(
 	// TODO Change CORS in production
 	handler := cors.AllowAll().Handler(r)
 
-	return &ServerHTTP{router: handler}
+	return &ServerHTTP{router: handler, ratingsSvc: staysReviewsHandler.Svc}
 }
 
-func (sh *ServerHTTP) Start(cfg *config.Config, log *slog.Logger) {
+// Start serves the API and runs the background workers until ctx is cancelled,
+// then waits for the requests in flight and the workers to finish
+func (sh *ServerHTTP) Start(ctx context.Context, cfg *config.Config, log *slog.Logger) {
 	fmt.Print(fmt.Sprintf("Port is %s", cfg.Server.Port))
 	log.Info(fmt.Sprintf("Starting server on port: %s", cfg.Server.Port))
-	addr := cfg.Server.Addr + ":" + cfg.Server.Port
-	err := http.ListenAndServe(addr, sh.router)
-	if err != nil {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var workers sync.WaitGroup
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		revealRatings(ctx, sh.ratingsSvc, log)
+	}()
+
+	srv := &http.Server{Addr: cfg.Server.Addr + ":" + cfg.Server.Port, Handler: sh.router}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
 		log.Error(err.Error())
-		return
+	case <-ctx.Done():
+		log.Info("Shutting down server")
+
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancelShutdown()
+
+		err := srv.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Error(err.Error())
+		}
 	}
+
+	cancel()
+	workers.Wait()
 }
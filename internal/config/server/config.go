@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,14 @@ type Server struct {
 	ReadTimeout  time.Duration `env-default:"5s"`
 	WriteTimeout time.Duration `env-default:"10s"`
 	IdleTimeout  time.Duration `env-default:"60s"`
+	// AttemptStore keeps the login and OTP attempt counters: memory for the single node, postgres for the cluster
+	AttemptStore string `env-default:"memory"`
+	// ChatPubSub delivers the chat messages to the instances: memory for the single node, postgres for the cluster
+	ChatPubSub string `env-default:"memory"`
+	// AllowedOrigins are the web origins allowed to open the chat websocket besides the API host, comma separated
+	AllowedOrigins []string
+	// TrustedProxies are the addresses or CIDR ranges of the proxies passing the client address in X-Real-IP, comma separated
+	TrustedProxies []string
 }
 
 func InitServerConfig() Server {
@@ -19,5 +28,24 @@ func InitServerConfig() Server {
 		Addr: os.Getenv("SERVER_ADDR"),
 		Port: os.Getenv("SERVER_PORT"),
 		Host: os.Getenv("SERVER_HOST"),
+
+		AttemptStore: os.Getenv("SERVER_ATTEMPT_STORE"),
+		ChatPubSub:   os.Getenv("SERVER_CHAT_PUBSUB"),
+
+		AllowedOrigins: splitList(os.Getenv("SERVER_ALLOWED_ORIGINS")),
+		TrustedProxies: splitList(os.Getenv("SERVER_TRUSTED_PROXIES")),
 	}
 }
+
+// splitList splits the comma separated env value skipping the empty items
+func splitList(value string) []string {
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
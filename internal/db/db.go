@@ -11,8 +11,9 @@ import (
 	"log"
 )
 
-func ConnectToBD(cfg *config.Config) (*sql.DB, error) {
-	addr := fmt.Sprintf(
+// DSN is the connection string of the database, the listeners open their own connections with it
+func DSN(cfg *config.Config) string {
+	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.DB.Host,
 		cfg.DB.Port,
@@ -21,6 +22,10 @@ func ConnectToBD(cfg *config.Config) (*sql.DB, error) {
 		cfg.DB.DatabaseName,
 		cfg.DB.SSLMode,
 	)
+}
+
+func ConnectToBD(cfg *config.Config) (*sql.DB, error) {
+	addr := DSN(cfg)
 	// fixed
 
 	db, err := sql.Open("postgres", addr)
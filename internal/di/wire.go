@@ -9,23 +9,32 @@ import (
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/db"
 	advProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/advantage"
+	apikeyProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/apikey"
+	attemptProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/attempt"
 	authProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/auth"
 	chatProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/chat"
 	confirmEmailProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/confirmEmail"
 	ctrctProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/contracts"
+	curProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/currency"
 	fvrtProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/favourite"
 	flProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/file"
+	guestReviewsProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/guestreviews"
 	kafkaProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/kafka"
 	locProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/location"
 	msgProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/message"
+	moderationProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/moderation"
 	paymentProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/payment"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/paymentconsumer"
+	prcProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/pricing"
 	resProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/reservation"
+	roleProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/role"
 	srchProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/searchhistory"
+	sessionProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/session"
 	staysProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/stays"
 	staysAdvProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/staysadvantage"
 	staysReportsProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/staysreports"
 	staysReviewProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/staysreviews"
+	supportProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/support"
 	usrProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/user"
 	usrsReportsProvider "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/usersreports"
 
@@ -53,6 +62,15 @@ func InitializeAPI(cfg *config.Config, log *slog.Logger) (*api.ServerHTTP, error
 		chatProvider.ChatProviderSet,
 		confirmEmailProvider.ProvideSet,
 		paymentProvider.PaymentProviderSet,
+		curProvider.CurrencyProviderSet,
+		prcProvider.PricingProviderSet,
+		roleProvider.RoleProviderSet,
+		guestReviewsProvider.GuestReviewsProviderSet,
+		moderationProvider.ModerationProviderSet,
+		supportProvider.SupportProviderSet,
+		sessionProvider.SessionProviderSet,
+		apikeyProvider.APIKeyProviderSet,
+		attemptProvider.AttemptProviderSet,
 
 		paymentconsumer.PaymentConsumerProviderSet,
 
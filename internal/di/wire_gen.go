@@ -11,24 +11,32 @@ import (
 	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka"
 	"github.com/imperatorofdwelling/Full-backend/internal/config"
 	"github.com/imperatorofdwelling/Full-backend/internal/db"
-	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/connectionmanager"
 	providers3 "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/advantage"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/apikey"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/attempt"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/auth"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/chat"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/confirmEmail"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/contracts"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/currency"
 	user2 "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/favourite"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/file"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/guestreviews"
 	providers2 "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/location"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/message"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/moderation"
 	providers6 "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/payment"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/paymentconsumer"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/pricing"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/reservation"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/role"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/searchhistory"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/session"
 	providers4 "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/stays"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/staysadvantage"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/staysreports"
 	providers5 "github.com/imperatorofdwelling/Full-backend/internal/domain/providers/staysreviews"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/support"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/user"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/providers/usersreports"
 	"log/slog"
@@ -44,37 +52,52 @@ func InitializeAPI(cfg *config.Config, log *slog.Logger) (*api.ServerHTTP, error
 	repository := auth.ProvideAuthRepository(sqlDB)
 	userRepository := user.ProvideUserRepository(sqlDB)
 	repo := confirmEmail.ProvideConfirmEmailRepo(sqlDB)
-	service := auth.ProvideAuthService(repository, userRepository, repo)
+	sessionRepo := session.ProvideSessionRepository(sqlDB)
+	apikeyRepo := apikey.ProvideAPIKeyRepository(sqlDB)
+	attemptStore := attempt.ProvideAttemptStore(cfg, sqlDB)
+	attemptService := attempt.ProvideAttemptService(attemptStore)
+	service := auth.ProvideAuthService(repository, userRepository, repo, sessionRepo, apikeyRepo, attemptService)
 	authHandler := auth.ProvideAuthHandler(service, log)
 	fileService := providers.ProvideFileService()
-	userService := user.ProvideUserService(userRepository, fileService, repo)
-	userHandler := user.ProvideUserHandler(userService, log)
+	guestreviewsRepo := guestreviews.ProvideGuestReviewsRepository(sqlDB)
+	userService := user.ProvideUserService(userRepository, fileService, repo, guestreviewsRepo, sessionRepo)
+	roleRepo := role.ProvideRoleRepository(sqlDB)
+	roleService := role.ProvideRoleService(roleRepo)
+	userHandler := user.ProvideUserHandler(userService, roleService, log)
 	locationRepo := providers2.ProvideLocationRepository(sqlDB)
 	locationService := providers2.ProvideLocationService(locationRepo)
-	handler := providers2.ProvideLocationHandler(locationService, log)
+	handler := providers2.ProvideLocationHandler(locationService, roleService, log)
 	advantageRepo := providers3.ProvideAdvantageRepository(sqlDB)
 	advantageService := providers3.ProvideAdvantageService(advantageRepo, fileService)
-	advantageHandler := providers3.ProvideAdvantageHandler(advantageService, log)
+	advantageHandler := providers3.ProvideAdvantageHandler(advantageService, roleService, log)
 	staysRepo := providers4.ProvideStaysRepo(sqlDB)
-	staysService := providers4.ProvideStaysService(staysRepo, locationService, fileService, userService)
-	staysHandler := providers4.ProvideStaysHandler(staysService, log)
+	reservationRepo := reservation.ProvideReservationRepository(sqlDB)
+	pricingRepo := pricing.ProvidePricingRepository(sqlDB)
+	pricingService := pricing.ProvidePricingService(pricingRepo)
+	producer, err := kafka.NewKafkaProducer()
+	if err != nil {
+		return nil, err
+	}
+	reservationService := reservation.ProvideReservationService(reservationRepo, pricingService, producer)
+	searchhistoryRepo := searchhistory.ProvideSearchHistoryRepository(sqlDB)
+	searchhistoryService := searchhistory.ProvideSearchHistoryService(searchhistoryRepo)
+	currencyRepo := currency.ProvideCurrencyRepository(sqlDB)
+	currencyService := currency.ProvideCurrencyService(currencyRepo)
+	staysService := providers4.ProvideStaysService(staysRepo, locationService, fileService, userService, reservationService, searchhistoryService, currencyService, pricingService)
+	staysHandler := providers4.ProvideStaysHandler(staysService, roleService, log)
 	staysadvantageRepo := staysadvantage.ProvideStaysAdvantageRepo(sqlDB)
 	staysadvantageService := staysadvantage.ProvideStaysAdvantageService(staysadvantageRepo, staysService, advantageService)
 	staysadvantageHandler := staysadvantage.ProvideStaysAdvantageHandler(staysadvantageService, log)
-	reservationRepo := reservation.ProvideReservationRepository(sqlDB)
-	reservationService := reservation.ProvideReservationService(reservationRepo)
 	reservationHandler := reservation.ProvideReservationHandler(reservationService, log)
 	staysreviewsRepo := providers5.ProvideStaysReviewsRepository(sqlDB)
-	staysreviewsService := providers5.ProvideStaysReviewsService(staysreviewsRepo)
+	staysreviewsService := providers5.ProvideStaysReviewsService(staysreviewsRepo, reservationService, staysService)
 	staysreviewsHandler := providers5.ProvideStaysReviewsHandler(staysreviewsService, log)
 	favouriteRepo := user2.ProvideFavouriteRepository(sqlDB)
 	favouriteService := user2.ProvideFavouriteService(favouriteRepo)
 	favHandler := user2.ProvideFavouriteHandler(favouriteService, log)
-	searchhistoryRepo := searchhistory.ProvideSearchHistoryRepository(sqlDB)
-	searchhistoryService := searchhistory.ProvideSearchHistoryService(searchhistoryRepo)
 	searchhistoryHandler := searchhistory.ProvideSearchHistoryHandler(searchhistoryService, log)
 	contractsRepo := contracts.ProvideContractRepository(sqlDB)
-	contractsService := contracts.ProvideContractService(contractsRepo)
+	contractsService := contracts.ProvideContractService(contractsRepo, pricingService, reservationRepo)
 	contractsHandler := contracts.ProvideContractHandler(contractsService, log)
 	staysreportsRepo := staysreports.ProvideStaysReportRepo(sqlDB)
 	staysreportsService := staysreports.ProvideStaysReportService(staysreportsRepo, fileService)
@@ -86,21 +109,33 @@ func InitializeAPI(cfg *config.Config, log *slog.Logger) (*api.ServerHTTP, error
 	messageService := message.ProvideMessageService(messageRepo)
 	messageHandler := message.ProvideMessageHandler(messageService, log)
 	chatRepo := chat.ProvideChatRepo(sqlDB)
-	chatService := chat.ProvideChatService(chatRepo)
-	connectionManager := connectionmanager.NewConnectionManager()
-	chatHandler := chat.ProvideChatHandler(chatService, log, connectionManager)
-	fileHandler := providers.ProvideFileHandler(fileService, log)
-	confirmEmailService := confirmEmail.ProvideConfirmEmailService(repo, userRepository)
-	confirmEmailHandler := confirmEmail.ProvideConfirmEmailHandler(confirmEmailService, log)
-	producer, err := kafka.NewKafkaProducer()
+	chatPubSub, err := chat.ProvideChatPubSub(cfg, sqlDB, log)
 	if err != nil {
 		return nil, err
 	}
-	v := paymentconsumer.ProvideWaitPaymentForResponseChan()
-	paymentConsumerHdl := paymentconsumer.ProvidePaymentConsumer(log, v)
+	chatService := chat.ProvideChatService(chatRepo, chatPubSub, fileService)
+	connectionManager := chat.ProvideConnectionManager(chatPubSub)
+	chatHandler := chat.ProvideChatHandler(cfg, chatService, log, connectionManager)
+	fileHandler := providers.ProvideFileHandler(fileService, log)
+	confirmEmailService := confirmEmail.ProvideConfirmEmailService(repo, userRepository, attemptService)
+	confirmEmailHandler := confirmEmail.ProvideConfirmEmailHandler(confirmEmailService, log)
+	waiters := paymentconsumer.ProvidePaymentWaiters()
+	paymentRepo := providers6.ProvidePaymentRepository(sqlDB)
+	paymentService := providers6.ProvidePaymentService(paymentRepo, reservationService, pricingService, producer, producer)
+	paymentConsumerHdl := paymentconsumer.ProvidePaymentConsumer(paymentService, log, waiters)
 	consumer := kafka.NewKafkaConsumer(paymentConsumerHdl)
 	client := kafka.NewClient(producer, consumer, log)
-	paymentHandler := providers6.ProvidePaymentHandler(client, log, v)
-	serverHTTP := api.NewServerHTTP(cfg, authHandler, userHandler, handler, advantageHandler, staysHandler, staysadvantageHandler, reservationHandler, staysreviewsHandler, favHandler, searchhistoryHandler, contractsHandler, staysreportsHandler, usersreportsHandler, messageHandler, chatHandler, fileHandler, confirmEmailHandler, paymentHandler)
+	paymentHandler := providers6.ProvidePaymentHandler(paymentService, client, log, waiters)
+	currencyHandler := currency.ProvideCurrencyHandler(currencyService, roleService, log)
+	roleHandler := role.ProvideRoleHandler(roleService, log)
+	guestreviewsService := guestreviews.ProvideGuestReviewsService(guestreviewsRepo, reservationService, staysService, staysreviewsRepo)
+	guestreviewsHandler := guestreviews.ProvideGuestReviewsHandler(guestreviewsService, log)
+	moderationRepo := moderation.ProvideModerationRepository(sqlDB)
+	moderationService := moderation.ProvideModerationService(moderationRepo, userRepository, userService, roleService)
+	moderationHandler := moderation.ProvideModerationHandler(moderationService, roleService, log)
+	supportRepo := support.ProvideSupportRepository(sqlDB)
+	supportService := support.ProvideSupportService(supportRepo, chatService, userRepository, roleService)
+	supportHandler := support.ProvideSupportHandler(supportService, roleService, log)
+	serverHTTP := api.NewServerHTTP(cfg, authHandler, userHandler, handler, advantageHandler, staysHandler, staysadvantageHandler, reservationHandler, staysreviewsHandler, favHandler, searchhistoryHandler, contractsHandler, staysreportsHandler, usersreportsHandler, messageHandler, chatHandler, fileHandler, confirmEmailHandler, paymentHandler, currencyHandler, roleHandler, guestreviewsHandler, moderationHandler, supportHandler)
 	return serverHTTP, nil
 }
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
+)
+
+//go:generate mockery --name APIKeyRepo
+type APIKeyRepo interface {
+	CreateAPIKey(ctx context.Context, key *apikey.APIKey, keyHash string) error
+	GetAPIKeys(ctx context.Context, userID uuid.UUID) ([]apikey.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id uuid.UUID) error
+	Authenticate(ctx context.Context, keyHash string) (*apikey.Principal, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
+)
+
+//go:generate mockery --name AttemptStore
+type AttemptStore interface {
+	GetAttempts(ctx context.Context, key string) (*attempt.Counter, error)
+	TakeAttempt(ctx context.Context, key string, policy attempt.Policy) (*attempt.Counter, bool, error)
+	ReleaseAttempt(ctx context.Context, key string) error
+	ResetAttempts(ctx context.Context, key string) error
+}
+
+//go:generate mockery --name AttemptLimiter
+type AttemptLimiter interface {
+	Check(ctx context.Context, limits ...attempt.Limit) error
+	Take(ctx context.Context, limits ...attempt.Limit) error
+	Release(ctx context.Context, limits ...attempt.Limit) error
+	Reset(ctx context.Context, limits ...attempt.Limit) error
+}
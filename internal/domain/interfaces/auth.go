@@ -3,7 +3,9 @@ package interfaces
 import (
 	"context"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/auth"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
 	"net/http"
 )
 
@@ -28,7 +30,15 @@ type (
 		CheckEmailOTP(ctx context.Context, userID, otp string) error
 		CheckPasswordOTP(ctx context.Context, email, otp string) error
 		CheckEmailChangeOTP(ctx context.Context, userID, otp string) error
-
+		CreateSession(ctx context.Context, userID uuid.UUID, roleID int, device session.Device) (*session.Tokens, error)
+		RefreshSession(ctx context.Context, refreshToken string) (*session.Tokens, error)
+		Logout(ctx context.Context, refreshToken string) error
+		GetSessions(ctx context.Context, userID string) ([]session.Session, error)
+		RevokeSession(ctx context.Context, userID string, sessionID uuid.UUID) error
+		CreateAPIKey(ctx context.Context, userID string, key *apikey.Entity) (*apikey.Created, error)
+		GetAPIKeys(ctx context.Context, userID string) ([]apikey.APIKey, error)
+		RevokeAPIKey(ctx context.Context, userID string, id uuid.UUID) error
+		AuthenticateAPIKey(ctx context.Context, key string) (*apikey.Principal, error)
 	}
 )
 
@@ -37,5 +47,12 @@ type (
 		Registration(w http.ResponseWriter, r *http.Request)
 		LoginUser(w http.ResponseWriter, r *http.Request)
 		ConfirmEmailOTP(w http.ResponseWriter, r *http.Request)
+		RefreshToken(w http.ResponseWriter, r *http.Request)
+		Logout(w http.ResponseWriter, r *http.Request)
+		GetSessions(w http.ResponseWriter, r *http.Request)
+		RevokeSession(w http.ResponseWriter, r *http.Request)
+		CreateAPIKey(w http.ResponseWriter, r *http.Request)
+		GetAPIKeys(w http.ResponseWriter, r *http.Request)
+		RevokeAPIKey(w http.ResponseWriter, r *http.Request)
 	}
 )
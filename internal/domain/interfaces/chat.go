@@ -4,30 +4,44 @@ import (
 	"context"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"net/http"
+	"os"
 )
 
 //go:generate mockery --name ChatRepository
 type (
 	ChatRepository interface {
-		GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Chat, error)
+		GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Overview, error)
 		GetChatByChatID(ctx context.Context, chatID string) (*chat.Chat, error)
 		GetOrCreateChatID(ctx context.Context, userID, otherUserID string) (*string, error)
-		GetMessagesByChatID(ctx context.Context, chatID string) ([]*message.Entity, error)
+		GetMessagesByChatID(ctx context.Context, chatID string, page api.PageRequest) (api.Page[message.Message], error)
 		SendMessage(ctx context.Context, senderId, receiverId string, msg message.Entity) error
-		SendMessageInChat(ctx context.Context, chatId, senderId string, msg message.Entity) error
+		SendMessageInChat(ctx context.Context, chatId, senderId string, msg message.Entity) (*message.Message, error)
+		MarkRead(ctx context.Context, chatID, userID, messageID string) (*chat.ReadMarker, error)
+		GetReadMarkers(ctx context.Context, chatID string) ([]chat.ReadMarker, error)
+		SendAttachmentInChat(ctx context.Context, chatID, senderID, text string, att message.Attachment) (*message.Message, error)
+		GetAttachment(ctx context.Context, chatID, attachmentID string) (*message.Attachment, error)
 	}
 )
 
 //go:generate mockery --name ChatService
 type (
 	ChatService interface {
-		GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Chat, error)
+		GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Overview, error)
 		GetChatByChatID(ctx context.Context, chatID string) (*chat.Chat, error)
 		GetOrCreateChatID(ctx context.Context, userID, otherUserID string) (*string, error)
-		GetMessagesByChatID(ctx context.Context, chatID string) ([]*message.Entity, error)
+		GetMessagesByChatID(ctx context.Context, chatID string, page api.PageRequest) (api.Page[message.Message], error)
 		SendMessage(ctx context.Context, senderId, receiverId string, msg message.Entity) error
 		SendMessageInChat(ctx context.Context, chatId, senderId string, msg message.Entity) error
+		GetParticipantChat(ctx context.Context, chatID, userID string) (*chat.Chat, error)
+		SendTyping(ctx context.Context, chatID, userID string) error
+		MarkRead(ctx context.Context, chatID, userID, messageID string) (*chat.ReadMarker, error)
+		GetReadMarkers(ctx context.Context, chatID string) ([]chat.ReadMarker, error)
+		SendAttachment(ctx context.Context, chatID, userID, name, text string, data []byte) (*message.Message, error)
+		OpenAttachment(ctx context.Context, chatID, userID, attachmentID string) (*message.Attachment, *os.File, error)
+		Broadcast(ctx context.Context, chatID string, frame chat.Frame) error
+		Disconnect(ctx context.Context, chatID, userID string, frame chat.Frame) error
 	}
 )
 
@@ -36,6 +50,9 @@ type (
 		GetChatsByUserID(w http.ResponseWriter, r *http.Request)
 		GetMessagesByChatID(w http.ResponseWriter, r *http.Request)
 		SendMessage(w http.ResponseWriter, r *http.Request)
+		MarkRead(w http.ResponseWriter, r *http.Request)
+		SendAttachment(w http.ResponseWriter, r *http.Request)
+		GetAttachment(w http.ResponseWriter, r *http.Request)
 		HandleWebSocket(w http.ResponseWriter, r *http.Request)
 	}
 )
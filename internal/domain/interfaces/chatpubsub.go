@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+)
+
+//go:generate mockery --name ChatPubSub
+type ChatPubSub interface {
+	Publish(ctx context.Context, event chat.Event) error
+	Subscribe(handle func(event chat.Event))
+}
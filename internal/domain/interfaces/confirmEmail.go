@@ -31,6 +31,9 @@ type (
 		ResetEmailChangeOTP(ctx context.Context, userID string) error
 		CheckEmailChangeOTPVerified(ctx context.Context, userID string) (bool, error)
 		CheckEmailChangeOTPVerifiedForTooLong(ctx context.Context, userID string) (bool, error)
+		ExpireEmailOTP(ctx context.Context, userID string) error
+		ExpirePasswordOTP(ctx context.Context, email string) error
+		ExpireEmailChangeOTP(ctx context.Context, userID string) error
 	}
 )
 
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/contracts"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
 	"golang.org/x/net/context"
 	"net/http"
 	"time"
@@ -9,8 +10,8 @@ import (
 
 //go:generate mockery --name ContractsRepo
 type ContractsRepo interface {
-	AddContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time) error
-	UpdateContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time) (*contracts.ContractEntity, error)
+	AddContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time, price money.Money) error
+	UpdateContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time, price money.Money) (*contracts.ContractEntity, error)
 	GetAllContracts(ctx context.Context, userId string) ([]contracts.ContractEntity, error)
 }
 
@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"net/http"
+)
+
+//go:generate mockery --name CurrencyRepo
+type CurrencyRepo interface {
+	GetRates(ctx context.Context) ([]money.Rate, error)
+	GetRate(ctx context.Context, currency money.Currency) (*money.Rate, error)
+	SetRate(ctx context.Context, currency money.Currency, rate float64) error
+	DeleteRate(ctx context.Context, currency money.Currency) error
+}
+
+//go:generate mockery --name CurrencyService
+type CurrencyService interface {
+	GetRates(ctx context.Context) ([]money.Rate, error)
+	GetRate(ctx context.Context, currency money.Currency) (*money.Rate, error)
+	SetRate(ctx context.Context, currency money.Currency, rate float64) error
+	DeleteRate(ctx context.Context, currency money.Currency) error
+	Convert(ctx context.Context, amount money.Money, to money.Currency) (money.Money, error)
+}
+
+type CurrencyHandler interface {
+	GetRates(w http.ResponseWriter, r *http.Request)
+	SetRate(w http.ResponseWriter, r *http.Request)
+	DeleteRate(w http.ResponseWriter, r *http.Request)
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	stays2 "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"net/http"
 )
 
@@ -10,14 +11,14 @@ import (
 type FavouriteRepo interface {
 	AddFavourite(ctx context.Context, userId, stayID string) error
 	RemoveFavourite(ctx context.Context, userID, stayID string) error
-	GetAllFavourites(ctx context.Context, userID string) ([]stays2.StayEntityFav, error)
+	GetAllFavourites(ctx context.Context, userID string, page api.PageRequest) (api.Page[stays2.StayEntityFav], error)
 }
 
 //go:generate mockery --name FavouriteService
 type FavouriteService interface {
 	AddToFavourites(ctx context.Context, userID, stayID string) error
 	RemoveFromFavourites(ctx context.Context, userID, stayID string) error
-	GetAllFavourites(ctx context.Context, userID string) ([]stays2.StayEntityFav, error)
+	GetAllFavourites(ctx context.Context, userID string, page api.PageRequest) (api.Page[stays2.StayEntityFav], error)
 }
 
 type FavouriteHandler interface {
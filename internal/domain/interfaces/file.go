@@ -3,12 +3,16 @@ package interfaces
 import (
 	"github.com/imperatorofdwelling/Full-backend/internal/service/file"
 	"net/http"
+	"os"
 )
 
 //go:generate mockery --name FileService
 type FileService interface {
 	UploadImage(img []byte, imgType file.ImageType, filePath file.PathType) (string, error)
 	RemoveFile(fileName string) error
+	UploadPrivateFile(data []byte, ext string, filePath file.PathType) (string, error)
+	OpenPrivateFile(fileName string) (*os.File, error)
+	RemovePrivateFile(fileName string) error
 	GenRandomFileName() (string, error)
 }
 
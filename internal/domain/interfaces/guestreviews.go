@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"net/http"
+)
+
+//go:generate mockery --name GuestReviewsRepo
+type GuestReviewsRepo interface {
+	CreateGuestReview(ctx context.Context, review *guestreviews.Entity) (uuid.UUID, error)
+	GetGuestReviewByID(ctx context.Context, id uuid.UUID) (*guestreviews.GuestReview, error)
+	GetGuestReviewsByGuestID(ctx context.Context, guestID uuid.UUID, page api.PageRequest) (api.Page[guestreviews.GuestReview], error)
+	GetGuestSummary(ctx context.Context, guestID uuid.UUID) (*guestreviews.Summary, error)
+}
+
+//go:generate mockery --name GuestReviewsService
+type GuestReviewsService interface {
+	CreateGuestReview(ctx context.Context, review *guestreviews.Entity, hostID string) (*guestreviews.GuestReview, error)
+	GetGuestReviews(ctx context.Context, guestID uuid.UUID, page api.PageRequest) (api.Page[guestreviews.GuestReview], error)
+}
+
+type GuestReviewsHandler interface {
+	CreateGuestReview(w http.ResponseWriter, r *http.Request)
+	GetGuestReviews(w http.ResponseWriter, r *http.Request)
+}
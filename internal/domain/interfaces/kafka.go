@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+
+//go:generate mockery --name KafkaProducer
+type KafkaProducer interface {
+	SendMessage(topic string, key string, message interface{}) error
+}
+
+//go:generate mockery --name PayoutProducer
+type PayoutProducer interface {
+	RequestRefund(req reservation.RefundRequest) error
+}
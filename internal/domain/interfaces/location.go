@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/gofrs/uuid"
 	models "github.com/imperatorofdwelling/Full-backend/internal/domain/models/location"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"net/http"
 )
 
@@ -11,7 +12,7 @@ import (
 type LocationRepo interface {
 	FindByNameMatch(ctx context.Context, match string) (*[]models.Location, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Location, error)
-	GetAll(ctx context.Context) (*[]models.Location, error)
+	GetAll(ctx context.Context, page api.PageRequest) (api.Page[models.Location], error)
 	DeleteByID(ctx context.Context, id uuid.UUID) error
 	UpdateByID(ctx context.Context, id uuid.UUID, location models.LocationEntity) error
 }
@@ -20,7 +21,7 @@ type LocationRepo interface {
 type LocationService interface {
 	FindByNameMatch(ctx context.Context, match string) (*[]models.Location, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Location, error)
-	GetAll(ctx context.Context) (*[]models.Location, error)
+	GetAll(ctx context.Context, page api.PageRequest) (api.Page[models.Location], error)
 	DeleteByID(ctx context.Context, id uuid.UUID) error
 	UpdateByID(ctx context.Context, id uuid.UUID, location models.LocationEntity) error
 }
@@ -0,0 +1,130 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	attempt "github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttemptLimiter is an autogenerated mock type for the AttemptLimiter type
+type AttemptLimiter struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, limits
+func (_m *AttemptLimiter) Check(ctx context.Context, limits ...attempt.Limit) error {
+	_va := make([]interface{}, len(limits))
+	for _i := range limits {
+		_va[_i] = limits[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...attempt.Limit) error); ok {
+		r0 = rf(ctx, limits...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: ctx, limits
+func (_m *AttemptLimiter) Release(ctx context.Context, limits ...attempt.Limit) error {
+	_va := make([]interface{}, len(limits))
+	for _i := range limits {
+		_va[_i] = limits[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...attempt.Limit) error); ok {
+		r0 = rf(ctx, limits...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reset provides a mock function with given fields: ctx, limits
+func (_m *AttemptLimiter) Reset(ctx context.Context, limits ...attempt.Limit) error {
+	_va := make([]interface{}, len(limits))
+	for _i := range limits {
+		_va[_i] = limits[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...attempt.Limit) error); ok {
+		r0 = rf(ctx, limits...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Take provides a mock function with given fields: ctx, limits
+func (_m *AttemptLimiter) Take(ctx context.Context, limits ...attempt.Limit) error {
+	_va := make([]interface{}, len(limits))
+	for _i := range limits {
+		_va[_i] = limits[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...attempt.Limit) error); ok {
+		r0 = rf(ctx, limits...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAttemptLimiter creates a new instance of AttemptLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttemptLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttemptLimiter {
+	mock := &AttemptLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,133 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	attempt "github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttemptStore is an autogenerated mock type for the AttemptStore type
+type AttemptStore struct {
+	mock.Mock
+}
+
+// GetAttempts provides a mock function with given fields: ctx, key
+func (_m *AttemptStore) GetAttempts(ctx context.Context, key string) (*attempt.Counter, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttempts")
+	}
+
+	var r0 *attempt.Counter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*attempt.Counter, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *attempt.Counter); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attempt.Counter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseAttempt provides a mock function with given fields: ctx, key
+func (_m *AttemptStore) ReleaseAttempt(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetAttempts provides a mock function with given fields: ctx, key
+func (_m *AttemptStore) ResetAttempts(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TakeAttempt provides a mock function with given fields: ctx, key, policy
+func (_m *AttemptStore) TakeAttempt(ctx context.Context, key string, policy attempt.Policy) (*attempt.Counter, bool, error) {
+	ret := _m.Called(ctx, key, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeAttempt")
+	}
+
+	var r0 *attempt.Counter
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, attempt.Policy) (*attempt.Counter, bool, error)); ok {
+		return rf(ctx, key, policy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, attempt.Policy) *attempt.Counter); ok {
+		r0 = rf(ctx, key, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attempt.Counter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, attempt.Policy) bool); ok {
+		r1 = rf(ctx, key, policy)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, attempt.Policy) error); ok {
+		r2 = rf(ctx, key, policy)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewAttemptStore creates a new instance of AttemptStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttemptStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttemptStore {
+	mock := &AttemptStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,12 +3,15 @@
 package mocks
 
 import (
-	context "context"
-
+	apikey "github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
 	auth "github.com/imperatorofdwelling/Full-backend/internal/domain/models/auth"
 
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
+	session "github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
+
 	uuid "github.com/gofrs/uuid"
 )
 
@@ -17,6 +20,36 @@ type AuthService struct {
 	mock.Mock
 }
 
+// AuthenticateAPIKey provides a mock function with given fields: ctx, key
+func (_m *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (*apikey.Principal, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateAPIKey")
+	}
+
+	var r0 *apikey.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*apikey.Principal, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *apikey.Principal); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apikey.Principal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckEmailChangeOTP provides a mock function with given fields: ctx, userID, otp
 func (_m *AuthService) CheckEmailChangeOTP(ctx context.Context, userID string, otp string) error {
 	ret := _m.Called(ctx, userID, otp)
@@ -71,6 +104,126 @@ func (_m *AuthService) CheckPasswordOTP(ctx context.Context, email string, otp s
 	return r0
 }
 
+// CreateAPIKey provides a mock function with given fields: ctx, userID, key
+func (_m *AuthService) CreateAPIKey(ctx context.Context, userID string, key *apikey.Entity) (*apikey.Created, error) {
+	ret := _m.Called(ctx, userID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 *apikey.Created
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *apikey.Entity) (*apikey.Created, error)); ok {
+		return rf(ctx, userID, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *apikey.Entity) *apikey.Created); ok {
+		r0 = rf(ctx, userID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apikey.Created)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *apikey.Entity) error); ok {
+		r1 = rf(ctx, userID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSession provides a mock function with given fields: ctx, userID, roleID, device
+func (_m *AuthService) CreateSession(ctx context.Context, userID uuid.UUID, roleID int, device session.Device) (*session.Tokens, error) {
+	ret := _m.Called(ctx, userID, roleID, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSession")
+	}
+
+	var r0 *session.Tokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, session.Device) (*session.Tokens, error)); ok {
+		return rf(ctx, userID, roleID, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, session.Device) *session.Tokens); ok {
+		r0 = rf(ctx, userID, roleID, device)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*session.Tokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, session.Device) error); ok {
+		r1 = rf(ctx, userID, roleID, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAPIKeys provides a mock function with given fields: ctx, userID
+func (_m *AuthService) GetAPIKeys(ctx context.Context, userID string) ([]apikey.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeys")
+	}
+
+	var r0 []apikey.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]apikey.APIKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []apikey.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]apikey.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessions provides a mock function with given fields: ctx, userID
+func (_m *AuthService) GetSessions(ctx context.Context, userID string) ([]session.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []session.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]session.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []session.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]session.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, user
 func (_m *AuthService) Login(ctx context.Context, user auth.Login) (uuid.UUID, int, error) {
 	ret := _m.Called(ctx, user)
@@ -108,6 +261,54 @@ func (_m *AuthService) Login(ctx context.Context, user auth.Login) (uuid.UUID, i
 	return r0, r1, r2
 }
 
+// Logout provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshSession provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthService) RefreshSession(ctx context.Context, refreshToken string) (*session.Tokens, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSession")
+	}
+
+	var r0 *session.Tokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*session.Tokens, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *session.Tokens); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*session.Tokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: ctx, user
 func (_m *AuthService) Register(ctx context.Context, user auth.Registration) (uuid.UUID, error) {
 	ret := _m.Called(ctx, user)
@@ -138,6 +339,42 @@ func (_m *AuthService) Register(ctx context.Context, user auth.Registration) (uu
 	return r0, r1
 }
 
+// RevokeAPIKey provides a mock function with given fields: ctx, userID, id
+func (_m *AuthService) RevokeAPIKey(ctx context.Context, userID string, id uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *AuthService) RevokeSession(ctx context.Context, userID string, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthService(t interface {
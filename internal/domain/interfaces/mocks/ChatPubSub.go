@@ -0,0 +1,53 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	chat "github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChatPubSub is an autogenerated mock type for the ChatPubSub type
+type ChatPubSub struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: ctx, event
+func (_m *ChatPubSub) Publish(ctx context.Context, event chat.Event) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, chat.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: handle
+func (_m *ChatPubSub) Subscribe(handle func(chat.Event)) {
+	_m.Called(handle)
+}
+
+// NewChatPubSub creates a new instance of ChatPubSub. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChatPubSub(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChatPubSub {
+	mock := &ChatPubSub{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	chat "github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
 
 	message "github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
 
@@ -17,6 +18,36 @@ type ChatRepository struct {
 	mock.Mock
 }
 
+// GetAttachment provides a mock function with given fields: ctx, chatID, attachmentID
+func (_m *ChatRepository) GetAttachment(ctx context.Context, chatID string, attachmentID string) (*message.Attachment, error) {
+	ret := _m.Called(ctx, chatID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachment")
+	}
+
+	var r0 *message.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*message.Attachment, error)); ok {
+		return rf(ctx, chatID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *message.Attachment); ok {
+		r0 = rf(ctx, chatID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*message.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, chatID, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChatByChatID provides a mock function with given fields: ctx, chatID
 func (_m *ChatRepository) GetChatByChatID(ctx context.Context, chatID string) (*chat.Chat, error) {
 	ret := _m.Called(ctx, chatID)
@@ -48,23 +79,23 @@ func (_m *ChatRepository) GetChatByChatID(ctx context.Context, chatID string) (*
 }
 
 // GetChatsByUserID provides a mock function with given fields: ctx, userID
-func (_m *ChatRepository) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Chat, error) {
+func (_m *ChatRepository) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Overview, error) {
 	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetChatsByUserID")
 	}
 
-	var r0 []*chat.Chat
+	var r0 []*chat.Overview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*chat.Chat, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*chat.Overview, error)); ok {
 		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*chat.Chat); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*chat.Overview); ok {
 		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*chat.Chat)
+			r0 = ret.Get(0).([]*chat.Overview)
 		}
 	}
 
@@ -77,29 +108,27 @@ func (_m *ChatRepository) GetChatsByUserID(ctx context.Context, userID string) (
 	return r0, r1
 }
 
-// GetMessagesByChatID provides a mock function with given fields: ctx, chatID
-func (_m *ChatRepository) GetMessagesByChatID(ctx context.Context, chatID string) ([]*message.Entity, error) {
-	ret := _m.Called(ctx, chatID)
+// GetMessagesByChatID provides a mock function with given fields: ctx, chatID, page
+func (_m *ChatRepository) GetMessagesByChatID(ctx context.Context, chatID string, page api.PageRequest) (api.Page[message.Message], error) {
+	ret := _m.Called(ctx, chatID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMessagesByChatID")
 	}
 
-	var r0 []*message.Entity
+	var r0 api.Page[message.Message]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*message.Entity, error)); ok {
-		return rf(ctx, chatID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PageRequest) (api.Page[message.Message], error)); ok {
+		return rf(ctx, chatID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*message.Entity); ok {
-		r0 = rf(ctx, chatID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PageRequest) api.Page[message.Message]); ok {
+		r0 = rf(ctx, chatID, page)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*message.Entity)
-		}
+		r0 = ret.Get(0).(api.Page[message.Message])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, chatID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.PageRequest) error); ok {
+		r1 = rf(ctx, chatID, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -137,6 +166,96 @@ func (_m *ChatRepository) GetOrCreateChatID(ctx context.Context, userID string,
 	return r0, r1
 }
 
+// GetReadMarkers provides a mock function with given fields: ctx, chatID
+func (_m *ChatRepository) GetReadMarkers(ctx context.Context, chatID string) ([]chat.ReadMarker, error) {
+	ret := _m.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadMarkers")
+	}
+
+	var r0 []chat.ReadMarker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]chat.ReadMarker, error)); ok {
+		return rf(ctx, chatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []chat.ReadMarker); ok {
+		r0 = rf(ctx, chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]chat.ReadMarker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkRead provides a mock function with given fields: ctx, chatID, userID, messageID
+func (_m *ChatRepository) MarkRead(ctx context.Context, chatID string, userID string, messageID string) (*chat.ReadMarker, error) {
+	ret := _m.Called(ctx, chatID, userID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 *chat.ReadMarker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*chat.ReadMarker, error)); ok {
+		return rf(ctx, chatID, userID, messageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *chat.ReadMarker); ok {
+		r0 = rf(ctx, chatID, userID, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chat.ReadMarker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, chatID, userID, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendAttachmentInChat provides a mock function with given fields: ctx, chatID, senderID, text, att
+func (_m *ChatRepository) SendAttachmentInChat(ctx context.Context, chatID string, senderID string, text string, att message.Attachment) (*message.Message, error) {
+	ret := _m.Called(ctx, chatID, senderID, text, att)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAttachmentInChat")
+	}
+
+	var r0 *message.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, message.Attachment) (*message.Message, error)); ok {
+		return rf(ctx, chatID, senderID, text, att)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, message.Attachment) *message.Message); ok {
+		r0 = rf(ctx, chatID, senderID, text, att)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*message.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, message.Attachment) error); ok {
+		r1 = rf(ctx, chatID, senderID, text, att)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendMessage provides a mock function with given fields: ctx, senderId, receiverId, msg
 func (_m *ChatRepository) SendMessage(ctx context.Context, senderId string, receiverId string, msg message.Entity) error {
 	ret := _m.Called(ctx, senderId, receiverId, msg)
@@ -156,21 +275,33 @@ func (_m *ChatRepository) SendMessage(ctx context.Context, senderId string, rece
 }
 
 // SendMessageInChat provides a mock function with given fields: ctx, chatId, senderId, msg
-func (_m *ChatRepository) SendMessageInChat(ctx context.Context, chatId string, senderId string, msg message.Entity) error {
+func (_m *ChatRepository) SendMessageInChat(ctx context.Context, chatId string, senderId string, msg message.Entity) (*message.Message, error) {
 	ret := _m.Called(ctx, chatId, senderId, msg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendMessageInChat")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, message.Entity) error); ok {
+	var r0 *message.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, message.Entity) (*message.Message, error)); ok {
+		return rf(ctx, chatId, senderId, msg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, message.Entity) *message.Message); ok {
 		r0 = rf(ctx, chatId, senderId, msg)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*message.Message)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, message.Entity) error); ok {
+		r1 = rf(ctx, chatId, senderId, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewChatRepository creates a new instance of ChatRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -6,10 +6,13 @@ import (
 	context "context"
 
 	chat "github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
 
 	message "github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
 
 	mock "github.com/stretchr/testify/mock"
+
+	os "os"
 )
 
 // ChatService is an autogenerated mock type for the ChatService type
@@ -17,6 +20,42 @@ type ChatService struct {
 	mock.Mock
 }
 
+// Broadcast provides a mock function with given fields: ctx, chatID, frame
+func (_m *ChatService) Broadcast(ctx context.Context, chatID string, frame chat.Frame) error {
+	ret := _m.Called(ctx, chatID, frame)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Broadcast")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, chat.Frame) error); ok {
+		r0 = rf(ctx, chatID, frame)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Disconnect provides a mock function with given fields: ctx, chatID, userID, frame
+func (_m *ChatService) Disconnect(ctx context.Context, chatID string, userID string, frame chat.Frame) error {
+	ret := _m.Called(ctx, chatID, userID, frame)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disconnect")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chat.Frame) error); ok {
+		r0 = rf(ctx, chatID, userID, frame)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetChatByChatID provides a mock function with given fields: ctx, chatID
 func (_m *ChatService) GetChatByChatID(ctx context.Context, chatID string) (*chat.Chat, error) {
 	ret := _m.Called(ctx, chatID)
@@ -48,23 +87,23 @@ func (_m *ChatService) GetChatByChatID(ctx context.Context, chatID string) (*cha
 }
 
 // GetChatsByUserID provides a mock function with given fields: ctx, userID
-func (_m *ChatService) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Chat, error) {
+func (_m *ChatService) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Overview, error) {
 	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetChatsByUserID")
 	}
 
-	var r0 []*chat.Chat
+	var r0 []*chat.Overview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*chat.Chat, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*chat.Overview, error)); ok {
 		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*chat.Chat); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*chat.Overview); ok {
 		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*chat.Chat)
+			r0 = ret.Get(0).([]*chat.Overview)
 		}
 	}
 
@@ -77,29 +116,27 @@ func (_m *ChatService) GetChatsByUserID(ctx context.Context, userID string) ([]*
 	return r0, r1
 }
 
-// GetMessagesByChatID provides a mock function with given fields: ctx, chatID
-func (_m *ChatService) GetMessagesByChatID(ctx context.Context, chatID string) ([]*message.Entity, error) {
-	ret := _m.Called(ctx, chatID)
+// GetMessagesByChatID provides a mock function with given fields: ctx, chatID, page
+func (_m *ChatService) GetMessagesByChatID(ctx context.Context, chatID string, page api.PageRequest) (api.Page[message.Message], error) {
+	ret := _m.Called(ctx, chatID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMessagesByChatID")
 	}
 
-	var r0 []*message.Entity
+	var r0 api.Page[message.Message]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*message.Entity, error)); ok {
-		return rf(ctx, chatID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PageRequest) (api.Page[message.Message], error)); ok {
+		return rf(ctx, chatID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*message.Entity); ok {
-		r0 = rf(ctx, chatID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PageRequest) api.Page[message.Message]); ok {
+		r0 = rf(ctx, chatID, page)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*message.Entity)
-		}
+		r0 = ret.Get(0).(api.Page[message.Message])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, chatID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.PageRequest) error); ok {
+		r1 = rf(ctx, chatID, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -137,6 +174,165 @@ func (_m *ChatService) GetOrCreateChatID(ctx context.Context, userID string, oth
 	return r0, r1
 }
 
+// GetParticipantChat provides a mock function with given fields: ctx, chatID, userID
+func (_m *ChatService) GetParticipantChat(ctx context.Context, chatID string, userID string) (*chat.Chat, error) {
+	ret := _m.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParticipantChat")
+	}
+
+	var r0 *chat.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*chat.Chat, error)); ok {
+		return rf(ctx, chatID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *chat.Chat); ok {
+		r0 = rf(ctx, chatID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chat.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, chatID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadMarkers provides a mock function with given fields: ctx, chatID
+func (_m *ChatService) GetReadMarkers(ctx context.Context, chatID string) ([]chat.ReadMarker, error) {
+	ret := _m.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadMarkers")
+	}
+
+	var r0 []chat.ReadMarker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]chat.ReadMarker, error)); ok {
+		return rf(ctx, chatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []chat.ReadMarker); ok {
+		r0 = rf(ctx, chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]chat.ReadMarker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkRead provides a mock function with given fields: ctx, chatID, userID, messageID
+func (_m *ChatService) MarkRead(ctx context.Context, chatID string, userID string, messageID string) (*chat.ReadMarker, error) {
+	ret := _m.Called(ctx, chatID, userID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 *chat.ReadMarker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*chat.ReadMarker, error)); ok {
+		return rf(ctx, chatID, userID, messageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *chat.ReadMarker); ok {
+		r0 = rf(ctx, chatID, userID, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chat.ReadMarker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, chatID, userID, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenAttachment provides a mock function with given fields: ctx, chatID, userID, attachmentID
+func (_m *ChatService) OpenAttachment(ctx context.Context, chatID string, userID string, attachmentID string) (*message.Attachment, *os.File, error) {
+	ret := _m.Called(ctx, chatID, userID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenAttachment")
+	}
+
+	var r0 *message.Attachment
+	var r1 *os.File
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*message.Attachment, *os.File, error)); ok {
+		return rf(ctx, chatID, userID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *message.Attachment); ok {
+		r0 = rf(ctx, chatID, userID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*message.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) *os.File); ok {
+		r1 = rf(ctx, chatID, userID, attachmentID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*os.File)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(ctx, chatID, userID, attachmentID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SendAttachment provides a mock function with given fields: ctx, chatID, userID, name, text, data
+func (_m *ChatService) SendAttachment(ctx context.Context, chatID string, userID string, name string, text string, data []byte) (*message.Message, error) {
+	ret := _m.Called(ctx, chatID, userID, name, text, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAttachment")
+	}
+
+	var r0 *message.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, []byte) (*message.Message, error)); ok {
+		return rf(ctx, chatID, userID, name, text, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, []byte) *message.Message); ok {
+		r0 = rf(ctx, chatID, userID, name, text, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*message.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, []byte) error); ok {
+		r1 = rf(ctx, chatID, userID, name, text, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendMessage provides a mock function with given fields: ctx, senderId, receiverId, msg
 func (_m *ChatService) SendMessage(ctx context.Context, senderId string, receiverId string, msg message.Entity) error {
 	ret := _m.Called(ctx, senderId, receiverId, msg)
@@ -173,6 +369,24 @@ func (_m *ChatService) SendMessageInChat(ctx context.Context, chatId string, sen
 	return r0
 }
 
+// SendTyping provides a mock function with given fields: ctx, chatID, userID
+func (_m *ChatService) SendTyping(ctx context.Context, chatID string, userID string) error {
+	ret := _m.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTyping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewChatService creates a new instance of ChatService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewChatService(t interface {
@@ -377,6 +377,60 @@ func (_m *ConfirmEmailRepository) CreatePasswordOTP(ctx context.Context, email s
 	return r0, r1
 }
 
+// ExpireEmailChangeOTP provides a mock function with given fields: ctx, userID
+func (_m *ConfirmEmailRepository) ExpireEmailChangeOTP(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireEmailChangeOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireEmailOTP provides a mock function with given fields: ctx, userID
+func (_m *ConfirmEmailRepository) ExpireEmailOTP(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireEmailOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpirePasswordOTP provides a mock function with given fields: ctx, email
+func (_m *ConfirmEmailRepository) ExpirePasswordOTP(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePasswordOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetEmailChangeOTP provides a mock function with given fields: ctx, email
 func (_m *ConfirmEmailRepository) GetEmailChangeOTP(ctx context.Context, email string) (string, error) {
 	ret := _m.Called(ctx, email)
@@ -9,6 +9,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	money "github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+
 	time "time"
 )
 
@@ -17,17 +19,17 @@ type ContractsRepo struct {
 	mock.Mock
 }
 
-// AddContract provides a mock function with given fields: ctx, userId, stayId, dateStart, dateEnd
-func (_m *ContractsRepo) AddContract(ctx context.Context, userId string, stayId string, dateStart time.Time, dateEnd time.Time) error {
-	ret := _m.Called(ctx, userId, stayId, dateStart, dateEnd)
+// AddContract provides a mock function with given fields: ctx, userId, stayId, dateStart, dateEnd, price
+func (_m *ContractsRepo) AddContract(ctx context.Context, userId string, stayId string, dateStart time.Time, dateEnd time.Time, price money.Money) error {
+	ret := _m.Called(ctx, userId, stayId, dateStart, dateEnd, price)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddContract")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) error); ok {
-		r0 = rf(ctx, userId, stayId, dateStart, dateEnd)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, money.Money) error); ok {
+		r0 = rf(ctx, userId, stayId, dateStart, dateEnd, price)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -65,9 +67,9 @@ func (_m *ContractsRepo) GetAllContracts(ctx context.Context, userId string) ([]
 	return r0, r1
 }
 
-// UpdateContract provides a mock function with given fields: ctx, userId, stayId, dateStart, dateEnd
-func (_m *ContractsRepo) UpdateContract(ctx context.Context, userId string, stayId string, dateStart time.Time, dateEnd time.Time) (*contracts.ContractEntity, error) {
-	ret := _m.Called(ctx, userId, stayId, dateStart, dateEnd)
+// UpdateContract provides a mock function with given fields: ctx, userId, stayId, dateStart, dateEnd, price
+func (_m *ContractsRepo) UpdateContract(ctx context.Context, userId string, stayId string, dateStart time.Time, dateEnd time.Time, price money.Money) (*contracts.ContractEntity, error) {
+	ret := _m.Called(ctx, userId, stayId, dateStart, dateEnd, price)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateContract")
@@ -75,19 +77,19 @@ func (_m *ContractsRepo) UpdateContract(ctx context.Context, userId string, stay
 
 	var r0 *contracts.ContractEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (*contracts.ContractEntity, error)); ok {
-		return rf(ctx, userId, stayId, dateStart, dateEnd)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, money.Money) (*contracts.ContractEntity, error)); ok {
+		return rf(ctx, userId, stayId, dateStart, dateEnd, price)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) *contracts.ContractEntity); ok {
-		r0 = rf(ctx, userId, stayId, dateStart, dateEnd)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, money.Money) *contracts.ContractEntity); ok {
+		r0 = rf(ctx, userId, stayId, dateStart, dateEnd, price)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*contracts.ContractEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
-		r1 = rf(ctx, userId, stayId, dateStart, dateEnd)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time, money.Money) error); ok {
+		r1 = rf(ctx, userId, stayId, dateStart, dateEnd, price)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+)
+
+// CurrencyRepo is an autogenerated mock type for the CurrencyRepo type
+type CurrencyRepo struct {
+	mock.Mock
+}
+
+// DeleteRate provides a mock function with given fields: ctx, currency
+func (_m *CurrencyRepo) DeleteRate(ctx context.Context, currency money.Currency) error {
+	ret := _m.Called(ctx, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) error); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRate provides a mock function with given fields: ctx, currency
+func (_m *CurrencyRepo) GetRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	ret := _m.Called(ctx, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRate")
+	}
+
+	var r0 *money.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) (*money.Rate, error)); ok {
+		return rf(ctx, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) *money.Rate); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*money.Rate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Currency) error); ok {
+		r1 = rf(ctx, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRates provides a mock function with given fields: ctx
+func (_m *CurrencyRepo) GetRates(ctx context.Context) ([]money.Rate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRates")
+	}
+
+	var r0 []money.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]money.Rate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []money.Rate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]money.Rate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRate provides a mock function with given fields: ctx, currency, rate
+func (_m *CurrencyRepo) SetRate(ctx context.Context, currency money.Currency, rate float64) error {
+	ret := _m.Called(ctx, currency, rate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency, float64) error); ok {
+		r0 = rf(ctx, currency, rate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCurrencyRepo creates a new instance of CurrencyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCurrencyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CurrencyRepo {
+	mock := &CurrencyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
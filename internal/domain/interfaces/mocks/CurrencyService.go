@@ -0,0 +1,154 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	money "github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+)
+
+// CurrencyService is an autogenerated mock type for the CurrencyService type
+type CurrencyService struct {
+	mock.Mock
+}
+
+// Convert provides a mock function with given fields: ctx, amount, to
+func (_m *CurrencyService) Convert(ctx context.Context, amount money.Money, to money.Currency) (money.Money, error) {
+	ret := _m.Called(ctx, amount, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Convert")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money, money.Currency) (money.Money, error)); ok {
+		return rf(ctx, amount, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money, money.Currency) money.Money); ok {
+		r0 = rf(ctx, amount, to)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Money, money.Currency) error); ok {
+		r1 = rf(ctx, amount, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRate provides a mock function with given fields: ctx, currency
+func (_m *CurrencyService) DeleteRate(ctx context.Context, currency money.Currency) error {
+	ret := _m.Called(ctx, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) error); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRate provides a mock function with given fields: ctx, currency
+func (_m *CurrencyService) GetRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	ret := _m.Called(ctx, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRate")
+	}
+
+	var r0 *money.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) (*money.Rate, error)); ok {
+		return rf(ctx, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency) *money.Rate); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*money.Rate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Currency) error); ok {
+		r1 = rf(ctx, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRates provides a mock function with given fields: ctx
+func (_m *CurrencyService) GetRates(ctx context.Context) ([]money.Rate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRates")
+	}
+
+	var r0 []money.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]money.Rate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []money.Rate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]money.Rate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRate provides a mock function with given fields: ctx, currency, rate
+func (_m *CurrencyService) SetRate(ctx context.Context, currency money.Currency, rate float64) error {
+	ret := _m.Called(ctx, currency, rate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Currency, float64) error); ok {
+		r0 = rf(ctx, currency, rate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCurrencyService creates a new instance of CurrencyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCurrencyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CurrencyService {
+	mock := &CurrencyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
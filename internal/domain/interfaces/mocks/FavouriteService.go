@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
 	mock "github.com/stretchr/testify/mock"
 
 	stays "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
@@ -33,29 +35,27 @@ func (_m *FavouriteService) AddToFavourites(ctx context.Context, userID string,
 	return r0
 }
 
-// GetAllFavourites provides a mock function with given fields: ctx, userID
-func (_m *FavouriteService) GetAllFavourites(ctx context.Context, userID string) ([]stays.StayEntityFav, error) {
-	ret := _m.Called(ctx, userID)
+// GetAllFavourites provides a mock function with given fields: ctx, userID, page
+func (_m *FavouriteService) GetAllFavourites(ctx context.Context, userID string, page api.PageRequest) (api.Page[stays.StayEntityFav], error) {
+	ret := _m.Called(ctx, userID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllFavourites")
 	}
 
-	var r0 []stays.StayEntityFav
+	var r0 api.Page[stays.StayEntityFav]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]stays.StayEntityFav, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PageRequest) (api.Page[stays.StayEntityFav], error)); ok {
+		return rf(ctx, userID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []stays.StayEntityFav); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PageRequest) api.Page[stays.StayEntityFav]); ok {
+		r0 = rf(ctx, userID, page)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]stays.StayEntityFav)
-		}
+		r0 = ret.Get(0).(api.Page[stays.StayEntityFav])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.PageRequest) error); ok {
+		r1 = rf(ctx, userID, page)
 	} else {
 		r1 = ret.Error(1)
 	}
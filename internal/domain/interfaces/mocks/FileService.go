@@ -6,6 +6,8 @@ import (
 	file "github.com/imperatorofdwelling/Full-backend/internal/service/file"
 
 	mock "github.com/stretchr/testify/mock"
+
+	os "os"
 )
 
 // FileService is an autogenerated mock type for the FileService type
@@ -41,6 +43,36 @@ func (_m *FileService) GenRandomFileName() (string, error) {
 	return r0, r1
 }
 
+// OpenPrivateFile provides a mock function with given fields: fileName
+func (_m *FileService) OpenPrivateFile(fileName string) (*os.File, error) {
+	ret := _m.Called(fileName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenPrivateFile")
+	}
+
+	var r0 *os.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*os.File, error)); ok {
+		return rf(fileName)
+	}
+	if rf, ok := ret.Get(0).(func(string) *os.File); ok {
+		r0 = rf(fileName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*os.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(fileName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveFile provides a mock function with given fields: fileName
 func (_m *FileService) RemoveFile(fileName string) error {
 	ret := _m.Called(fileName)
@@ -59,6 +91,24 @@ func (_m *FileService) RemoveFile(fileName string) error {
 	return r0
 }
 
+// RemovePrivateFile provides a mock function with given fields: fileName
+func (_m *FileService) RemovePrivateFile(fileName string) error {
+	ret := _m.Called(fileName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePrivateFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(fileName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UploadImage provides a mock function with given fields: img, imgType, filePath
 func (_m *FileService) UploadImage(img []byte, imgType file.ImageType, filePath file.PathType) (string, error) {
 	ret := _m.Called(img, imgType, filePath)
@@ -87,6 +137,34 @@ func (_m *FileService) UploadImage(img []byte, imgType file.ImageType, filePath
 	return r0, r1
 }
 
+// UploadPrivateFile provides a mock function with given fields: data, ext, filePath
+func (_m *FileService) UploadPrivateFile(data []byte, ext string, filePath file.PathType) (string, error) {
+	ret := _m.Called(data, ext, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadPrivateFile")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, string, file.PathType) (string, error)); ok {
+		return rf(data, ext, filePath)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, string, file.PathType) string); ok {
+		r0 = rf(data, ext, filePath)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, string, file.PathType) error); ok {
+		r1 = rf(data, ext, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewFileService creates a new instance of FileService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewFileService(t interface {
@@ -0,0 +1,152 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	guestreviews "github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// GuestReviewsRepo is an autogenerated mock type for the GuestReviewsRepo type
+type GuestReviewsRepo struct {
+	mock.Mock
+}
+
+// CreateGuestReview provides a mock function with given fields: ctx, review
+func (_m *GuestReviewsRepo) CreateGuestReview(ctx context.Context, review *guestreviews.Entity) (uuid.UUID, error) {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGuestReview")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *guestreviews.Entity) (uuid.UUID, error)); ok {
+		return rf(ctx, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *guestreviews.Entity) uuid.UUID); ok {
+		r0 = rf(ctx, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *guestreviews.Entity) error); ok {
+		r1 = rf(ctx, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuestReviewByID provides a mock function with given fields: ctx, id
+func (_m *GuestReviewsRepo) GetGuestReviewByID(ctx context.Context, id uuid.UUID) (*guestreviews.GuestReview, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestReviewByID")
+	}
+
+	var r0 *guestreviews.GuestReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*guestreviews.GuestReview, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *guestreviews.GuestReview); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*guestreviews.GuestReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuestReviewsByGuestID provides a mock function with given fields: ctx, guestID, page
+func (_m *GuestReviewsRepo) GetGuestReviewsByGuestID(ctx context.Context, guestID uuid.UUID, page api.PageRequest) (api.Page[guestreviews.GuestReview], error) {
+	ret := _m.Called(ctx, guestID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestReviewsByGuestID")
+	}
+
+	var r0 api.Page[guestreviews.GuestReview]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) (api.Page[guestreviews.GuestReview], error)); ok {
+		return rf(ctx, guestID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) api.Page[guestreviews.GuestReview]); ok {
+		r0 = rf(ctx, guestID, page)
+	} else {
+		r0 = ret.Get(0).(api.Page[guestreviews.GuestReview])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, api.PageRequest) error); ok {
+		r1 = rf(ctx, guestID, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuestSummary provides a mock function with given fields: ctx, guestID
+func (_m *GuestReviewsRepo) GetGuestSummary(ctx context.Context, guestID uuid.UUID) (*guestreviews.Summary, error) {
+	ret := _m.Called(ctx, guestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestSummary")
+	}
+
+	var r0 *guestreviews.Summary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*guestreviews.Summary, error)); ok {
+		return rf(ctx, guestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *guestreviews.Summary); ok {
+		r0 = rf(ctx, guestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*guestreviews.Summary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, guestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGuestReviewsRepo creates a new instance of GuestReviewsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGuestReviewsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GuestReviewsRepo {
+	mock := &GuestReviewsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
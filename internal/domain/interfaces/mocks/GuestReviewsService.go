@@ -0,0 +1,92 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	guestreviews "github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// GuestReviewsService is an autogenerated mock type for the GuestReviewsService type
+type GuestReviewsService struct {
+	mock.Mock
+}
+
+// CreateGuestReview provides a mock function with given fields: ctx, review, hostID
+func (_m *GuestReviewsService) CreateGuestReview(ctx context.Context, review *guestreviews.Entity, hostID string) (*guestreviews.GuestReview, error) {
+	ret := _m.Called(ctx, review, hostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGuestReview")
+	}
+
+	var r0 *guestreviews.GuestReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *guestreviews.Entity, string) (*guestreviews.GuestReview, error)); ok {
+		return rf(ctx, review, hostID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *guestreviews.Entity, string) *guestreviews.GuestReview); ok {
+		r0 = rf(ctx, review, hostID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*guestreviews.GuestReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *guestreviews.Entity, string) error); ok {
+		r1 = rf(ctx, review, hostID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuestReviews provides a mock function with given fields: ctx, guestID, page
+func (_m *GuestReviewsService) GetGuestReviews(ctx context.Context, guestID uuid.UUID, page api.PageRequest) (api.Page[guestreviews.GuestReview], error) {
+	ret := _m.Called(ctx, guestID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestReviews")
+	}
+
+	var r0 api.Page[guestreviews.GuestReview]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) (api.Page[guestreviews.GuestReview], error)); ok {
+		return rf(ctx, guestID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) api.Page[guestreviews.GuestReview]); ok {
+		r0 = rf(ctx, guestID, page)
+	} else {
+		r0 = ret.Get(0).(api.Page[guestreviews.GuestReview])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, api.PageRequest) error); ok {
+		r1 = rf(ctx, guestID, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGuestReviewsService creates a new instance of GuestReviewsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGuestReviewsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GuestReviewsService {
+	mock := &GuestReviewsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
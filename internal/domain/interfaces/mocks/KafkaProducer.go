@@ -0,0 +1,42 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// KafkaProducer is an autogenerated mock type for the KafkaProducer type
+type KafkaProducer struct {
+	mock.Mock
+}
+
+// SendMessage provides a mock function with given fields: topic, key, message
+func (_m *KafkaProducer) SendMessage(topic string, key string, message interface{}) error {
+	ret := _m.Called(topic, key, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, interface{}) error); ok {
+		r0 = rf(topic, key, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewKafkaProducer creates a new instance of KafkaProducer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKafkaProducer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KafkaProducer {
+	mock := &KafkaProducer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
 	location "github.com/imperatorofdwelling/Full-backend/internal/domain/models/location"
 
 	mock "github.com/stretchr/testify/mock"
@@ -65,29 +67,27 @@ func (_m *LocationRepo) FindByNameMatch(ctx context.Context, match string) (*[]l
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *LocationRepo) GetAll(ctx context.Context) (*[]location.Location, error) {
-	ret := _m.Called(ctx)
+// GetAll provides a mock function with given fields: ctx, page
+func (_m *LocationRepo) GetAll(ctx context.Context, page api.PageRequest) (api.Page[location.Location], error) {
+	ret := _m.Called(ctx, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
 	}
 
-	var r0 *[]location.Location
+	var r0 api.Page[location.Location]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*[]location.Location, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) (api.Page[location.Location], error)); ok {
+		return rf(ctx, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *[]location.Location); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) api.Page[location.Location]); ok {
+		r0 = rf(ctx, page)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*[]location.Location)
-		}
+		r0 = ret.Get(0).(api.Page[location.Location])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, api.PageRequest) error); ok {
+		r1 = rf(ctx, page)
 	} else {
 		r1 = ret.Error(1)
 	}
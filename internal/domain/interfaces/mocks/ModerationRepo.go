@@ -0,0 +1,188 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	mock "github.com/stretchr/testify/mock"
+
+	moderation "github.com/imperatorofdwelling/Full-backend/internal/domain/models/moderation"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ModerationRepo is an autogenerated mock type for the ModerationRepo type
+type ModerationRepo struct {
+	mock.Mock
+}
+
+// AssignReport provides a mock function with given fields: ctx, reportType, id, assigneeID
+func (_m *ModerationRepo) AssignReport(ctx context.Context, reportType moderation.Type, id uuid.UUID, assigneeID uuid.UUID) error {
+	ret := _m.Called(ctx, reportType, id, assigneeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, reportType, id, assigneeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateNote provides a mock function with given fields: ctx, reportType, id, authorID, text
+func (_m *ModerationRepo) CreateNote(ctx context.Context, reportType moderation.Type, id uuid.UUID, authorID uuid.UUID, text string) (*moderation.Note, error) {
+	ret := _m.Called(ctx, reportType, id, authorID, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNote")
+	}
+
+	var r0 *moderation.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, uuid.UUID, string) (*moderation.Note, error)); ok {
+		return rf(ctx, reportType, id, authorID, text)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, uuid.UUID, string) *moderation.Note); ok {
+		r0 = rf(ctx, reportType, id, authorID, text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*moderation.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Type, uuid.UUID, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, reportType, id, authorID, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotes provides a mock function with given fields: ctx, reportType, id
+func (_m *ModerationRepo) GetNotes(ctx context.Context, reportType moderation.Type, id uuid.UUID) ([]moderation.Note, error) {
+	ret := _m.Called(ctx, reportType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotes")
+	}
+
+	var r0 []moderation.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID) ([]moderation.Note, error)); ok {
+		return rf(ctx, reportType, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID) []moderation.Note); ok {
+		r0 = rf(ctx, reportType, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]moderation.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Type, uuid.UUID) error); ok {
+		r1 = rf(ctx, reportType, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReport provides a mock function with given fields: ctx, reportType, id
+func (_m *ModerationRepo) GetReport(ctx context.Context, reportType moderation.Type, id uuid.UUID) (*moderation.Report, error) {
+	ret := _m.Called(ctx, reportType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 *moderation.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID) (*moderation.Report, error)); ok {
+		return rf(ctx, reportType, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID) *moderation.Report); ok {
+		r0 = rf(ctx, reportType, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*moderation.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Type, uuid.UUID) error); ok {
+		r1 = rf(ctx, reportType, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReports provides a mock function with given fields: ctx, filter, page
+func (_m *ModerationRepo) GetReports(ctx context.Context, filter moderation.Filter, page api.PageRequest) (api.Page[moderation.Report], error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReports")
+	}
+
+	var r0 api.Page[moderation.Report]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Filter, api.PageRequest) (api.Page[moderation.Report], error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Filter, api.PageRequest) api.Page[moderation.Report]); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		r0 = ret.Get(0).(api.Page[moderation.Report])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Filter, api.PageRequest) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveReport provides a mock function with given fields: ctx, report, resolution, moderatorID
+func (_m *ModerationRepo) ResolveReport(ctx context.Context, report *moderation.Report, resolution *moderation.ResolutionEntity, moderatorID uuid.UUID) error {
+	ret := _m.Called(ctx, report, resolution, moderatorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *moderation.Report, *moderation.ResolutionEntity, uuid.UUID) error); ok {
+		r0 = rf(ctx, report, resolution, moderatorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewModerationRepo creates a new instance of ModerationRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewModerationRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ModerationRepo {
+	mock := &ModerationRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	mock "github.com/stretchr/testify/mock"
+
+	moderation "github.com/imperatorofdwelling/Full-backend/internal/domain/models/moderation"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ModerationService is an autogenerated mock type for the ModerationService type
+type ModerationService struct {
+	mock.Mock
+}
+
+// AssignReport provides a mock function with given fields: ctx, reportType, id, assign, moderatorID
+func (_m *ModerationService) AssignReport(ctx context.Context, reportType moderation.Type, id uuid.UUID, assign *moderation.AssignEntity, moderatorID string) (*moderation.Report, error) {
+	ret := _m.Called(ctx, reportType, id, assign, moderatorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignReport")
+	}
+
+	var r0 *moderation.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, *moderation.AssignEntity, string) (*moderation.Report, error)); ok {
+		return rf(ctx, reportType, id, assign, moderatorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, *moderation.AssignEntity, string) *moderation.Report); ok {
+		r0 = rf(ctx, reportType, id, assign, moderatorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*moderation.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Type, uuid.UUID, *moderation.AssignEntity, string) error); ok {
+		r1 = rf(ctx, reportType, id, assign, moderatorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateNote provides a mock function with given fields: ctx, reportType, id, note, authorID
+func (_m *ModerationService) CreateNote(ctx context.Context, reportType moderation.Type, id uuid.UUID, note *moderation.NoteEntity, authorID string) (*moderation.Note, error) {
+	ret := _m.Called(ctx, reportType, id, note, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNote")
+	}
+
+	var r0 *moderation.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, *moderation.NoteEntity, string) (*moderation.Note, error)); ok {
+		return rf(ctx, reportType, id, note, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, *moderation.NoteEntity, string) *moderation.Note); ok {
+		r0 = rf(ctx, reportType, id, note, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*moderation.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Type, uuid.UUID, *moderation.NoteEntity, string) error); ok {
+		r1 = rf(ctx, reportType, id, note, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReport provides a mock function with given fields: ctx, reportType, id
+func (_m *ModerationService) GetReport(ctx context.Context, reportType moderation.Type, id uuid.UUID) (*moderation.ReportDetails, error) {
+	ret := _m.Called(ctx, reportType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 *moderation.ReportDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID) (*moderation.ReportDetails, error)); ok {
+		return rf(ctx, reportType, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID) *moderation.ReportDetails); ok {
+		r0 = rf(ctx, reportType, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*moderation.ReportDetails)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Type, uuid.UUID) error); ok {
+		r1 = rf(ctx, reportType, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReports provides a mock function with given fields: ctx, filter, page
+func (_m *ModerationService) GetReports(ctx context.Context, filter moderation.Filter, page api.PageRequest) (api.Page[moderation.Report], error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReports")
+	}
+
+	var r0 api.Page[moderation.Report]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Filter, api.PageRequest) (api.Page[moderation.Report], error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Filter, api.PageRequest) api.Page[moderation.Report]); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		r0 = ret.Get(0).(api.Page[moderation.Report])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, moderation.Filter, api.PageRequest) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveReport provides a mock function with given fields: ctx, reportType, id, resolution, moderatorID
+func (_m *ModerationService) ResolveReport(ctx context.Context, reportType moderation.Type, id uuid.UUID, resolution *moderation.ResolutionEntity, moderatorID string) error {
+	ret := _m.Called(ctx, reportType, id, resolution, moderatorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, moderation.Type, uuid.UUID, *moderation.ResolutionEntity, string) error); ok {
+		r0 = rf(ctx, reportType, id, resolution, moderatorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewModerationService creates a new instance of ModerationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewModerationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ModerationService {
+	mock := &ModerationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -13,6 +13,11 @@ type PaymentHandler struct {
 	mock.Mock
 }
 
+// GetPayment provides a mock function with given fields: w, r
+func (_m *PaymentHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // MakePayment provides a mock function with given fields: w, r
 func (_m *PaymentHandler) MakePayment(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
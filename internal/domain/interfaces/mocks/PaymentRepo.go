@@ -0,0 +1,114 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	payment "github.com/imperatorofdwelling/Full-backend/internal/domain/models/payment"
+)
+
+// PaymentRepo is an autogenerated mock type for the PaymentRepo type
+type PaymentRepo struct {
+	mock.Mock
+}
+
+// CreatePayment provides a mock function with given fields: ctx, pay
+func (_m *PaymentRepo) CreatePayment(ctx context.Context, pay *payment.Payment) error {
+	ret := _m.Called(ctx, pay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *payment.Payment) error); ok {
+		r0 = rf(ctx, pay)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePaymentByKey provides a mock function with given fields: ctx, key
+func (_m *PaymentRepo) DeletePaymentByKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePaymentByKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPaymentByKey provides a mock function with given fields: ctx, key
+func (_m *PaymentRepo) GetPaymentByKey(ctx context.Context, key string) (*payment.Payment, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentByKey")
+	}
+
+	var r0 *payment.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*payment.Payment, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *payment.Payment); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePaymentStatus provides a mock function with given fields: ctx, key, providerID, status, response
+func (_m *PaymentRepo) UpdatePaymentStatus(ctx context.Context, key string, providerID string, status payment.Status, response []byte) error {
+	ret := _m.Called(ctx, key, providerID, status, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePaymentStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, payment.Status, []byte) error); ok {
+		r0 = rf(ctx, key, providerID, status, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentRepo creates a new instance of PaymentRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentRepo {
+	mock := &PaymentRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
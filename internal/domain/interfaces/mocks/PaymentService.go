@@ -0,0 +1,119 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	payment "github.com/imperatorofdwelling/Full-backend/internal/domain/models/payment"
+
+	uuid "github.com/gofrs/uuid"
+
+	yoomodel "github.com/eclipsemode/go-yookassa-sdk/yookassa/model"
+)
+
+// PaymentService is an autogenerated mock type for the PaymentService type
+type PaymentService struct {
+	mock.Mock
+}
+
+// CreatePayment provides a mock function with given fields: ctx, key, reservationID, userID, req
+func (_m *PaymentService) CreatePayment(ctx context.Context, key string, reservationID uuid.UUID, userID string, req yoomodel.Payment) (*payment.Payment, bool, error) {
+	ret := _m.Called(ctx, key, reservationID, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePayment")
+	}
+
+	var r0 *payment.Payment
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, string, yoomodel.Payment) (*payment.Payment, bool, error)); ok {
+		return rf(ctx, key, reservationID, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, string, yoomodel.Payment) *payment.Payment); ok {
+		r0 = rf(ctx, key, reservationID, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, string, yoomodel.Payment) bool); ok {
+		r1 = rf(ctx, key, reservationID, userID, req)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, uuid.UUID, string, yoomodel.Payment) error); ok {
+		r2 = rf(ctx, key, reservationID, userID, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPayment provides a mock function with given fields: ctx, key, userID
+func (_m *PaymentService) GetPayment(ctx context.Context, key string, userID string) (*payment.Payment, error) {
+	ret := _m.Called(ctx, key, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayment")
+	}
+
+	var r0 *payment.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*payment.Payment, error)); ok {
+		return rf(ctx, key, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *payment.Payment); ok {
+		r0 = rf(ctx, key, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*payment.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleProviderResponse provides a mock function with given fields: ctx, key, res
+func (_m *PaymentService) HandleProviderResponse(ctx context.Context, key string, res yoomodel.Payment) error {
+	ret := _m.Called(ctx, key, res)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleProviderResponse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, yoomodel.Payment) error); ok {
+		r0 = rf(ctx, key, res)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentService creates a new instance of PaymentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentService {
+	mock := &PaymentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
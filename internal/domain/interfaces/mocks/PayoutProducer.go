@@ -0,0 +1,45 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	reservation "github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayoutProducer is an autogenerated mock type for the PayoutProducer type
+type PayoutProducer struct {
+	mock.Mock
+}
+
+// RequestRefund provides a mock function with given fields: req
+func (_m *PayoutProducer) RequestRefund(req reservation.RefundRequest) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(reservation.RefundRequest) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPayoutProducer creates a new instance of PayoutProducer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPayoutProducer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PayoutProducer {
+	mock := &PayoutProducer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,236 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pricing "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// PricingRepo is an autogenerated mock type for the PricingRepo type
+type PricingRepo struct {
+	mock.Mock
+}
+
+// CheckSeasonOverlaps provides a mock function with given fields: ctx, stayID, from, to
+func (_m *PricingRepo) CheckSeasonOverlaps(ctx context.Context, stayID uuid.UUID, from time.Time, to time.Time) (bool, error) {
+	ret := _m.Called(ctx, stayID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSeasonOverlaps")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, stayID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, stayID, from, to)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, stayID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSeason provides a mock function with given fields: ctx, stayID, season
+func (_m *PricingRepo) CreateSeason(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity) error {
+	ret := _m.Called(ctx, stayID, season)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *pricing.SeasonEntity) error); ok {
+		r0 = rf(ctx, stayID, season)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSeason provides a mock function with given fields: ctx, id
+func (_m *PricingRepo) DeleteSeason(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSeason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBase provides a mock function with given fields: ctx, stayID
+func (_m *PricingRepo) GetBase(ctx context.Context, stayID uuid.UUID) (*pricing.Base, error) {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBase")
+	}
+
+	var r0 *pricing.Base
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*pricing.Base, error)); ok {
+		return rf(ctx, stayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *pricing.Base); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pricing.Base)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, stayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRules provides a mock function with given fields: ctx, stayID
+func (_m *PricingRepo) GetRules(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error) {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRules")
+	}
+
+	var r0 *pricing.Rules
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*pricing.Rules, error)); ok {
+		return rf(ctx, stayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *pricing.Rules); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pricing.Rules)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, stayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSeasonByID provides a mock function with given fields: ctx, id
+func (_m *PricingRepo) GetSeasonByID(ctx context.Context, id uuid.UUID) (*pricing.Season, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeasonByID")
+	}
+
+	var r0 *pricing.Season
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*pricing.Season, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *pricing.Season); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pricing.Season)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSeasons provides a mock function with given fields: ctx, stayID, from, to
+func (_m *PricingRepo) GetSeasons(ctx context.Context, stayID uuid.UUID, from time.Time, to time.Time) ([]pricing.Season, error) {
+	ret := _m.Called(ctx, stayID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeasons")
+	}
+
+	var r0 []pricing.Season
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]pricing.Season, error)); ok {
+		return rf(ctx, stayID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []pricing.Season); ok {
+		r0 = rf(ctx, stayID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pricing.Season)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, stayID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertRules provides a mock function with given fields: ctx, stayID, rules
+func (_m *PricingRepo) UpsertRules(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity) error {
+	ret := _m.Called(ctx, stayID, rules)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *pricing.RulesEntity) error); ok {
+		r0 = rf(ctx, stayID, rules)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPricingRepo creates a new instance of PricingRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPricingRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PricingRepo {
+	mock := &PricingRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,148 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pricing "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// PricingService is an autogenerated mock type for the PricingService type
+type PricingService struct {
+	mock.Mock
+}
+
+// CreateSeason provides a mock function with given fields: ctx, stayID, season, userID
+func (_m *PricingService) CreateSeason(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity, userID string) error {
+	ret := _m.Called(ctx, stayID, season, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *pricing.SeasonEntity, string) error); ok {
+		r0 = rf(ctx, stayID, season, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSeason provides a mock function with given fields: ctx, stayID, seasonID, userID
+func (_m *PricingService) DeleteSeason(ctx context.Context, stayID uuid.UUID, seasonID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, stayID, seasonID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSeason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, stayID, seasonID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRules provides a mock function with given fields: ctx, stayID
+func (_m *PricingService) GetRules(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error) {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRules")
+	}
+
+	var r0 *pricing.Rules
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*pricing.Rules, error)); ok {
+		return rf(ctx, stayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *pricing.Rules); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pricing.Rules)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, stayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Quote provides a mock function with given fields: ctx, stayID, arrival, departure, guests
+func (_m *PricingService) Quote(ctx context.Context, stayID uuid.UUID, arrival time.Time, departure time.Time, guests int) (*pricing.Quote, error) {
+	ret := _m.Called(ctx, stayID, arrival, departure, guests)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quote")
+	}
+
+	var r0 *pricing.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time, int) (*pricing.Quote, error)); ok {
+		return rf(ctx, stayID, arrival, departure, guests)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time, int) *pricing.Quote); ok {
+		r0 = rf(ctx, stayID, arrival, departure, guests)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pricing.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, stayID, arrival, departure, guests)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRules provides a mock function with given fields: ctx, stayID, rules, userID
+func (_m *PricingService) UpdateRules(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity, userID string) error {
+	ret := _m.Called(ctx, stayID, rules, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *pricing.RulesEntity, string) error); ok {
+		r0 = rf(ctx, stayID, rules, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPricingService creates a new instance of PricingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPricingService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PricingService {
+	mock := &PricingService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,12 +5,19 @@ package mocks
 import (
 	context "context"
 
+	cancellation "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/cancellation"
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
 	mock "github.com/stretchr/testify/mock"
 
+	money "github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+
 	reservation "github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 
 	stays "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 
+	time "time"
+
 	uuid "github.com/gofrs/uuid"
 )
 
@@ -19,32 +26,22 @@ type ReservationRepo struct {
 	mock.Mock
 }
 
-// CheckIfArrivalIsCorrect provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *ReservationRepo) CheckIfArrivalIsCorrect(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID, _a3 reservation.ReservationCheckInEntity) (bool, error) {
-	ret := _m.Called(_a0, _a1, _a2, _a3)
+// ChangeStatus provides a mock function with given fields: _a0, _a1
+func (_m *ReservationRepo) ChangeStatus(_a0 context.Context, _a1 *reservation.StatusChange) error {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckIfArrivalIsCorrect")
-	}
-
-	var r0 bool
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, reservation.ReservationCheckInEntity) (bool, error)); ok {
-		return rf(_a0, _a1, _a2, _a3)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, reservation.ReservationCheckInEntity) bool); ok {
-		r0 = rf(_a0, _a1, _a2, _a3)
-	} else {
-		r0 = ret.Get(0).(bool)
+		panic("no return value specified for ChangeStatus")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, reservation.ReservationCheckInEntity) error); ok {
-		r1 = rf(_a0, _a1, _a2, _a3)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.StatusChange) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // CheckIfReservationExists provides a mock function with given fields: _a0, _a1
@@ -75,34 +72,6 @@ func (_m *ReservationRepo) CheckIfReservationExists(_a0 context.Context, _a1 uui
 	return r0, r1
 }
 
-// CheckIfReservationExistsByStayID provides a mock function with given fields: _a0, _a1
-func (_m *ReservationRepo) CheckIfReservationExistsByStayID(_a0 context.Context, _a1 uuid.UUID) (bool, error) {
-	ret := _m.Called(_a0, _a1)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CheckIfReservationExistsByStayID")
-	}
-
-	var r0 bool
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
-		return rf(_a0, _a1)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(_a0, _a1)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CheckIfUserIsOwner provides a mock function with given fields: _a0, _a1, _a2
 func (_m *ReservationRepo) CheckIfUserIsOwner(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID) (bool, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -131,17 +100,17 @@ func (_m *ReservationRepo) CheckIfUserIsOwner(_a0 context.Context, _a1 uuid.UUID
 	return r0, r1
 }
 
-// CheckInApproval provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ReservationRepo) CheckInApproval(_a0 context.Context, _a1 uuid.UUID, _a2 reservation.ReservationCheckInEntity) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// CheckReservationIsFree provides a mock function with given fields: ctx, reserv, except
+func (_m *ReservationRepo) CheckReservationIsFree(ctx context.Context, reserv *reservation.ReservationEntity, except uuid.UUID) error {
+	ret := _m.Called(ctx, reserv, except)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckInApproval")
+		panic("no return value specified for CheckReservationIsFree")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, reservation.ReservationCheckInEntity) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationEntity, uuid.UUID) error); ok {
+		r0 = rf(ctx, reserv, except)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -149,45 +118,35 @@ func (_m *ReservationRepo) CheckInApproval(_a0 context.Context, _a1 uuid.UUID, _
 	return r0
 }
 
-// CheckReservationIfExistsByUserId provides a mock function with given fields: _a0, _a1
-func (_m *ReservationRepo) CheckReservationIfExistsByUserId(_a0 context.Context, _a1 uuid.UUID) (bool, error) {
+// CheckReservationIsNotBlocked provides a mock function with given fields: _a0, _a1
+func (_m *ReservationRepo) CheckReservationIsNotBlocked(_a0 context.Context, _a1 *reservation.ReservationEntity) error {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckReservationIfExistsByUserId")
+		panic("no return value specified for CheckReservationIsNotBlocked")
 	}
 
-	var r0 bool
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
-		return rf(_a0, _a1)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationEntity) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Get(0).(bool)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(_a0, _a1)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// CheckReservationIsFree provides a mock function with given fields: _a0, _a1
-func (_m *ReservationRepo) CheckReservationIsFree(_a0 context.Context, _a1 *reservation.ReservationEntity) error {
-	ret := _m.Called(_a0, _a1)
+// CreateReservation provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ReservationRepo) CreateReservation(_a0 context.Context, _a1 *reservation.ReservationEntity, _a2 string, _a3 reservation.Status) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckReservationIsFree")
+		panic("no return value specified for CreateReservation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationEntity) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationEntity, string, reservation.Status) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -195,27 +154,29 @@ func (_m *ReservationRepo) CheckReservationIsFree(_a0 context.Context, _a1 *rese
 	return r0
 }
 
-// CheckTimeForCheckOutReservation provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ReservationRepo) CheckTimeForCheckOutReservation(_a0 context.Context, _a1 string, _a2 string) (bool, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// CreateStayBlock provides a mock function with given fields: _a0, _a1
+func (_m *ReservationRepo) CreateStayBlock(_a0 context.Context, _a1 *reservation.StayBlockEntity) (*reservation.StayBlock, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckTimeForCheckOutReservation")
+		panic("no return value specified for CreateStayBlock")
 	}
 
-	var r0 bool
+	var r0 *reservation.StayBlock
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.StayBlockEntity) (*reservation.StayBlock, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.StayBlockEntity) *reservation.StayBlock); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.StayBlock)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, *reservation.StayBlockEntity) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -223,35 +184,17 @@ func (_m *ReservationRepo) CheckTimeForCheckOutReservation(_a0 context.Context,
 	return r0, r1
 }
 
-// ConfirmCheckOutReservation provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ReservationRepo) ConfirmCheckOutReservation(_a0 context.Context, _a1 string, _a2 string) error {
-	ret := _m.Called(_a0, _a1, _a2)
-
-	if len(ret) == 0 {
-		panic("no return value specified for ConfirmCheckOutReservation")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(_a0, _a1, _a2)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// CreateReservation provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ReservationRepo) CreateReservation(_a0 context.Context, _a1 *reservation.ReservationEntity, _a2 string) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// DeleteReservationByID provides a mock function with given fields: _a0, _a1
+func (_m *ReservationRepo) DeleteReservationByID(_a0 context.Context, _a1 uuid.UUID) error {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateReservation")
+		panic("no return value specified for DeleteReservationByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationEntity, string) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -259,12 +202,12 @@ func (_m *ReservationRepo) CreateReservation(_a0 context.Context, _a1 *reservati
 	return r0
 }
 
-// DeleteReservationByID provides a mock function with given fields: _a0, _a1
-func (_m *ReservationRepo) DeleteReservationByID(_a0 context.Context, _a1 uuid.UUID) error {
+// DeleteStayBlockByID provides a mock function with given fields: _a0, _a1
+func (_m *ReservationRepo) DeleteStayBlockByID(_a0 context.Context, _a1 uuid.UUID) error {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteReservationByID")
+		panic("no return value specified for DeleteStayBlockByID")
 	}
 
 	var r0 error
@@ -277,29 +220,27 @@ func (_m *ReservationRepo) DeleteReservationByID(_a0 context.Context, _a1 uuid.U
 	return r0
 }
 
-// GetAllReservationsByUserID provides a mock function with given fields: _a0, _a1
-func (_m *ReservationRepo) GetAllReservationsByUserID(_a0 context.Context, _a1 uuid.UUID) (*[]reservation.Reservation, error) {
-	ret := _m.Called(_a0, _a1)
+// GetAllReservationsByUserID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ReservationRepo) GetAllReservationsByUserID(_a0 context.Context, _a1 uuid.UUID, _a2 api.PageRequest) (api.Page[reservation.Reservation], error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllReservationsByUserID")
 	}
 
-	var r0 *[]reservation.Reservation
+	var r0 api.Page[reservation.Reservation]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*[]reservation.Reservation, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) (api.Page[reservation.Reservation], error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *[]reservation.Reservation); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) api.Page[reservation.Reservation]); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*[]reservation.Reservation)
-		}
+		r0 = ret.Get(0).(api.Page[reservation.Reservation])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, api.PageRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -367,6 +308,34 @@ func (_m *ReservationRepo) GetOccupiedReservationsByUserID(ctx context.Context,
 	return r0, r1
 }
 
+// GetPaidAmount provides a mock function with given fields: ctx, id
+func (_m *ReservationRepo) GetPaidAmount(ctx context.Context, id uuid.UUID) (money.Money, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaidAmount")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (money.Money, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) money.Money); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReservationByID provides a mock function with given fields: _a0, _a1
 func (_m *ReservationRepo) GetReservationByID(_a0 context.Context, _a1 uuid.UUID) (*reservation.Reservation, error) {
 	ret := _m.Called(_a0, _a1)
@@ -397,17 +366,271 @@ func (_m *ReservationRepo) GetReservationByID(_a0 context.Context, _a1 uuid.UUID
 	return r0, r1
 }
 
-// UpdateReservationByID provides a mock function with given fields: _a0, _a1
-func (_m *ReservationRepo) UpdateReservationByID(_a0 context.Context, _a1 *reservation.ReservationUpdateEntity) error {
+// GetReservationByStayAndUser provides a mock function with given fields: ctx, stayID, userID, statuses
+func (_m *ReservationRepo) GetReservationByStayAndUser(ctx context.Context, stayID uuid.UUID, userID uuid.UUID, statuses []reservation.Status) (*reservation.Reservation, error) {
+	ret := _m.Called(ctx, stayID, userID, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationByStayAndUser")
+	}
+
+	var r0 *reservation.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []reservation.Status) (*reservation.Reservation, error)); ok {
+		return rf(ctx, stayID, userID, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []reservation.Status) *reservation.Reservation); ok {
+		r0 = rf(ctx, stayID, userID, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, []reservation.Status) error); ok {
+		r1 = rf(ctx, stayID, userID, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReservationCancellationPolicy provides a mock function with given fields: ctx, id
+func (_m *ReservationRepo) GetReservationCancellationPolicy(ctx context.Context, id uuid.UUID) (cancellation.Policy, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationCancellationPolicy")
+	}
+
+	var r0 cancellation.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (cancellation.Policy, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) cancellation.Policy); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(cancellation.Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReservationsByStayID provides a mock function with given fields: ctx, stayID, from, to
+func (_m *ReservationRepo) GetReservationsByStayID(ctx context.Context, stayID uuid.UUID, from time.Time, to time.Time) ([]reservation.Reservation, error) {
+	ret := _m.Called(ctx, stayID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationsByStayID")
+	}
+
+	var r0 []reservation.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]reservation.Reservation, error)); ok {
+		return rf(ctx, stayID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []reservation.Reservation); ok {
+		r0 = rf(ctx, stayID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, stayID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: _a0, _a1
+func (_m *ReservationRepo) GetStatusHistory(_a0 context.Context, _a1 uuid.UUID) ([]reservation.StatusChange, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatusHistory")
+	}
+
+	var r0 []reservation.StatusChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]reservation.StatusChange, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []reservation.StatusChange); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.StatusChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStayBlockByID provides a mock function with given fields: _a0, _a1
+func (_m *ReservationRepo) GetStayBlockByID(_a0 context.Context, _a1 uuid.UUID) (*reservation.StayBlock, error) {
 	ret := _m.Called(_a0, _a1)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetStayBlockByID")
+	}
+
+	var r0 *reservation.StayBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*reservation.StayBlock, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *reservation.StayBlock); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.StayBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStayBlocks provides a mock function with given fields: ctx, stayID
+func (_m *ReservationRepo) GetStayBlocks(ctx context.Context, stayID uuid.UUID) ([]reservation.StayBlock, error) {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStayBlocks")
+	}
+
+	var r0 []reservation.StayBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]reservation.StayBlock, error)); ok {
+		return rf(ctx, stayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []reservation.StayBlock); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.StayBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, stayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStayBlocksByStayID provides a mock function with given fields: ctx, stayID, from, to
+func (_m *ReservationRepo) GetStayBlocksByStayID(ctx context.Context, stayID uuid.UUID, from time.Time, to time.Time) ([]reservation.StayBlock, error) {
+	ret := _m.Called(ctx, stayID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStayBlocksByStayID")
+	}
+
+	var r0 []reservation.StayBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]reservation.StayBlock, error)); ok {
+		return rf(ctx, stayID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []reservation.StayBlock); ok {
+		r0 = rf(ctx, stayID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.StayBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, stayID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStayInstantBook provides a mock function with given fields: ctx, stayID
+func (_m *ReservationRepo) GetStayInstantBook(ctx context.Context, stayID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStayInstantBook")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, stayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, stayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRefund provides a mock function with given fields: ctx, id, refund
+func (_m *ReservationRepo) SetRefund(ctx context.Context, id uuid.UUID, refund *reservation.Refund) error {
+	ret := _m.Called(ctx, id, refund)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *reservation.Refund) error); ok {
+		r0 = rf(ctx, id, refund)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateReservationByID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ReservationRepo) UpdateReservationByID(_a0 context.Context, _a1 *reservation.ReservationUpdateEntity, _a2 *reservation.StatusChange) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateReservationByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationUpdateEntity) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationUpdateEntity, *reservation.StatusChange) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
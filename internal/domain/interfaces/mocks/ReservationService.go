@@ -5,12 +5,16 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
 	mock "github.com/stretchr/testify/mock"
 
 	reservation "github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 
 	stays "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 
+	time "time"
+
 	uuid "github.com/gofrs/uuid"
 )
 
@@ -19,6 +23,72 @@ type ReservationService struct {
 	mock.Mock
 }
 
+// ApproveReservation provides a mock function with given fields: ctx, id, ownerID
+func (_m *ReservationService) ApproveReservation(ctx context.Context, id uuid.UUID, ownerID string) error {
+	ret := _m.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelReservation provides a mock function with given fields: ctx, id, userID, reason
+func (_m *ReservationService) CancelReservation(ctx context.Context, id uuid.UUID, userID string, reason string) (*reservation.Refund, error) {
+	ret := _m.Called(ctx, id, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReservation")
+	}
+
+	var r0 *reservation.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) (*reservation.Refund, error)); ok {
+		return rf(ctx, id, userID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) *reservation.Refund); ok {
+		r0 = rf(ctx, id, userID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r1 = rf(ctx, id, userID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeStatus provides a mock function with given fields: ctx, id, to, changedBy, reason
+func (_m *ReservationService) ChangeStatus(ctx context.Context, id uuid.UUID, to reservation.Status, changedBy *uuid.UUID, reason string) error {
+	ret := _m.Called(ctx, id, to, changedBy, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, reservation.Status, *uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, to, changedBy, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckReservation provides a mock function with given fields: _a0, _a1, _a2
 func (_m *ReservationService) CheckReservation(_a0 context.Context, _a1 *reservation.ReservationEntity, _a2 string) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -91,17 +161,65 @@ func (_m *ReservationService) CreateReservation(_a0 context.Context, _a1 *reserv
 	return r0
 }
 
-// DeleteReservationByID provides a mock function with given fields: _a0, _a1
-func (_m *ReservationService) DeleteReservationByID(_a0 context.Context, _a1 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1)
+// CreateStayBlock provides a mock function with given fields: ctx, block, userID
+func (_m *ReservationService) CreateStayBlock(ctx context.Context, block *reservation.StayBlockEntity, userID string) (*reservation.StayBlock, error) {
+	ret := _m.Called(ctx, block, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStayBlock")
+	}
+
+	var r0 *reservation.StayBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.StayBlockEntity, string) (*reservation.StayBlock, error)); ok {
+		return rf(ctx, block, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.StayBlockEntity, string) *reservation.StayBlock); ok {
+		r0 = rf(ctx, block, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.StayBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *reservation.StayBlockEntity, string) error); ok {
+		r1 = rf(ctx, block, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeclineReservation provides a mock function with given fields: ctx, id, ownerID, reason
+func (_m *ReservationService) DeclineReservation(ctx context.Context, id uuid.UUID, ownerID string, reason string) error {
+	ret := _m.Called(ctx, id, ownerID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeclineReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, id, ownerID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReservationByID provides a mock function with given fields: ctx, id, userID
+func (_m *ReservationService) DeleteReservationByID(ctx context.Context, id uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteReservationByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -109,29 +227,45 @@ func (_m *ReservationService) DeleteReservationByID(_a0 context.Context, _a1 uui
 	return r0
 }
 
-// GetAllReservationsByUser provides a mock function with given fields: _a0, _a1
-func (_m *ReservationService) GetAllReservationsByUser(_a0 context.Context, _a1 uuid.UUID) (*[]reservation.Reservation, error) {
-	ret := _m.Called(_a0, _a1)
+// DeleteStayBlock provides a mock function with given fields: ctx, stayID, blockID, userID
+func (_m *ReservationService) DeleteStayBlock(ctx context.Context, stayID uuid.UUID, blockID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, stayID, blockID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStayBlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, stayID, blockID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllReservationsByUser provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ReservationService) GetAllReservationsByUser(_a0 context.Context, _a1 uuid.UUID, _a2 api.PageRequest) (api.Page[reservation.Reservation], error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllReservationsByUser")
 	}
 
-	var r0 *[]reservation.Reservation
+	var r0 api.Page[reservation.Reservation]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*[]reservation.Reservation, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) (api.Page[reservation.Reservation], error)); ok {
+		return rf(_a0, _a1, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *[]reservation.Reservation); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.PageRequest) api.Page[reservation.Reservation]); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*[]reservation.Reservation)
-		}
+		r0 = ret.Get(0).(api.Page[reservation.Reservation])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, api.PageRequest) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -229,17 +363,155 @@ func (_m *ReservationService) GetReservationByID(_a0 context.Context, _a1 uuid.U
 	return r0, r1
 }
 
-// UpdateReservation provides a mock function with given fields: _a0, _a1
-func (_m *ReservationService) UpdateReservation(_a0 context.Context, _a1 *reservation.ReservationUpdateEntity) error {
-	ret := _m.Called(_a0, _a1)
+// GetReservationHistory provides a mock function with given fields: ctx, id, userID
+func (_m *ReservationService) GetReservationHistory(ctx context.Context, id uuid.UUID, userID string) ([]reservation.StatusChange, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationHistory")
+	}
+
+	var r0 []reservation.StatusChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]reservation.StatusChange, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []reservation.StatusChange); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.StatusChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStayBlocks provides a mock function with given fields: ctx, stayID, userID
+func (_m *ReservationService) GetStayBlocks(ctx context.Context, stayID uuid.UUID, userID string) ([]reservation.StayBlock, error) {
+	ret := _m.Called(ctx, stayID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStayBlocks")
+	}
+
+	var r0 []reservation.StayBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]reservation.StayBlock, error)); ok {
+		return rf(ctx, stayID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []reservation.StayBlock); ok {
+		r0 = rf(ctx, stayID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.StayBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stayID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStayCalendar provides a mock function with given fields: ctx, stayID, from, to
+func (_m *ReservationService) GetStayCalendar(ctx context.Context, stayID uuid.UUID, from time.Time, to time.Time) ([]reservation.CalendarDay, error) {
+	ret := _m.Called(ctx, stayID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStayCalendar")
+	}
+
+	var r0 []reservation.CalendarDay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]reservation.CalendarDay, error)); ok {
+		return rf(ctx, stayID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []reservation.CalendarDay); ok {
+		r0 = rf(ctx, stayID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.CalendarDay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, stayID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkNoShow provides a mock function with given fields: ctx, id, ownerID
+func (_m *ReservationService) MarkNoShow(ctx context.Context, id uuid.UUID, ownerID string) error {
+	ret := _m.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkNoShow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryRefund provides a mock function with given fields: ctx, id, userID
+func (_m *ReservationService) RetryRefund(ctx context.Context, id uuid.UUID, userID string) (*reservation.Refund, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryRefund")
+	}
+
+	var r0 *reservation.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*reservation.Refund, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *reservation.Refund); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateReservation provides a mock function with given fields: ctx, reserv, userID
+func (_m *ReservationService) UpdateReservation(ctx context.Context, reserv *reservation.ReservationUpdateEntity, userID string) error {
+	ret := _m.Called(ctx, reserv, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateReservation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationUpdateEntity) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.ReservationUpdateEntity, string) error); ok {
+		r0 = rf(ctx, reserv, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
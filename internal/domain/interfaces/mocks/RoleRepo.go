@@ -0,0 +1,244 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	role "github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+)
+
+// RoleRepo is an autogenerated mock type for the RoleRepo type
+type RoleRepo struct {
+	mock.Mock
+}
+
+// CheckRoleIfExists provides a mock function with given fields: ctx, id
+func (_m *RoleRepo) CheckRoleIfExists(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckRoleIfExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateObject provides a mock function with given fields: ctx, object
+func (_m *RoleRepo) CreateObject(ctx context.Context, object *role.ObjectEntity) (*role.Object, error) {
+	ret := _m.Called(ctx, object)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateObject")
+	}
+
+	var r0 *role.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *role.ObjectEntity) (*role.Object, error)); ok {
+		return rf(ctx, object)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *role.ObjectEntity) *role.Object); ok {
+		r0 = rf(ctx, object)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Object)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *role.ObjectEntity) error); ok {
+		r1 = rf(ctx, object)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetObjectByID provides a mock function with given fields: ctx, id
+func (_m *RoleRepo) GetObjectByID(ctx context.Context, id int) (*role.Object, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjectByID")
+	}
+
+	var r0 *role.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*role.Object, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *role.Object); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Object)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetObjects provides a mock function with given fields: ctx
+func (_m *RoleRepo) GetObjects(ctx context.Context) ([]role.Object, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjects")
+	}
+
+	var r0 []role.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]role.Object, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []role.Object); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]role.Object)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissions provides a mock function with given fields: ctx, roleID
+func (_m *RoleRepo) GetPermissions(ctx context.Context, roleID int) (role.Permissions, error) {
+	ret := _m.Called(ctx, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 role.Permissions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (role.Permissions, error)); ok {
+		return rf(ctx, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) role.Permissions); ok {
+		r0 = rf(ctx, roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(role.Permissions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: ctx
+func (_m *RoleRepo) GetRoles(ctx context.Context) ([]role.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 []role.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]role.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []role.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]role.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantObject provides a mock function with given fields: ctx, roleID, objectID
+func (_m *RoleRepo) GrantObject(ctx context.Context, roleID int, objectID int) error {
+	ret := _m.Called(ctx, roleID, objectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, roleID, objectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeObject provides a mock function with given fields: ctx, roleID, objectID
+func (_m *RoleRepo) RevokeObject(ctx context.Context, roleID int, objectID int) error {
+	ret := _m.Called(ctx, roleID, objectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, roleID, objectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRoleRepo creates a new instance of RoleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleRepo {
+	mock := &RoleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,184 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	role "github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+)
+
+// RoleService is an autogenerated mock type for the RoleService type
+type RoleService struct {
+	mock.Mock
+}
+
+// CreateObject provides a mock function with given fields: ctx, object
+func (_m *RoleService) CreateObject(ctx context.Context, object *role.ObjectEntity) (*role.Object, error) {
+	ret := _m.Called(ctx, object)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateObject")
+	}
+
+	var r0 *role.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *role.ObjectEntity) (*role.Object, error)); ok {
+		return rf(ctx, object)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *role.ObjectEntity) *role.Object); ok {
+		r0 = rf(ctx, object)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Object)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *role.ObjectEntity) error); ok {
+		r1 = rf(ctx, object)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetObjects provides a mock function with given fields: ctx
+func (_m *RoleService) GetObjects(ctx context.Context) ([]role.Object, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjects")
+	}
+
+	var r0 []role.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]role.Object, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []role.Object); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]role.Object)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: ctx
+func (_m *RoleService) GetRoles(ctx context.Context) ([]role.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 []role.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]role.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []role.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]role.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantObject provides a mock function with given fields: ctx, roleID, objectID
+func (_m *RoleService) GrantObject(ctx context.Context, roleID int, objectID int) error {
+	ret := _m.Called(ctx, roleID, objectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, roleID, objectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HasPermission provides a mock function with given fields: ctx, roleID, route, action
+func (_m *RoleService) HasPermission(ctx context.Context, roleID int, route string, action role.Action) (bool, error) {
+	ret := _m.Called(ctx, roleID, route, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasPermission")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, role.Action) (bool, error)); ok {
+		return rf(ctx, roleID, route, action)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, role.Action) bool); ok {
+		r0 = rf(ctx, roleID, route, action)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, role.Action) error); ok {
+		r1 = rf(ctx, roleID, route, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeObject provides a mock function with given fields: ctx, roleID, objectID
+func (_m *RoleService) RevokeObject(ctx context.Context, roleID int, objectID int) error {
+	ret := _m.Called(ctx, roleID, objectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, roleID, objectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRoleService creates a new instance of RoleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleService {
+	mock := &RoleService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,164 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	session "github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// SessionRepo is an autogenerated mock type for the SessionRepo type
+type SessionRepo struct {
+	mock.Mock
+}
+
+// CreateSession provides a mock function with given fields: ctx, _a1, tokenHash
+func (_m *SessionRepo) CreateSession(ctx context.Context, _a1 *session.Session, tokenHash string) error {
+	ret := _m.Called(ctx, _a1, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *session.Session, string) error); ok {
+		r0 = rf(ctx, _a1, tokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetSessions provides a mock function with given fields: ctx, userID
+func (_m *SessionRepo) GetSessions(ctx context.Context, userID uuid.UUID) ([]session.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []session.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]session.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []session.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]session.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeOtherSessions provides a mock function with given fields: ctx, userID, keepID, reason
+func (_m *SessionRepo) RevokeOtherSessions(ctx context.Context, userID uuid.UUID, keepID uuid.UUID, reason session.RevokeReason) error {
+	ret := _m.Called(ctx, userID, keepID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, session.RevokeReason) error); ok {
+		r0 = rf(ctx, userID, keepID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID, reason
+func (_m *SessionRepo) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, reason session.RevokeReason) error {
+	ret := _m.Called(ctx, userID, sessionID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, session.RevokeReason) error); ok {
+		r0 = rf(ctx, userID, sessionID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessionByToken provides a mock function with given fields: ctx, tokenHash, reason
+func (_m *SessionRepo) RevokeSessionByToken(ctx context.Context, tokenHash string, reason session.RevokeReason) error {
+	ret := _m.Called(ctx, tokenHash, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessionByToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, session.RevokeReason) error); ok {
+		r0 = rf(ctx, tokenHash, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateRefreshToken provides a mock function with given fields: ctx, tokenHash, newTokenHash
+func (_m *SessionRepo) RotateRefreshToken(ctx context.Context, tokenHash string, newTokenHash string) (*session.Session, error) {
+	ret := _m.Called(ctx, tokenHash, newTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 *session.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*session.Session, error)); ok {
+		return rf(ctx, tokenHash, newTokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *session.Session); ok {
+		r0 = rf(ctx, tokenHash, newTokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*session.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tokenHash, newTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSessionRepo creates a new instance of SessionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionRepo {
+	mock := &SessionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
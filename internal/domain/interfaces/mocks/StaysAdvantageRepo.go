@@ -81,6 +81,36 @@ func (_m *StaysAdvantageRepo) DeleteStaysAdvantageByID(_a0 context.Context, _a1
 	return r0
 }
 
+// GetStaysAdvantageByID provides a mock function with given fields: _a0, _a1
+func (_m *StaysAdvantageRepo) GetStaysAdvantageByID(_a0 context.Context, _a1 uuid.UUID) (*staysadvantage.StayAdvantage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaysAdvantageByID")
+	}
+
+	var r0 *staysadvantage.StayAdvantage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*staysadvantage.StayAdvantage, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *staysadvantage.StayAdvantage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*staysadvantage.StayAdvantage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewStaysAdvantageRepo creates a new instance of StaysAdvantageRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStaysAdvantageRepo(t interface {
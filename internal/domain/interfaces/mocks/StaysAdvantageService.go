@@ -17,17 +17,17 @@ type StaysAdvantageService struct {
 	mock.Mock
 }
 
-// CreateStaysAdvantage provides a mock function with given fields: ctx, req
-func (_m *StaysAdvantageService) CreateStaysAdvantage(ctx context.Context, req *staysadvantage.StayAdvantageCreateReq) error {
-	ret := _m.Called(ctx, req)
+// CreateStaysAdvantage provides a mock function with given fields: ctx, req, userID
+func (_m *StaysAdvantageService) CreateStaysAdvantage(ctx context.Context, req *staysadvantage.StayAdvantageCreateReq, userID string) error {
+	ret := _m.Called(ctx, req, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateStaysAdvantage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *staysadvantage.StayAdvantageCreateReq) error); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, *staysadvantage.StayAdvantageCreateReq, string) error); ok {
+		r0 = rf(ctx, req, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -35,17 +35,17 @@ func (_m *StaysAdvantageService) CreateStaysAdvantage(ctx context.Context, req *
 	return r0
 }
 
-// DeleteStaysAdvantageByID provides a mock function with given fields: ctx, id
-func (_m *StaysAdvantageService) DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// DeleteStaysAdvantageByID provides a mock function with given fields: ctx, id, userID
+func (_m *StaysAdvantageService) DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteStaysAdvantageByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -5,6 +5,10 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	geo "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/geo"
+
 	mock "github.com/stretchr/testify/mock"
 
 	stays "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
@@ -205,6 +209,66 @@ func (_m *StaysRepo) GetMainImageByStayID(_a0 context.Context, _a1 uuid.UUID) (s
 	return r0, r1
 }
 
+// GetNearbyStays provides a mock function with given fields: ctx, point, radiusKm
+func (_m *StaysRepo) GetNearbyStays(ctx context.Context, point geo.Point, radiusKm float64) ([]stays.StayNearby, error) {
+	ret := _m.Called(ctx, point, radiusKm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNearbyStays")
+	}
+
+	var r0 []stays.StayNearby
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, geo.Point, float64) ([]stays.StayNearby, error)); ok {
+		return rf(ctx, point, radiusKm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, geo.Point, float64) []stays.StayNearby); ok {
+		r0 = rf(ctx, point, radiusKm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stays.StayNearby)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, geo.Point, float64) error); ok {
+		r1 = rf(ctx, point, radiusKm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicStayByID provides a mock function with given fields: ctx, id, userID
+func (_m *StaysRepo) GetPublicStayByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*stays.Stay, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicStayByID")
+	}
+
+	var r0 *stays.Stay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*stays.Stay, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *stays.Stay); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stays.Stay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStatistics provides a mock function with given fields: ctx, userID
 func (_m *StaysRepo) GetStatistics(ctx context.Context, userID string) (*stays.Statistics, error) {
 	ret := _m.Called(ctx, userID)
@@ -293,29 +357,27 @@ func (_m *StaysRepo) GetStayImageByID(_a0 context.Context, _a1 uuid.UUID) (stays
 	return r0, r1
 }
 
-// GetStays provides a mock function with given fields: _a0
-func (_m *StaysRepo) GetStays(_a0 context.Context) ([]stays.StayResponse, error) {
-	ret := _m.Called(_a0)
+// GetStays provides a mock function with given fields: _a0, _a1
+func (_m *StaysRepo) GetStays(_a0 context.Context, _a1 api.PageRequest) (api.Page[stays.StayResponse], error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetStays")
 	}
 
-	var r0 []stays.StayResponse
+	var r0 api.Page[stays.StayResponse]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]stays.StayResponse, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []stays.StayResponse); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) api.Page[stays.StayResponse]); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]stays.StayResponse)
-		}
+		r0 = ret.Get(0).(api.Page[stays.StayResponse])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, api.PageRequest) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -383,6 +445,94 @@ func (_m *StaysRepo) GetStaysByUserID(_a0 context.Context, _a1 uuid.UUID) ([]*st
 	return r0, r1
 }
 
+// GetStaysForReview provides a mock function with given fields: _a0, _a1
+func (_m *StaysRepo) GetStaysForReview(_a0 context.Context, _a1 api.PageRequest) (api.Page[stays.StayResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaysForReview")
+	}
+
+	var r0 api.Page[stays.StayResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) api.Page[stays.StayResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(api.Page[stays.StayResponse])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, api.PageRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStaysInBoundingBox provides a mock function with given fields: ctx, box
+func (_m *StaysRepo) GetStaysInBoundingBox(ctx context.Context, box geo.BoundingBox) ([]stays.StayNearby, error) {
+	ret := _m.Called(ctx, box)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaysInBoundingBox")
+	}
+
+	var r0 []stays.StayNearby
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, geo.BoundingBox) ([]stays.StayNearby, error)); ok {
+		return rf(ctx, box)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, geo.BoundingBox) []stays.StayNearby); ok {
+		r0 = rf(ctx, box)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stays.StayNearby)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, geo.BoundingBox) error); ok {
+		r1 = rf(ctx, box)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchStays provides a mock function with given fields: ctx, query, limit
+func (_m *StaysRepo) SearchStays(ctx context.Context, query string, limit int) ([]stays.StaySearchResult, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchStays")
+	}
+
+	var r0 []stays.StaySearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]stays.StaySearchResult, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []stays.StaySearchResult); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stays.StaySearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStayByID provides a mock function with given fields: _a0, _a1, _a2
 func (_m *StaysRepo) UpdateStayByID(_a0 context.Context, _a1 *stays.StayEntity, _a2 uuid.UUID) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -401,6 +551,24 @@ func (_m *StaysRepo) UpdateStayByID(_a0 context.Context, _a1 *stays.StayEntity,
 	return r0
 }
 
+// UpdateStayStatus provides a mock function with given fields: ctx, id, from, to, comment
+func (_m *StaysRepo) UpdateStayStatus(ctx context.Context, id uuid.UUID, from stays.Status, to stays.Status, comment *string) error {
+	ret := _m.Called(ctx, id, from, to, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStayStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, stays.Status, stays.Status, *string) error); ok {
+		r0 = rf(ctx, id, from, to, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewStaysRepo creates a new instance of StaysRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStaysRepo(t interface {
@@ -5,10 +5,14 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
 	mock "github.com/stretchr/testify/mock"
 
 	staysreviews "github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysreviews"
 
+	time "time"
+
 	uuid "github.com/gofrs/uuid"
 )
 
@@ -81,29 +85,27 @@ func (_m *StaysReviewsRepo) DeleteStaysReviewByID(_a0 context.Context, _a1 uuid.
 	return r0
 }
 
-// FindAllStaysReviews provides a mock function with given fields: _a0
-func (_m *StaysReviewsRepo) FindAllStaysReviews(_a0 context.Context) ([]staysreviews.StaysReview, error) {
-	ret := _m.Called(_a0)
+// FindAllStaysReviews provides a mock function with given fields: ctx, stayID, page
+func (_m *StaysReviewsRepo) FindAllStaysReviews(ctx context.Context, stayID *uuid.UUID, page api.PageRequest) (api.Page[staysreviews.StaysReview], error) {
+	ret := _m.Called(ctx, stayID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAllStaysReviews")
 	}
 
-	var r0 []staysreviews.StaysReview
+	var r0 api.Page[staysreviews.StaysReview]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]staysreviews.StaysReview, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, api.PageRequest) (api.Page[staysreviews.StaysReview], error)); ok {
+		return rf(ctx, stayID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []staysreviews.StaysReview); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, api.PageRequest) api.Page[staysreviews.StaysReview]); ok {
+		r0 = rf(ctx, stayID, page)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]staysreviews.StaysReview)
-		}
+		r0 = ret.Get(0).(api.Page[staysreviews.StaysReview])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, api.PageRequest) error); ok {
+		r1 = rf(ctx, stayID, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -141,6 +143,100 @@ func (_m *StaysReviewsRepo) FindOneStaysReviewByID(_a0 context.Context, _a1 uuid
 	return r0, r1
 }
 
+// GetRatingSummary provides a mock function with given fields: ctx, stayID
+func (_m *StaysReviewsRepo) GetRatingSummary(ctx context.Context, stayID uuid.UUID) (*staysreviews.RatingSummary, error) {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRatingSummary")
+	}
+
+	var r0 *staysreviews.RatingSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*staysreviews.RatingSummary, error)); ok {
+		return rf(ctx, stayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *staysreviews.RatingSummary); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*staysreviews.RatingSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, stayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecomputeRevealedRatings provides a mock function with given fields: ctx, from, to
+func (_m *StaysReviewsRepo) RecomputeRevealedRatings(ctx context.Context, from time.Time, to time.Time) (int, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeRevealedRatings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (int, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecomputeStayRating provides a mock function with given fields: ctx, stayID
+func (_m *StaysReviewsRepo) RecomputeStayRating(ctx context.Context, stayID uuid.UUID) error {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeStayRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetReply provides a mock function with given fields: ctx, id, text
+func (_m *StaysReviewsRepo) SetReply(ctx context.Context, id uuid.UUID, text string) error {
+	ret := _m.Called(ctx, id, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReply")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateStaysReviewByID provides a mock function with given fields: _a0, _a1, _a2
 func (_m *StaysReviewsRepo) UpdateStaysReviewByID(_a0 context.Context, _a1 *staysreviews.StaysReviewEntity, _a2 uuid.UUID) error {
 	ret := _m.Called(_a0, _a1, _a2)
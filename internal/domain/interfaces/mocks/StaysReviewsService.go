@@ -5,10 +5,14 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
 	mock "github.com/stretchr/testify/mock"
 
 	staysreviews "github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysreviews"
 
+	time "time"
+
 	uuid "github.com/gofrs/uuid"
 )
 
@@ -35,17 +39,17 @@ func (_m *StaysReviewsService) CreateStaysReview(_a0 context.Context, _a1 *stays
 	return r0
 }
 
-// DeleteStaysReview provides a mock function with given fields: _a0, _a1
-func (_m *StaysReviewsService) DeleteStaysReview(_a0 context.Context, _a1 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1)
+// DeleteStaysReview provides a mock function with given fields: ctx, id, userID
+func (_m *StaysReviewsService) DeleteStaysReview(ctx context.Context, id uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteStaysReview")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -53,29 +57,29 @@ func (_m *StaysReviewsService) DeleteStaysReview(_a0 context.Context, _a1 uuid.U
 	return r0
 }
 
-// FindAllStaysReviews provides a mock function with given fields: _a0
-func (_m *StaysReviewsService) FindAllStaysReviews(_a0 context.Context) ([]staysreviews.StaysReview, error) {
-	ret := _m.Called(_a0)
+// FindAllStaysReviews provides a mock function with given fields: ctx, stayID, page
+func (_m *StaysReviewsService) FindAllStaysReviews(ctx context.Context, stayID *uuid.UUID, page api.PageRequest) (*staysreviews.StaysReviewsPage, error) {
+	ret := _m.Called(ctx, stayID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAllStaysReviews")
 	}
 
-	var r0 []staysreviews.StaysReview
+	var r0 *staysreviews.StaysReviewsPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]staysreviews.StaysReview, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, api.PageRequest) (*staysreviews.StaysReviewsPage, error)); ok {
+		return rf(ctx, stayID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []staysreviews.StaysReview); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, api.PageRequest) *staysreviews.StaysReviewsPage); ok {
+		r0 = rf(ctx, stayID, page)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]staysreviews.StaysReview)
+			r0 = ret.Get(0).(*staysreviews.StaysReviewsPage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, api.PageRequest) error); ok {
+		r1 = rf(ctx, stayID, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -83,9 +87,9 @@ func (_m *StaysReviewsService) FindAllStaysReviews(_a0 context.Context) ([]stays
 	return r0, r1
 }
 
-// FindOneStaysReview provides a mock function with given fields: _a0, _a1
-func (_m *StaysReviewsService) FindOneStaysReview(_a0 context.Context, _a1 uuid.UUID) (*staysreviews.StaysReview, error) {
-	ret := _m.Called(_a0, _a1)
+// FindOneStaysReview provides a mock function with given fields: ctx, id, userID
+func (_m *StaysReviewsService) FindOneStaysReview(ctx context.Context, id uuid.UUID, userID string) (*staysreviews.StaysReview, error) {
+	ret := _m.Called(ctx, id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindOneStaysReview")
@@ -93,19 +97,77 @@ func (_m *StaysReviewsService) FindOneStaysReview(_a0 context.Context, _a1 uuid.
 
 	var r0 *staysreviews.StaysReview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*staysreviews.StaysReview, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*staysreviews.StaysReview, error)); ok {
+		return rf(ctx, id, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *staysreviews.StaysReview); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *staysreviews.StaysReview); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*staysreviews.StaysReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecomputeRevealedRatings provides a mock function with given fields: ctx, from, to
+func (_m *StaysReviewsService) RecomputeRevealedRatings(ctx context.Context, from time.Time, to time.Time) (int, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeRevealedRatings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (int, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplyToStaysReview provides a mock function with given fields: ctx, id, userID, reply
+func (_m *StaysReviewsService) ReplyToStaysReview(ctx context.Context, id uuid.UUID, userID string, reply *staysreviews.ReplyEntity) (*staysreviews.StaysReview, error) {
+	ret := _m.Called(ctx, id, userID, reply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplyToStaysReview")
+	}
+
+	var r0 *staysreviews.StaysReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, *staysreviews.ReplyEntity) (*staysreviews.StaysReview, error)); ok {
+		return rf(ctx, id, userID, reply)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, *staysreviews.ReplyEntity) *staysreviews.StaysReview); ok {
+		r0 = rf(ctx, id, userID, reply)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*staysreviews.StaysReview)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, *staysreviews.ReplyEntity) error); ok {
+		r1 = rf(ctx, id, userID, reply)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -113,9 +175,9 @@ func (_m *StaysReviewsService) FindOneStaysReview(_a0 context.Context, _a1 uuid.
 	return r0, r1
 }
 
-// UpdateStaysReview provides a mock function with given fields: _a0, _a1, _a2
-func (_m *StaysReviewsService) UpdateStaysReview(_a0 context.Context, _a1 *staysreviews.StaysReviewEntity, _a2 uuid.UUID) (*staysreviews.StaysReview, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// UpdateStaysReview provides a mock function with given fields: ctx, stayReview, id, userID
+func (_m *StaysReviewsService) UpdateStaysReview(ctx context.Context, stayReview *staysreviews.StaysReviewEntity, id uuid.UUID, userID string) (*staysreviews.StaysReview, error) {
+	ret := _m.Called(ctx, stayReview, id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStaysReview")
@@ -123,19 +185,19 @@ func (_m *StaysReviewsService) UpdateStaysReview(_a0 context.Context, _a1 *stays
 
 	var r0 *staysreviews.StaysReview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID) (*staysreviews.StaysReview, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID, string) (*staysreviews.StaysReview, error)); ok {
+		return rf(ctx, stayReview, id, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID) *staysreviews.StaysReview); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID, string) *staysreviews.StaysReview); ok {
+		r0 = rf(ctx, stayReview, id, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*staysreviews.StaysReview)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stayReview, id, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5,12 +5,22 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	geo "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/geo"
+
 	mock "github.com/stretchr/testify/mock"
 
 	multipart "mime/multipart"
 
+	pricing "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+
+	reservation "github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+
 	stays "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 
+	time "time"
+
 	uuid "github.com/gofrs/uuid"
 )
 
@@ -19,17 +29,77 @@ type StaysService struct {
 	mock.Mock
 }
 
-// CreateImages provides a mock function with given fields: _a0, _a1, _a2
-func (_m *StaysService) CreateImages(_a0 context.Context, _a1 []*multipart.FileHeader, _a2 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// ArchiveStay provides a mock function with given fields: ctx, stayID, userID
+func (_m *StaysService) ArchiveStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error) {
+	ret := _m.Called(ctx, stayID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveStay")
+	}
+
+	var r0 *stays.Stay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*stays.Stay, error)); ok {
+		return rf(ctx, stayID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *stays.Stay); ok {
+		r0 = rf(ctx, stayID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stays.Stay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stayID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCalendarBlock provides a mock function with given fields: ctx, block, userID
+func (_m *StaysService) CreateCalendarBlock(ctx context.Context, block *reservation.StayBlockEntity, userID string) (*reservation.StayBlock, error) {
+	ret := _m.Called(ctx, block, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCalendarBlock")
+	}
+
+	var r0 *reservation.StayBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.StayBlockEntity, string) (*reservation.StayBlock, error)); ok {
+		return rf(ctx, block, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *reservation.StayBlockEntity, string) *reservation.StayBlock); ok {
+		r0 = rf(ctx, block, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.StayBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *reservation.StayBlockEntity, string) error); ok {
+		r1 = rf(ctx, block, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateImages provides a mock function with given fields: ctx, filesHeaders, stayID, userID
+func (_m *StaysService) CreateImages(ctx context.Context, filesHeaders []*multipart.FileHeader, stayID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, filesHeaders, stayID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateImages")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []*multipart.FileHeader, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, []*multipart.FileHeader, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, filesHeaders, stayID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -37,17 +107,35 @@ func (_m *StaysService) CreateImages(_a0 context.Context, _a1 []*multipart.FileH
 	return r0
 }
 
-// CreateMainImage provides a mock function with given fields: _a0, _a1, _a2
-func (_m *StaysService) CreateMainImage(_a0 context.Context, _a1 *multipart.FileHeader, _a2 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// CreateMainImage provides a mock function with given fields: ctx, fileHeader, stayID, userID
+func (_m *StaysService) CreateMainImage(ctx context.Context, fileHeader *multipart.FileHeader, stayID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, fileHeader, stayID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateMainImage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, fileHeader, stayID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSeasonalPrice provides a mock function with given fields: ctx, stayID, season, userID
+func (_m *StaysService) CreateSeasonalPrice(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity, userID string) error {
+	ret := _m.Called(ctx, stayID, season, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeasonalPrice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *pricing.SeasonEntity, string) error); ok {
+		r0 = rf(ctx, stayID, season, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -73,17 +161,53 @@ func (_m *StaysService) CreateStay(_a0 context.Context, _a1 *stays.StayEntity) e
 	return r0
 }
 
-// DeleteStayByID provides a mock function with given fields: _a0, _a1
-func (_m *StaysService) DeleteStayByID(_a0 context.Context, _a1 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1)
+// DeleteCalendarBlock provides a mock function with given fields: ctx, stayID, blockID, userID
+func (_m *StaysService) DeleteCalendarBlock(ctx context.Context, stayID uuid.UUID, blockID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, stayID, blockID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCalendarBlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, stayID, blockID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSeasonalPrice provides a mock function with given fields: ctx, stayID, seasonID, userID
+func (_m *StaysService) DeleteSeasonalPrice(ctx context.Context, stayID uuid.UUID, seasonID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, stayID, seasonID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSeasonalPrice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, stayID, seasonID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteStayByID provides a mock function with given fields: ctx, id, userID
+func (_m *StaysService) DeleteStayByID(ctx context.Context, id uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteStayByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,17 +215,17 @@ func (_m *StaysService) DeleteStayByID(_a0 context.Context, _a1 uuid.UUID) error
 	return r0
 }
 
-// DeleteStayImage provides a mock function with given fields: _a0, _a1
-func (_m *StaysService) DeleteStayImage(_a0 context.Context, _a1 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1)
+// DeleteStayImage provides a mock function with given fields: ctx, imageID, userID
+func (_m *StaysService) DeleteStayImage(ctx context.Context, imageID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, imageID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteStayImage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, imageID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -139,6 +263,96 @@ func (_m *StaysService) Filtration(ctx context.Context, search stays.Filtration)
 	return r0, r1
 }
 
+// GetCalendar provides a mock function with given fields: ctx, stayID, from, to
+func (_m *StaysService) GetCalendar(ctx context.Context, stayID uuid.UUID, from time.Time, to time.Time) ([]reservation.CalendarDay, error) {
+	ret := _m.Called(ctx, stayID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendar")
+	}
+
+	var r0 []reservation.CalendarDay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]reservation.CalendarDay, error)); ok {
+		return rf(ctx, stayID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []reservation.CalendarDay); ok {
+		r0 = rf(ctx, stayID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.CalendarDay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, stayID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCalendarBlocks provides a mock function with given fields: ctx, stayID, userID
+func (_m *StaysService) GetCalendarBlocks(ctx context.Context, stayID uuid.UUID, userID string) ([]reservation.StayBlock, error) {
+	ret := _m.Called(ctx, stayID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendarBlocks")
+	}
+
+	var r0 []reservation.StayBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]reservation.StayBlock, error)); ok {
+		return rf(ctx, stayID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []reservation.StayBlock); ok {
+		r0 = rf(ctx, stayID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.StayBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stayID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCompleteness provides a mock function with given fields: ctx, stayID, userID
+func (_m *StaysService) GetCompleteness(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Completeness, error) {
+	ret := _m.Called(ctx, stayID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCompleteness")
+	}
+
+	var r0 *stays.Completeness
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*stays.Completeness, error)); ok {
+		return rf(ctx, stayID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *stays.Completeness); ok {
+		r0 = rf(ctx, stayID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stays.Completeness)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stayID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImagesByStayID provides a mock function with given fields: _a0, _a1
 func (_m *StaysService) GetImagesByStayID(_a0 context.Context, _a1 uuid.UUID) ([]stays.StayImage, error) {
 	ret := _m.Called(_a0, _a1)
@@ -197,6 +411,126 @@ func (_m *StaysService) GetMainImageByStayID(_a0 context.Context, _a1 uuid.UUID)
 	return r0, r1
 }
 
+// GetNearbyStays provides a mock function with given fields: ctx, point, radiusKm
+func (_m *StaysService) GetNearbyStays(ctx context.Context, point geo.Point, radiusKm float64) ([]stays.StayNearby, error) {
+	ret := _m.Called(ctx, point, radiusKm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNearbyStays")
+	}
+
+	var r0 []stays.StayNearby
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, geo.Point, float64) ([]stays.StayNearby, error)); ok {
+		return rf(ctx, point, radiusKm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, geo.Point, float64) []stays.StayNearby); ok {
+		r0 = rf(ctx, point, radiusKm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stays.StayNearby)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, geo.Point, float64) error); ok {
+		r1 = rf(ctx, point, radiusKm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPricing provides a mock function with given fields: ctx, stayID
+func (_m *StaysService) GetPricing(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error) {
+	ret := _m.Called(ctx, stayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPricing")
+	}
+
+	var r0 *pricing.Rules
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*pricing.Rules, error)); ok {
+		return rf(ctx, stayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *pricing.Rules); ok {
+		r0 = rf(ctx, stayID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pricing.Rules)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, stayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicStayByID provides a mock function with given fields: ctx, id, userID
+func (_m *StaysService) GetPublicStayByID(ctx context.Context, id uuid.UUID, userID string) (*stays.Stay, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicStayByID")
+	}
+
+	var r0 *stays.Stay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*stays.Stay, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *stays.Stay); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stays.Stay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuote provides a mock function with given fields: ctx, stayID, arrival, departure, guests
+func (_m *StaysService) GetQuote(ctx context.Context, stayID uuid.UUID, arrival time.Time, departure time.Time, guests int) (*pricing.Quote, error) {
+	ret := _m.Called(ctx, stayID, arrival, departure, guests)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuote")
+	}
+
+	var r0 *pricing.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time, int) (*pricing.Quote, error)); ok {
+		return rf(ctx, stayID, arrival, departure, guests)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time, int) *pricing.Quote); ok {
+		r0 = rf(ctx, stayID, arrival, departure, guests)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pricing.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, stayID, arrival, departure, guests)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStatistics provides a mock function with given fields: ctx, userID
 func (_m *StaysService) GetStatistics(ctx context.Context, userID string) (*stays.Statistics, error) {
 	ret := _m.Called(ctx, userID)
@@ -257,29 +591,27 @@ func (_m *StaysService) GetStayByID(_a0 context.Context, _a1 uuid.UUID) (*stays.
 	return r0, r1
 }
 
-// GetStays provides a mock function with given fields: _a0
-func (_m *StaysService) GetStays(_a0 context.Context) ([]stays.StayResponse, error) {
-	ret := _m.Called(_a0)
+// GetStays provides a mock function with given fields: _a0, _a1
+func (_m *StaysService) GetStays(_a0 context.Context, _a1 api.PageRequest) (api.Page[stays.StayResponse], error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetStays")
 	}
 
-	var r0 []stays.StayResponse
+	var r0 api.Page[stays.StayResponse]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]stays.StayResponse, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []stays.StayResponse); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) api.Page[stays.StayResponse]); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]stays.StayResponse)
-		}
+		r0 = ret.Get(0).(api.Page[stays.StayResponse])
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, api.PageRequest) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -347,9 +679,205 @@ func (_m *StaysService) GetStaysByUserID(_a0 context.Context, _a1 uuid.UUID) ([]
 	return r0, r1
 }
 
-// UpdateStayByID provides a mock function with given fields: _a0, _a1, _a2
-func (_m *StaysService) UpdateStayByID(_a0 context.Context, _a1 *stays.StayEntity, _a2 uuid.UUID) (*stays.Stay, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// GetStaysForReview provides a mock function with given fields: _a0, _a1
+func (_m *StaysService) GetStaysForReview(_a0 context.Context, _a1 api.PageRequest) (api.Page[stays.StayResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaysForReview")
+	}
+
+	var r0 api.Page[stays.StayResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, api.PageRequest) api.Page[stays.StayResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(api.Page[stays.StayResponse])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, api.PageRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStaysInBoundingBox provides a mock function with given fields: ctx, box
+func (_m *StaysService) GetStaysInBoundingBox(ctx context.Context, box geo.BoundingBox) ([]stays.StayNearby, error) {
+	ret := _m.Called(ctx, box)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaysInBoundingBox")
+	}
+
+	var r0 []stays.StayNearby
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, geo.BoundingBox) ([]stays.StayNearby, error)); ok {
+		return rf(ctx, box)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, geo.BoundingBox) []stays.StayNearby); ok {
+		r0 = rf(ctx, box)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stays.StayNearby)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, geo.BoundingBox) error); ok {
+		r1 = rf(ctx, box)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewStay provides a mock function with given fields: ctx, stayID, review
+func (_m *StaysService) ReviewStay(ctx context.Context, stayID uuid.UUID, review *stays.ReviewEntity) (*stays.Stay, error) {
+	ret := _m.Called(ctx, stayID, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewStay")
+	}
+
+	var r0 *stays.Stay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *stays.ReviewEntity) (*stays.Stay, error)); ok {
+		return rf(ctx, stayID, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *stays.ReviewEntity) *stays.Stay); ok {
+		r0 = rf(ctx, stayID, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stays.Stay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *stays.ReviewEntity) error); ok {
+		r1 = rf(ctx, stayID, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchStays provides a mock function with given fields: ctx, query, limit, userID
+func (_m *StaysService) SearchStays(ctx context.Context, query string, limit int, userID string) ([]stays.StaySearchResult, error) {
+	ret := _m.Called(ctx, query, limit, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchStays")
+	}
+
+	var r0 []stays.StaySearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) ([]stays.StaySearchResult, error)); ok {
+		return rf(ctx, query, limit, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) []stays.StaySearchResult); ok {
+		r0 = rf(ctx, query, limit, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]stays.StaySearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string) error); ok {
+		r1 = rf(ctx, query, limit, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitStay provides a mock function with given fields: ctx, stayID, userID
+func (_m *StaysService) SubmitStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error) {
+	ret := _m.Called(ctx, stayID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitStay")
+	}
+
+	var r0 *stays.Stay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*stays.Stay, error)); ok {
+		return rf(ctx, stayID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *stays.Stay); ok {
+		r0 = rf(ctx, stayID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stays.Stay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stayID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnarchiveStay provides a mock function with given fields: ctx, stayID, userID
+func (_m *StaysService) UnarchiveStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error) {
+	ret := _m.Called(ctx, stayID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnarchiveStay")
+	}
+
+	var r0 *stays.Stay
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*stays.Stay, error)); ok {
+		return rf(ctx, stayID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *stays.Stay); ok {
+		r0 = rf(ctx, stayID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stays.Stay)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stayID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePricing provides a mock function with given fields: ctx, stayID, rules, userID
+func (_m *StaysService) UpdatePricing(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity, userID string) error {
+	ret := _m.Called(ctx, stayID, rules, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePricing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *pricing.RulesEntity, string) error); ok {
+		r0 = rf(ctx, stayID, rules, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStayByID provides a mock function with given fields: ctx, stay, id, userID
+func (_m *StaysService) UpdateStayByID(ctx context.Context, stay *stays.StayEntity, id uuid.UUID, userID string) (*stays.Stay, error) {
+	ret := _m.Called(ctx, stay, id, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStayByID")
@@ -357,19 +885,19 @@ func (_m *StaysService) UpdateStayByID(_a0 context.Context, _a1 *stays.StayEntit
 
 	var r0 *stays.Stay
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *stays.StayEntity, uuid.UUID) (*stays.Stay, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *stays.StayEntity, uuid.UUID, string) (*stays.Stay, error)); ok {
+		return rf(ctx, stay, id, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *stays.StayEntity, uuid.UUID) *stays.Stay); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *stays.StayEntity, uuid.UUID, string) *stays.Stay); ok {
+		r0 = rf(ctx, stay, id, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*stays.Stay)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *stays.StayEntity, uuid.UUID) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, *stays.StayEntity, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, stay, id, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,214 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	mock "github.com/stretchr/testify/mock"
+
+	support "github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// SupportRepo is an autogenerated mock type for the SupportRepo type
+type SupportRepo struct {
+	mock.Mock
+}
+
+// AssignEscalation provides a mock function with given fields: ctx, id, current, operatorID, now
+func (_m *SupportRepo) AssignEscalation(ctx context.Context, id uuid.UUID, current *uuid.UUID, operatorID uuid.UUID, now time.Time) (*support.Escalation, error) {
+	ret := _m.Called(ctx, id, current, operatorID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignEscalation")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, uuid.UUID, time.Time) (*support.Escalation, error)); ok {
+		return rf(ctx, id, current, operatorID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, uuid.UUID, time.Time) *support.Escalation); ok {
+		r0 = rf(ctx, id, current, operatorID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, current, operatorID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseEscalation provides a mock function with given fields: ctx, id, current, operatorID, note, now
+func (_m *SupportRepo) CloseEscalation(ctx context.Context, id uuid.UUID, current *uuid.UUID, operatorID uuid.UUID, note string, now time.Time) (*support.Escalation, error) {
+	ret := _m.Called(ctx, id, current, operatorID, note, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseEscalation")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, uuid.UUID, string, time.Time) (*support.Escalation, error)); ok {
+		return rf(ctx, id, current, operatorID, note, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, uuid.UUID, string, time.Time) *support.Escalation); ok {
+		r0 = rf(ctx, id, current, operatorID, note, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, id, current, operatorID, note, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEscalation provides a mock function with given fields: ctx, chatID, requesterID, reason, now
+func (_m *SupportRepo) CreateEscalation(ctx context.Context, chatID uuid.UUID, requesterID uuid.UUID, reason string, now time.Time) (*support.Escalation, error) {
+	ret := _m.Called(ctx, chatID, requesterID, reason, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEscalation")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time) (*support.Escalation, error)); ok {
+		return rf(ctx, chatID, requesterID, reason, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time) *support.Escalation); ok {
+		r0 = rf(ctx, chatID, requesterID, reason, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, chatID, requesterID, reason, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChatEscalations provides a mock function with given fields: ctx, chatID
+func (_m *SupportRepo) GetChatEscalations(ctx context.Context, chatID uuid.UUID) ([]support.Escalation, error) {
+	ret := _m.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatEscalations")
+	}
+
+	var r0 []support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]support.Escalation, error)); ok {
+		return rf(ctx, chatID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []support.Escalation); ok {
+		r0 = rf(ctx, chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, chatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEscalation provides a mock function with given fields: ctx, id
+func (_m *SupportRepo) GetEscalation(ctx context.Context, id uuid.UUID) (*support.Escalation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEscalation")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*support.Escalation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *support.Escalation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEscalations provides a mock function with given fields: ctx, filter, now, page
+func (_m *SupportRepo) GetEscalations(ctx context.Context, filter support.Filter, now time.Time, page api.PageRequest) (api.Page[support.Escalation], error) {
+	ret := _m.Called(ctx, filter, now, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEscalations")
+	}
+
+	var r0 api.Page[support.Escalation]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, support.Filter, time.Time, api.PageRequest) (api.Page[support.Escalation], error)); ok {
+		return rf(ctx, filter, now, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, support.Filter, time.Time, api.PageRequest) api.Page[support.Escalation]); ok {
+		r0 = rf(ctx, filter, now, page)
+	} else {
+		r0 = ret.Get(0).(api.Page[support.Escalation])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, support.Filter, time.Time, api.PageRequest) error); ok {
+		r1 = rf(ctx, filter, now, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSupportRepo creates a new instance of SupportRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSupportRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SupportRepo {
+	mock := &SupportRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
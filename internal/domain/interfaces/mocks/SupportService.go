@@ -0,0 +1,212 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/imperatorofdwelling/Full-backend/pkg/api"
+
+	mock "github.com/stretchr/testify/mock"
+
+	support "github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// SupportService is an autogenerated mock type for the SupportService type
+type SupportService struct {
+	mock.Mock
+}
+
+// AssignEscalation provides a mock function with given fields: ctx, id, assign, operatorID
+func (_m *SupportService) AssignEscalation(ctx context.Context, id uuid.UUID, assign *support.AssignEntity, operatorID string) (*support.Escalation, error) {
+	ret := _m.Called(ctx, id, assign, operatorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignEscalation")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *support.AssignEntity, string) (*support.Escalation, error)); ok {
+		return rf(ctx, id, assign, operatorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *support.AssignEntity, string) *support.Escalation); ok {
+		r0 = rf(ctx, id, assign, operatorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *support.AssignEntity, string) error); ok {
+		r1 = rf(ctx, id, assign, operatorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseEscalation provides a mock function with given fields: ctx, id, entity, operatorID
+func (_m *SupportService) CloseEscalation(ctx context.Context, id uuid.UUID, entity *support.CloseEntity, operatorID string) (*support.Escalation, error) {
+	ret := _m.Called(ctx, id, entity, operatorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseEscalation")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *support.CloseEntity, string) (*support.Escalation, error)); ok {
+		return rf(ctx, id, entity, operatorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *support.CloseEntity, string) *support.Escalation); ok {
+		r0 = rf(ctx, id, entity, operatorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *support.CloseEntity, string) error); ok {
+		r1 = rf(ctx, id, entity, operatorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Escalate provides a mock function with given fields: ctx, chatID, userID, entity
+func (_m *SupportService) Escalate(ctx context.Context, chatID string, userID string, entity *support.EscalationEntity) (*support.Escalation, error) {
+	ret := _m.Called(ctx, chatID, userID, entity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Escalate")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *support.EscalationEntity) (*support.Escalation, error)); ok {
+		return rf(ctx, chatID, userID, entity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *support.EscalationEntity) *support.Escalation); ok {
+		r0 = rf(ctx, chatID, userID, entity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *support.EscalationEntity) error); ok {
+		r1 = rf(ctx, chatID, userID, entity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChatEscalations provides a mock function with given fields: ctx, chatID, userID
+func (_m *SupportService) GetChatEscalations(ctx context.Context, chatID string, userID string) ([]support.Escalation, error) {
+	ret := _m.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatEscalations")
+	}
+
+	var r0 []support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]support.Escalation, error)); ok {
+		return rf(ctx, chatID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []support.Escalation); ok {
+		r0 = rf(ctx, chatID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, chatID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEscalation provides a mock function with given fields: ctx, id
+func (_m *SupportService) GetEscalation(ctx context.Context, id uuid.UUID) (*support.Escalation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEscalation")
+	}
+
+	var r0 *support.Escalation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*support.Escalation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *support.Escalation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*support.Escalation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEscalations provides a mock function with given fields: ctx, filter, page
+func (_m *SupportService) GetEscalations(ctx context.Context, filter support.Filter, page api.PageRequest) (api.Page[support.Escalation], error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEscalations")
+	}
+
+	var r0 api.Page[support.Escalation]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, support.Filter, api.PageRequest) (api.Page[support.Escalation], error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, support.Filter, api.PageRequest) api.Page[support.Escalation]); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		r0 = ret.Get(0).(api.Page[support.Escalation])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, support.Filter, api.PageRequest) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSupportService creates a new instance of SupportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSupportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SupportService {
+	mock := &SupportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
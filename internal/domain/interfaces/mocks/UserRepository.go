@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	user "github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
 
 	uuid "github.com/gofrs/uuid"
@@ -99,6 +101,24 @@ func (_m *UserRepository) DeleteUserPfp(ctx context.Context, userId uuid.UUID) e
 	return r0
 }
 
+// ExtendSuspension provides a mock function with given fields: ctx, id, reason, until
+func (_m *UserRepository) ExtendSuspension(ctx context.Context, id uuid.UUID, reason string, until time.Time) error {
+	ret := _m.Called(ctx, id, reason, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendSuspension")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r0 = rf(ctx, id, reason, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindUserByID provides a mock function with given fields: ctx, id
 func (_m *UserRepository) FindUserByID(ctx context.Context, id uuid.UUID) (user.User, error) {
 	ret := _m.Called(ctx, id)
@@ -127,6 +147,34 @@ func (_m *UserRepository) FindUserByID(ctx context.Context, id uuid.UUID) (user.
 	return r0, r1
 }
 
+// GetSuspension provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetSuspension(ctx context.Context, id uuid.UUID) (user.Suspension, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuspension")
+	}
+
+	var r0 user.Suspension
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (user.Suspension, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) user.Suspension); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(user.Suspension)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserIDByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) GetUserIDByEmail(ctx context.Context, email string) (string, error) {
 	ret := _m.Called(ctx, email)
@@ -211,6 +259,24 @@ func (_m *UserRepository) GetUserPfp(ctx context.Context, userId string) (string
 	return r0, r1
 }
 
+// SetSuspension provides a mock function with given fields: ctx, id, suspension
+func (_m *UserRepository) SetSuspension(ctx context.Context, id uuid.UUID, suspension user.Suspension) error {
+	ret := _m.Called(ctx, id, suspension)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSuspension")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, user.Suspension) error); ok {
+		r0 = rf(ctx, id, suspension)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserByID provides a mock function with given fields: ctx, id, _a2
 func (_m *UserRepository) UpdateUserByID(ctx context.Context, id uuid.UUID, _a2 user.User) error {
 	ret := _m.Called(ctx, id, _a2)
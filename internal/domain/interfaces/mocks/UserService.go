@@ -129,6 +129,54 @@ func (_m *UserService) DeleteUserPfp(ctx context.Context, userId uuid.UUID) erro
 	return r0
 }
 
+// ExtendSuspension provides a mock function with given fields: ctx, idStr, reason, days
+func (_m *UserService) ExtendSuspension(ctx context.Context, idStr string, reason string, days int) error {
+	ret := _m.Called(ctx, idStr, reason, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendSuspension")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, idStr, reason, days)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveSuspension provides a mock function with given fields: ctx, userID
+func (_m *UserService) GetActiveSuspension(ctx context.Context, userID string) (*user.Suspension, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveSuspension")
+	}
+
+	var r0 *user.Suspension
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*user.Suspension, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *user.Suspension); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.Suspension)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByID provides a mock function with given fields: ctx, idStr
 func (_m *UserService) GetUserByID(ctx context.Context, idStr string) (user.User, error) {
 	ret := _m.Called(ctx, idStr)
@@ -185,6 +233,54 @@ func (_m *UserService) GetUserPfp(ctx context.Context, userId string) (string, e
 	return r0, r1
 }
 
+// ReinstateUser provides a mock function with given fields: ctx, idStr
+func (_m *UserService) ReinstateUser(ctx context.Context, idStr string) error {
+	ret := _m.Called(ctx, idStr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReinstateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, idStr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SuspendUser provides a mock function with given fields: ctx, idStr, suspension
+func (_m *UserService) SuspendUser(ctx context.Context, idStr string, suspension *user.SuspensionEntity) (*user.Suspension, error) {
+	ret := _m.Called(ctx, idStr, suspension)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuspendUser")
+	}
+
+	var r0 *user.Suspension
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *user.SuspensionEntity) (*user.Suspension, error)); ok {
+		return rf(ctx, idStr, suspension)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *user.SuspensionEntity) *user.Suspension); ok {
+		r0 = rf(ctx, idStr, suspension)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.Suspension)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *user.SuspensionEntity) error); ok {
+		r1 = rf(ctx, idStr, suspension)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUserByID provides a mock function with given fields: ctx, idStr, _a2
 func (_m *UserService) UpdateUserByID(ctx context.Context, idStr string, _a2 user.User) (user.User, error) {
 	ret := _m.Called(ctx, idStr, _a2)
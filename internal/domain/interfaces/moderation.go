@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/moderation"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"net/http"
+)
+
+//go:generate mockery --name ModerationRepo
+type ModerationRepo interface {
+	GetReports(ctx context.Context, filter moderation.Filter, page api.PageRequest) (api.Page[moderation.Report], error)
+	GetReport(ctx context.Context, reportType moderation.Type, id uuid.UUID) (*moderation.Report, error)
+	AssignReport(ctx context.Context, reportType moderation.Type, id, assigneeID uuid.UUID) error
+	CreateNote(ctx context.Context, reportType moderation.Type, id, authorID uuid.UUID, text string) (*moderation.Note, error)
+	GetNotes(ctx context.Context, reportType moderation.Type, id uuid.UUID) ([]moderation.Note, error)
+	ResolveReport(ctx context.Context, report *moderation.Report, resolution *moderation.ResolutionEntity, moderatorID uuid.UUID) error
+}
+
+//go:generate mockery --name ModerationService
+type ModerationService interface {
+	GetReports(ctx context.Context, filter moderation.Filter, page api.PageRequest) (api.Page[moderation.Report], error)
+	GetReport(ctx context.Context, reportType moderation.Type, id uuid.UUID) (*moderation.ReportDetails, error)
+	AssignReport(ctx context.Context, reportType moderation.Type, id uuid.UUID, assign *moderation.AssignEntity, moderatorID string) (*moderation.Report, error)
+	CreateNote(ctx context.Context, reportType moderation.Type, id uuid.UUID, note *moderation.NoteEntity, authorID string) (*moderation.Note, error)
+	ResolveReport(ctx context.Context, reportType moderation.Type, id uuid.UUID, resolution *moderation.ResolutionEntity, moderatorID string) error
+}
+
+type ModerationHandler interface {
+	GetReports(w http.ResponseWriter, r *http.Request)
+	GetReport(w http.ResponseWriter, r *http.Request)
+	AssignReport(w http.ResponseWriter, r *http.Request)
+	CreateNote(w http.ResponseWriter, r *http.Request)
+	ResolveReport(w http.ResponseWriter, r *http.Request)
+}
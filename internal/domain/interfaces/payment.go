@@ -1,8 +1,30 @@
 package interfaces
 
-import "net/http"
+import (
+	"context"
+	yoomodel "github.com/eclipsemode/go-yookassa-sdk/yookassa/model"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/payment"
+	"net/http"
+)
+
+//go:generate mockery --name PaymentRepo
+type PaymentRepo interface {
+	CreatePayment(ctx context.Context, pay *payment.Payment) error
+	GetPaymentByKey(ctx context.Context, key string) (*payment.Payment, error)
+	UpdatePaymentStatus(ctx context.Context, key string, providerID string, status payment.Status, response []byte) error
+	DeletePaymentByKey(ctx context.Context, key string) error
+}
+
+//go:generate mockery --name PaymentService
+type PaymentService interface {
+	CreatePayment(ctx context.Context, key string, reservationID uuid.UUID, userID string, req yoomodel.Payment) (*payment.Payment, bool, error)
+	GetPayment(ctx context.Context, key string, userID string) (*payment.Payment, error)
+	HandleProviderResponse(ctx context.Context, key string, res yoomodel.Payment) error
+}
 
 //go:generate mockery --name PaymentHandler
 type PaymentHandler interface {
 	MakePayment(w http.ResponseWriter, r *http.Request)
+	GetPayment(w http.ResponseWriter, r *http.Request)
 }
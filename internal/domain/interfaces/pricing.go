@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+	"time"
+)
+
+//go:generate mockery --name PricingRepo
+type PricingRepo interface {
+	GetBase(ctx context.Context, stayID uuid.UUID) (*pricing.Base, error)
+	GetRules(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error)
+	UpsertRules(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity) error
+	GetSeasons(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]pricing.Season, error)
+	GetSeasonByID(ctx context.Context, id uuid.UUID) (*pricing.Season, error)
+	CheckSeasonOverlaps(ctx context.Context, stayID uuid.UUID, from, to time.Time) (bool, error)
+	CreateSeason(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity) error
+	DeleteSeason(ctx context.Context, id uuid.UUID) error
+}
+
+//go:generate mockery --name PricingService
+type PricingService interface {
+	GetRules(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error)
+	UpdateRules(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity, userID string) error
+	CreateSeason(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity, userID string) error
+	DeleteSeason(ctx context.Context, stayID, seasonID uuid.UUID, userID string) error
+	Quote(ctx context.Context, stayID uuid.UUID, arrival, departure time.Time, guests int) (*pricing.Quote, error)
+}
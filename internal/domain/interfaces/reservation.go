@@ -3,43 +3,66 @@ package interfaces
 import (
 	"context"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/cancellation"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"net/http"
+	"time"
 )
 
 //go:generate mockery --name ReservationRepo
 type ReservationRepo interface {
-	CheckReservationIsFree(context.Context, *reservation.ReservationEntity) error
-	CreateReservation(context.Context, *reservation.ReservationEntity, string) error
-	UpdateReservationByID(context.Context, *reservation.ReservationUpdateEntity) error
+	CheckReservationIsFree(ctx context.Context, reserv *reservation.ReservationEntity, except uuid.UUID) error
+	CreateReservation(context.Context, *reservation.ReservationEntity, string, reservation.Status) error
+	UpdateReservationByID(context.Context, *reservation.ReservationUpdateEntity, *reservation.StatusChange) error
 	DeleteReservationByID(context.Context, uuid.UUID) error
-	CheckReservationIfExistsByUserId(context.Context, uuid.UUID) (bool, error)
-	CheckIfArrivalIsCorrect(context.Context, uuid.UUID, uuid.UUID, reservation.ReservationCheckInEntity) (bool, error)
-	CheckInApproval(context.Context, uuid.UUID, reservation.ReservationCheckInEntity) error
 	CheckIfReservationExists(context.Context, uuid.UUID) (bool, error)
-	CheckIfReservationExistsByStayID(context.Context, uuid.UUID) (bool, error)
 	GetReservationByID(context.Context, uuid.UUID) (*reservation.Reservation, error)
-	GetAllReservationsByUserID(context.Context, uuid.UUID) (*[]reservation.Reservation, error)
+	GetReservationByStayAndUser(ctx context.Context, stayID, userID uuid.UUID, statuses []reservation.Status) (*reservation.Reservation, error)
+	GetAllReservationsByUserID(context.Context, uuid.UUID, api.PageRequest) (api.Page[reservation.Reservation], error)
 	CheckIfUserIsOwner(context.Context, uuid.UUID, uuid.UUID) (bool, error)
-	ConfirmCheckOutReservation(context.Context, string, string) error
-	CheckTimeForCheckOutReservation(context.Context, string, string) (bool, error)
+	GetStayInstantBook(ctx context.Context, stayID uuid.UUID) (bool, error)
+	ChangeStatus(context.Context, *reservation.StatusChange) error
+	GetStatusHistory(context.Context, uuid.UUID) ([]reservation.StatusChange, error)
+	GetReservationCancellationPolicy(ctx context.Context, id uuid.UUID) (cancellation.Policy, error)
+	GetPaidAmount(ctx context.Context, id uuid.UUID) (money.Money, error)
+	SetRefund(ctx context.Context, id uuid.UUID, refund *reservation.Refund) error
 	GetFreeReservationsByUserID(ctx context.Context, id uuid.UUID) (*[]stays.Stay, error)
 	GetOccupiedReservationsByUserID(ctx context.Context, id uuid.UUID) (*[]stays.StayOccupied, error)
+	CheckReservationIsNotBlocked(context.Context, *reservation.ReservationEntity) error
+	GetReservationsByStayID(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.Reservation, error)
+	CreateStayBlock(context.Context, *reservation.StayBlockEntity) (*reservation.StayBlock, error)
+	GetStayBlockByID(context.Context, uuid.UUID) (*reservation.StayBlock, error)
+	GetStayBlocks(ctx context.Context, stayID uuid.UUID) ([]reservation.StayBlock, error)
+	GetStayBlocksByStayID(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.StayBlock, error)
+	DeleteStayBlockByID(context.Context, uuid.UUID) error
 }
 
 //go:generate mockery --name ReservationService
 type ReservationService interface {
 	CheckReservation(context.Context, *reservation.ReservationEntity, string) error
 	CreateReservation(context.Context, *reservation.ReservationEntity, string) error
-	UpdateReservation(context.Context, *reservation.ReservationUpdateEntity) error
-	DeleteReservationByID(context.Context, uuid.UUID) error
+	UpdateReservation(ctx context.Context, reserv *reservation.ReservationUpdateEntity, userID string) error
+	DeleteReservationByID(ctx context.Context, id uuid.UUID, userID string) error
 	GetReservationByID(context.Context, uuid.UUID) (*reservation.Reservation, error)
-	GetAllReservationsByUser(context.Context, uuid.UUID) (*[]reservation.Reservation, error)
+	GetAllReservationsByUser(context.Context, uuid.UUID, api.PageRequest) (api.Page[reservation.Reservation], error)
 	ConfirmCheckInReservation(context.Context, string, string, reservation.ReservationCheckInEntity) error
 	ConfirmCheckOutReservation(context.Context, string, string) error
+	ApproveReservation(ctx context.Context, id uuid.UUID, ownerID string) error
+	DeclineReservation(ctx context.Context, id uuid.UUID, ownerID string, reason string) error
+	MarkNoShow(ctx context.Context, id uuid.UUID, ownerID string) error
+	CancelReservation(ctx context.Context, id uuid.UUID, userID string, reason string) (*reservation.Refund, error)
+	RetryRefund(ctx context.Context, id uuid.UUID, userID string) (*reservation.Refund, error)
+	ChangeStatus(ctx context.Context, id uuid.UUID, to reservation.Status, changedBy *uuid.UUID, reason string) error
+	GetReservationHistory(ctx context.Context, id uuid.UUID, userID string) ([]reservation.StatusChange, error)
 	GetFreeReservationsByUserID(ctx context.Context, id uuid.UUID) (*[]stays.Stay, error)
 	GetOccupiedReservationsByUserID(ctx context.Context, id uuid.UUID) (*[]stays.StayOccupied, error)
+	GetStayCalendar(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.CalendarDay, error)
+	GetStayBlocks(ctx context.Context, stayID uuid.UUID, userID string) ([]reservation.StayBlock, error)
+	CreateStayBlock(ctx context.Context, block *reservation.StayBlockEntity, userID string) (*reservation.StayBlock, error)
+	DeleteStayBlock(ctx context.Context, stayID, blockID uuid.UUID, userID string) error
 }
 
 type ReservationHandler interface {
@@ -50,6 +73,12 @@ type ReservationHandler interface {
 	GetAllReservationsByUser(http.ResponseWriter, *http.Request)
 	ConfirmCheckInReservation(http.ResponseWriter, *http.Request)
 	ConfirmCheckOutReservation(http.ResponseWriter, *http.Request)
+	ApproveReservation(http.ResponseWriter, *http.Request)
+	DeclineReservation(http.ResponseWriter, *http.Request)
+	MarkNoShow(http.ResponseWriter, *http.Request)
+	CancelReservation(http.ResponseWriter, *http.Request)
+	RetryRefund(http.ResponseWriter, *http.Request)
+	GetReservationHistory(http.ResponseWriter, *http.Request)
 	GetFreeReservationsByUserID(http.ResponseWriter, *http.Request)
 	GetOccupiedReservationsByUserID(http.ResponseWriter, *http.Request)
 }
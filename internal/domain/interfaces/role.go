@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	"net/http"
+)
+
+//go:generate mockery --name RoleRepo
+type RoleRepo interface {
+	GetRoles(ctx context.Context) ([]role.Role, error)
+	CheckRoleIfExists(ctx context.Context, id int) (bool, error)
+	GetObjects(ctx context.Context) ([]role.Object, error)
+	GetObjectByID(ctx context.Context, id int) (*role.Object, error)
+	CreateObject(ctx context.Context, object *role.ObjectEntity) (*role.Object, error)
+	GrantObject(ctx context.Context, roleID, objectID int) error
+	RevokeObject(ctx context.Context, roleID, objectID int) error
+	GetPermissions(ctx context.Context, roleID int) (role.Permissions, error)
+}
+
+//go:generate mockery --name RoleService
+type RoleService interface {
+	HasPermission(ctx context.Context, roleID int, route string, action role.Action) (bool, error)
+	GetRoles(ctx context.Context) ([]role.Role, error)
+	GetObjects(ctx context.Context) ([]role.Object, error)
+	CreateObject(ctx context.Context, object *role.ObjectEntity) (*role.Object, error)
+	GrantObject(ctx context.Context, roleID, objectID int) error
+	RevokeObject(ctx context.Context, roleID, objectID int) error
+}
+
+type RoleHandler interface {
+	GetRoles(w http.ResponseWriter, r *http.Request)
+	GetObjects(w http.ResponseWriter, r *http.Request)
+	CreateObject(w http.ResponseWriter, r *http.Request)
+	GrantObject(w http.ResponseWriter, r *http.Request)
+	RevokeObject(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
+)
+
+//go:generate mockery --name SessionRepo
+type SessionRepo interface {
+	CreateSession(ctx context.Context, session *session.Session, tokenHash string) error
+	RotateRefreshToken(ctx context.Context, tokenHash, newTokenHash string) (*session.Session, error)
+	GetSessions(ctx context.Context, userID uuid.UUID) ([]session.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, reason session.RevokeReason) error
+	RevokeSessionByToken(ctx context.Context, tokenHash string, reason session.RevokeReason) error
+	RevokeOtherSessions(ctx context.Context, userID, keepID uuid.UUID, reason session.RevokeReason) error
+}
@@ -3,16 +3,22 @@ package interfaces
 import (
 	"context"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/geo"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"mime/multipart"
 	"net/http"
+	"time"
 )
 
 //go:generate mockery --name StaysRepo
 type StaysRepo interface {
 	CreateStay(context.Context, *stays.StayEntity) error
 	GetStayByID(context.Context, uuid.UUID) (*stays.Stay, error)
-	GetStays(context.Context) ([]stays.StayResponse, error)
+	GetPublicStayByID(ctx context.Context, id, userID uuid.UUID) (*stays.Stay, error)
+	GetStays(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)
 	GetStaysByUserID(context.Context, uuid.UUID) ([]*stays.Stay, error)
 	DeleteStayByID(context.Context, uuid.UUID) error
 	UpdateStayByID(context.Context, *stays.StayEntity, uuid.UUID) error
@@ -25,24 +31,48 @@ type StaysRepo interface {
 	GetStaysByLocationID(context.Context, uuid.UUID) (*[]stays.Stay, error)
 	Filtration(ctx context.Context, search stays.Filtration) ([]stays.Stay, error)
 	GetStatistics(ctx context.Context, userID string) (*stays.Statistics, error)
+	GetNearbyStays(ctx context.Context, point geo.Point, radiusKm float64) ([]stays.StayNearby, error)
+	GetStaysInBoundingBox(ctx context.Context, box geo.BoundingBox) ([]stays.StayNearby, error)
+	SearchStays(ctx context.Context, query string, limit int) ([]stays.StaySearchResult, error)
+	GetStaysForReview(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)
+	UpdateStayStatus(ctx context.Context, id uuid.UUID, from, to stays.Status, comment *string) error
 }
 
 //go:generate mockery --name StaysService
 type StaysService interface {
 	CreateStay(context.Context, *stays.StayEntity) error
 	GetStayByID(context.Context, uuid.UUID) (*stays.Stay, error)
-	GetStays(context.Context) ([]stays.StayResponse, error)
+	GetPublicStayByID(ctx context.Context, id uuid.UUID, userID string) (*stays.Stay, error)
+	GetStays(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)
 	GetStaysByUserID(context.Context, uuid.UUID) ([]*stays.Stay, error)
-	DeleteStayByID(context.Context, uuid.UUID) error
-	UpdateStayByID(context.Context, *stays.StayEntity, uuid.UUID) (*stays.Stay, error)
+	DeleteStayByID(ctx context.Context, id uuid.UUID, userID string) error
+	UpdateStayByID(ctx context.Context, stay *stays.StayEntity, id uuid.UUID, userID string) (*stays.Stay, error)
 	GetImagesByStayID(context.Context, uuid.UUID) ([]stays.StayImage, error)
 	GetMainImageByStayID(context.Context, uuid.UUID) (stays.StayImage, error)
-	CreateImages(context.Context, []*multipart.FileHeader, uuid.UUID) error
-	CreateMainImage(context.Context, *multipart.FileHeader, uuid.UUID) error
-	DeleteStayImage(context.Context, uuid.UUID) error
+	CreateImages(ctx context.Context, filesHeaders []*multipart.FileHeader, stayID uuid.UUID, userID string) error
+	CreateMainImage(ctx context.Context, fileHeader *multipart.FileHeader, stayID uuid.UUID, userID string) error
+	DeleteStayImage(ctx context.Context, imageID uuid.UUID, userID string) error
 	GetStaysByLocationID(context.Context, uuid.UUID) (*[]stays.Stay, error)
 	Filtration(ctx context.Context, search stays.Filtration) ([]stays.Stay, error)
 	GetStatistics(ctx context.Context, userID string) (*stays.Statistics, error)
+	GetCalendar(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.CalendarDay, error)
+	GetCalendarBlocks(ctx context.Context, stayID uuid.UUID, userID string) ([]reservation.StayBlock, error)
+	CreateCalendarBlock(ctx context.Context, block *reservation.StayBlockEntity, userID string) (*reservation.StayBlock, error)
+	DeleteCalendarBlock(ctx context.Context, stayID, blockID uuid.UUID, userID string) error
+	GetNearbyStays(ctx context.Context, point geo.Point, radiusKm float64) ([]stays.StayNearby, error)
+	GetStaysInBoundingBox(ctx context.Context, box geo.BoundingBox) ([]stays.StayNearby, error)
+	SearchStays(ctx context.Context, query string, limit int, userID string) ([]stays.StaySearchResult, error)
+	GetQuote(ctx context.Context, stayID uuid.UUID, arrival, departure time.Time, guests int) (*pricing.Quote, error)
+	GetPricing(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error)
+	UpdatePricing(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity, userID string) error
+	CreateSeasonalPrice(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity, userID string) error
+	DeleteSeasonalPrice(ctx context.Context, stayID, seasonID uuid.UUID, userID string) error
+	GetCompleteness(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Completeness, error)
+	SubmitStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error)
+	ArchiveStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error)
+	UnarchiveStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error)
+	GetStaysForReview(context.Context, api.PageRequest) (api.Page[stays.StayResponse], error)
+	ReviewStay(ctx context.Context, stayID uuid.UUID, review *stays.ReviewEntity) (*stays.Stay, error)
 }
 
 type StaysHandler interface {
@@ -60,4 +90,22 @@ type StaysHandler interface {
 	GetStaysByLocationID(http.ResponseWriter, *http.Request)
 	Filtration(http.ResponseWriter, *http.Request)
 	GetStatistics(http.ResponseWriter, *http.Request)
+	GetCalendar(http.ResponseWriter, *http.Request)
+	GetCalendarBlocks(http.ResponseWriter, *http.Request)
+	CreateCalendarBlock(http.ResponseWriter, *http.Request)
+	DeleteCalendarBlock(http.ResponseWriter, *http.Request)
+	GetNearbyStays(http.ResponseWriter, *http.Request)
+	GetStaysInBoundingBox(http.ResponseWriter, *http.Request)
+	SearchStays(http.ResponseWriter, *http.Request)
+	GetQuote(http.ResponseWriter, *http.Request)
+	GetPricing(http.ResponseWriter, *http.Request)
+	UpdatePricing(http.ResponseWriter, *http.Request)
+	CreateSeasonalPrice(http.ResponseWriter, *http.Request)
+	DeleteSeasonalPrice(http.ResponseWriter, *http.Request)
+	GetCompleteness(http.ResponseWriter, *http.Request)
+	SubmitStay(http.ResponseWriter, *http.Request)
+	ArchiveStay(http.ResponseWriter, *http.Request)
+	UnarchiveStay(http.ResponseWriter, *http.Request)
+	GetStaysForReview(http.ResponseWriter, *http.Request)
+	ReviewStay(http.ResponseWriter, *http.Request)
 }
@@ -12,12 +12,13 @@ type StaysAdvantageRepo interface {
 	CreateStaysAdvantage(ctx context.Context, stayAdv *models.StayAdvantageEntity) error
 	DeleteStaysAdvantageByID(context.Context, uuid.UUID) error
 	CheckStaysAdvantageIfExists(context.Context, uuid.UUID) (bool, error)
+	GetStaysAdvantageByID(context.Context, uuid.UUID) (*models.StayAdvantage, error)
 }
 
 //go:generate mockery --name StaysAdvantageService
 type StaysAdvantageService interface {
-	CreateStaysAdvantage(ctx context.Context, req *models.StayAdvantageCreateReq) error
-	DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID) error
+	CreateStaysAdvantage(ctx context.Context, req *models.StayAdvantageCreateReq, userID string) error
+	DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID, userID string) error
 }
 
 type StaysAdvantageHandler interface {
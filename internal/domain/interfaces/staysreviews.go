@@ -4,7 +4,9 @@ import (
 	"context"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysreviews"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"net/http"
+	"time"
 )
 
 //go:generate mockery --name StaysReviewsRepo
@@ -13,17 +15,23 @@ type StaysReviewsRepo interface {
 	UpdateStaysReviewByID(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID) error
 	DeleteStaysReviewByID(context.Context, uuid.UUID) error
 	FindOneStaysReviewByID(context.Context, uuid.UUID) (*staysreviews.StaysReview, error)
-	FindAllStaysReviews(context.Context) ([]staysreviews.StaysReview, error)
+	FindAllStaysReviews(ctx context.Context, stayID *uuid.UUID, page api.PageRequest) (api.Page[staysreviews.StaysReview], error)
+	GetRatingSummary(ctx context.Context, stayID uuid.UUID) (*staysreviews.RatingSummary, error)
+	SetReply(ctx context.Context, id uuid.UUID, text string) error
+	RecomputeStayRating(ctx context.Context, stayID uuid.UUID) error
+	RecomputeRevealedRatings(ctx context.Context, from, to time.Time) (int, error)
 	CheckIfExists(context.Context, uuid.UUID) (bool, error)
 }
 
 //go:generate mockery --name StaysReviewsService
 type StaysReviewsService interface {
 	CreateStaysReview(context.Context, *staysreviews.StaysReviewEntity) error
-	UpdateStaysReview(context.Context, *staysreviews.StaysReviewEntity, uuid.UUID) (*staysreviews.StaysReview, error)
-	DeleteStaysReview(context.Context, uuid.UUID) error
-	FindOneStaysReview(context.Context, uuid.UUID) (*staysreviews.StaysReview, error)
-	FindAllStaysReviews(context.Context) ([]staysreviews.StaysReview, error)
+	UpdateStaysReview(ctx context.Context, stayReview *staysreviews.StaysReviewEntity, id uuid.UUID, userID string) (*staysreviews.StaysReview, error)
+	DeleteStaysReview(ctx context.Context, id uuid.UUID, userID string) error
+	FindOneStaysReview(ctx context.Context, id uuid.UUID, userID string) (*staysreviews.StaysReview, error)
+	FindAllStaysReviews(ctx context.Context, stayID *uuid.UUID, page api.PageRequest) (*staysreviews.StaysReviewsPage, error)
+	RecomputeRevealedRatings(ctx context.Context, from, to time.Time) (int, error)
+	ReplyToStaysReview(ctx context.Context, id uuid.UUID, userID string, reply *staysreviews.ReplyEntity) (*staysreviews.StaysReview, error)
 }
 
 type StaysReviewsHandler interface {
@@ -32,4 +40,5 @@ type StaysReviewsHandler interface {
 	DeleteStaysReview(w http.ResponseWriter, r *http.Request)
 	FindOneStaysReview(w http.ResponseWriter, r *http.Request)
 	FindAllStaysReviews(w http.ResponseWriter, r *http.Request)
+	ReplyToStaysReview(w http.ResponseWriter, r *http.Request)
 }
@@ -0,0 +1,39 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name SupportRepo
+type SupportRepo interface {
+	CreateEscalation(ctx context.Context, chatID, requesterID uuid.UUID, reason string, now time.Time) (*support.Escalation, error)
+	GetEscalations(ctx context.Context, filter support.Filter, now time.Time, page api.PageRequest) (api.Page[support.Escalation], error)
+	GetEscalation(ctx context.Context, id uuid.UUID) (*support.Escalation, error)
+	GetChatEscalations(ctx context.Context, chatID uuid.UUID) ([]support.Escalation, error)
+	AssignEscalation(ctx context.Context, id uuid.UUID, current *uuid.UUID, operatorID uuid.UUID, now time.Time) (*support.Escalation, error)
+	CloseEscalation(ctx context.Context, id uuid.UUID, current *uuid.UUID, operatorID uuid.UUID, note string, now time.Time) (*support.Escalation, error)
+}
+
+//go:generate mockery --name SupportService
+type SupportService interface {
+	Escalate(ctx context.Context, chatID, userID string, entity *support.EscalationEntity) (*support.Escalation, error)
+	GetChatEscalations(ctx context.Context, chatID, userID string) ([]support.Escalation, error)
+	GetEscalations(ctx context.Context, filter support.Filter, page api.PageRequest) (api.Page[support.Escalation], error)
+	GetEscalation(ctx context.Context, id uuid.UUID) (*support.Escalation, error)
+	AssignEscalation(ctx context.Context, id uuid.UUID, assign *support.AssignEntity, operatorID string) (*support.Escalation, error)
+	CloseEscalation(ctx context.Context, id uuid.UUID, entity *support.CloseEntity, operatorID string) (*support.Escalation, error)
+}
+
+type SupportHandler interface {
+	Escalate(w http.ResponseWriter, r *http.Request)
+	GetChatEscalations(w http.ResponseWriter, r *http.Request)
+	GetEscalations(w http.ResponseWriter, r *http.Request)
+	GetEscalation(w http.ResponseWriter, r *http.Request)
+	AssignEscalation(w http.ResponseWriter, r *http.Request)
+	CloseEscalation(w http.ResponseWriter, r *http.Request)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
 	"mime/multipart"
 	"net/http"
+	"time"
 )
 
 //go:generate mockery --name UserRepository
@@ -24,6 +25,9 @@ type (
 		CreateUserPfp(ctx context.Context, userId, imagePath string) error
 		DeleteUserPfp(ctx context.Context, userId uuid.UUID) error
 		UpdateUserPfp(ctx context.Context, userId uuid.UUID, imagePath string) error
+		GetSuspension(ctx context.Context, id uuid.UUID) (user.Suspension, error)
+		SetSuspension(ctx context.Context, id uuid.UUID, suspension user.Suspension) error
+		ExtendSuspension(ctx context.Context, id uuid.UUID, reason string, until time.Time) error
 	}
 )
 
@@ -41,6 +45,10 @@ type (
 		CreateUserPfp(ctx context.Context, userId string, image []byte) error
 		ChangeUserPfp(ctx context.Context, userId uuid.UUID, newImage *multipart.FileHeader) error
 		DeleteUserPfp(ctx context.Context, userId uuid.UUID) error
+		GetActiveSuspension(ctx context.Context, userID string) (*user.Suspension, error)
+		SuspendUser(ctx context.Context, idStr string, suspension *user.SuspensionEntity) (*user.Suspension, error)
+		ExtendSuspension(ctx context.Context, idStr string, reason string, days int) error
+		ReinstateUser(ctx context.Context, idStr string) error
 	}
 )
 
@@ -56,5 +64,7 @@ type (
 		UpdateUserEmailById(w http.ResponseWriter, r *http.Request)
 		UpdateUserPfp(w http.ResponseWriter, r *http.Request)
 		DeleteUserPfp(w http.ResponseWriter, r *http.Request)
+		SuspendUser(w http.ResponseWriter, r *http.Request)
+		ReinstateUser(w http.ResponseWriter, r *http.Request)
 	}
 )
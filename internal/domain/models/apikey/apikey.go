@@ -0,0 +1,175 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// Prefix tells the API keys from the access tokens in the Authorization header
+	Prefix = "iod_"
+
+	MaxKeysPerUser = 20
+	MaxNameLength  = 100
+	MaxExpiresDays = 365
+
+	// LastUsedPrecision is how stale last_used_at may get, the key is not written on every request
+	LastUsedPrecision = 5 * time.Minute
+
+	keyBytes = 32
+	// displayLength is the start of the key shown in the list of the keys
+	displayLength = len(Prefix) + 6
+)
+
+var (
+	ScopeStaysRead         Scope = "stays:read"
+	ScopeStaysWrite        Scope = "stays:write"
+	ScopeReservationsRead  Scope = "reservations:read"
+	ScopeReservationsWrite Scope = "reservations:write"
+)
+
+// scopeRoutes are the routes of the scopes relative to the API root, a write scope allows reading too
+var scopeRoutes = map[Scope]struct {
+	route string
+	write bool
+}{
+	ScopeStaysRead:         {route: "/stays"},
+	ScopeStaysWrite:        {route: "/stays", write: true},
+	ScopeReservationsRead:  {route: "/reservation"},
+	ScopeReservationsWrite: {route: "/reservation", write: true},
+}
+
+type (
+	// Scope limits the routes the API key reaches, the key acts as its user within the scopes only
+	Scope string // @name APIKeyScope
+
+	APIKey struct {
+		ID     uuid.UUID `json:"id"`
+		UserID uuid.UUID `json:"user_id"`
+		Name   string    `json:"name" example:"Listing sync"`
+		// Prefix is the start of the key to tell the keys apart, the key itself is shown once on creation
+		Prefix     string     `json:"prefix" example:"iod_Xb3k9Q"`
+		Scopes     []Scope    `json:"scopes" example:"stays:write"`
+		CreatedAt  time.Time  `json:"created_at"`
+		LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+		ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	} // @name APIKey
+
+	// Entity creates the API key, the key never expires when ExpiresInDays is omitted
+	Entity struct {
+		Name          string  `json:"name" example:"Listing sync"`
+		Scopes        []Scope `json:"scopes" example:"stays:read,stays:write"`
+		ExpiresInDays *int    `json:"expires_in_days,omitempty" example:"90"`
+	} // @name APIKeyEntity
+
+	// Created is the new API key with its secret, the secret can't be read again
+	Created struct {
+		APIKey
+		Key string `json:"key"`
+	} // @name CreatedAPIKey
+
+	// Principal is the user authenticated by the API key
+	Principal struct {
+		KeyID  uuid.UUID
+		UserID uuid.UUID
+		RoleID int
+		Scopes []Scope
+	}
+)
+
+func (s Scope) Validate() error {
+	if _, ok := scopeRoutes[s]; !ok {
+		return fmt.Errorf("unknown scope %q", s)
+	}
+	return nil
+}
+
+// Validate trims the name and drops the repeated scopes
+func (e *Entity) Validate() error {
+	e.Name = strings.TrimSpace(e.Name)
+
+	if e.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len([]rune(e.Name)) > MaxNameLength {
+		return fmt.Errorf("name can't be longer than %d characters", MaxNameLength)
+	}
+	if len(e.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	if e.ExpiresInDays != nil && (*e.ExpiresInDays < 1 || *e.ExpiresInDays > MaxExpiresDays) {
+		return fmt.Errorf("key must expire in 1 to %d days", MaxExpiresDays)
+	}
+
+	seen := make(map[Scope]bool, len(e.Scopes))
+	scopes := make([]Scope, 0, len(e.Scopes))
+
+	for _, scope := range e.Scopes {
+		if err := scope.Validate(); err != nil {
+			return err
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	e.Scopes = scopes
+
+	return nil
+}
+
+// Allows reports whether the scopes of the key reach the route, path is relative to the API root
+func (p Principal) Allows(method, path string) bool {
+	write := method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+
+	for _, scope := range p.Scopes {
+		sr, ok := scopeRoutes[scope]
+		if !ok || (write && !sr.write) {
+			continue
+		}
+		if path == sr.route || strings.HasPrefix(path, sr.route+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewKey returns a random API key
+func NewKey() (string, error) {
+	b := make([]byte, keyBytes)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return Prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// IsKey reports whether the bearer token is an API key
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// Hash is the form of the API key kept in the database
+func Hash(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// DisplayPrefix is the start of the key kept to tell the keys apart
+func DisplayPrefix(key string) string {
+	if len(key) < displayLength {
+		return key
+	}
+	return key[:displayLength]
+}
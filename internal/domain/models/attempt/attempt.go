@@ -0,0 +1,198 @@
+package attempt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kind is the action whose attempts are counted
+type Kind string
+
+const (
+	KindLogin          Kind = "login"
+	KindEmailOTP       Kind = "email_otp"
+	KindPasswordOTP    Kind = "password_otp"
+	KindEmailChangeOTP Kind = "email_change_otp"
+	KindOTPResend      Kind = "otp_resend"
+)
+
+// LockedCode is the error code of the locked attempts
+const LockedCode = "too_many_attempts"
+
+var (
+	// AccountPolicy guards the single account, the OTP of the account is invalidated when it locks
+	AccountPolicy = Policy{MaxFailures: 5, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour, ResetAfter: 24 * time.Hour}
+	// IPPolicy guards against the client trying many accounts
+	IPPolicy = Policy{MaxFailures: 20, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour, ResetAfter: 24 * time.Hour}
+	// ResendPolicy counts every sent OTP, not only the failures
+	ResendPolicy = Policy{MaxFailures: 3, Window: 30 * time.Minute, Lockout: 15 * time.Minute, MaxLockout: 6 * time.Hour, ResetAfter: 24 * time.Hour}
+)
+
+type (
+	// Policy locks the key after MaxFailures failures, the failures more than Window apart start over.
+	// The first lock lasts Lockout, every next one doubles up to MaxLockout.
+	// The key idle for ResetAfter is forgotten with its locks.
+	Policy struct {
+		MaxFailures int
+		Window      time.Duration
+		Lockout     time.Duration
+		MaxLockout  time.Duration
+		ResetAfter  time.Duration
+	}
+
+	// Counter is the state of the key kept by the store
+	Counter struct {
+		Failures    int
+		Lockouts    int
+		LockedUntil time.Time
+		UpdatedAt   time.Time
+	}
+
+	// Limit is the key counted under the policy, the limit with the empty key is skipped
+	Limit struct {
+		Key    string
+		Policy Policy
+	}
+
+	// LockedError tells how long the locked key waits
+	LockedError struct {
+		RetryAfter time.Duration
+	}
+)
+
+// Fail counts the failure at now and locks the counter when the policy is exceeded
+func (p Policy) Fail(c Counter, now time.Time) Counter {
+	idle := now.Sub(c.UpdatedAt)
+
+	switch {
+	case idle > p.ResetAfter:
+		c = Counter{}
+	case idle > p.Window:
+		c.Failures = 0
+	}
+
+	c.Failures++
+	c.UpdatedAt = now
+
+	if c.Failures >= p.MaxFailures {
+		c.Failures = 0
+		c.Lockouts++
+		c.LockedUntil = now.Add(p.lockout(c.Lockouts))
+	}
+
+	return c
+}
+
+// Take counts the attempt at now before it is made unless the counter is locked, then it is returned unchanged.
+// The attempt locking the counter is still let through.
+func (p Policy) Take(c Counter, now time.Time) (Counter, bool) {
+	if c.RetryAfter(now) > 0 {
+		return c, false
+	}
+
+	return p.Fail(c, now), true
+}
+
+// Release takes back one attempt counted by Take after it succeeded, the lock set meanwhile stays
+func (c Counter) Release() Counter {
+	if c.Failures > 0 {
+		c.Failures--
+	}
+
+	return c
+}
+
+// ExpiresAt is when the counter can be forgotten
+func (p Policy) ExpiresAt(c Counter) time.Time {
+	expiresAt := c.UpdatedAt.Add(p.ResetAfter)
+	if c.LockedUntil.After(expiresAt) {
+		return c.LockedUntil
+	}
+
+	return expiresAt
+}
+
+func (p Policy) lockout(lockouts int) time.Duration {
+	d := p.Lockout
+	for i := 1; i < lockouts && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+
+	if d > p.MaxLockout {
+		return p.MaxLockout
+	}
+
+	return d
+}
+
+// RetryAfter is how long the counter stays locked, zero for the unlocked one
+func (c Counter) RetryAfter(now time.Time) time.Duration {
+	if now.Before(c.LockedUntil) {
+		return c.LockedUntil.Sub(now)
+	}
+
+	return 0
+}
+
+// AccountKey names the counter of the account, e.g. login:account:user@mail.com
+func AccountKey(kind Kind, account string) string {
+	if strings.TrimSpace(account) == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:account:%s", kind, strings.ToLower(strings.TrimSpace(account)))
+}
+
+// IPKey names the counter of the client address, e.g. login:ip:10.0.0.1
+func IPKey(kind Kind, ip string) string {
+	if ip == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:ip:%s", kind, ip)
+}
+
+// AccountLimit limits the attempts of the kind on the account
+func AccountLimit(kind Kind, account string) Limit {
+	return Limit{Key: AccountKey(kind, account), Policy: AccountPolicy}
+}
+
+// IPLimit limits the attempts of the kind from the client address of the request
+func IPLimit(ctx context.Context, kind Kind) Limit {
+	return Limit{Key: IPKey(kind, IPFromContext(ctx)), Policy: IPPolicy}
+}
+
+// ResendLimit limits the OTPs sent to the account
+func ResendLimit(account string) Limit {
+	return Limit{Key: AccountKey(KindOTPResend, account), Policy: ResendPolicy}
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// RetryAfter returns the wait of the LockedError in the chain, zero when there is none
+func RetryAfter(err error) time.Duration {
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		return locked.RetryAfter
+	}
+
+	return 0
+}
+
+type contextKey struct{}
+
+// WithIP stores the client address of the request in the context
+func WithIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextKey{}, ip)
+}
+
+// IPFromContext returns the client address of the request, empty when it is unknown
+func IPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(contextKey{}).(string)
+	return ip
+}
@@ -1,7 +1,9 @@
 package chat
 
 import (
+	"context"
 	"github.com/google/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
 	"time"
 )
 
@@ -13,3 +15,54 @@ type Chat struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 } // @name Chat
+
+// Overview is the chat in the list of the user with the last message and the messages of the others the user has not read
+type Overview struct {
+	Chat
+	LastMessage       *message.Message `json:"last_message"`
+	UnreadCount       int              `json:"unread_count"`
+	LastReadMessageID *uuid.UUID       `json:"last_read_message_id"`
+} // @name ChatOverview
+
+// ReadMarker is the last message of the chat the participant has read
+type ReadMarker struct {
+	ChatID    uuid.UUID `json:"chat_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	MessageID uuid.UUID `json:"message_id"`
+	ReadAt    time.Time `json:"read_at"`
+} // @name ChatReadMarker
+
+// Event is the delivery to the connections of the chat, it is published to every server instance
+// and each one delivers it to its own connections
+type Event struct {
+	ChatID string `json:"chat_id"`
+	// ExceptConnection is the connection the event came from, it is not delivered back there
+	ExceptConnection string `json:"except_connection,omitempty"`
+	// DisconnectUser is the user leaving the chat, their connections are closed after the payload
+	DisconnectUser string `json:"disconnect_user,omitempty"`
+	// Resync goes to the connections of every chat when the events could be lost, it is never published
+	Resync  bool   `json:"-"`
+	Payload []byte `json:"payload"`
+}
+
+// IsParticipant tells whether the user takes part in the chat as the owner, the guest or the operator
+func (c *Chat) IsParticipant(userID string) bool {
+	if c.StayOwnerID.String() == userID || c.StayUserID.String() == userID {
+		return true
+	}
+
+	return c.OperatorID != nil && c.OperatorID.String() == userID
+}
+
+type contextKey struct{}
+
+// WithConnectionID stores the websocket connection the request came from in the context
+func WithConnectionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// ConnectionIDFromContext returns the websocket connection of the request, empty for the other requests
+func ConnectionIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+	"time"
+)
+
+type FrameType string
+
+const (
+	FrameMessage    FrameType = "message"
+	FrameTyping     FrameType = "typing"
+	FrameRead       FrameType = "read"
+	FrameEscalation FrameType = "escalation"
+	FrameHistory    FrameType = "history"
+	FrameError      FrameType = "error"
+	FrameResync     FrameType = "resync"
+)
+
+// TypingTimeout is how long the clients show the typing indicator, the typing client repeats the frame meanwhile
+const TypingTimeout = 5 * time.Second
+
+// Frame is the JSON frame of the chat websocket, every frame is one websocket text message.
+//
+// The client sends:
+//
+//	{"type": "message", "text": "Hello"}
+//	{"type": "typing"}
+//	{"type": "read", "message_id": "<id of the last read message>"}
+//
+// The server sends:
+//
+//	{"type": "message", "chat_id": "...", "user_id": "<sender>", "message": {Message}}
+//	{"type": "typing", "chat_id": "...", "user_id": "<typing participant>"}
+//	{"type": "read", "chat_id": "...", "user_id": "<reader>", "message_id": "...", "read_at": "..."}
+//	{"type": "escalation", "chat_id": "...", "escalation": {ChatEscalation}}
+//	{"type": "history", "chat_id": "...", "next_cursor": "..."}
+//	{"type": "error", "error": "..."}
+//	{"type": "resync"}
+//
+// One page of the history and the read markers of the chat come as message and read frames after connecting.
+// The history frame follows the page when there are more messages, the client loads them from its cursor.
+// The resync frame tells the frames of the chat may have been lost, the client reloads the messages
+// with GET /chat/{chatId} from the last one it has.
+type Frame struct {
+	Type      FrameType        `json:"type"`
+	ChatID    string           `json:"chat_id,omitempty"`
+	UserID    string           `json:"user_id,omitempty"`
+	Text      string           `json:"text,omitempty"`
+	Message   *message.Message `json:"message,omitempty"`
+	MessageID string           `json:"message_id,omitempty"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+	// Escalation comes when the chat is escalated to the support, taken by an operator or closed with the resolution note
+	Escalation *support.Escalation `json:"escalation,omitempty"`
+	// NextCursor of the history frame is the cursor of GET /chat/{chatId} for the messages after the sent page
+	NextCursor string `json:"next_cursor,omitempty"`
+	Error      string `json:"error,omitempty"`
+} // @name ChatFrame
+
+func MessageFrame(msg message.Message) Frame {
+	return Frame{Type: FrameMessage, ChatID: msg.ChatID.String(), UserID: msg.UserID.String(), Message: &msg}
+}
+
+func ReadFrame(marker ReadMarker) Frame {
+	return Frame{
+		Type:      FrameRead,
+		ChatID:    marker.ChatID.String(),
+		UserID:    marker.UserID.String(),
+		MessageID: marker.MessageID.String(),
+		ReadAt:    &marker.ReadAt,
+	}
+}
+
+func EscalationFrame(escalation support.Escalation) Frame {
+	return Frame{Type: FrameEscalation, ChatID: escalation.ChatID.String(), Escalation: &escalation}
+}
+
+func HistoryFrame(chatID, nextCursor string) Frame {
+	return Frame{Type: FrameHistory, ChatID: chatID, NextCursor: nextCursor}
+}
+
+func ResyncFrame() Frame {
+	return Frame{Type: FrameResync}
+}
+
+func ErrorFrame(err string) Frame {
+	return Frame{Type: FrameError, Error: err}
+}
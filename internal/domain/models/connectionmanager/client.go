@@ -0,0 +1,135 @@
+package connectionmanager
+
+import (
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"sync"
+	"time"
+)
+
+const (
+	// writeWait is the time allowed to write a message to the peer
+	writeWait = 10 * time.Second
+	// pongWait is the time allowed to read the next pong from the peer
+	pongWait = 60 * time.Second
+	// pingPeriod must be less than pongWait
+	pingPeriod = pongWait * 9 / 10
+	// maxMessageSize is the largest message read from the peer, the event carrying it must fit the Postgres NOTIFY payload
+	maxMessageSize = 4 * 1024
+	// sendBufferSize is how many messages wait for the write pump, the client falling behind is disconnected
+	sendBufferSize = 256
+)
+
+// Client is the websocket connection of the user to the chat on one device.
+// Gorilla connections support one concurrent writer, so every write goes through the write pump.
+type Client struct {
+	ID     string
+	UserID string
+	ChatID string
+
+	conn      *websocket.Conn
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewClient(conn *websocket.Conn, userID, chatID string) *Client {
+	return &Client{
+		ID:     uuid.NewString(),
+		UserID: userID,
+		ChatID: chatID,
+		conn:   conn,
+		send:   make(chan []byte, sendBufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// Send queues the message for the write pump, false when the client is closed or disconnected for falling behind
+func (c *Client) Send(message []byte) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.send <- message:
+		return true
+	default:
+		c.Close()
+		return false
+	}
+}
+
+// Finish queues the last message, the write pump closes the connection after writing it
+func (c *Client) Finish(message []byte) {
+	if c.Send(message) {
+		// nil is the end of the queue for the write pump
+		c.Send(nil)
+	}
+}
+
+// Close stops the write pump, the pump closes the connection
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// WritePump writes the queued messages and the pings to the connection until the client is closed.
+// It is the only writer of the connection once started.
+func (c *Client) WritePump() {
+	ticker := time.NewTicker(pingPeriod)
+
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message := <-c.send:
+			if message == nil {
+				c.Close()
+				continue
+			}
+
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				c.Close()
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.Close()
+				return
+			}
+		case <-c.done:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
+
+// ReadPump passes the messages of the peer to handle until the connection fails or the peer goes silent,
+// the client is closed then
+func (c *Client) ReadPump(handle func(message []byte)) error {
+	defer c.Close()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		handle(message)
+	}
+}
@@ -1,58 +1,81 @@
 package connectionmanager
 
 import (
-	"fmt"
-	"github.com/gorilla/websocket"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
 	"sync"
 )
 
+// ConnectionManager routes the messages to the websocket connections of this instance by chat.
+// A user may be connected to a chat from several devices, every connection is a Client.
+// The events reach the manager of every instance through the chat pub/sub.
 type ConnectionManager struct {
-	connections map[string]*websocket.Conn // userId -> connection
-	mu          sync.RWMutex               // for safe channels
+	rooms map[string]map[*Client]struct{} // chatId -> connections
+	mu    sync.RWMutex                    // for safe channels
 } // @name ConnectionManager
 
 func NewConnectionManager() *ConnectionManager {
 	return &ConnectionManager{
-		connections: make(map[string]*websocket.Conn),
+		rooms: make(map[string]map[*Client]struct{}),
 	}
 }
 
-func (cm *ConnectionManager) AddConnection(userId string, conn *websocket.Conn) {
+// Join adds the connection to the room of its chat
+func (cm *ConnectionManager) Join(client *Client) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
-	cm.connections[userId] = conn
+
+	room, ok := cm.rooms[client.ChatID]
+	if !ok {
+		room = make(map[*Client]struct{})
+		cm.rooms[client.ChatID] = room
+	}
+
+	room[client] = struct{}{}
 }
 
-func (cm *ConnectionManager) RemoveConnection(userId string) {
+// Leave removes the connection from the room of its chat
+func (cm *ConnectionManager) Leave(client *Client) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
-	delete(cm.connections, userId)
-}
 
-func (cm *ConnectionManager) GetConnection(userId string) (*websocket.Conn, bool) {
-	cm.mu.RLock()
-	defer cm.mu.RUnlock()
-	conn, exists := cm.connections[userId]
-	return conn, exists
-}
+	room, ok := cm.rooms[client.ChatID]
+	if !ok {
+		return
+	}
+
+	delete(room, client)
 
-func (cm *ConnectionManager) AllConnections() {
-	for name := range cm.connections {
-		fmt.Println(name, " ", "true")
+	if len(room) == 0 {
+		delete(cm.rooms, client.ChatID)
 	}
 }
 
-func (cm *ConnectionManager) BroadcastMessage(ownerId string, message []byte) {
+// Deliver sends the event to every connection of this instance in the chat except the one it came from,
+// the other devices of the sender get it too. The connections of the user leaving the chat are closed after the event.
+// The resync event goes to every connection of this instance.
+func (cm *ConnectionManager) Deliver(event chat.Event) {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
 
-	for userId, conn := range cm.connections {
-		if userId == ownerId {
+	if event.Resync {
+		for _, room := range cm.rooms {
+			for client := range room {
+				client.Send(event.Payload)
+			}
+		}
+		return
+	}
+
+	for client := range cm.rooms[event.ChatID] {
+		if event.DisconnectUser != "" && client.UserID == event.DisconnectUser {
+			client.Finish(event.Payload)
 			continue
 		}
 
-		if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
-			fmt.Printf("Failed to send message to user %s: %v\n", userId, err)
+		if client.ID == event.ExceptConnection {
+			continue
 		}
+
+		client.Send(event.Payload)
 	}
 }
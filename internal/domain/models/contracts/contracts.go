@@ -1,28 +1,33 @@
 package contracts
 
-import "time"
+import (
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"time"
+)
 
 type (
 	Contract struct {
-		UserID    string    `json:"user_id"`
-		StayID    string    `json:"stay_id"`
-		Price     float64   `json:"price"`
-		DateStart time.Time `json:"date_start"`
-		DateEnd   time.Time `json:"date_end"`
-		Square    float64   `json:"square"`
-		Street    string    `json:"street"`
-		House     string    `json:"house"`
-		Entrance  string    `json:"entrance"`
-		Floor     string    `json:"floor,omitempty"`
-		Room      string    `json:"room,omitempty"`
-		CreatedAt time.Time `json:"created_at"`
-		UpdatedAt time.Time `json:"updated_at"`
+		UserID    string         `json:"user_id"`
+		StayID    string         `json:"stay_id"`
+		Price     money.Amount   `json:"price" swaggertype:"number"`
+		Currency  money.Currency `json:"currency"`
+		DateStart time.Time      `json:"date_start"`
+		DateEnd   time.Time      `json:"date_end"`
+		Square    float64        `json:"square"`
+		Street    string         `json:"street"`
+		House     string         `json:"house"`
+		Entrance  string         `json:"entrance"`
+		Floor     string         `json:"floor,omitempty"`
+		Room      string         `json:"room,omitempty"`
+		CreatedAt time.Time      `json:"created_at"`
+		UpdatedAt time.Time      `json:"updated_at"`
 	} // @name Contract
 
 	ContractEntity struct {
 		UserName  string
 		StayName  string
-		Price     float64
+		Price     money.Amount
+		Currency  money.Currency
 		DateStart time.Time
 		DateEnd   time.Time
 	} // @name ContractEntity
@@ -2,6 +2,7 @@ package favourite
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 	"time"
 )
 
@@ -19,4 +20,10 @@ type (
 		StayID uuid.UUID `json:"stay_id"`
 		City   string    `json:"city"`
 	} // @name Favourite
+
+	// Page is a page of favourite stays grouped by location id
+	Page struct {
+		Items      map[uuid.UUID][]stays.StayEntityFav `json:"items"`
+		NextCursor string                              `json:"next_cursor,omitempty"`
+	} // @name FavouritePage
 )
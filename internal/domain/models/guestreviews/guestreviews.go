@@ -0,0 +1,60 @@
+package guestreviews
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// MaxDescriptionLength limits the text of a guest review
+const MaxDescriptionLength = 2000
+
+type (
+	// Entity is the review of the guest of a completed reservation written by the stay owner,
+	// the stay, the host and the guest come from the reservation
+	Entity struct {
+		ReservationID uuid.UUID `json:"reservation_id"`
+		Rating        float32   `json:"rating" example:"5"`
+		Description   string    `json:"description"`
+		StayID        uuid.UUID `json:"-"`
+		HostID        uuid.UUID `json:"-"`
+		GuestID       uuid.UUID `json:"-"`
+	} // @name GuestReviewEntity
+
+	GuestReview struct {
+		ID            uuid.UUID `json:"id"`
+		ReservationID uuid.UUID `json:"reservation_id"`
+		StayID        uuid.UUID `json:"stay_id"`
+		HostID        uuid.UUID `json:"host_id"`
+		GuestID       uuid.UUID `json:"guest_id"`
+		Rating        float32   `json:"rating"`
+		Description   string    `json:"description"`
+		CreatedAt     time.Time `json:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at"`
+	} // @name GuestReview
+
+	// Summary is the rating of the user as a guest, only the public reviews count
+	Summary struct {
+		Rating       float64 `json:"rating"`
+		ReviewsCount int     `json:"reviews_count"`
+	} // @name GuestRating
+)
+
+// Validate checks the rating and trims the description
+func (e *Entity) Validate() error {
+	e.Description = strings.TrimSpace(e.Description)
+
+	if e.Rating < MinRating || e.Rating > MaxRating {
+		return fmt.Errorf("rating must be between %d and %d", MinRating, MaxRating)
+	}
+	if len([]rune(e.Description)) > MaxDescriptionLength {
+		return fmt.Errorf("description can't be longer than %d characters", MaxDescriptionLength)
+	}
+	return nil
+}
@@ -5,16 +5,41 @@ import (
 	"time"
 )
 
+// MaxTextLength is the length of the text column in runes
+const MaxTextLength = 255
+
 type Message struct {
-	ID        uuid.UUID `json:"id"`
-	ChatID    uuid.UUID `json:"chat_id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Text      string    `json:"text"`
-	Media     *string   `json:"media"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID     uuid.UUID `json:"id"`
+	ChatID uuid.UUID `json:"chat_id"`
+	UserID uuid.UUID `json:"user_id"`
+	Text   string    `json:"text"`
+	Media  *string   `json:"media"`
+	// Attachment is the file sent with the message, it is downloaded from /chat/{chatId}/attachments/{id}
+	Attachment *Attachment `json:"attachment"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
 } // @name Message
 
+type AttachmentType string
+
+const (
+	AttachmentImage AttachmentType = "image"
+	AttachmentPDF   AttachmentType = "pdf"
+)
+
+type Attachment struct {
+	ID       uuid.UUID      `json:"id"`
+	Type     AttachmentType `json:"type"`
+	MimeType string         `json:"mime_type"`
+	Name     string         `json:"name"`
+	Size     int64          `json:"size"`
+	// Width and Height are the pixels of the images
+	Width  *int `json:"width,omitempty"`
+	Height *int `json:"height,omitempty"`
+	// Path is the file under the private root
+	Path string `json:"-"`
+} // @name MessageAttachment
+
 type Entity struct {
 	UserID    uuid.UUID `json:"user_id"`
 	Text      string    `json:"text"`
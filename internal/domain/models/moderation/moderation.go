@@ -0,0 +1,193 @@
+package moderation
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	TypeStay Type = "stay"
+	TypeUser Type = "user"
+)
+
+const (
+	StatusOpen     Status = "open"
+	StatusInReview Status = "in_review"
+	StatusResolved Status = "resolved"
+	StatusRejected Status = "rejected"
+)
+
+const (
+	ActionUnpublishStay Action = "unpublish_stay"
+	ActionSuspendUser   Action = "suspend_user"
+	ActionWarn          Action = "warn"
+)
+
+const (
+	// DefaultSuspendDays is the suspension of the reported user when the moderator sets none
+	DefaultSuspendDays = 7
+	MaxSuspendDays     = 365
+	MaxNoteLength      = 2000
+)
+
+type (
+	// Type tells the stay reports from the user reports, their ids are unique within the type only
+	Type string // @name ReportType
+
+	// Status is a step of the report moderation: open -> in_review -> resolved or rejected,
+	// an open report may be resolved or rejected right away
+	Status string // @name ReportStatus
+
+	// Action is what the moderator does to the reported user or stay when resolving the report
+	Action string // @name ReportAction
+
+	Report struct {
+		ID         uuid.UUID  `json:"id"`
+		Type       Type       `json:"type" example:"stay"`
+		ReporterID uuid.UUID  `json:"reporter_id"`
+		StayID     *uuid.UUID `json:"stay_id,omitempty"`
+		// ReportedUserID is the reported user or the owner of the reported stay
+		ReportedUserID    uuid.UUID  `json:"reported_user_id"`
+		Title             string     `json:"title"`
+		Description       string     `json:"description"`
+		ReportAttach      *string    `json:"report_attach,omitempty"`
+		Status            Status     `json:"status" example:"open"`
+		AssigneeID        *uuid.UUID `json:"assignee_id,omitempty"`
+		Action            *Action    `json:"action,omitempty"`
+		ResolutionComment string     `json:"resolution_comment,omitempty"`
+		ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+		CreatedAt         time.Time  `json:"created_at"`
+		UpdatedAt         time.Time  `json:"updated_at"`
+
+		ReporterEmail     string `json:"-"`
+		ReportedUserEmail string `json:"-"`
+	} // @name ModerationReport
+
+	// ReportDetails is the report with the internal notes of the moderators
+	ReportDetails struct {
+		Report
+		Notes []Note `json:"notes"`
+	} // @name ModerationReportDetails
+
+	Filter struct {
+		Status     Status
+		Type       Type
+		AssigneeID *uuid.UUID
+	}
+
+	AssignEntity struct {
+		// AssigneeID is the moderator taking the report, the current user when empty
+		AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+	} // @name ReportAssignEntity
+
+	NoteEntity struct {
+		Text string `json:"text" example:"The photos of the listing match the report"`
+	} // @name ReportNoteEntity
+
+	Note struct {
+		ID        uuid.UUID  `json:"id"`
+		AuthorID  *uuid.UUID `json:"author_id"`
+		Text      string     `json:"text"`
+		CreatedAt time.Time  `json:"created_at"`
+	} // @name ReportNote
+
+	// ResolutionEntity closes the report. A resolved report may come with an action,
+	// the comment is sent to the reporter.
+	ResolutionEntity struct {
+		Status      Status  `json:"status" example:"resolved"`
+		Action      *Action `json:"action,omitempty" example:"suspend_user"`
+		Comment     string  `json:"comment" example:"The host is suspended"`
+		SuspendDays int     `json:"suspend_days,omitempty" example:"7"`
+	} // @name ReportResolutionEntity
+)
+
+func (t Type) Validate() error {
+	switch t {
+	case TypeStay, TypeUser:
+		return nil
+	default:
+		return fmt.Errorf("unknown report type %q", t)
+	}
+}
+
+func (s Status) Validate() error {
+	switch s {
+	case StatusOpen, StatusInReview, StatusResolved, StatusRejected:
+		return nil
+	default:
+		return fmt.Errorf("unknown report status %q", s)
+	}
+}
+
+// IsClosed reports whether the report is already resolved or rejected
+func (s Status) IsClosed() bool {
+	return s == StatusResolved || s == StatusRejected
+}
+
+func (f Filter) Validate() error {
+	if f.Status != "" {
+		if err := f.Status.Validate(); err != nil {
+			return err
+		}
+	}
+	if f.Type != "" {
+		if err := f.Type.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate trims the note text
+func (e *NoteEntity) Validate() error {
+	e.Text = strings.TrimSpace(e.Text)
+
+	if e.Text == "" {
+		return fmt.Errorf("note text is required")
+	}
+	if len([]rune(e.Text)) > MaxNoteLength {
+		return fmt.Errorf("note can't be longer than %d characters", MaxNoteLength)
+	}
+	return nil
+}
+
+// Validate checks the resolution fits the report type and sets the default suspension
+func (e *ResolutionEntity) Validate(reportType Type) error {
+	e.Comment = strings.TrimSpace(e.Comment)
+
+	switch e.Status {
+	case StatusResolved:
+	case StatusRejected:
+		if e.Action != nil {
+			return fmt.Errorf("rejected report can't have an action")
+		}
+		return nil
+	default:
+		return fmt.Errorf("report can be resolved or rejected only")
+	}
+
+	if e.Action == nil {
+		return nil
+	}
+
+	switch *e.Action {
+	case ActionUnpublishStay:
+		if reportType != TypeStay {
+			return fmt.Errorf("only the reported stays can be unpublished")
+		}
+	case ActionSuspendUser:
+		if e.SuspendDays == 0 {
+			e.SuspendDays = DefaultSuspendDays
+		}
+		if e.SuspendDays < 0 || e.SuspendDays > MaxSuspendDays {
+			return fmt.Errorf("suspension must be between 1 and %d days", MaxSuspendDays)
+		}
+	case ActionWarn:
+	default:
+		return fmt.Errorf("unknown action %q", *e.Action)
+	}
+
+	return nil
+}
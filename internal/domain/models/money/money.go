@@ -0,0 +1,192 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BaseCurrency is the currency all the rates are expressed in
+const BaseCurrency Currency = "RUB"
+
+// minorUnits is the number of the minor units in the major one, every currency is kept to two decimals
+const minorUnits = 100
+
+var (
+	currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+	decimal      = regexp.MustCompile(`^(-?)([0-9]+)(?:\.([0-9]+))?$`)
+)
+
+type (
+	// Currency is an ISO 4217 currency code
+	Currency string // @name Currency
+
+	// Amount is the sum in the minor units of the currency, kopecks or cents. It is written
+	// as a decimal of the major units in JSON and SQL, so 1500.50 is Amount(150050).
+	Amount int64 // @name Amount
+
+	Money struct {
+		Amount   Amount   `json:"amount" swaggertype:"number" example:"1500.50" validate:"gte=0"`
+		Currency Currency `json:"currency" validate:"required,len=3"`
+	} // @name Money
+
+	// Rate is the price of one unit of the currency in the BaseCurrency
+	Rate struct {
+		Currency  Currency  `json:"currency"`
+		Rate      float64   `json:"rate"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	} // @name CurrencyRate
+
+	RateEntity struct {
+		Rate float64 `json:"rate" validate:"required,gt=0"`
+	} // @name CurrencyRateEntity
+)
+
+// ParseCurrency normalizes the code to upper case, empty code means the BaseCurrency
+func ParseCurrency(s string) (Currency, error) {
+	if s == "" {
+		return BaseCurrency, nil
+	}
+
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+
+	return c, nil
+}
+
+func (c Currency) Validate() error {
+	if !currencyCode.MatchString(string(c)) {
+		return fmt.Errorf("invalid currency code %q", string(c))
+	}
+
+	return nil
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+// ParseAmount reads the decimal of the major units, the digits past the minor units are rounded half away from zero
+func ParseAmount(s string) (Amount, error) {
+	match := decimal.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	major, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil || major > math.MaxInt64/minorUnits-1 {
+		return 0, fmt.Errorf("amount %q is too large", s)
+	}
+
+	fraction := match[3] + "000"
+	minor, _ := strconv.ParseInt(fraction[:2], 10, 64)
+	if fraction[2] >= '5' {
+		minor++
+	}
+
+	amount := Amount(major*minorUnits + minor)
+	if match[1] == "-" {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// Percent returns the share of the amount rounded to the minor units
+func (a Amount) Percent(percent float64) Amount {
+	return Amount(math.Round(float64(a) * percent / 100))
+}
+
+func (a Amount) String() string {
+	sign := ""
+	if a < 0 {
+		sign, a = "-", -a
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, a/minorUnits, a%minorUnits)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts the amount both as a JSON number and as a string
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	amount, err := ParseAmount(strings.Trim(s, `"`))
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+
+	return nil
+}
+
+// Scan reads the NUMERIC columns, NULL is zero
+func (a *Amount) Scan(src any) error {
+	var err error
+
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+	case []byte:
+		*a, err = ParseAmount(string(v))
+	case string:
+		*a, err = ParseAmount(v)
+	case int64:
+		*a = Amount(v * minorUnits)
+	case float64:
+		*a = Amount(math.Round(v * minorUnits))
+	default:
+		err = fmt.Errorf("can't scan %T into amount", src)
+	}
+
+	return err
+}
+
+// Value writes the amount as the decimal the NUMERIC columns take
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func New(amount Amount, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Mul multiplies the amount, e.g. the price of a night by the number of nights
+func (m Money) Mul(n int) Money {
+	return New(m.Amount*Amount(n), m.Currency)
+}
+
+// Percent returns the share of the money, e.g. the refunded part of the payment
+func (m Money) Percent(percent float64) Money {
+	return New(m.Amount.Percent(percent), m.Currency)
+}
+
+// Convert recalculates the money with the rates of its currency and the target one
+func (m Money) Convert(from, to Rate) (Money, error) {
+	if from.Currency != m.Currency {
+		return Money{}, fmt.Errorf("rate of %s can't convert %s", from.Currency, m.Currency)
+	}
+	if from.Rate <= 0 || to.Rate <= 0 {
+		return Money{}, fmt.Errorf("rates must be positive")
+	}
+
+	return New(Amount(math.Round(float64(m.Amount)*from.Rate/to.Rate)), to.Currency), nil
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Amount, m.Currency)
+}
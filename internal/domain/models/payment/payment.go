@@ -0,0 +1,42 @@
+package payment
+
+import (
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"time"
+)
+
+// The statuses are the ones of the payment provider
+const (
+	StatusPending           Status = "pending"
+	StatusWaitingForCapture Status = "waiting_for_capture"
+	StatusSucceeded         Status = "succeeded"
+	StatusCanceled          Status = "canceled"
+)
+
+type (
+	Status string // @name PaymentStatus
+
+	// Payment is the record of a payment made for a reservation. The payment is identified by the
+	// idempotence key of the client request, the same key never produces a second payment.
+	// Request and Response keep the raw payloads exchanged with the payment provider.
+	Payment struct {
+		ID                uuid.UUID       `json:"id"`
+		IdempotenceKey    string          `json:"idempotence_key"`
+		ReservationID     *uuid.UUID      `json:"reservation_id"`
+		UserID            *uuid.UUID      `json:"user_id"`
+		ProviderPaymentID string          `json:"provider_payment_id,omitempty"`
+		Status            Status          `json:"status" example:"pending"`
+		Amount            money.Money     `json:"amount"`
+		Request           json.RawMessage `json:"request" swaggertype:"object"`
+		Response          json.RawMessage `json:"response,omitempty" swaggertype:"object"`
+		CreatedAt         time.Time       `json:"created_at"`
+		UpdatedAt         time.Time       `json:"updated_at"`
+	} // @name Payment
+)
+
+// IsFinal reports whether the provider won't change the status anymore
+func (s Status) IsFinal() bool {
+	return s == StatusSucceeded || s == StatusCanceled
+}
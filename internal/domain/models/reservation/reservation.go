@@ -1,10 +1,47 @@
 package reservation
 
 import (
+	"fmt"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
 	"time"
 )
 
+const (
+	StatusRequested Status = "requested"
+	StatusApproved  Status = "approved"
+	StatusDeclined  Status = "declined"
+	StatusPaid      Status = "paid"
+	StatusCheckedIn Status = "checked_in"
+	StatusCompleted Status = "completed"
+	StatusCancelled Status = "cancelled"
+	StatusNoShow    Status = "no_show"
+)
+
+// ReviewWindowDays is how long the guest and the host can review each other after the checkout
+const ReviewWindowDays = 14
+
+// transitions lists the statuses every status can be changed to, the ones missing here are final
+var transitions = map[Status][]Status{
+	StatusRequested: {StatusApproved, StatusDeclined, StatusCancelled},
+	StatusApproved:  {StatusPaid, StatusCheckedIn, StatusCancelled, StatusNoShow},
+	StatusPaid:      {StatusCheckedIn, StatusCancelled, StatusNoShow},
+	StatusCheckedIn: {StatusCompleted},
+}
+
+const (
+	RefundNotRequired RefundStatus = "not_required"
+	RefundRequested   RefundStatus = "requested"
+	RefundFailed      RefundStatus = "failed"
+)
+
+const (
+	CalendarDayFree      CalendarDayStatus = "free"
+	CalendarDayReserved  CalendarDayStatus = "reserved"
+	CalendarDayCheckedIn CalendarDayStatus = "checked_in"
+	CalendarDayBlocked   CalendarDayStatus = "blocked"
+)
+
 type (
 	ReservationUpdateEntity struct {
 		ID        uuid.UUID `json:"id"`
@@ -12,10 +49,12 @@ type (
 		Departure time.Time `json:"departure"`
 	} // @name ReservationUpdateEntity
 
+	// ReservationEntity books the stay, one guest is coming when Guests is omitted
 	ReservationEntity struct {
 		StayID    uuid.UUID `json:"stay_id"`
 		Arrived   time.Time `json:"arrived"`
 		Departure time.Time `json:"departure"`
+		Guests    int       `json:"guests,omitempty" example:"2"`
 	} // @name ReservationEntity
 
 	ReservationCheckInEntity struct {
@@ -28,7 +67,127 @@ type (
 		UserID    uuid.UUID `json:"user_id"`
 		Arrived   time.Time `json:"arrived"`
 		Departure time.Time `json:"departure"`
+		Guests    int       `json:"guests" example:"2"`
+		Status    Status    `json:"status" example:"approved"`
+		Refund    *Refund   `json:"refund,omitempty"`
+		// ReviewDeadline closes the review window opened by the checkout
+		ReviewDeadline *time.Time `json:"review_deadline,omitempty"`
+		CreatedAt      time.Time  `json:"created_at"`
+		UpdatedAt      time.Time  `json:"updated_at"`
+	} // @name Reservation
+
+	// Status is a step of the reservation lifecycle:
+	// requested -> approved -> paid -> checked_in -> completed, where a request may be declined,
+	// a reservation may be cancelled before check in and the guest may not show up
+	Status string // @name ReservationStatus
+
+	// StatusChange is an audit record of a reservation status transition.
+	// From is nil for the status the reservation was created with, ChangedBy is nil for system changes.
+	StatusChange struct {
+		ID            uuid.UUID  `json:"id"`
+		ReservationID uuid.UUID  `json:"reservation_id"`
+		From          *Status    `json:"from_status"`
+		To            Status     `json:"to_status"`
+		ChangedBy     *uuid.UUID `json:"changed_by"`
+		Reason        string     `json:"reason"`
+		CreatedAt     time.Time  `json:"created_at"`
+	} // @name ReservationStatusChange
+
+	StatusReasonEntity struct {
+		Reason string `json:"reason,omitempty"`
+	} // @name ReservationStatusReasonEntity
+
+	// RefundStatus tells whether the refund of a cancelled reservation was sent to the payout service
+	RefundStatus string // @name RefundStatus
+
+	// Refund is the part of the paid price returned to the guest on cancellation
+	Refund struct {
+		Percent float64      `json:"percent" example:"50"`
+		Amount  money.Money  `json:"amount"`
+		Status  RefundStatus `json:"status" example:"requested"`
+	} // @name Refund
+
+	// RefundRequest is the message sent over the payout topic, IdempotenceKey lets the payout service drop duplicates
+	RefundRequest struct {
+		IdempotenceKey string      `json:"idempotence_key"`
+		ReservationID  uuid.UUID   `json:"reservation_id"`
+		UserID         uuid.UUID   `json:"user_id"`
+		Amount         money.Money `json:"amount"`
+		Description    string      `json:"description"`
+	}
+
+	// StayBlockEntity is a date range manually closed for booking by the stay owner.
+	// DateEnd is exclusive, the same way as the reservation departure date.
+	StayBlockEntity struct {
+		StayID    uuid.UUID `json:"stay_id"`
+		DateStart time.Time `json:"date_start" validate:"required"`
+		DateEnd   time.Time `json:"date_end" validate:"required"`
+		Reason    string    `json:"reason,omitempty"`
+	} // @name StayBlockEntity
+
+	StayBlock struct {
+		ID        uuid.UUID `json:"id"`
+		StayID    uuid.UUID `json:"stay_id"`
+		DateStart time.Time `json:"date_start"`
+		DateEnd   time.Time `json:"date_end"`
+		Reason    string    `json:"reason"`
 		CreatedAt time.Time `json:"created_at"`
 		UpdatedAt time.Time `json:"updated_at"`
-	} // @name Reservation
+	} // @name StayBlock
+
+	CalendarDayStatus string
+
+	CalendarDay struct {
+		Date   string            `json:"date" example:"2025-01-01"`
+		Status CalendarDayStatus `json:"status" example:"free"`
+	} // @name CalendarDay
 )
+
+// CanTransitionTo reports whether the lifecycle allows changing the status to the given one
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HoldsDates reports whether the reservation keeps the stay dates from being booked by someone else
+func (s Status) HoldsDates() bool {
+	switch s {
+	case StatusDeclined, StatusCancelled, StatusNoShow:
+		return false
+	default:
+		return true
+	}
+}
+
+// ReviewDeadline is the end of the review window of the reservation completed at the time
+func ReviewDeadline(completedAt time.Time) time.Time {
+	return completedAt.AddDate(0, 0, ReviewWindowDays)
+}
+
+// CanBeReviewed reports whether the guest and the host can still review each other
+func (r Reservation) CanBeReviewed(now time.Time) bool {
+	return r.Status == StatusCompleted && r.ReviewDeadline != nil && now.Before(*r.ReviewDeadline)
+}
+
+// RevealedSQL is the condition of the reviews of the reservation in reservationColumn being public.
+// The stay review and the guest review of a reservation stay hidden until both are written
+// or the review window is closed, the reviews without reservation are always public.
+func RevealedSQL(reservationColumn string) string {
+	return fmt.Sprintf(`(%[1]s IS NULL OR EXISTS (
+		SELECT 1 FROM reservations rr
+		WHERE rr.id = %[1]s
+		  AND (rr.review_deadline <= NOW()
+			OR (EXISTS (SELECT 1 FROM stays_reviews rs WHERE rs.reservation_id = rr.id)
+				AND EXISTS (SELECT 1 FROM guest_reviews rg WHERE rg.reservation_id = rr.id)))
+	))`, reservationColumn)
+}
+
+// ActiveStatuses are the statuses of reservations that are not over yet
+func ActiveStatuses() []Status {
+	return []Status{StatusRequested, StatusApproved, StatusPaid, StatusCheckedIn}
+}
@@ -3,6 +3,7 @@ package response
 type (
 	ResponseError struct {
 		Error string `json:"error"`
+		Code  string `json:"code,omitempty"`
 	} // @name ResponseError
 
 	ResponseSuccess struct {
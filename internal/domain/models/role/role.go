@@ -1,7 +1,96 @@
 package role
 
-type Role struct {
-	ID       int
-	Name     string
-	ObjectID int
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// UserID is the role of the registered users
+	UserID = 1
+	// AdminID is the role that manages the grants of the other roles
+	AdminID = 2
+)
+
+const (
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type (
+	// Action is what a role may do on the route of an object
+	Action string // @name RoleAction
+
+	Role struct {
+		ID      int      `json:"id" example:"2"`
+		Name    string   `json:"name" example:"admin"`
+		Objects []Object `json:"objects"`
+	} // @name Role
+
+	// ObjectEntity is a route with the actions allowed on it, a role granted the object may do the actions
+	ObjectEntity struct {
+		Route   string   `json:"route" example:"/locations"`
+		Actions []Action `json:"actions" example:"create,update,delete"`
+	} // @name AdmObjectEntity
+
+	Object struct {
+		ID      int      `json:"id" example:"1"`
+		Route   string   `json:"route" example:"/locations"`
+		Actions []Action `json:"actions" example:"create,update,delete"`
+	} // @name AdmObject
+
+	// Permissions are the actions of a role by route
+	Permissions map[string]map[Action]bool
+)
+
+// ActionFromMethod is the action the request method performs
+func ActionFromMethod(method string) Action {
+	switch method {
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionDelete
+	default:
+		return ActionRead
+	}
+}
+
+func (a Action) Validate() error {
+	switch a {
+	case ActionRead, ActionCreate, ActionUpdate, ActionDelete:
+		return nil
+	default:
+		return fmt.Errorf("unknown action %q", a)
+	}
+}
+
+// Validate checks the actions and cleans the route up, the routes are kept without the trailing slash
+func (e *ObjectEntity) Validate() error {
+	e.Route = strings.TrimRight(strings.TrimSpace(e.Route), "/")
+
+	if !strings.HasPrefix(e.Route, "/") {
+		return fmt.Errorf("route must start with /")
+	}
+
+	if len(e.Actions) == 0 {
+		return fmt.Errorf("object needs at least one action")
+	}
+
+	for _, action := range e.Actions {
+		if err := action.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Allows reports whether the action on the route is granted
+func (p Permissions) Allows(route string, action Action) bool {
+	return p[route][action]
 }
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+const (
+	// AccessTokenTTL is the life of the access token, the revoked session keeps working until its access token expires
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is the life of the session, it is not prolonged by the refreshes
+	RefreshTokenTTL = 30 * 24 * time.Hour
+
+	refreshTokenBytes = 32
+)
+
+var (
+	ReasonLogout          RevokeReason = "logout"
+	ReasonRevoked         RevokeReason = "revoked"
+	ReasonTokenReused     RevokeReason = "token_reused"
+	ReasonPasswordChanged RevokeReason = "password_changed"
+)
+
+type (
+	// RevokeReason tells why the session is ended
+	RevokeReason string
+
+	// Session is the login of the user on a device. Every refresh rotates its refresh token,
+	// the tokens of the session are the family revoked together when a rotated token is used again.
+	Session struct {
+		ID         uuid.UUID `json:"id"`
+		UserID     uuid.UUID `json:"user_id"`
+		RoleID     int       `json:"-"`
+		UserAgent  string    `json:"user_agent"`
+		IP         string    `json:"ip"`
+		CreatedAt  time.Time `json:"created_at"`
+		LastUsedAt time.Time `json:"last_used_at"`
+		ExpiresAt  time.Time `json:"expires_at"`
+		// Current is set for the session of the request
+		Current bool `json:"current"`
+	} // @name Session
+
+	// Device is where the user logs in from
+	Device struct {
+		UserAgent string
+		IP        string
+	}
+
+	// Tokens is the session with its new refresh token, the refresh token is known to the client only
+	Tokens struct {
+		Session      Session
+		RefreshToken string
+	}
+)
+
+// NewRefreshToken returns a random opaque refresh token
+func NewRefreshToken() (string, error) {
+	b := make([]byte, refreshTokenBytes)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken is the form of the refresh token kept in the database
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+type contextKey struct{}
+
+// WithID stores the session of the request in the context
+func WithID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// IDFromContext returns the session of the request, false for the anonymous requests
+func IDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return id, ok && id != uuid.Nil
+}
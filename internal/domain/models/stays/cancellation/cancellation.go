@@ -0,0 +1,123 @@
+package cancellation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxTiers limits the number of tiers of a custom policy
+const MaxTiers = 10
+
+const (
+	Flexible PolicyType = "flexible"
+	Moderate PolicyType = "moderate"
+	Strict   PolicyType = "strict"
+	Custom   PolicyType = "custom"
+)
+
+// presets are the tiers of the standard policies
+var presets = map[PolicyType][]Tier{
+	Flexible: {{DaysBeforeArrival: 1, RefundPercent: 100}},
+	Moderate: {{DaysBeforeArrival: 5, RefundPercent: 100}, {DaysBeforeArrival: 1, RefundPercent: 50}},
+	Strict:   {{DaysBeforeArrival: 14, RefundPercent: 100}, {DaysBeforeArrival: 7, RefundPercent: 50}},
+}
+
+type (
+	// PolicyType is one of the standard policies or the custom one whose tiers are set by the owner
+	PolicyType string // @name CancellationPolicyType
+
+	// Tier refunds RefundPercent of the reservation price when it is cancelled
+	// DaysBeforeArrival days before the arrival day or earlier
+	Tier struct {
+		DaysBeforeArrival int     `json:"days_before_arrival" example:"7"`
+		RefundPercent     float64 `json:"refund_percent" example:"50"`
+	} // @name CancellationTier
+
+	Policy struct {
+		Type  PolicyType `json:"type"`
+		Tiers []Tier     `json:"tiers"`
+	} // @name CancellationPolicy
+)
+
+func (t PolicyType) String() string {
+	return string(t)
+}
+
+// ParsePolicyType accepts the policy type in any case, the flexible policy is the default one
+func ParsePolicyType(s string) (PolicyType, error) {
+	t := PolicyType(strings.ToLower(strings.TrimSpace(s)))
+
+	switch t {
+	case "":
+		return Flexible, nil
+	case Flexible, Moderate, Strict, Custom:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unknown cancellation policy %q", s)
+	}
+}
+
+// NewPolicy checks the tiers against the policy type, only the custom policy has its own tiers.
+// The tiers of the policy are sorted from the earliest cancellation to the latest one.
+func NewPolicy(t PolicyType, tiers []Tier) (Policy, error) {
+	if t != Custom {
+		if len(tiers) > 0 {
+			return Policy{}, fmt.Errorf("only the custom cancellation policy can have tiers")
+		}
+
+		preset, ok := presets[t]
+		if !ok {
+			return Policy{}, fmt.Errorf("unknown cancellation policy %q", t)
+		}
+
+		return Policy{Type: t, Tiers: preset}, nil
+	}
+
+	if len(tiers) == 0 || len(tiers) > MaxTiers {
+		return Policy{}, fmt.Errorf("custom cancellation policy needs from 1 to %d tiers", MaxTiers)
+	}
+
+	sorted := make([]Tier, len(tiers))
+	copy(sorted, tiers)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].DaysBeforeArrival > sorted[j].DaysBeforeArrival
+	})
+
+	for i, tier := range sorted {
+		if tier.DaysBeforeArrival < 0 {
+			return Policy{}, fmt.Errorf("days before arrival must not be negative")
+		}
+		if tier.RefundPercent < 0 || tier.RefundPercent > 100 {
+			return Policy{}, fmt.Errorf("refund must be between 0 and 100 percent")
+		}
+		if i > 0 && sorted[i-1].DaysBeforeArrival == tier.DaysBeforeArrival {
+			return Policy{}, fmt.Errorf("tiers must have different days before arrival")
+		}
+	}
+
+	return Policy{Type: Custom, Tiers: sorted}, nil
+}
+
+// RefundPercent is the refund of the first tier the cancellation is early enough for, nothing is refunded after the last one
+func (p Policy) RefundPercent(daysBeforeArrival int) float64 {
+	for _, tier := range p.Tiers {
+		if daysBeforeArrival >= tier.DaysBeforeArrival {
+			return tier.RefundPercent
+		}
+	}
+
+	return 0
+}
+
+// DaysBeforeArrival counts the whole days from the cancellation day to the arrival day, it is negative after the arrival
+func DaysBeforeArrival(cancelledAt, arrival time.Time) int {
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	return int(day(arrival).Sub(day(cancelledAt)).Hours() / 24)
+}
@@ -0,0 +1,133 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// EarthRadiusKm is the mean Earth radius used for distance calculations
+const EarthRadiusKm = 6371.0
+
+// kmPerDegree is the length of one degree of latitude
+const kmPerDegree = 111.195
+
+// MaxRadiusKm limits the radius of a nearby search
+const MaxRadiusKm = 500.0
+
+type (
+	// Point is a pair of WGS84 coordinates in degrees
+	Point struct {
+		Lat float64 `json:"lat" example:"55.7558"`
+		Lon float64 `json:"lon" example:"37.6173"`
+	} // @name GeoPoint
+
+	// BoundingBox is a map viewport limited by its south-west and north-east corners,
+	// the viewport with MinLon greater than MaxLon spans the antimeridian
+	BoundingBox struct {
+		MinLat float64 `json:"min_lat" example:"55.55"`
+		MinLon float64 `json:"min_lon" example:"37.35"`
+		MaxLat float64 `json:"max_lat" example:"55.95"`
+		MaxLon float64 `json:"max_lon" example:"37.85"`
+	} // @name GeoBoundingBox
+)
+
+// Validate checks that the point has valid coordinates
+func (p Point) Validate() error {
+	if p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// Validate checks that the box corners are valid and the south one is not above the north one
+func (b BoundingBox) Validate() error {
+	if err := (Point{Lat: b.MinLat, Lon: b.MinLon}).Validate(); err != nil {
+		return err
+	}
+	if err := (Point{Lat: b.MaxLat, Lon: b.MaxLon}).Validate(); err != nil {
+		return err
+	}
+	if b.MinLat > b.MaxLat {
+		return fmt.Errorf("min latitude must not be greater than max latitude")
+	}
+	return nil
+}
+
+// CrossesAntimeridian reports whether the box spans the 180th meridian, its west edge is then east of its east edge
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLon > b.MaxLon
+}
+
+// Center returns the middle point of the box
+func (b BoundingBox) Center() Point {
+	maxLon := b.MaxLon
+	if b.CrossesAntimeridian() {
+		maxLon += 360
+	}
+
+	return Point{
+		Lat: (b.MinLat + b.MaxLat) / 2,
+		Lon: normalizeLon((b.MinLon + maxLon) / 2),
+	}
+}
+
+// BoundingBoxAround returns the smallest box containing the circle with the given radius.
+// It is used as a cheap prefilter before the exact distance is calculated, the box of
+// a circle over the antimeridian spans it instead of being cut at the 180th meridian.
+func BoundingBoxAround(p Point, radiusKm float64) BoundingBox {
+	dLat := radiusKm / kmPerDegree
+
+	dLon := 180.0
+	if cos := math.Cos(p.Lat * math.Pi / 180); cos > 0.01 {
+		dLon = math.Min(radiusKm/(kmPerDegree*cos), 180)
+	}
+
+	box := BoundingBox{
+		MinLat: math.Max(p.Lat-dLat, -90),
+		MinLon: -180,
+		MaxLat: math.Min(p.Lat+dLat, 90),
+		MaxLon: 180,
+	}
+
+	if dLon < 180 {
+		box.MinLon, box.MaxLon = normalizeLon(p.Lon-dLon), normalizeLon(p.Lon+dLon)
+	}
+
+	return box
+}
+
+// normalizeLon wraps the longitude into [-180, 180]
+func normalizeLon(lon float64) float64 {
+	switch {
+	case lon > 180:
+		return lon - 360
+	case lon < -180:
+		return lon + 360
+	default:
+		return lon
+	}
+}
+
+// DistanceKm returns the great-circle distance between two points using the haversine formula
+func DistanceKm(a, b Point) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// DistanceSQL returns a plain SQL haversine expression for the distance in kilometers
+// between the lat/lon columns and the point passed in the latParam/lonParam placeholders.
+func DistanceSQL(latColumn, lonColumn string, latParam, lonParam int) string {
+	return fmt.Sprintf(
+		"(2 * %[1]f * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(%[2]s - $%[4]d) / 2), 2) + COS(RADIANS($%[4]d)) * COS(RADIANS(%[2]s)) * POWER(SIN(RADIANS(%[3]s - $%[5]d) / 2), 2)))))",
+		EarthRadiusKm, latColumn, lonColumn, latParam, lonParam,
+	)
+}
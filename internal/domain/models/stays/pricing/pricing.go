@@ -0,0 +1,296 @@
+package pricing
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"time"
+)
+
+const (
+	// WeeklyNights is the length of stay the weekly discount starts from
+	WeeklyNights = 7
+	// MonthlyNights is the length of stay the monthly discount starts from, it replaces the weekly one
+	MonthlyNights = 28
+	// MaxNights limits the length of a quoted stay
+	MaxNights = 365
+)
+
+const (
+	LineNights              LineKind = "nights"
+	LineWeekendNights       LineKind = "weekend_nights"
+	LineSeasonNights        LineKind = "season_nights"
+	LineSeasonWeekendNights LineKind = "season_weekend_nights"
+	LineWeeklyDiscount      LineKind = "weekly_discount"
+	LineMonthlyDiscount     LineKind = "monthly_discount"
+	LineExtraGuestFee       LineKind = "extra_guest_fee"
+	LineCleaningFee         LineKind = "cleaning_fee"
+)
+
+type (
+	LineKind string // @name QuoteLineKind
+
+	// RulesEntity is what the owner sets up on top of the nightly stay price.
+	// Percents and fees are in the stay currency.
+	RulesEntity struct {
+		// WeekendSurchargePercent is added to Friday and Saturday nights
+		WeekendSurchargePercent float64 `json:"weekend_surcharge_percent" validate:"gte=0"`
+		// WeeklyDiscountPercent is taken off the nights of a stay of WeeklyNights or longer
+		WeeklyDiscountPercent float64 `json:"weekly_discount_percent" validate:"gte=0,lte=100"`
+		// MonthlyDiscountPercent is taken off the nights of a stay of MonthlyNights or longer
+		MonthlyDiscountPercent float64 `json:"monthly_discount_percent" validate:"gte=0,lte=100"`
+		// CleaningFee is charged once per stay
+		CleaningFee money.Amount `json:"cleaning_fee" swaggertype:"number" validate:"gte=0"`
+		// ExtraGuestFee is charged per night for every guest above IncludedGuests
+		ExtraGuestFee  money.Amount `json:"extra_guest_fee" swaggertype:"number" validate:"gte=0"`
+		IncludedGuests int          `json:"included_guests" validate:"gte=1"`
+	} // @name PricingRulesEntity
+
+	Rules struct {
+		StayID uuid.UUID `json:"stay_id"`
+		RulesEntity
+		Seasons   []Season  `json:"seasons"`
+		UpdatedAt time.Time `json:"updated_at"`
+	} // @name PricingRules
+
+	// SeasonEntity replaces the nightly stay price for the nights in [DateStart, DateEnd)
+	SeasonEntity struct {
+		Name      string       `json:"name"`
+		DateStart time.Time    `json:"date_start" validate:"required"`
+		DateEnd   time.Time    `json:"date_end" validate:"required"`
+		Price     money.Amount `json:"price" swaggertype:"number" validate:"required,gt=0"`
+	} // @name SeasonalPriceEntity
+
+	Season struct {
+		ID        uuid.UUID    `json:"id"`
+		StayID    uuid.UUID    `json:"stay_id"`
+		Name      string       `json:"name"`
+		DateStart time.Time    `json:"date_start"`
+		DateEnd   time.Time    `json:"date_end"`
+		Price     money.Amount `json:"price" swaggertype:"number"`
+		CreatedAt time.Time    `json:"created_at"`
+		UpdatedAt time.Time    `json:"updated_at"`
+	} // @name SeasonalPrice
+
+	// Base is the part of the stay the price is calculated from
+	Base struct {
+		StayID uuid.UUID
+		UserID uuid.UUID
+		Price  money.Money
+		Guests int
+	}
+
+	QuoteLine struct {
+		Kind        LineKind    `json:"kind" example:"nights"`
+		Description string      `json:"description" example:"Nights"`
+		Quantity    int         `json:"quantity" example:"3"`
+		UnitPrice   money.Money `json:"unit_price"`
+		Amount      money.Money `json:"amount"`
+	} // @name QuoteLine
+
+	Quote struct {
+		StayID    uuid.UUID   `json:"stay_id"`
+		Arrival   time.Time   `json:"arrival"`
+		Departure time.Time   `json:"departure"`
+		Nights    int         `json:"nights"`
+		Guests    int         `json:"guests"`
+		Lines     []QuoteLine `json:"lines"`
+		Total     money.Money `json:"total"`
+	} // @name Quote
+)
+
+// DefaultRules are used for the stays whose owners set no rules, the quote is the plain nightly price
+func DefaultRules(stayID uuid.UUID) Rules {
+	return Rules{
+		StayID:      stayID,
+		RulesEntity: RulesEntity{IncludedGuests: 1},
+		Seasons:     []Season{},
+	}
+}
+
+func (e RulesEntity) Validate() error {
+	if e.WeekendSurchargePercent < 0 {
+		return fmt.Errorf("weekend surcharge must not be negative")
+	}
+	if e.WeeklyDiscountPercent < 0 || e.WeeklyDiscountPercent > 100 {
+		return fmt.Errorf("weekly discount must be between 0 and 100 percent")
+	}
+	if e.MonthlyDiscountPercent < 0 || e.MonthlyDiscountPercent > 100 {
+		return fmt.Errorf("monthly discount must be between 0 and 100 percent")
+	}
+	if e.CleaningFee < 0 || e.ExtraGuestFee < 0 {
+		return fmt.Errorf("fees must not be negative")
+	}
+	if e.IncludedGuests < 1 {
+		return fmt.Errorf("at least one guest must be included in the price")
+	}
+	return nil
+}
+
+// Validate checks the season and cuts its dates to days
+func (e *SeasonEntity) Validate() error {
+	e.DateStart, e.DateEnd = Day(e.DateStart), Day(e.DateEnd)
+
+	if e.DateStart.IsZero() || e.DateEnd.IsZero() {
+		return fmt.Errorf("season needs both of date_start and date_end values")
+	}
+	if !e.DateEnd.After(e.DateStart) {
+		return fmt.Errorf("season date_end must be after date_start")
+	}
+	if e.Price <= 0 {
+		return fmt.Errorf("season price must be positive")
+	}
+	return nil
+}
+
+// Day cuts the time to the start of its day in UTC
+func Day(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// IsWeekend reports whether the night starting on the day is a Friday or Saturday one
+func IsWeekend(day time.Time) bool {
+	return day.Weekday() == time.Friday || day.Weekday() == time.Saturday
+}
+
+func (s Season) contains(day time.Time) bool {
+	return !day.Before(s.DateStart) && day.Before(s.DateEnd)
+}
+
+// Calculate itemises the price of the stay from arrival to departure (exclusive) for the guests.
+// Every night costs the seasonal price if a season covers it or the stay price otherwise, weekend
+// nights get the surcharge on top. Discounts apply to the nights only, fees are never discounted.
+func Calculate(base Base, rules Rules, arrival, departure time.Time, guests int) (*Quote, error) {
+	arrival, departure = Day(arrival), Day(departure)
+
+	if arrival.IsZero() || departure.IsZero() {
+		return nil, fmt.Errorf("quote needs both of arrival and departure values")
+	}
+	if !departure.After(arrival) {
+		return nil, fmt.Errorf("departure must be after arrival")
+	}
+
+	nights := int(departure.Sub(arrival).Hours() / 24)
+	if nights > MaxNights {
+		return nil, fmt.Errorf("stay can't be longer than %d nights", MaxNights)
+	}
+
+	if guests < 1 {
+		return nil, fmt.Errorf("at least one guest is needed")
+	}
+	if base.Guests > 0 && guests > base.Guests {
+		return nil, fmt.Errorf("stay accommodates at most %d guests", base.Guests)
+	}
+
+	currency := base.Price.Currency
+
+	type lineKey struct {
+		kind   LineKind
+		season string
+		price  money.Amount
+	}
+
+	var (
+		keys     []lineKey
+		quantity = map[lineKey]int{}
+	)
+
+	for day := arrival; day.Before(departure); day = day.AddDate(0, 0, 1) {
+		key := lineKey{kind: LineNights, price: base.Price.Amount}
+
+		for _, season := range rules.Seasons {
+			if season.contains(day) {
+				key = lineKey{kind: LineSeasonNights, season: season.Name, price: season.Price}
+				break
+			}
+		}
+
+		if IsWeekend(day) && rules.WeekendSurchargePercent > 0 {
+			key.price += key.price.Percent(rules.WeekendSurchargePercent)
+			if key.kind == LineSeasonNights {
+				key.kind = LineSeasonWeekendNights
+			} else {
+				key.kind = LineWeekendNights
+			}
+		}
+
+		if _, ok := quantity[key]; !ok {
+			keys = append(keys, key)
+		}
+		quantity[key]++
+	}
+
+	quote := &Quote{
+		StayID:    base.StayID,
+		Arrival:   arrival,
+		Departure: departure,
+		Nights:    nights,
+		Guests:    guests,
+	}
+
+	var nightsTotal money.Amount
+
+	for _, key := range keys {
+		line := newLine(key.kind, describeNights(key.kind, key.season), quantity[key], money.New(key.price, currency))
+		nightsTotal += line.Amount.Amount
+		quote.Lines = append(quote.Lines, line)
+	}
+
+	switch {
+	case nights >= MonthlyNights && rules.MonthlyDiscountPercent > 0:
+		discount := -nightsTotal.Percent(rules.MonthlyDiscountPercent)
+		quote.Lines = append(quote.Lines, newLine(LineMonthlyDiscount,
+			fmt.Sprintf("Monthly discount %g%%", rules.MonthlyDiscountPercent), 1, money.New(discount, currency)))
+	case nights >= WeeklyNights && rules.WeeklyDiscountPercent > 0:
+		discount := -nightsTotal.Percent(rules.WeeklyDiscountPercent)
+		quote.Lines = append(quote.Lines, newLine(LineWeeklyDiscount,
+			fmt.Sprintf("Weekly discount %g%%", rules.WeeklyDiscountPercent), 1, money.New(discount, currency)))
+	}
+
+	if extra := guests - rules.IncludedGuests; extra > 0 && rules.ExtraGuestFee > 0 {
+		quote.Lines = append(quote.Lines, newLine(LineExtraGuestFee,
+			fmt.Sprintf("Extra guest fee, %d over %d included guests for %d nights", extra, rules.IncludedGuests, nights), extra*nights, money.New(rules.ExtraGuestFee, currency)))
+	}
+
+	if rules.CleaningFee > 0 {
+		quote.Lines = append(quote.Lines, newLine(LineCleaningFee, "Cleaning fee", 1, money.New(rules.CleaningFee, currency)))
+	}
+
+	var total money.Amount
+	for _, line := range quote.Lines {
+		total += line.Amount.Amount
+	}
+
+	quote.Total = money.New(total, currency)
+
+	return quote, nil
+}
+
+func newLine(kind LineKind, description string, quantity int, unitPrice money.Money) QuoteLine {
+	return QuoteLine{
+		Kind:        kind,
+		Description: description,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Amount:      unitPrice.Mul(quantity),
+	}
+}
+
+func describeNights(kind LineKind, season string) string {
+	switch kind {
+	case LineWeekendNights:
+		return "Weekend nights"
+	case LineSeasonNights:
+		return fmt.Sprintf("Season %q nights", season)
+	case LineSeasonWeekendNights:
+		return fmt.Sprintf("Season %q weekend nights", season)
+	default:
+		return "Nights"
+	}
+}
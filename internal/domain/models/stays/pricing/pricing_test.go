@@ -0,0 +1,249 @@
+package pricing
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// line is the part of the quote line the tests check, the descriptions are for the people
+type line struct {
+	kind      LineKind
+	quantity  int
+	unitPrice money.Amount
+	amount    money.Amount
+}
+
+func TestCalculate(t *testing.T) {
+	stayID, _ := uuid.NewV4()
+
+	base := Base{StayID: stayID, Price: money.New(10000, money.BaseCurrency), Guests: 4}
+
+	rules := func(entity RulesEntity, seasons ...Season) Rules {
+		r := DefaultRules(stayID)
+		r.RulesEntity = entity
+		if r.IncludedGuests == 0 {
+			r.IncludedGuests = 1
+		}
+		r.Seasons = append(r.Seasons, seasons...)
+		return r
+	}
+
+	// 2025-06-02 is a Monday, the nights of Friday 6 and Saturday 7 are the weekend ones
+	day := func(d int) time.Time {
+		return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		base      Base
+		rules     Rules
+		arrival   time.Time
+		departure time.Time
+		guests    int
+		lines     []line
+		total     money.Amount
+	}{
+		{
+			name:      "should be plain nights",
+			base:      base,
+			rules:     DefaultRules(stayID),
+			arrival:   day(2),
+			departure: day(5),
+			guests:    1,
+			lines:     []line{{LineNights, 3, 10000, 30000}},
+			total:     30000,
+		},
+		{
+			name:      "should count the nights from the days of arrival and departure",
+			base:      base,
+			rules:     DefaultRules(stayID),
+			arrival:   day(2).Add(15 * time.Hour),
+			departure: day(4).Add(11 * time.Hour),
+			guests:    1,
+			lines:     []line{{LineNights, 2, 10000, 20000}},
+			total:     20000,
+		},
+		{
+			name:      "should add weekend surcharge to friday and saturday",
+			base:      base,
+			rules:     rules(RulesEntity{WeekendSurchargePercent: 20}),
+			arrival:   day(5),
+			departure: day(9),
+			guests:    1,
+			lines: []line{
+				{LineNights, 2, 10000, 20000},
+				{LineWeekendNights, 2, 12000, 24000},
+			},
+			total: 44000,
+		},
+		{
+			name:      "should round weekend surcharge to minor units",
+			base:      Base{StayID: stayID, Price: money.New(9999, money.BaseCurrency)},
+			rules:     rules(RulesEntity{WeekendSurchargePercent: 15}),
+			arrival:   day(6),
+			departure: day(7),
+			guests:    1,
+			lines:     []line{{LineWeekendNights, 1, 11499, 11499}},
+			total:     11499,
+		},
+		{
+			name: "should replace price in season and add weekend surcharge on top",
+			base: base,
+			rules: rules(RulesEntity{WeekendSurchargePercent: 10},
+				Season{Name: "Summer", DateStart: day(5), DateEnd: day(7), Price: 15000}),
+			arrival:   day(5),
+			departure: day(8),
+			guests:    1,
+			lines: []line{
+				{LineSeasonNights, 1, 15000, 15000},
+				{LineSeasonWeekendNights, 1, 16500, 16500},
+				{LineWeekendNights, 1, 11000, 11000},
+			},
+			total: 42500,
+		},
+		{
+			name: "should keep seasons apart",
+			base: base,
+			rules: rules(RulesEntity{},
+				Season{Name: "Early", DateStart: day(2), DateEnd: day(3), Price: 12000},
+				Season{Name: "Late", DateStart: day(3), DateEnd: day(5), Price: 13000}),
+			arrival:   day(2),
+			departure: day(5),
+			guests:    1,
+			lines: []line{
+				{LineSeasonNights, 1, 12000, 12000},
+				{LineSeasonNights, 2, 13000, 26000},
+			},
+			total: 38000,
+		},
+		{
+			name:      "should not discount stay shorter than a week",
+			base:      base,
+			rules:     rules(RulesEntity{WeeklyDiscountPercent: 10, MonthlyDiscountPercent: 25}),
+			arrival:   day(2),
+			departure: day(8),
+			guests:    1,
+			lines:     []line{{LineNights, 6, 10000, 60000}},
+			total:     60000,
+		},
+		{
+			name:      "should discount weekly stay",
+			base:      base,
+			rules:     rules(RulesEntity{WeeklyDiscountPercent: 10, MonthlyDiscountPercent: 25}),
+			arrival:   day(2),
+			departure: day(9),
+			guests:    1,
+			lines: []line{
+				{LineNights, 7, 10000, 70000},
+				{LineWeeklyDiscount, 1, -7000, -7000},
+			},
+			total: 63000,
+		},
+		{
+			name:      "should replace weekly discount with monthly one",
+			base:      base,
+			rules:     rules(RulesEntity{WeeklyDiscountPercent: 10, MonthlyDiscountPercent: 25}),
+			arrival:   day(2),
+			departure: day(30),
+			guests:    1,
+			lines: []line{
+				{LineNights, 28, 10000, 280000},
+				{LineMonthlyDiscount, 1, -70000, -70000},
+			},
+			total: 210000,
+		},
+		{
+			name: "should not discount fees",
+			base: base,
+			rules: rules(RulesEntity{
+				WeeklyDiscountPercent: 10,
+				CleaningFee:           5000,
+				ExtraGuestFee:         2000,
+				IncludedGuests:        2,
+			}),
+			arrival:   day(2),
+			departure: day(9),
+			guests:    3,
+			lines: []line{
+				{LineNights, 7, 10000, 70000},
+				{LineWeeklyDiscount, 1, -7000, -7000},
+				{LineExtraGuestFee, 7, 2000, 14000},
+				{LineCleaningFee, 1, 5000, 5000},
+			},
+			total: 82000,
+		},
+		{
+			name:      "should not charge included guests",
+			base:      base,
+			rules:     rules(RulesEntity{ExtraGuestFee: 2000, IncludedGuests: 2}),
+			arrival:   day(2),
+			departure: day(4),
+			guests:    2,
+			lines:     []line{{LineNights, 2, 10000, 20000}},
+			total:     20000,
+		},
+		{
+			name:      "should accept any guests when capacity is not set",
+			base:      Base{StayID: stayID, Price: money.New(10000, money.BaseCurrency)},
+			rules:     DefaultRules(stayID),
+			arrival:   day(2),
+			departure: day(3),
+			guests:    10,
+			lines:     []line{{LineNights, 1, 10000, 10000}},
+			total:     10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := Calculate(tt.base, tt.rules, tt.arrival, tt.departure, tt.guests)
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			lines := make([]line, len(quote.Lines))
+			for i, l := range quote.Lines {
+				assert.Equal(t, tt.base.Price.Currency, l.Amount.Currency)
+				lines[i] = line{l.Kind, l.Quantity, l.UnitPrice.Amount, l.Amount.Amount}
+			}
+
+			assert.Equal(t, tt.lines, lines)
+			assert.Equal(t, money.New(tt.total, tt.base.Price.Currency), quote.Total)
+			assert.Equal(t, int(Day(tt.departure).Sub(Day(tt.arrival)).Hours()/24), quote.Nights)
+		})
+	}
+}
+
+func TestCalculate_Errors(t *testing.T) {
+	stayID, _ := uuid.NewV4()
+
+	base := Base{StayID: stayID, Price: money.New(10000, money.BaseCurrency), Guests: 4}
+	arrival := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		arrival   time.Time
+		departure time.Time
+		guests    int
+	}{
+		{name: "should be missing dates error", arrival: arrival, guests: 1},
+		{name: "should be departure on arrival error", arrival: arrival, departure: arrival, guests: 1},
+		{name: "should be departure before arrival error", arrival: arrival, departure: arrival.AddDate(0, 0, -1), guests: 1},
+		{name: "should be too long stay error", arrival: arrival, departure: arrival.AddDate(0, 0, MaxNights+1), guests: 1},
+		{name: "should be no guests error", arrival: arrival, departure: arrival.AddDate(0, 0, 1), guests: 0},
+		{name: "should be over capacity error", arrival: arrival, departure: arrival.AddDate(0, 0, 1), guests: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := Calculate(base, DefaultRules(stayID), tt.arrival, tt.departure, tt.guests)
+
+			assert.Error(t, err)
+			assert.Nil(t, quote)
+		})
+	}
+}
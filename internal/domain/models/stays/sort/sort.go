@@ -10,6 +10,9 @@ const (
 	New               Sort = "New"
 	HighlyRecommended Sort = "Highly Recommended"
 	LowlyRecommended  Sort = "Lowly Recommended"
+	Nearest           Sort = "Nearest"
+	Cheap             Sort = "Cheap"
+	Expensive         Sort = "Expensive"
 )
 
 // String method to convert Sort to its string representation
@@ -25,5 +28,8 @@ func AllSorts() []Sort {
 		New,
 		HighlyRecommended,
 		LowlyRecommended,
+		Nearest,
+		Cheap,
+		Expensive,
 	}
 }
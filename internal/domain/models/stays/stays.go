@@ -3,8 +3,12 @@ package stays
 import (
 	"fmt"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/amenity"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/cancellation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/sort"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
+	"strings"
 	"time"
 )
 
@@ -14,8 +18,53 @@ var (
 	Hotel     StayType = "hotel"
 )
 
+const (
+	StatusDraft         Status = "draft"
+	StatusPendingReview Status = "pending_review"
+	StatusPublished     Status = "published"
+	StatusArchived      Status = "archived"
+)
+
+const (
+	DecisionApprove Decision = "approve"
+	DecisionReject  Decision = "reject"
+)
+
+// transitions are the status changes of the listing, the published stays are the only ones shown to the travellers
+var transitions = map[Status][]Status{
+	StatusDraft:         {StatusPendingReview, StatusArchived},
+	StatusPendingReview: {StatusPublished, StatusDraft},
+	StatusPublished:     {StatusPendingReview, StatusArchived},
+	StatusArchived:      {StatusDraft},
+}
+
 type (
-	StayType   string
+	StayType string
+
+	// Status is a step of the listing publication: the host submits the draft for review,
+	// the reviewer publishes it or sends it back to the drafts with a comment.
+	// The edited published stay is reviewed again before the travellers see the changes.
+	// The host archives the draft or the published stay, the unarchived stay is a draft again.
+	Status string // @name StayStatus
+
+	// Decision is the result of the listing review
+	Decision string // @name StayReviewDecision
+
+	// ReviewEntity is the decision on the stay pending review, the rejected stay needs the comment for its host
+	ReviewEntity struct {
+		Decision Decision `json:"decision" example:"reject"`
+		Comment  string   `json:"comment" example:"Add the photos of the bedroom"`
+	} // @name StayReviewEntity
+
+	// Completeness lists the fields the stay misses to be submitted for review
+	Completeness struct {
+		Complete bool     `json:"complete"`
+		Missing  []string `json:"missing"`
+	} // @name StayCompleteness
+
+	// StayEntity is the stay data set by the owner. InstantBook confirms reservations right away,
+	// otherwise the owner approves every request. Omitted InstantBook means true for a new stay
+	// and no change for an updated one. CancellationTiers are set for the custom cancellation policy only.
 	StayEntity struct {
 		UserID             uuid.UUID                `json:"user_id" validate:"required,uuid"`
 		LocationID         uuid.UUID                `json:"location_id" validate:"required,uuid"`
@@ -26,13 +75,17 @@ type (
 		House              string                   `json:"house" validate:"required"`
 		Entrance           string                   `json:"entrance,omitempty"`
 		Address            string                   `json:"address" validate:"required"`
-		RoomsCount         string                   `json:"rooms_count" validate:"required"`
-		BedsCount          string                   `json:"beds_count" validate:"required"`
-		Price              string                   `json:"price" validate:"required"`
+		RoomsCount         int                      `json:"rooms_count" validate:"gte=0"`
+		BedsCount          int                      `json:"beds_count" validate:"gte=0"`
+		Price              money.Money              `json:"price"`
 		Period             string                   `json:"period" validate:"required"`
 		OwnersRules        string                   `json:"owners_rules" validate:"required"`
-		CancellationPolicy string                   `json:"cancellation_policy" validate:"required"`
+		CancellationPolicy cancellation.PolicyType  `json:"cancellation_policy" validate:"required" example:"moderate"`
+		CancellationTiers  []cancellation.Tier      `json:"cancellation_tiers,omitempty"`
 		DescribeProperty   string                   `json:"describe_property" validate:"required"`
+		Lat                *float64                 `json:"lat,omitempty" validate:"omitempty,latitude"`
+		Lon                *float64                 `json:"lon,omitempty" validate:"omitempty,longitude"`
+		InstantBook        *bool                    `json:"instant_book,omitempty"`
 		CreatedAt          time.Time                `json:"created_at"`
 		UpdatedAt          time.Time                `json:"updated_at"`
 	} // @name StayEntity
@@ -55,15 +108,18 @@ type (
 		House              string                   `json:"house" validate:"required"`
 		Entrance           string                   `json:"entrance,omitempty"`
 		Address            string                   `json:"address" validate:"required"`
-		RoomsCount         string                   `json:"rooms_count" validate:"required"`
-		BedsCount          string                   `json:"beds_count" validate:"required"`
-		Price              string                   `json:"price" validate:"required"`
+		RoomsCount         int                      `json:"rooms_count" validate:"gte=0"`
+		BedsCount          int                      `json:"beds_count" validate:"gte=0"`
+		Price              money.Money              `json:"price"`
 		Period             string                   `json:"period" validate:"required"`
 		OwnersRules        string                   `json:"owners_rules" validate:"required"`
-		CancellationPolicy string                   `json:"cancellation_policy" validate:"required"`
+		CancellationPolicy cancellation.PolicyType  `json:"cancellation_policy" validate:"required"`
 		DescribeProperty   string                   `json:"describe_property" validate:"required"`
+		Lat                *float64                 `json:"lat,omitempty" validate:"omitempty,latitude"`
+		Lon                *float64                 `json:"lon,omitempty" validate:"omitempty,longitude"`
 		CreatedAt          time.Time                `json:"created_at"`
 		UpdatedAt          time.Time                `json:"updated_at"`
+		AddedAt            time.Time                `json:"added_at"`
 	} // @name StayEntityFav
 
 	Stay struct {
@@ -74,19 +130,27 @@ type (
 		Type               StayType                 `json:"type"`
 		Guests             int                      `json:"guests"`
 		Rating             float64                  `json:"rating"`
+		ReviewsCount       int                      `json:"reviews_count"`
 		Amenities          map[amenity.Amenity]bool `json:"amenities"`
 		House              string                   `json:"house"`
 		Entrance           string                   `json:"entrance"`
 		Address            string                   `json:"address"`
-		RoomsCount         string                   `json:"rooms_count"`
-		BedsCount          string                   `json:"beds_count"`
-		Price              string                   `json:"price"`
+		RoomsCount         int                      `json:"rooms_count"`
+		BedsCount          int                      `json:"beds_count"`
+		Price              money.Money              `json:"price"`
 		Period             string                   `json:"period"`
 		OwnersRules        string                   `json:"owners_rules"`
-		CancellationPolicy string                   `json:"cancellation_policy"`
+		CancellationPolicy cancellation.PolicyType  `json:"cancellation_policy"`
+		CancellationTiers  []cancellation.Tier      `json:"cancellation_tiers,omitempty"`
 		DescribeProperty   string                   `json:"describe_property"`
-		CreatedAt          time.Time                `json:"created_at"`
-		UpdatedAt          time.Time                `json:"updated_at"`
+		Lat                *float64                 `json:"lat"`
+		Lon                *float64                 `json:"lon"`
+		InstantBook        bool                     `json:"instant_book"`
+		Status             Status                   `json:"status" example:"published"`
+		// ReviewComment is the comment of the reviewer who rejected the stay or of the moderator who unpublished it
+		ReviewComment string    `json:"review_comment,omitempty"`
+		CreatedAt     time.Time `json:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at"`
 	} // @name Stay
 
 	StayOccupied struct {
@@ -101,12 +165,12 @@ type (
 		House              string                   `json:"house"`
 		Entrance           string                   `json:"entrance"`
 		Address            string                   `json:"address"`
-		RoomsCount         string                   `json:"rooms_count"`
-		BedsCount          string                   `json:"beds_count"`
-		Price              string                   `json:"price"`
+		RoomsCount         int                      `json:"rooms_count"`
+		BedsCount          int                      `json:"beds_count"`
+		Price              money.Money              `json:"price"`
 		Period             string                   `json:"period"`
 		OwnersRules        string                   `json:"owners_rules"`
-		CancellationPolicy string                   `json:"cancellation_policy"`
+		CancellationPolicy cancellation.PolicyType  `json:"cancellation_policy"`
 		DescribeProperty   string                   `json:"describe_property"`
 		CreatedAt          time.Time                `json:"created_at"`
 		UpdatedAt          time.Time                `json:"updated_at"`
@@ -133,6 +197,16 @@ type (
 		Images []StayImage `json:"images"`
 	} // @name StayResponse
 
+	StayNearby struct {
+		Stay
+		DistanceKm float64 `json:"distance_km"`
+	} // @name StayNearby
+
+	StaySearchResult struct {
+		Stay
+		Rank float64 `json:"rank"`
+	} // @name StaySearchResult
+
 	// Filtration represents the filtering options for stays.
 	Filtration struct {
 		// LocationID is the UUID of the location to filter stays by. Required value.
@@ -140,9 +214,14 @@ type (
 		LocationID uuid.UUID `json:"location_id" validate:"required"`
 
 		// SortBy specifies the sorting order for the results. Omitempty value.
-		// @Param sort_by query string false "Sort by options: Nil, Old, New, Highly Recommended, Lowly Recommended" Example: "New"
+		// @Param sort_by query string false "Sort by options: Nil, Old, New, Highly Recommended, Lowly Recommended, Nearest, Cheap, Expensive" Example: "New"
 		SortBy sort.Sort `json:"sort_by" validate:"omitempty"`
 
+		// Currency is the currency of price_min and price_max, prices of the stays are converted into it
+		// for filtering and sorting. Omitempty value, RUB by default.
+		// @Param currency query string false "Currency code" Example: "USD"
+		Currency money.Currency `json:"currency" validate:"omitempty"`
+
 		// PriceMin is the minimum price for filtering stays. Omitempty value.
 		// Need both min and max values if you use it.
 		// @Param price_min query float true "Minimum price" Example: 50.0
@@ -166,6 +245,28 @@ type (
 		// Need an array with a minimum length of 2. Example: [5, 4] or [5, 4, 3]
 		// @Param rating query float false "Rating range" Example: [5, 4]
 		Rating []float64 `json:"rating" validate:"omitempty"`
+
+		// Arrival is the date the traveller arrives. Omitempty value.
+		// Need both arrival and departure values if you use it.
+		// @Param arrival query string false "Arrival date" Example: "2025-01-01T00:00:00Z"
+		Arrival time.Time `json:"arrival" validate:"omitempty"`
+
+		// Departure is the date the traveller leaves. Omitempty value.
+		// Need both arrival and departure values if you use it.
+		// @Param departure query string false "Departure date" Example: "2025-01-05T00:00:00Z"
+		Departure time.Time `json:"departure" validate:"omitempty"`
+
+		// Guests is the number of travellers the stay must accommodate. Omitempty value.
+		// @Param guests query int false "Number of guests" Example: 2
+		Guests int `json:"guests" validate:"omitempty,min=0"`
+
+		// Lat and Lon are the point used by the Nearest sort. Omitempty value.
+		// Need both values if you sort by Nearest.
+		// @Param lat query float false "Latitude" Example: 55.7558
+		Lat *float64 `json:"lat" validate:"omitempty,latitude"`
+
+		// @Param lon query float false "Longitude" Example: 37.6173
+		Lon *float64 `json:"lon" validate:"omitempty,longitude"`
 	} // @name Filtration
 )
 
@@ -182,5 +283,100 @@ func (f *Filtration) SetDefaults() error {
 	if countPrice == 1 {
 		return fmt.Errorf("filtration only supports both of price_min and price_max values")
 	}
+	if f.Arrival.IsZero() != f.Departure.IsZero() {
+		return fmt.Errorf("filtration only supports both of arrival and departure values")
+	}
+	if !f.Arrival.IsZero() && !f.Departure.After(f.Arrival) {
+		return fmt.Errorf("departure must be after arrival")
+	}
+	if f.Guests < 0 {
+		return fmt.Errorf("guests must not be negative")
+	}
+	currency, err := money.ParseCurrency(f.Currency.String())
+	if err != nil {
+		return err
+	}
+	f.Currency = currency
+	if f.SortBy == sort.Nearest && (f.Lat == nil || f.Lon == nil) {
+		return fmt.Errorf("sort by %s needs both of lat and lon values", sort.Nearest)
+	}
 	return nil
 }
+
+// HasDates reports whether the search is limited to the traveller's dates
+func (f *Filtration) HasDates() bool {
+	return !f.Arrival.IsZero() && !f.Departure.IsZero()
+}
+
+// PublicSQL is the SQL condition true when the stay of the stayTable is shown to the travellers,
+// only the published stays of the hosts who are not suspended are shown
+func PublicSQL(stayTable string) string {
+	return stayTable + ".status = '" + string(StatusPublished) + "' AND NOT " + user.SuspendedSQL(stayTable+".user_id")
+}
+
+func (s Status) Validate() error {
+	switch s {
+	case StatusDraft, StatusPendingReview, StatusPublished, StatusArchived:
+		return nil
+	default:
+		return fmt.Errorf("unknown stay status %q", s)
+	}
+}
+
+// CanMoveTo reports whether the listing in the status can move to the next one
+func (s Status) CanMoveTo(next Status) bool {
+	for _, status := range transitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate trims the comment of the review
+func (e *ReviewEntity) Validate() error {
+	e.Comment = strings.TrimSpace(e.Comment)
+
+	switch e.Decision {
+	case DecisionApprove:
+	case DecisionReject:
+		if e.Comment == "" {
+			return fmt.Errorf("rejected stay needs a comment for its host")
+		}
+	default:
+		return fmt.Errorf("stay can be approved or rejected only")
+	}
+	return nil
+}
+
+// CheckCompleteness lists the required fields the stay misses, the main image is required as well
+func CheckCompleteness(stay *Stay, hasMainImage bool) Completeness {
+	missing := []string{}
+
+	required := []struct {
+		field string
+		ok    bool
+	}{
+		{"location_id", stay.LocationID != uuid.Nil},
+		{"name", strings.TrimSpace(stay.Name) != ""},
+		{"type", stay.Type != ""},
+		{"guests", stay.Guests > 0},
+		{"amenities", len(stay.Amenities) > 0},
+		{"house", strings.TrimSpace(stay.House) != ""},
+		{"address", strings.TrimSpace(stay.Address) != ""},
+		{"price", stay.Price.Amount > 0},
+		{"period", strings.TrimSpace(stay.Period) != ""},
+		{"owners_rules", strings.TrimSpace(stay.OwnersRules) != ""},
+		{"cancellation_policy", stay.CancellationPolicy != ""},
+		{"describe_property", strings.TrimSpace(stay.DescribeProperty) != ""},
+		{"main_image", hasMainImage},
+	}
+
+	for _, r := range required {
+		if !r.ok {
+			missing = append(missing, r.field)
+		}
+	}
+
+	return Completeness{Complete: len(missing) == 0, Missing: missing}
+}
@@ -1,27 +1,147 @@
 package staysreviews
 
 import (
+	"fmt"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"strings"
 	"time"
 )
 
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// The rating score of a stay is its average rating weighted as if it had PriorWeight more reviews
+// rated PriorMean, so a few good reviews don't put a stay above the ones with many
+const (
+	PriorMean   = 3.5
+	PriorWeight = 5
+)
+
+// MaxReplyLength limits the host reply to a review
+const MaxReplyLength = 2000
+
 type (
+	// Criteria are the ratings of the stay the guest gives, each from MinRating to MaxRating
+	Criteria struct {
+		Cleanliness   float32 `json:"cleanliness" example:"5"`
+		Accuracy      float32 `json:"accuracy" example:"4"`
+		Location      float32 `json:"location" example:"5"`
+		Communication float32 `json:"communication" example:"5"`
+		Value         float32 `json:"value" example:"4"`
+	} // @name ReviewCriteria
+
+	// StaysReviewEntity is the review of a completed reservation written by its guest. The stay
+	// and the author come from the reservation, the overall rating is the average of the criteria.
+	// The reservation of the review is never changed.
 	StaysReviewEntity struct {
-		StayID      uuid.UUID `json:"stay_id"`
-		UserID      uuid.UUID `json:"user_id"`
-		Title       string    `json:"title"`
-		Description string    `json:"description"`
-		Rating      float32   `json:"rating"`
+		ReservationID uuid.UUID `json:"reservation_id"`
+		StayID        uuid.UUID `json:"-"`
+		UserID        uuid.UUID `json:"-"`
+		Title         string    `json:"title"`
+		Description   string    `json:"description"`
+		Criteria      Criteria  `json:"criteria"`
+		Rating        float32   `json:"-"`
 	} // @name StaysReviewEntity
 
+	ReplyEntity struct {
+		Text string `json:"text" example:"Thank you for staying with us!"`
+	} // @name StaysReviewReplyEntity
+
+	// Reply is the public answer of the stay owner, a review has one at most
+	Reply struct {
+		Text      string    `json:"text"`
+		RepliedAt time.Time `json:"replied_at"`
+	} // @name StaysReviewReply
+
 	StaysReview struct {
-		ID          uuid.UUID `json:"id"`
-		StayID      uuid.UUID `json:"stay_id"`
-		UserID      uuid.UUID `json:"user_id"`
-		Title       string    `json:"title"`
-		Description string    `json:"description"`
-		Rating      float32   `json:"rating"`
-		CreatedAt   time.Time `json:"created_at"`
-		UpdatedAt   time.Time `json:"updated_at"`
+		ID            uuid.UUID  `json:"id"`
+		StayID        uuid.UUID  `json:"stay_id"`
+		UserID        uuid.UUID  `json:"user_id"`
+		ReservationID *uuid.UUID `json:"reservation_id"`
+		Title         string     `json:"title"`
+		Description   string     `json:"description"`
+		Rating        float32    `json:"rating"`
+		Criteria      Criteria   `json:"criteria"`
+		Reply         *Reply     `json:"reply,omitempty"`
+		CreatedAt     time.Time  `json:"created_at"`
+		UpdatedAt     time.Time  `json:"updated_at"`
+		// Revealed is false while the review is hidden from everyone but its author
+		Revealed bool `json:"-"`
 	} // @name StaysReview
+
+	// RatingSummary holds the averages of the reviews of a stay
+	RatingSummary struct {
+		StayID        uuid.UUID `json:"stay_id"`
+		ReviewsCount  int       `json:"reviews_count"`
+		Rating        float64   `json:"rating"`
+		Cleanliness   float64   `json:"cleanliness"`
+		Accuracy      float64   `json:"accuracy"`
+		Location      float64   `json:"location"`
+		Communication float64   `json:"communication"`
+		Value         float64   `json:"value"`
+	} // @name StayRatingSummary
+
+	// StaysReviewsPage is the page of reviews, the reviews of a single stay come with their averages
+	StaysReviewsPage struct {
+		api.Page[StaysReview]
+		Summary *RatingSummary `json:"summary,omitempty"`
+	} // @name StaysReviewsPage
 )
+
+func (c Criteria) Validate() error {
+	ratings := []struct {
+		name   string
+		rating float32
+	}{
+		{"cleanliness", c.Cleanliness},
+		{"accuracy", c.Accuracy},
+		{"location", c.Location},
+		{"communication", c.Communication},
+		{"value", c.Value},
+	}
+
+	for _, r := range ratings {
+		if r.rating < MinRating || r.rating > MaxRating {
+			return fmt.Errorf("%s rating must be between %d and %d", r.name, MinRating, MaxRating)
+		}
+	}
+	return nil
+}
+
+// Overall is the average of the criteria
+func (c Criteria) Overall() float32 {
+	return (c.Cleanliness + c.Accuracy + c.Location + c.Communication + c.Value) / 5
+}
+
+// Validate checks the criteria and sets the overall rating from them
+func (e *StaysReviewEntity) Validate() error {
+	err := e.Criteria.Validate()
+	if err != nil {
+		return err
+	}
+
+	e.Rating = e.Criteria.Overall()
+
+	return nil
+}
+
+// Validate trims the reply text
+func (e *ReplyEntity) Validate() error {
+	e.Text = strings.TrimSpace(e.Text)
+
+	if e.Text == "" {
+		return fmt.Errorf("reply text is required")
+	}
+	if len([]rune(e.Text)) > MaxReplyLength {
+		return fmt.Errorf("reply can't be longer than %d characters", MaxReplyLength)
+	}
+	return nil
+}
+
+// BayesianScore is the rating score of a stay with count reviews of the average rating
+func BayesianScore(average float64, count int) float64 {
+	return (PriorWeight*PriorMean + average*float64(count)) / (PriorWeight + float64(count))
+}
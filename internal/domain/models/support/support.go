@@ -0,0 +1,131 @@
+package support
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	StatusOpen     Status = "open"
+	StatusAssigned Status = "assigned"
+	StatusClosed   Status = "closed"
+)
+
+const (
+	// ResponseSLA is the time an operator has to take the escalation
+	ResponseSLA = time.Hour
+	// ResolutionSLA is the time the escalation has to be closed in
+	ResolutionSLA = 24 * time.Hour
+
+	MaxReasonLength = 2000
+	MaxNoteLength   = 2000
+)
+
+type (
+	// Status is a step of the escalation: open -> assigned -> closed, an open escalation may be closed right away
+	Status string // @name EscalationStatus
+
+	// Escalation brings a support operator into the chat of the guest and the host.
+	// The assigned operator is the third participant of the chat until the escalation is closed.
+	Escalation struct {
+		ID          uuid.UUID  `json:"id"`
+		ChatID      uuid.UUID  `json:"chat_id"`
+		RequesterID *uuid.UUID `json:"requester_id"`
+		Reason      string     `json:"reason"`
+		Status      Status     `json:"status" example:"open"`
+		OperatorID  *uuid.UUID `json:"operator_id,omitempty"`
+		// ResolutionNote is left by the operator closing the escalation, both sides of the chat see it
+		ResolutionNote  string     `json:"resolution_note,omitempty"`
+		ResponseDueAt   time.Time  `json:"response_due_at"`
+		ResolutionDueAt time.Time  `json:"resolution_due_at"`
+		AssignedAt      *time.Time `json:"assigned_at,omitempty"`
+		ClosedAt        *time.Time `json:"closed_at,omitempty"`
+		CreatedAt       time.Time  `json:"created_at"`
+		UpdatedAt       time.Time  `json:"updated_at"`
+
+		// ResponseOverdue and ResolutionOverdue tell the missed SLA timers
+		ResponseOverdue   bool `json:"response_overdue"`
+		ResolutionOverdue bool `json:"resolution_overdue"`
+	} // @name ChatEscalation
+
+	Filter struct {
+		Status     Status
+		OperatorID *uuid.UUID
+		// Overdue keeps the escalations past their SLA which are not closed
+		Overdue bool
+	}
+
+	EscalationEntity struct {
+		Reason string `json:"reason" example:"The host asks to pay outside the platform"`
+	} // @name ChatEscalationEntity
+
+	AssignEntity struct {
+		// OperatorID is the operator taking the escalation, the current user when empty
+		OperatorID *uuid.UUID `json:"operator_id,omitempty"`
+		// Reassign takes the escalation from the operator it is assigned to
+		Reassign bool `json:"reassign,omitempty"`
+	} // @name EscalationAssignEntity
+
+	CloseEntity struct {
+		Note string `json:"note" example:"The reservation is refunded to the guest"`
+	} // @name EscalationCloseEntity
+)
+
+func (s Status) Validate() error {
+	switch s {
+	case StatusOpen, StatusAssigned, StatusClosed:
+		return nil
+	default:
+		return fmt.Errorf("unknown escalation status %q", s)
+	}
+}
+
+// SetOverdue marks the SLA timers missed at the moment: the escalation taken late or not taken yet,
+// and the one closed late or still not closed after the resolution time
+func (e *Escalation) SetOverdue(now time.Time) {
+	taken := now
+	if e.AssignedAt != nil {
+		taken = *e.AssignedAt
+	} else if e.ClosedAt != nil {
+		taken = *e.ClosedAt
+	}
+	e.ResponseOverdue = taken.After(e.ResponseDueAt)
+
+	closed := now
+	if e.ClosedAt != nil {
+		closed = *e.ClosedAt
+	}
+	e.ResolutionOverdue = closed.After(e.ResolutionDueAt)
+}
+
+func (f Filter) Validate() error {
+	if f.Status != "" {
+		return f.Status.Validate()
+	}
+	return nil
+}
+
+// Validate trims the reason
+func (e *EscalationEntity) Validate() error {
+	e.Reason = strings.TrimSpace(e.Reason)
+
+	if len([]rune(e.Reason)) > MaxReasonLength {
+		return fmt.Errorf("reason can't be longer than %d characters", MaxReasonLength)
+	}
+	return nil
+}
+
+// Validate trims the resolution note
+func (e *CloseEntity) Validate() error {
+	e.Note = strings.TrimSpace(e.Note)
+
+	if e.Note == "" {
+		return fmt.Errorf("resolution note is required")
+	}
+	if len([]rune(e.Note)) > MaxNoteLength {
+		return fmt.Errorf("note can't be longer than %d characters", MaxNoteLength)
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package user
 
 import (
 	"database/sql"
+	"fmt"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+	"strings"
 	"time"
 )
 
@@ -42,6 +45,8 @@ type (
 		RoleID    int64        `json:"role_id"`
 		CreatedAt time.Time    `json:"createdAt"`
 		UpdatedAt time.Time    `json:"updatedAt"`
+		// GuestRating is the rating the hosts gave the user as a guest
+		GuestRating *guestreviews.Summary `json:"guest_rating,omitempty"`
 	} // @name User
 
 	Info struct {
@@ -56,4 +61,71 @@ type (
 		RoleID    int64        `json:"role_id"`
 	} // @name UserInfo
 
+	// Suspension blocks the user until the time, a ban blocks them for good
+	Suspension struct {
+		Reason string     `json:"reason" example:"Spam in the chats"`
+		Until  *time.Time `json:"until,omitempty"`
+		Banned bool       `json:"banned"`
+	} // @name UserSuspension
+
+	// SuspensionEntity suspends the user for the days or bans them
+	SuspensionEntity struct {
+		Reason string `json:"reason" example:"Spam in the chats"`
+		Days   int    `json:"days,omitempty" example:"7"`
+		Ban    bool   `json:"ban"`
+	} // @name UserSuspensionEntity
+)
+
+// The codes of the errors of the blocked users, the clients tell a block from the other 403 errors by them
+const (
+	SuspendedCode = "account_suspended"
+	BannedCode    = "account_banned"
 )
+
+const MaxSuspendDays = 365
+
+// SuspendedSQL is the SQL condition true when the user of the userColumn is banned or suspended now
+func SuspendedSQL(userColumn string) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM users su
+		WHERE su.id = %s AND (su.banned_at IS NOT NULL OR su.suspended_until > NOW())
+	)`, userColumn)
+}
+
+// Active reports whether the suspension blocks the user at the time
+func (s Suspension) Active(now time.Time) bool {
+	return s.Banned || s.Until != nil && now.Before(*s.Until)
+}
+
+// Code is the error code of the blocked user
+func (s Suspension) Code() string {
+	if s.Banned {
+		return BannedCode
+	}
+	return SuspendedCode
+}
+
+func (s Suspension) String() string {
+	if s.Banned {
+		return fmt.Sprintf("account is banned: %s", s.Reason)
+	}
+	return fmt.Sprintf("account is suspended until %s: %s", s.Until.Format(time.RFC3339), s.Reason)
+}
+
+func (e *SuspensionEntity) Validate() error {
+	e.Reason = strings.TrimSpace(e.Reason)
+
+	if e.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if e.Ban {
+		if e.Days != 0 {
+			return fmt.Errorf("ban can't have days")
+		}
+		return nil
+	}
+	if e.Days < 1 || e.Days > MaxSuspendDays {
+		return fmt.Errorf("suspension must be between 1 and %d days", MaxSuspendDays)
+	}
+	return nil
+}
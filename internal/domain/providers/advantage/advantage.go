@@ -32,11 +32,12 @@ var AdvantageProviderSet wire.ProviderSet = wire.NewSet(
 	wire.Bind(new(interfaces.AdvantageRepo), new(*advRepo.Repo)),
 )
 
-func ProvideAdvantageHandler(svc interfaces.AdvantageService, log *slog.Logger) *advHdl.Handler {
+func ProvideAdvantageHandler(svc interfaces.AdvantageService, roleSvc interfaces.RoleService, log *slog.Logger) *advHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &advHdl.Handler{
-			Svc: svc,
-			Log: log,
+			Svc:     svc,
+			RoleSvc: roleSvc,
+			Log:     log,
 		}
 	})
 	return hdl
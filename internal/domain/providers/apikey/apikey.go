@@ -0,0 +1,30 @@
+package apikey
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	apikeyRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/apikey"
+	"sync"
+)
+
+var (
+	repository     *apikeyRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var APIKeyProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideAPIKeyRepository,
+
+	wire.Bind(new(interfaces.APIKeyRepo), new(*apikeyRepo.Repo)),
+)
+
+func ProvideAPIKeyRepository(db *sql.DB) *apikeyRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &apikeyRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
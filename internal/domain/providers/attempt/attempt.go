@@ -0,0 +1,55 @@
+package attempt
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	attemptRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/attempt"
+	attemptSvc "github.com/imperatorofdwelling/Full-backend/internal/service/attempt"
+	"sync"
+)
+
+// PostgresStore is the SERVER_ATTEMPT_STORE value sharing the attempt counters between the nodes
+const PostgresStore = "postgres"
+
+var (
+	svc     *attemptSvc.Service
+	svcOnce sync.Once
+
+	store     interfaces.AttemptStore
+	storeOnce sync.Once
+)
+
+var AttemptProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideAttemptService,
+	ProvideAttemptStore,
+
+	wire.Bind(new(interfaces.AttemptLimiter), new(*attemptSvc.Service)),
+)
+
+func ProvideAttemptService(store interfaces.AttemptStore) *attemptSvc.Service {
+	svcOnce.Do(func() {
+		svc = &attemptSvc.Service{
+			Store: store,
+		}
+	})
+
+	return svc
+}
+
+// ProvideAttemptStore keeps the counters in memory unless the postgres store is configured
+func ProvideAttemptStore(cfg *config.Config, db *sql.DB) interfaces.AttemptStore {
+	storeOnce.Do(func() {
+		if cfg.Server.AttemptStore == PostgresStore {
+			store = &attemptRepo.Repo{
+				Db: db,
+			}
+			return
+		}
+
+		store = attemptRepo.NewMemoryStore()
+	})
+
+	return store
+}
@@ -43,12 +43,15 @@ func ProvideAuthHandler(svc interfaces.AuthService, log *slog.Logger) *authHdl.A
 	return hdl
 }
 
-func ProvideAuthService(authRepo interfaces.AuthRepository, userRepo interfaces.UserRepository, confirmEmailRepo interfaces.ConfirmEmailRepository) *authSvc.Service {
+func ProvideAuthService(authRepo interfaces.AuthRepository, userRepo interfaces.UserRepository, confirmEmailRepo interfaces.ConfirmEmailRepository, sessionRepo interfaces.SessionRepo, apiKeyRepo interfaces.APIKeyRepo, limiter interfaces.AttemptLimiter) *authSvc.Service {
 	svcOnce.Do(func() {
 		svc = &authSvc.Service{
 			AuthRepo:         authRepo,
 			UserRepo:         userRepo,
 			ConfirmEmailRepo: confirmEmailRepo,
+			SessionRepo:      sessionRepo,
+			APIKeyRepo:       apiKeyRepo,
+			Limiter:          limiter,
 		}
 	})
 
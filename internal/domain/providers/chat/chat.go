@@ -4,9 +4,12 @@ import (
 	"database/sql"
 	"github.com/google/wire"
 	chatHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/chat"
+	"github.com/imperatorofdwelling/Full-backend/internal/config"
+	"github.com/imperatorofdwelling/Full-backend/internal/db"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/connectionmanager"
 	chatRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/chat"
+	"github.com/imperatorofdwelling/Full-backend/internal/repo/chatpubsub"
 	chatSvc "github.com/imperatorofdwelling/Full-backend/internal/service/chat"
 	"log/slog"
 	"sync"
@@ -21,35 +24,49 @@ var (
 
 	rep     *chatRepo.Repo
 	repOnce sync.Once
+
+	cm     *connectionmanager.ConnectionManager
+	cmOnce sync.Once
+
+	pubSub     interfaces.ChatPubSub
+	pubSubErr  error
+	pubSubOnce sync.Once
 )
 
+// PostgresPubSub is the SERVER_CHAT_PUBSUB value delivering the chat messages to every instance
+const PostgresPubSub = "postgres"
+
 var ChatProviderSet wire.ProviderSet = wire.NewSet(
 	ProvideChatHandler,
 	ProvideChatService,
 	ProvideChatRepo,
-	connectionmanager.NewConnectionManager,
+	ProvideConnectionManager,
+	ProvideChatPubSub,
 
 	wire.Bind(new(interfaces.ChatHandler), new(*chatHdl.Handler)),
 	wire.Bind(new(interfaces.ChatService), new(*chatSvc.Service)),
 	wire.Bind(new(interfaces.ChatRepository), new(*chatRepo.Repo)),
 )
 
-func ProvideChatHandler(svc interfaces.ChatService, log *slog.Logger, cm *connectionmanager.ConnectionManager) *chatHdl.Handler {
+func ProvideChatHandler(cfg *config.Config, svc interfaces.ChatService, log *slog.Logger, cm *connectionmanager.ConnectionManager) *chatHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &chatHdl.Handler{
-			Svc: svc,
-			Log: log,
-			Cm:  cm,
+			Svc:     svc,
+			Log:     log,
+			Cm:      cm,
+			Origins: cfg.Server.AllowedOrigins,
 		}
 	})
 
 	return hdl
 }
 
-func ProvideChatService(repo interfaces.ChatRepository) *chatSvc.Service {
+func ProvideChatService(repo interfaces.ChatRepository, pubSub interfaces.ChatPubSub, fileSvc interfaces.FileService) *chatSvc.Service {
 	svcOnce.Do(func() {
 		svc = &chatSvc.Service{
-			Repo: repo,
+			Repo:    repo,
+			PubSub:  pubSub,
+			FileSvc: fileSvc,
 		}
 	})
 
@@ -65,3 +82,27 @@ func ProvideChatRepo(db *sql.DB) *chatRepo.Repo {
 
 	return rep
 }
+
+// ProvideConnectionManager subscribes the connections of this instance to the chat events
+func ProvideConnectionManager(pubSub interfaces.ChatPubSub) *connectionmanager.ConnectionManager {
+	cmOnce.Do(func() {
+		cm = connectionmanager.NewConnectionManager()
+		pubSub.Subscribe(cm.Deliver)
+	})
+
+	return cm
+}
+
+// ProvideChatPubSub delivers the chat events in process unless the postgres pub/sub is configured
+func ProvideChatPubSub(cfg *config.Config, sqlDB *sql.DB, log *slog.Logger) (interfaces.ChatPubSub, error) {
+	pubSubOnce.Do(func() {
+		if cfg.Server.ChatPubSub == PostgresPubSub {
+			pubSub, pubSubErr = chatpubsub.NewPostgresPubSub(sqlDB, db.DSN(cfg), log)
+			return
+		}
+
+		pubSub = chatpubsub.NewLocalPubSub()
+	})
+
+	return pubSub, pubSubErr
+}
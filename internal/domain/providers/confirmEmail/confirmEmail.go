@@ -43,11 +43,12 @@ func ProvideConfirmEmailHandler(svc interfaces.ConfirmEmailService, log *slog.Lo
 	return hdl
 }
 
-func ProvideConfirmEmailService(confirmEmailRepo interfaces.ConfirmEmailRepository, userRepo interfaces.UserRepository) *emailConfService.Service {
+func ProvideConfirmEmailService(confirmEmailRepo interfaces.ConfirmEmailRepository, userRepo interfaces.UserRepository, limiter interfaces.AttemptLimiter) *emailConfService.Service {
 	svcOnce.Do(func() {
 		svc = &emailConfService.Service{
 			ConfirmEmailRepo: confirmEmailRepo,
 			UserRepo:         userRepo,
+			Limiter:          limiter,
 		}
 	})
 
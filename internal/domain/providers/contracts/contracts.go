@@ -42,10 +42,12 @@ func ProvideContractHandler(svc interfaces.ContractService, log *slog.Logger) *c
 	return contractHandler
 }
 
-func ProvideContractService(repo interfaces.ContractsRepo) *ctrctSvc.Service {
+func ProvideContractService(repo interfaces.ContractsRepo, prcSvc interfaces.PricingService, reservRepo interfaces.ReservationRepo) *ctrctSvc.Service {
 	contractServiceOnce.Do(func() {
 		contractService = &ctrctSvc.Service{
-			Repo: repo,
+			Repo:       repo,
+			PrcSvc:     prcSvc,
+			ReservRepo: reservRepo,
 		}
 	})
 	return contractService
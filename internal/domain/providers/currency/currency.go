@@ -0,0 +1,65 @@
+package currency
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	curHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/currency"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	curRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/currency"
+	curSvc "github.com/imperatorofdwelling/Full-backend/internal/service/currency"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *curHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *curSvc.Service
+	svcOnce sync.Once
+
+	repository     *curRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var CurrencyProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideCurrencyHandler,
+	ProvideCurrencyService,
+	ProvideCurrencyRepository,
+
+	wire.Bind(new(interfaces.CurrencyHandler), new(*curHdl.Handler)),
+	wire.Bind(new(interfaces.CurrencyService), new(*curSvc.Service)),
+	wire.Bind(new(interfaces.CurrencyRepo), new(*curRepo.Repo)),
+)
+
+func ProvideCurrencyHandler(svc interfaces.CurrencyService, roleSvc interfaces.RoleService, log *slog.Logger) *curHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &curHdl.Handler{
+			Svc:     svc,
+			RoleSvc: roleSvc,
+			Log:     log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideCurrencyService(repo interfaces.CurrencyRepo) *curSvc.Service {
+	svcOnce.Do(func() {
+		svc = &curSvc.Service{
+			Repo: repo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideCurrencyRepository(db *sql.DB) *curRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &curRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
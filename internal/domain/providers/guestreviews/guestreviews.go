@@ -0,0 +1,67 @@
+package guestreviews
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	grHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/guestreviews"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	grRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/guestreviews"
+	grSvc "github.com/imperatorofdwelling/Full-backend/internal/service/guestreviews"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *grHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *grSvc.Service
+	svcOnce sync.Once
+
+	repository     *grRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var GuestReviewsProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideGuestReviewsHandler,
+	ProvideGuestReviewsService,
+	ProvideGuestReviewsRepository,
+
+	wire.Bind(new(interfaces.GuestReviewsHandler), new(*grHdl.Handler)),
+	wire.Bind(new(interfaces.GuestReviewsService), new(*grSvc.Service)),
+	wire.Bind(new(interfaces.GuestReviewsRepo), new(*grRepo.Repo)),
+)
+
+func ProvideGuestReviewsHandler(svc interfaces.GuestReviewsService, log *slog.Logger) *grHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &grHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideGuestReviewsService(repo interfaces.GuestReviewsRepo, resSvc interfaces.ReservationService, staysSvc interfaces.StaysService, stRevRepo interfaces.StaysReviewsRepo) *grSvc.Service {
+	svcOnce.Do(func() {
+		svc = &grSvc.Service{
+			Repo:      repo,
+			ResSvc:    resSvc,
+			StaysSvc:  staysSvc,
+			StRevRepo: stRevRepo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideGuestReviewsRepository(db *sql.DB) *grRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &grRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
@@ -3,10 +3,14 @@ package providers
 import (
 	"github.com/google/wire"
 	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 )
 
 var KafkaProviderSet wire.ProviderSet = wire.NewSet(
 	kafka.NewKafkaProducer,
 	kafka.NewKafkaConsumer,
 	kafka.NewClient,
+
+	wire.Bind(new(interfaces.KafkaProducer), new(*kafka.Producer)),
+	wire.Bind(new(interfaces.PayoutProducer), new(*kafka.Producer)),
 )
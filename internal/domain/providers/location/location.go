@@ -32,11 +32,12 @@ var LocationProviderSet wire.ProviderSet = wire.NewSet(
 	wire.Bind(new(interfaces.LocationRepo), new(*locRepo.Repo)),
 )
 
-func ProvideLocationHandler(svc interfaces.LocationService, log *slog.Logger) *locHdl.Handler {
+func ProvideLocationHandler(svc interfaces.LocationService, roleSvc interfaces.RoleService, log *slog.Logger) *locHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &locHdl.Handler{
-			Svc: svc,
-			Log: log,
+			Svc:     svc,
+			RoleSvc: roleSvc,
+			Log:     log,
 		}
 	})
 
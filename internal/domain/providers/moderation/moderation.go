@@ -0,0 +1,68 @@
+package moderation
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	modHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/moderation"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	modRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/moderation"
+	modSvc "github.com/imperatorofdwelling/Full-backend/internal/service/moderation"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *modHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *modSvc.Service
+	svcOnce sync.Once
+
+	repository     *modRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var ModerationProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideModerationHandler,
+	ProvideModerationService,
+	ProvideModerationRepository,
+
+	wire.Bind(new(interfaces.ModerationHandler), new(*modHdl.Handler)),
+	wire.Bind(new(interfaces.ModerationService), new(*modSvc.Service)),
+	wire.Bind(new(interfaces.ModerationRepo), new(*modRepo.Repo)),
+)
+
+func ProvideModerationHandler(svc interfaces.ModerationService, roleSvc interfaces.RoleService, log *slog.Logger) *modHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &modHdl.Handler{
+			Svc:     svc,
+			RoleSvc: roleSvc,
+			Log:     log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideModerationService(repo interfaces.ModerationRepo, userRepo interfaces.UserRepository, userSvc interfaces.UserService, roleSvc interfaces.RoleService) *modSvc.Service {
+	svcOnce.Do(func() {
+		svc = &modSvc.Service{
+			Repo:     repo,
+			UserRepo: userRepo,
+			UserSvc:  userSvc,
+			RoleSvc:  roleSvc,
+		}
+	})
+
+	return svc
+}
+
+func ProvideModerationRepository(db *sql.DB) *modRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &modRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
@@ -1,11 +1,14 @@
 package providers
 
 import (
+	"database/sql"
 	"github.com/google/wire"
 	paymentHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/payment"
 	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka"
 	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka/consumer"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	paymentRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/payment"
+	paymentSvc "github.com/imperatorofdwelling/Full-backend/internal/service/payment"
 	"log/slog"
 	"sync"
 )
@@ -13,22 +16,57 @@ import (
 var (
 	hdl     *paymentHdl.Handler
 	hdlOnce sync.Once
+
+	svc     *paymentSvc.Service
+	svcOnce sync.Once
+
+	repo     *paymentRepo.Repo
+	repoOnce sync.Once
 )
 
 var PaymentProviderSet wire.ProviderSet = wire.NewSet(
 	ProvidePaymentHandler,
+	ProvidePaymentService,
+	ProvidePaymentRepository,
 
 	wire.Bind(new(interfaces.PaymentHandler), new(*paymentHdl.Handler)),
+	wire.Bind(new(interfaces.PaymentService), new(*paymentSvc.Service)),
+	wire.Bind(new(interfaces.PaymentRepo), new(*paymentRepo.Repo)),
 )
 
-func ProvidePaymentHandler(kafka *kafka.Client, log *slog.Logger, waitForResponse map[string]chan consumer.PaymentResponse) *paymentHdl.Handler {
+func ProvidePaymentHandler(svc interfaces.PaymentService, kafka *kafka.Client, log *slog.Logger, waiters *consumer.Waiters) *paymentHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &paymentHdl.Handler{
+			Svc:             svc,
 			Kafka:           kafka,
 			Log:             log,
-			WaitForResponse: waitForResponse,
+			WaitForResponse: waiters,
 		}
 	})
 
 	return hdl
 }
+
+func ProvidePaymentService(repo interfaces.PaymentRepo, resSvc interfaces.ReservationService, prcSvc interfaces.PricingService, producer interfaces.KafkaProducer, payouts interfaces.PayoutProducer) *paymentSvc.Service {
+	svcOnce.Do(func() {
+		svc = &paymentSvc.Service{
+			Repo:     repo,
+			ResSvc:   resSvc,
+			PrcSvc:   prcSvc,
+			Producer: producer,
+			Payouts:  payouts,
+		}
+	})
+
+	return svc
+}
+
+func ProvidePaymentRepository(db *sql.DB) *paymentRepo.Repo {
+	repoOnce.Do(func() {
+		repo = &paymentRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repo
+}
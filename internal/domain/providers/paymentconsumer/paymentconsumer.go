@@ -3,6 +3,7 @@ package paymentconsumer
 import (
 	"github.com/google/wire"
 	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka/consumer"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	"log/slog"
 	"sync"
 )
@@ -13,21 +14,23 @@ var (
 )
 
 var PaymentConsumerProviderSet wire.ProviderSet = wire.NewSet(
-	ProvideWaitPaymentForResponseChan,
+	ProvidePaymentWaiters,
 	ProvidePaymentConsumer,
 )
 
-func ProvidePaymentConsumer(log *slog.Logger, waitForResponse map[string]chan consumer.PaymentResponse) *consumer.PaymentConsumerHdl {
+func ProvidePaymentConsumer(svc interfaces.PaymentService, log *slog.Logger, waiters *consumer.Waiters) *consumer.PaymentConsumerHdl {
 	conOnce.Do(func() {
 		con = &consumer.PaymentConsumerHdl{
+			Svc:             svc,
 			Log:             log,
-			WaitForResponse: waitForResponse,
+			WaitForResponse: waiters,
 		}
 	})
 
 	return con
 }
 
-func ProvideWaitPaymentForResponseChan() map[string]chan consumer.PaymentResponse {
-	return make(map[string]chan consumer.PaymentResponse)
+// ProvidePaymentWaiters is shared by the payment handler and the consumer, wire calls it once per injector
+func ProvidePaymentWaiters() *consumer.Waiters {
+	return consumer.NewWaiters()
 }
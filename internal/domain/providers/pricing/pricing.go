@@ -0,0 +1,46 @@
+package pricing
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	priceRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/pricing"
+	priceSvc "github.com/imperatorofdwelling/Full-backend/internal/service/pricing"
+	"sync"
+)
+
+var (
+	svc     *priceSvc.Service
+	svcOnce sync.Once
+
+	repository     *priceRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var PricingProviderSet wire.ProviderSet = wire.NewSet(
+	ProvidePricingService,
+	ProvidePricingRepository,
+
+	wire.Bind(new(interfaces.PricingService), new(*priceSvc.Service)),
+	wire.Bind(new(interfaces.PricingRepo), new(*priceRepo.Repo)),
+)
+
+func ProvidePricingService(repo interfaces.PricingRepo) *priceSvc.Service {
+	svcOnce.Do(func() {
+		svc = &priceSvc.Service{
+			Repo: repo,
+		}
+	})
+
+	return svc
+}
+
+func ProvidePricingRepository(db *sql.DB) *priceRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &priceRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
@@ -42,10 +42,12 @@ func ProvideReservationHandler(svc interfaces.ReservationService, log *slog.Logg
 	return hdl
 }
 
-func ProvideReservationService(repo interfaces.ReservationRepo) *resSvc.Service {
+func ProvideReservationService(repo interfaces.ReservationRepo, prcSvc interfaces.PricingService, payouts interfaces.PayoutProducer) *resSvc.Service {
 	svcOnce.Do(func() {
 		svc = &resSvc.Service{
-			Repo: repo,
+			Repo:    repo,
+			PrcSvc:  prcSvc,
+			Payouts: payouts,
 		}
 	})
 
@@ -0,0 +1,64 @@
+package role
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	roleHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/role"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	roleRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/role"
+	roleSvc "github.com/imperatorofdwelling/Full-backend/internal/service/role"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *roleHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *roleSvc.Service
+	svcOnce sync.Once
+
+	repository     *roleRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var RoleProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideRoleHandler,
+	ProvideRoleService,
+	ProvideRoleRepository,
+
+	wire.Bind(new(interfaces.RoleHandler), new(*roleHdl.Handler)),
+	wire.Bind(new(interfaces.RoleService), new(*roleSvc.Service)),
+	wire.Bind(new(interfaces.RoleRepo), new(*roleRepo.Repo)),
+)
+
+func ProvideRoleHandler(svc interfaces.RoleService, log *slog.Logger) *roleHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &roleHdl.Handler{
+			Svc: svc,
+			Log: log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideRoleService(repo interfaces.RoleRepo) *roleSvc.Service {
+	svcOnce.Do(func() {
+		svc = &roleSvc.Service{
+			Repo: repo,
+		}
+	})
+
+	return svc
+}
+
+func ProvideRoleRepository(db *sql.DB) *roleRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &roleRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
@@ -0,0 +1,30 @@
+package session
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	sessionRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/session"
+	"sync"
+)
+
+var (
+	repository     *sessionRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var SessionProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideSessionRepository,
+
+	wire.Bind(new(interfaces.SessionRepo), new(*sessionRepo.Repo)),
+)
+
+func ProvideSessionRepository(db *sql.DB) *sessionRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &sessionRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
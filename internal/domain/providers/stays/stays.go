@@ -32,24 +32,29 @@ var StaysProviderSet wire.ProviderSet = wire.NewSet(
 	wire.Bind(new(interfaces.StaysRepo), new(*staysRepo.Repo)),
 )
 
-func ProvideStaysHandler(svc interfaces.StaysService, log *slog.Logger) *staysHdl.Handler {
+func ProvideStaysHandler(svc interfaces.StaysService, roleSvc interfaces.RoleService, log *slog.Logger) *staysHdl.Handler {
 	hdlOnce.Do(func() {
 		hdl = &staysHdl.Handler{
-			Svc: svc,
-			Log: log,
+			Svc:     svc,
+			RoleSvc: roleSvc,
+			Log:     log,
 		}
 	})
 
 	return hdl
 }
 
-func ProvideStaysService(repo interfaces.StaysRepo, locSvc interfaces.LocationService, fileSvc interfaces.FileService, userSvc interfaces.UserService) *staysSvc.Service {
+func ProvideStaysService(repo interfaces.StaysRepo, locSvc interfaces.LocationService, fileSvc interfaces.FileService, userSvc interfaces.UserService, resSvc interfaces.ReservationService, histSvc interfaces.SearchHistoryService, curSvc interfaces.CurrencyService, prcSvc interfaces.PricingService) *staysSvc.Service {
 	svcOnce.Do(func() {
 		svc = &staysSvc.Service{
 			Repo:    repo,
 			LocSvc:  locSvc,
 			FileSvc: fileSvc,
 			UserSvc: userSvc,
+			ResSvc:  resSvc,
+			HistSvc: histSvc,
+			CurSvc:  curSvc,
+			PrcSvc:  prcSvc,
 		}
 	})
 
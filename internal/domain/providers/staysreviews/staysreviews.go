@@ -42,10 +42,12 @@ func ProvideStaysReviewsHandler(svc interfaces.StaysReviewsService, log *slog.Lo
 	return hdl
 }
 
-func ProvideStaysReviewsService(repo interfaces.StaysReviewsRepo) *stRevSvc.Service {
+func ProvideStaysReviewsService(repo interfaces.StaysReviewsRepo, resSvc interfaces.ReservationService, staysSvc interfaces.StaysService) *stRevSvc.Service {
 	svcOnce.Do(func() {
 		svc = &stRevSvc.Service{
-			Repo: repo,
+			Repo:     repo,
+			ResSvc:   resSvc,
+			StaysSvc: staysSvc,
 		}
 	})
 
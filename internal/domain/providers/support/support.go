@@ -0,0 +1,68 @@
+package support
+
+import (
+	"database/sql"
+	"github.com/google/wire"
+	supportHdl "github.com/imperatorofdwelling/Full-backend/internal/api/handler/support"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	supportRepo "github.com/imperatorofdwelling/Full-backend/internal/repo/support"
+	supportSvc "github.com/imperatorofdwelling/Full-backend/internal/service/support"
+	"log/slog"
+	"sync"
+)
+
+var (
+	hdl     *supportHdl.Handler
+	hdlOnce sync.Once
+
+	svc     *supportSvc.Service
+	svcOnce sync.Once
+
+	repository     *supportRepo.Repo
+	repositoryOnce sync.Once
+)
+
+var SupportProviderSet wire.ProviderSet = wire.NewSet(
+	ProvideSupportHandler,
+	ProvideSupportService,
+	ProvideSupportRepository,
+
+	wire.Bind(new(interfaces.SupportHandler), new(*supportHdl.Handler)),
+	wire.Bind(new(interfaces.SupportService), new(*supportSvc.Service)),
+	wire.Bind(new(interfaces.SupportRepo), new(*supportRepo.Repo)),
+)
+
+func ProvideSupportHandler(svc interfaces.SupportService, roleSvc interfaces.RoleService, log *slog.Logger) *supportHdl.Handler {
+	hdlOnce.Do(func() {
+		hdl = &supportHdl.Handler{
+			Svc:     svc,
+			RoleSvc: roleSvc,
+			Log:     log,
+		}
+	})
+
+	return hdl
+}
+
+func ProvideSupportService(repo interfaces.SupportRepo, chatSvc interfaces.ChatService, userRepo interfaces.UserRepository, roleSvc interfaces.RoleService) *supportSvc.Service {
+	svcOnce.Do(func() {
+		svc = &supportSvc.Service{
+			Repo:     repo,
+			ChatSvc:  chatSvc,
+			UserRepo: userRepo,
+			RoleSvc:  roleSvc,
+		}
+	})
+
+	return svc
+}
+
+func ProvideSupportRepository(db *sql.DB) *supportRepo.Repo {
+	repositoryOnce.Do(func() {
+		repository = &supportRepo.Repo{
+			Db: db,
+		}
+	})
+
+	return repository
+}
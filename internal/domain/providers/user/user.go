@@ -32,23 +32,26 @@ var ProviderSet wire.ProviderSet = wire.NewSet(
 	wire.Bind(new(interfaces.UserRepository), new(*usrRepo.Repository)),
 )
 
-func ProvideUserHandler(svc interfaces.UserService, log *slog.Logger) *usrHdl.UserHandler {
+func ProvideUserHandler(svc interfaces.UserService, roleSvc interfaces.RoleService, log *slog.Logger) *usrHdl.UserHandler {
 	hdlOnce.Do(func() {
 		hdl = &usrHdl.UserHandler{
-			Svc: svc,
-			Log: log,
+			Svc:     svc,
+			RoleSvc: roleSvc,
+			Log:     log,
 		}
 	})
 
 	return hdl
 }
 
-func ProvideUserService(userRepo interfaces.UserRepository, fileSvc interfaces.FileService, confirmRepo interfaces.ConfirmEmailRepository) *usrSvc.Service {
+func ProvideUserService(userRepo interfaces.UserRepository, fileSvc interfaces.FileService, confirmRepo interfaces.ConfirmEmailRepository, guestReviewsRepo interfaces.GuestReviewsRepo, sessionRepo interfaces.SessionRepo) *usrSvc.Service {
 	svcOnce.Do(func() {
 		svc = &usrSvc.Service{
 			UserRepo:         userRepo,
 			ConfirmEmailRepo: confirmRepo,
 			FileSvc:          fileSvc,
+			GuestReviewsRepo: guestReviewsRepo,
+			SessionRepo:      sessionRepo,
 		}
 	})
 
@@ -5,10 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"github.com/dgrijalva/jwt-go/v4"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
 	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
 	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 )
 
 type contextKey string
@@ -18,45 +25,216 @@ const (
 	userRoleKey contextKey = "user_role"
 )
 
+const (
+	// AccessTokenCookie keeps the short-lived access token
+	AccessTokenCookie = "jwt-token"
+	// RefreshTokenCookie keeps the refresh token of the session
+	RefreshTokenCookie = "refresh-token"
+)
+
+// APIPrefix is the root of the API routes, the API key scopes are matched against the path under it
+const APIPrefix = "/api/v1"
+
+// SuspensionChecker finds the suspension blocking the user now, nil when the user is not blocked
+type SuspensionChecker interface {
+	GetActiveSuspension(ctx context.Context, userID string) (*user.Suspension, error)
+}
+
+var suspensionChecker SuspensionChecker
+
+// SetSuspensionChecker makes WithAuth and WithOptionalAuth reject the suspended and the banned users, it is set once on the server start
+func SetSuspensionChecker(checker SuspensionChecker) {
+	suspensionChecker = checker
+}
+
+// APIKeyAuthenticator finds the user of the API key
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*apikey.Principal, error)
+}
+
+var apiKeyAuthenticator APIKeyAuthenticator
+
+// SetAPIKeyAuthenticator makes WithAuth accept the API keys sent as the bearer token, it is set once on the server start
+func SetAPIKeyAuthenticator(authenticator APIKeyAuthenticator) {
+	apiKeyAuthenticator = authenticator
+}
+
 func WithAuth(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		tokenString, err := getTokenFromRequest(r)
 		if err != nil {
 			permissionDenied(w, r, "unable to get token from request")
+			return
+		}
+
+		var (
+			userID    string
+			userRole  float64
+			sessionID uuid.UUID
+		)
+
+		if apikey.IsKey(tokenString) {
+			principal, err := authenticateAPIKey(r.Context(), tokenString)
+			if err != nil {
+				permissionDenied(w, r, "invalid api key")
+				return
+			}
+
+			if !principal.Allows(r.Method, strings.TrimPrefix(r.URL.Path, APIPrefix)) {
+				responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(errors.New("forbidden: api key scope does not allow the request")))
+				return
+			}
+
+			userID = principal.UserID.String()
+			userRole = float64(principal.RoleID)
+		} else {
+			token, err := validateToken(tokenString)
+			if err != nil || !token.Valid {
+				permissionDenied(w, r, "invalid token")
+				return
+			}
+
+			userID, err = getUserIDFromToken(token)
+			if err != nil {
+				permissionDenied(w, r, "unable to get user ID from token")
+				return
+			}
+
+			userRole, err = getUserRoleFromToken(token)
+			if err != nil {
+				permissionDenied(w, r, "unable to get user role from token")
+				return
+			}
+
+			sessionID = getSessionIDFromToken(token)
+		}
+
+		if !checkSuspension(w, r, userID) {
+			return
+		}
+
+		// Store the user ID in the request context
+		ctx := context.WithValue(r.Context(), UserIdKey, userID)
+		ctx = context.WithValue(ctx, userRoleKey, userRole)
+		ctx = session.WithID(ctx, sessionID)
+		r = r.WithContext(ctx)
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// WithOptionalAuth stores the user in the request context when a valid token is present
+// and lets anonymous requests through unchanged. The suspended and the banned users are rejected as in WithAuth.
+func WithOptionalAuth(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, err := getTokenFromRequest(r)
+		if err != nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if apikey.IsKey(tokenString) {
+			principal, err := authenticateAPIKey(r.Context(), tokenString)
+			if err != nil || !principal.Allows(r.Method, strings.TrimPrefix(r.URL.Path, APIPrefix)) {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			if !checkSuspension(w, r, principal.UserID.String()) {
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserIdKey, principal.UserID.String())
+			ctx = context.WithValue(ctx, userRoleKey, float64(principal.RoleID))
+
+			handler.ServeHTTP(w, r.WithContext(ctx))
+			return
 		}
 
 		token, err := validateToken(tokenString)
 		if err != nil || !token.Valid {
-			permissionDenied(w, r, "invalid token")
+			handler.ServeHTTP(w, r)
+			return
 		}
 
 		userID, err := getUserIDFromToken(token)
 		if err != nil {
-			permissionDenied(w, r, "unable to get user ID from token")
+			handler.ServeHTTP(w, r)
+			return
 		}
 
-		userRole, err := getUserRoleFromToken(token)
-		if err != nil {
-			permissionDenied(w, r, "unable to get user role from token")
+		if !checkSuspension(w, r, userID) {
 			return
 		}
 
-		// Store the user ID in the request context
 		ctx := context.WithValue(r.Context(), UserIdKey, userID)
-		ctx = context.WithValue(ctx, userRoleKey, userRole)
-		r = r.WithContext(ctx)
+		if userRole, err := getUserRoleFromToken(token); err == nil {
+			ctx = context.WithValue(ctx, userRoleKey, userRole)
+		}
+		ctx = session.WithID(ctx, getSessionIDFromToken(token))
+
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithAdmin lets through only the users with the admin role, it must be used after WithAuth.
+// It guards the grant management only, the other admin routes are granted with WithPermission
+func WithAdmin(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userRole, ok := UserRole(r.Context())
+		if !ok || userRole != role.AdminID {
+			responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(errors.New("forbidden: admin role required")))
+			return
+		}
 
 		handler.ServeHTTP(w, r)
 	})
 }
 
+// checkSuspension writes the error and returns false when the user is suspended or banned now
+func checkSuspension(w http.ResponseWriter, r *http.Request, userID string) bool {
+	if suspensionChecker == nil {
+		return true
+	}
+
+	suspension, err := suspensionChecker.GetActiveSuspension(r.Context(), userID)
+	if err != nil {
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return false
+	}
+	if suspension != nil {
+		responseApi.WriteErrorCode(w, r, http.StatusForbidden, suspension.Code(), suspension.String())
+		return false
+	}
+
+	return true
+}
+
 func permissionDenied(w http.ResponseWriter, r *http.Request, error string) {
 	responseApi.WriteError(w, r, http.StatusUnauthorized, slogError.Err(errors.New("permission denied: "+error)))
 	return
 }
 
+func authenticateAPIKey(ctx context.Context, key string) (*apikey.Principal, error) {
+	if apiKeyAuthenticator == nil {
+		return nil, errors.New("api keys are not accepted")
+	}
+
+	return apiKeyAuthenticator.AuthenticateAPIKey(ctx, key)
+}
+
+// getTokenFromRequest takes the bearer token of the Authorization header first and the access token cookie then
 func getTokenFromRequest(r *http.Request) (string, error) {
-	cookie, err := r.Cookie("jwt-token")
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, tokenString, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(tokenString) == "" {
+			return "", errors.New("malformed authorization header")
+		}
+
+		return strings.TrimSpace(tokenString), nil
+	}
+
+	cookie, err := r.Cookie(AccessTokenCookie)
 	if err != nil {
 		return "", err
 	}
@@ -66,6 +244,25 @@ func getTokenFromRequest(r *http.Request) (string, error) {
 	return tokenString, nil
 }
 
+// NewAccessToken signs the access token of the session, it expires after session.AccessTokenTTL
+func NewAccessToken(userID uuid.UUID, roleID int, sessionID uuid.UUID) (string, time.Time, error) {
+	expiresAt := time.Now().Add(session.AccessTokenTTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp":     expiresAt.Unix(),
+		"user_id": userID.String(),
+		"role_id": roleID,
+		"sid":     sessionID.String(),
+	})
+
+	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET_KEY_AUTH")))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
 func validateToken(token string) (*jwt.Token, error) {
 	return jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -102,3 +299,15 @@ func getUserRoleFromToken(token *jwt.Token) (float64, error) {
 
 	return userRole, nil
 }
+
+// getSessionIDFromToken returns uuid.Nil for the tokens issued before the sessions
+func getSessionIDFromToken(token *jwt.Token) uuid.UUID {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil
+	}
+
+	sessionID, _ := claims["sid"].(string)
+
+	return uuid.FromStringOrNil(sessionID)
+}
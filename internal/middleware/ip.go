@@ -0,0 +1,77 @@
+package mw
+
+import (
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WithClientIP stores the client address in the request context for the attempt limits.
+// Only the trusted proxies may pass the client address, nginx sets it in X-Real-IP. The other forwarding
+// headers and X-Real-IP of the other peers are ignored, the clients control them.
+// The trusted proxies are IP addresses or CIDR ranges, the invalid ones are skipped.
+func WithClientIP(trustedProxies ...string) func(http.Handler) http.Handler {
+	trusted := parseNets(trustedProxies)
+
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				ip = r.RemoteAddr
+			}
+
+			if realIP := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); realIP != nil && contains(trusted, net.ParseIP(ip)) {
+				ip = realIP.String()
+				// the sessions take the device address from the request
+				r.RemoteAddr = ip
+			}
+
+			handler.ServeHTTP(w, r.WithContext(attempt.WithIP(r.Context(), ip)))
+		})
+	}
+}
+
+func parseNets(values []string) []*net.IPNet {
+	var nets []*net.IPNet
+
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				continue
+			}
+
+			bits := 8 * len(ip.To4())
+			if bits == 0 {
+				bits = 8 * net.IPv6len
+			}
+
+			value = value + "/" + strconv.Itoa(bits)
+		}
+
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			continue
+		}
+
+		nets = append(nets, ipNet)
+	}
+
+	return nets
+}
+
+func contains(nets []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package mw
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	responseApi "github.com/imperatorofdwelling/Full-backend/internal/utils/response"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"net/http"
+)
+
+// PermissionChecker resolves whether a role is granted an action on a route
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, roleID int, route string, action role.Action) (bool, error)
+}
+
+// WithPermission lets through the requests whose role is granted the action of the request method on the route,
+// it must be used after WithAuth
+func WithPermission(checker PermissionChecker, route string) func(http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !checkPermission(w, r, checker, route) {
+				return
+			}
+
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WithSelfOrPermission lets the users act on themselves, the user id is taken from the URL param.
+// Acting on another user needs the permission like WithPermission does.
+func WithSelfOrPermission(checker PermissionChecker, route string, param string) func(http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := r.Context().Value(UserIdKey).(string)
+			if ok && userID == chi.URLParam(r, param) {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			if !checkPermission(w, r, checker, route) {
+				return
+			}
+
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// UserRole returns the role stored by WithAuth
+func UserRole(ctx context.Context) (int, bool) {
+	userRole, ok := ctx.Value(userRoleKey).(float64)
+	if !ok {
+		return 0, false
+	}
+
+	return int(userRole), true
+}
+
+func checkPermission(w http.ResponseWriter, r *http.Request, checker PermissionChecker, route string) bool {
+	roleID, ok := UserRole(r.Context())
+	if !ok {
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(errors.New("forbidden: user role not found")))
+		return false
+	}
+
+	allowed, err := checker.HasPermission(r.Context(), roleID, route, role.ActionFromMethod(r.Method))
+	if err != nil {
+		responseApi.WriteError(w, r, http.StatusInternalServerError, slogError.Err(err))
+		return false
+	}
+
+	if !allowed {
+		responseApi.WriteError(w, r, http.StatusForbidden, slogError.Err(errors.New("forbidden: permission denied")))
+		return false
+	}
+
+	return true
+}
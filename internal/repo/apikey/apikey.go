@@ -0,0 +1,188 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/lib/pq"
+	"time"
+)
+
+type Repo struct {
+	Db *sql.DB
+}
+
+func toScopes(values []string) []model.Scope {
+	scopes := make([]model.Scope, len(values))
+	for i, v := range values {
+		scopes[i] = model.Scope(v)
+	}
+	return scopes
+}
+
+func fromScopes(scopes []model.Scope) []string {
+	values := make([]string, len(scopes))
+	for i, s := range scopes {
+		values[i] = string(s)
+	}
+	return values
+}
+
+func (r *Repo) CreateAPIKey(ctx context.Context, key *model.APIKey, keyHash string) error {
+	const op = "repo.apikey.CreateAPIKey"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, key.UserID, key.Name, key.Prefix, keyHash, pq.Array(fromScopes(key.Scopes)), key.CreatedAt, key.ExpiresAt).
+		Scan(&key.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetAPIKeys returns the keys of the user that are not revoked, the newest first
+func (r *Repo) GetAPIKeys(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+	const op = "repo.apikey.GetAPIKeys"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT id, user_id, name, prefix, scopes, created_at, last_used_at, expires_at
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+
+	for rows.Next() {
+		var (
+			key        model.APIKey
+			scopes     []string
+			lastUsedAt sql.NullTime
+			expiresAt  sql.NullTime
+		)
+
+		err = rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, pq.Array(&scopes), &key.CreatedAt, &lastUsedAt, &expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		key.Scopes = toScopes(scopes)
+		if lastUsedAt.Valid {
+			key.LastUsedAt = &lastUsedAt.Time
+		}
+		if expiresAt.Valid {
+			key.ExpiresAt = &expiresAt.Time
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+func (r *Repo) RevokeAPIKey(ctx context.Context, userID, id uuid.UUID) error {
+	const op = "repo.apikey.RevokeAPIKey"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE api_keys SET revoked_at = $1
+		WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, time.Now(), id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrAPIKeyNotFound)
+	}
+
+	return nil
+}
+
+// Authenticate finds the active key by its hash and marks it used,
+// last_used_at is written only once it is older than model.LastUsedPrecision
+func (r *Repo) Authenticate(ctx context.Context, keyHash string) (*model.Principal, error) {
+	const op = "repo.apikey.Authenticate"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		WITH key AS (
+			SELECT k.id, k.user_id, u.role_id, k.scopes, k.last_used_at
+			FROM api_keys k
+			JOIN users u ON u.id = k.user_id
+			WHERE k.key_hash = $1
+			  AND k.revoked_at IS NULL
+			  AND (k.expires_at IS NULL OR k.expires_at > $2)
+		), used AS (
+			UPDATE api_keys SET last_used_at = $2
+			WHERE id IN (SELECT id FROM key WHERE last_used_at IS NULL OR last_used_at < $3)
+		)
+		SELECT id, user_id, role_id, scopes FROM key
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	var (
+		principal model.Principal
+		scopes    []string
+	)
+
+	now := time.Now()
+
+	err = stmt.QueryRowContext(ctx, keyHash, now, now.Add(-model.LastUsedPrecision)).
+		Scan(&principal.KeyID, &principal.UserID, &principal.RoleID, pq.Array(&scopes))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrInvalidAPIKey)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	principal.Scopes = toScopes(scopes)
+
+	return &principal, nil
+}
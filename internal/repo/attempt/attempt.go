@@ -0,0 +1,180 @@
+package attempt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
+	"sync"
+	"time"
+)
+
+// Repo keeps the counters in Postgres, the nodes of the cluster share them
+type Repo struct {
+	Db *sql.DB
+
+	mu       sync.Mutex
+	prunedAt time.Time
+}
+
+func (r *Repo) GetAttempts(ctx context.Context, key string) (*model.Counter, error) {
+	const op = "repo.attempt.GetAttempts"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT failures, lockouts, locked_until, updated_at
+		FROM auth_attempts
+		WHERE key = $1 AND expires_at > $2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	counter, err := scanCounter(stmt.QueryRowContext(ctx, key, time.Now()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.Counter{}, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counter, nil
+}
+
+// TakeAttempt counts the attempt of the key under the lock of its row unless the key is locked,
+// the lock check and the count are one step for the parallel attempts. The expired counter of the key starts over.
+func (r *Repo) TakeAttempt(ctx context.Context, key string, policy model.Policy) (*model.Counter, bool, error) {
+	const op = "repo.attempt.TakeAttempt"
+
+	now := time.Now()
+
+	r.prune(ctx, now)
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth_attempts (key, updated_at, expires_at) VALUES ($1, $2, $2)
+		ON CONFLICT (key) DO NOTHING
+	`, key, now)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var expiresAt time.Time
+
+	counter, err := scanCounter(tx.QueryRowContext(ctx, `
+		SELECT failures, lockouts, locked_until, updated_at, expires_at
+		FROM auth_attempts
+		WHERE key = $1
+		FOR UPDATE
+	`, key), &expiresAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !now.Before(expiresAt) {
+		counter = &model.Counter{}
+	}
+
+	taken, ok := policy.Take(*counter, now)
+	if !ok {
+		return &taken, false, nil
+	}
+
+	var lockedUntil *time.Time
+	if !taken.LockedUntil.IsZero() {
+		lockedUntil = &taken.LockedUntil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE auth_attempts SET failures = $1, lockouts = $2, locked_until = $3, updated_at = $4, expires_at = $5
+		WHERE key = $6
+	`, taken.Failures, taken.Lockouts, lockedUntil, taken.UpdatedAt, policy.ExpiresAt(taken), key)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &taken, true, nil
+}
+
+// ReleaseAttempt takes back one counted attempt of the key
+func (r *Repo) ReleaseAttempt(ctx context.Context, key string) error {
+	const op = "repo.attempt.ReleaseAttempt"
+
+	stmt, err := r.Db.PrepareContext(ctx, "UPDATE auth_attempts SET failures = GREATEST(failures - 1, 0) WHERE key = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) ResetAttempts(ctx context.Context, key string) error {
+	const op = "repo.attempt.ResetAttempts"
+
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM auth_attempts WHERE key = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// prune drops the expired counters of all the keys, the node does it at most once per pruneInterval
+func (r *Repo) prune(ctx context.Context, now time.Time) {
+	r.mu.Lock()
+	if now.Sub(r.prunedAt) < pruneInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.prunedAt = now
+	r.mu.Unlock()
+
+	// The counters are checked for the expiry when they are read, a failed cleanup waits for the next one
+	_, _ = r.Db.ExecContext(ctx, "DELETE FROM auth_attempts WHERE expires_at <= $1", now)
+}
+
+// scanCounter scans the counter columns followed by the extra ones into dest
+func scanCounter(row *sql.Row, dest ...interface{}) (*model.Counter, error) {
+	var (
+		counter     model.Counter
+		lockedUntil sql.NullTime
+	)
+
+	err := row.Scan(append([]interface{}{&counter.Failures, &counter.Lockouts, &lockedUntil, &counter.UpdatedAt}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if lockedUntil.Valid {
+		counter.LockedUntil = lockedUntil.Time
+	}
+
+	return &counter, nil
+}
@@ -0,0 +1,102 @@
+package attempt
+
+import (
+	"context"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
+	"sync"
+	"time"
+)
+
+// pruneInterval is how often MemoryStore drops the expired counters
+const pruneInterval = time.Minute
+
+type memoryCounter struct {
+	counter   model.Counter
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the counters of the single node, they are lost on the restart
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]memoryCounter
+	prunedAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: make(map[string]memoryCounter),
+	}
+}
+
+func (s *MemoryStore) GetAttempts(_ context.Context, key string) (*model.Counter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.counters[key]
+	if !ok || !time.Now().Before(stored.expiresAt) {
+		return &model.Counter{}, nil
+	}
+
+	counter := stored.counter
+
+	return &counter, nil
+}
+
+func (s *MemoryStore) TakeAttempt(_ context.Context, key string, policy model.Policy) (*model.Counter, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.prune(now)
+
+	var current model.Counter
+	if stored, ok := s.counters[key]; ok && now.Before(stored.expiresAt) {
+		current = stored.counter
+	}
+
+	counter, taken := policy.Take(current, now)
+	if taken {
+		s.counters[key] = memoryCounter{counter: counter, expiresAt: policy.ExpiresAt(counter)}
+	}
+
+	return &counter, taken, nil
+}
+
+func (s *MemoryStore) ReleaseAttempt(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.counters[key]
+	if !ok {
+		return nil
+	}
+
+	stored.counter = stored.counter.Release()
+	s.counters[key] = stored
+
+	return nil
+}
+
+func (s *MemoryStore) ResetAttempts(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+
+	return nil
+}
+
+// prune drops the expired counters, it must be called with the lock held
+func (s *MemoryStore) prune(now time.Time) {
+	if now.Sub(s.prunedAt) < pruneInterval {
+		return
+	}
+
+	for key, stored := range s.counters {
+		if !now.Before(stored.expiresAt) {
+			delete(s.counters, key)
+		}
+	}
+
+	s.prunedAt = now
+}
@@ -0,0 +1,115 @@
+package chat
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/gofrs/uuid"
+	guuid "github.com/google/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
+)
+
+// attachmentColumns are the columns of the attachment aliased a and left joined to the message, they are null for the messages without attachment
+const attachmentColumns = "a.id, a.type, a.mime_type, a.name, a.path, a.size, a.width, a.height"
+
+// attachmentRow scans the attachment columns, they are null for the messages without attachment
+type attachmentRow struct {
+	ID       *guuid.UUID
+	Type     *string
+	MimeType *string
+	Name     *string
+	Path     *string
+	Size     *int64
+	Width    *int
+	Height   *int
+}
+
+func (a *attachmentRow) dest() []interface{} {
+	return []interface{}{&a.ID, &a.Type, &a.MimeType, &a.Name, &a.Path, &a.Size, &a.Width, &a.Height}
+}
+
+func (a *attachmentRow) attachment() *message.Attachment {
+	if a.ID == nil {
+		return nil
+	}
+
+	return &message.Attachment{
+		ID:       *a.ID,
+		Type:     message.AttachmentType(*a.Type),
+		MimeType: *a.MimeType,
+		Name:     *a.Name,
+		Path:     *a.Path,
+		Size:     *a.Size,
+		Width:    a.Width,
+		Height:   a.Height,
+	}
+}
+
+// SendAttachmentInChat saves the attachment with the message carrying it
+func (r *Repo) SendAttachmentInChat(ctx context.Context, chatID, senderID, text string, att message.Attachment) (*message.Message, error) {
+	const op = "repo.chat.SendAttachmentInChat"
+
+	attachmentID, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to generate UUID: %w", op, err)
+	}
+
+	messageID, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to generate UUID: %w", op, err)
+	}
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO chat_attachments (id, chat_id, user_id, type, mime_type, name, path, size, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, attachmentID, chatID, senderID, att.Type, att.MimeType, att.Name, att.Path, att.Size, att.Width, att.Height)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert attachment: %w", op, err)
+	}
+
+	var saved message.Message
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO message (id, chat_id, user_id, text, attachment_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, chat_id, user_id, text, media, created_at, updated_at
+	`, messageID, chatID, senderID, text, attachmentID).
+		Scan(&saved.ID, &saved.ChatID, &saved.UserID, &saved.Text, &saved.Media, &saved.CreatedAt, &saved.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert message: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	att.ID = guuid.UUID(attachmentID)
+	saved.Attachment = &att
+
+	return &saved, nil
+}
+
+// GetAttachment returns the attachment of the chat or nil if there is none
+func (r *Repo) GetAttachment(ctx context.Context, chatID, attachmentID string) (*message.Attachment, error) {
+	const op = "repo.chat.GetAttachment"
+
+	var row attachmentRow
+
+	err := r.Db.QueryRowContext(ctx, `
+		SELECT `+attachmentColumns+` FROM chat_attachments a WHERE a.chat_id = $1 AND a.id = $2
+	`, chatID, attachmentID).Scan(row.dest()...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return row.attachment(), nil
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/gofrs/uuid"
+	guuid "github.com/google/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/checkers"
 	"time"
 )
@@ -15,36 +17,90 @@ type Repo struct {
 	Db *sql.DB
 }
 
-func (r *Repo) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Chat, error) {
+// GetChatsByUserID returns the chats of the participant with the last message first
+func (r *Repo) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Overview, error) {
 	const op = "repo.chat.GetChatsByUserID"
 
 	stmt, err := r.Db.PrepareContext(ctx, `
-        SELECT chat_id, stay_owner_id, stay_user_id, operator_id, created_at, updated_at
-        FROM chat WHERE stay_user_id = $1 OR stay_owner_id = $2
+        SELECT c.chat_id, c.stay_owner_id, c.stay_user_id, c.operator_id, c.created_at, c.updated_at,
+               cr.message_id,
+               lm.id, lm.user_id, lm.text, lm.media, lm.created_at, lm.updated_at,
+               (SELECT COUNT(*) FROM message m
+                WHERE m.chat_id = c.chat_id AND m.user_id <> $1::UUID
+                  AND (cr.message_id IS NULL OR (m.created_at, m.id) > (cr.message_created_at, cr.message_id))),
+               `+attachmentColumns+`
+        FROM chat c
+        LEFT JOIN chat_read cr ON cr.chat_id = c.chat_id AND cr.user_id = $1::UUID
+        LEFT JOIN LATERAL (
+            SELECT id, user_id, text, media, created_at, updated_at, attachment_id
+            FROM message WHERE chat_id = c.chat_id
+            ORDER BY created_at DESC, id DESC
+            LIMIT 1
+        ) lm ON TRUE
+        LEFT JOIN chat_attachments a ON a.id = lm.attachment_id
+        WHERE c.stay_user_id = $1::UUID OR c.stay_owner_id = $1::UUID OR c.operator_id = $1::UUID
+        ORDER BY COALESCE(lm.created_at, c.created_at) DESC
     `)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	var chats []*chat.Chat
+	var chats []*chat.Overview
 
-	rows, err := stmt.QueryContext(ctx, userID, userID)
+	rows, err := stmt.QueryContext(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var chat chat.Chat
+		var (
+			overview chat.Overview
+			last     struct {
+				ID        *guuid.UUID
+				UserID    *guuid.UUID
+				Text      *string
+				Media     *string
+				CreatedAt *time.Time
+				UpdatedAt *time.Time
+			}
+			att attachmentRow
+		)
+
+		dest := []interface{}{
+			&overview.ChatID, &overview.StayOwnerID, &overview.StayUserID, &overview.OperatorID, &overview.CreatedAt, &overview.UpdatedAt,
+			&overview.LastReadMessageID,
+			&last.ID, &last.UserID, &last.Text, &last.Media, &last.CreatedAt, &last.UpdatedAt,
+			&overview.UnreadCount,
+		}
 
-		err = rows.Scan(&chat.ChatID, &chat.StayOwnerID, &chat.StayUserID, &chat.OperatorID, &chat.CreatedAt, &chat.UpdatedAt)
+		err = rows.Scan(append(dest, att.dest()...)...)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		chats = append(chats, &chat)
+
+		if last.ID != nil {
+			overview.LastMessage = &message.Message{
+				ID:         *last.ID,
+				ChatID:     overview.ChatID,
+				UserID:     *last.UserID,
+				Text:       *last.Text,
+				Media:      last.Media,
+				Attachment: att.attachment(),
+				CreatedAt:  *last.CreatedAt,
+				UpdatedAt:  *last.UpdatedAt,
+			}
+		}
+
+		chats = append(chats, &overview)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return chats, rows.Err()
+	return chats, nil
 }
 
 func (r *Repo) GetChatByChatID(ctx context.Context, chatID string) (*chat.Chat, error) {
@@ -109,46 +165,62 @@ func (r *Repo) GetOrCreateChatID(ctx context.Context, stayOwnerID, stayUserID st
 	return &chatID, nil
 }
 
-func (r *Repo) GetMessagesByChatID(ctx context.Context, chatID string) ([]*message.Entity, error) {
+func (r *Repo) GetMessagesByChatID(ctx context.Context, chatID string, page api.PageRequest) (api.Page[message.Message], error) {
 	const op = "repo.chat.GetMessagesByChatID"
 
 	exists, err := checkers.CheckChatExists(ctx, r.Db, chatID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[message.Message]{}, fmt.Errorf("%s: %w", op, err)
 	}
 	if !exists {
-		return nil, fmt.Errorf("%s: chat does not exist: %s", op, chatID)
+		return api.Page[message.Message]{}, fmt.Errorf("%s: chat does not exist: %s", op, chatID)
 	}
 
 	stmt, err := r.Db.PrepareContext(ctx, `
-			SELECT user_id, text, media, updated_at 
-			FROM message WHERE chat_id = $1
-			ORDER BY updated_at ASC  
+			SELECT m.id, m.chat_id, m.user_id, m.text, m.media, m.created_at, m.updated_at, `+attachmentColumns+`
+			FROM message m
+			LEFT JOIN chat_attachments a ON a.id = m.attachment_id
+			WHERE m.chat_id = $1
+			  AND ($2::TIMESTAMP IS NULL OR (m.created_at, m.id) > ($2::TIMESTAMP, $3::UUID))
+			ORDER BY m.created_at, m.id
+			LIMIT $4
 	`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[message.Message]{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, chatID)
+	rows, err := stmt.QueryContext(ctx, append([]interface{}{chatID}, page.KeysetArgs()...)...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[message.Message]{}, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
-	var messages []*message.Entity
+	var messages []message.Message
 
 	for rows.Next() {
-		var message message.Entity
+		var (
+			msg message.Message
+			att attachmentRow
+		)
 
-		err = rows.Scan(&message.UserID, &message.Text, &message.Media, &message.UpdatedAt)
+		err = rows.Scan(append([]interface{}{&msg.ID, &msg.ChatID, &msg.UserID, &msg.Text, &msg.Media, &msg.CreatedAt, &msg.UpdatedAt}, att.dest()...)...)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return api.Page[message.Message]{}, fmt.Errorf("%s: %w", op, err)
 		}
 
-		messages = append(messages, &message)
+		msg.Attachment = att.attachment()
+
+		messages = append(messages, msg)
+	}
+
+	if err = rows.Err(); err != nil {
+		return api.Page[message.Message]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return messages, rows.Err()
+	return api.NewPage(messages, page, func(m message.Message) api.Cursor {
+		return api.Cursor{CreatedAt: m.CreatedAt, ID: uuid.UUID(m.ID)}
+	}), nil
 }
 
 func (r *Repo) SendMessage(ctx context.Context, senderId, receiverId string, msg message.Entity) error {
@@ -184,23 +256,112 @@ func (r *Repo) SendMessage(ctx context.Context, senderId, receiverId string, msg
 	return nil
 }
 
-func (r *Repo) SendMessageInChat(ctx context.Context, chatId, senderId string, msg message.Entity) error {
+func (r *Repo) SendMessageInChat(ctx context.Context, chatId, senderId string, msg message.Entity) (*message.Message, error) {
 	const op = "repo.chat.SendMessageInChat"
 
 	messageID, err := uuid.NewV4()
 	if err != nil {
-		return fmt.Errorf("%s: failed to generate UUID: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to generate UUID: %w", op, err)
 	}
 
 	query := `
 		INSERT INTO message (id, chat_id, user_id, text, media)
 		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, chat_id, user_id, text, media, created_at, updated_at
 	`
 
-	_, err = r.Db.ExecContext(ctx, query, messageID, chatId, senderId, msg.Text, msg.Media)
+	var saved message.Message
+
+	err = r.Db.QueryRowContext(ctx, query, messageID, chatId, senderId, msg.Text, msg.Media).
+		Scan(&saved.ID, &saved.ChatID, &saved.UserID, &saved.Text, &saved.Media, &saved.CreatedAt, &saved.UpdatedAt)
 	if err != nil {
-		return fmt.Errorf("%s: failed to insert message: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to insert message: %w", op, err)
 	}
 
-	return nil
+	return &saved, nil
+}
+
+// MarkRead moves the read marker of the participant forward to the message, the marker never moves back.
+// It returns the marker after the update or nil if the message is not in the chat.
+func (r *Repo) MarkRead(ctx context.Context, chatID, userID, messageID string) (*chat.ReadMarker, error) {
+	const op = "repo.chat.MarkRead"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM message WHERE chat_id = $1 AND id = $2)`, chatID, messageID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO chat_read (chat_id, user_id, message_id, message_created_at)
+		SELECT chat_id, $2, id, created_at FROM message WHERE chat_id = $1 AND id = $3
+		ON CONFLICT (chat_id, user_id) DO UPDATE
+		SET message_id = EXCLUDED.message_id, message_created_at = EXCLUDED.message_created_at, read_at = CURRENT_TIMESTAMP
+		WHERE (chat_read.message_created_at, chat_read.message_id) < (EXCLUDED.message_created_at, EXCLUDED.message_id)
+	`, chatID, userID, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var marker chat.ReadMarker
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT chat_id, user_id, message_id, read_at FROM chat_read WHERE chat_id = $1 AND user_id = $2
+	`, chatID, userID).Scan(&marker.ChatID, &marker.UserID, &marker.MessageID, &marker.ReadAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &marker, nil
+}
+
+func (r *Repo) GetReadMarkers(ctx context.Context, chatID string) ([]chat.ReadMarker, error) {
+	const op = "repo.chat.GetReadMarkers"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT chat_id, user_id, message_id, read_at FROM chat_read WHERE chat_id = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var markers []chat.ReadMarker
+
+	for rows.Next() {
+		var marker chat.ReadMarker
+
+		err = rows.Scan(&marker.ChatID, &marker.UserID, &marker.MessageID, &marker.ReadAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		markers = append(markers, marker)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return markers, nil
 }
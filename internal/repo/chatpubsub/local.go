@@ -0,0 +1,36 @@
+package chatpubsub
+
+import (
+	"context"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+	"sync"
+)
+
+// LocalPubSub delivers the events within the process, it serves the single instance
+type LocalPubSub struct {
+	mu       sync.RWMutex
+	handlers []func(event chat.Event)
+}
+
+func NewLocalPubSub() *LocalPubSub {
+	return &LocalPubSub{}
+}
+
+func (p *LocalPubSub) Publish(_ context.Context, event chat.Event) error {
+	p.mu.RLock()
+	handlers := p.handlers
+	p.mu.RUnlock()
+
+	for _, handle := range handlers {
+		handle(event)
+	}
+
+	return nil
+}
+
+func (p *LocalPubSub) Subscribe(handle func(event chat.Event)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers = append(p.handlers, handle)
+}
@@ -0,0 +1,162 @@
+package chatpubsub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+	"github.com/imperatorofdwelling/Full-backend/pkg/logger/slogError"
+	"github.com/lib/pq"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// Channel is the Postgres channel the chat events are notified on
+	Channel = "chat_events"
+
+	// maxPayload is the limit of the NOTIFY payload
+	maxPayload = 7999
+
+	minReconnectInterval = 10 * time.Second
+	maxReconnectInterval = time.Minute
+
+	// queueSize is how many events wait for a subscriber, the events of the subscriber falling behind are dropped
+	queueSize = 256
+)
+
+var ErrPayloadTooLarge = errors.New("chat event is too large to notify")
+
+// PostgresPubSub delivers the events to every instance connected to the database with LISTEN/NOTIFY.
+// The events notified while the listener reconnects are lost, so the subscribers get the resync event
+// once it is back and the clients reload the messages they could miss.
+// Every subscriber reads the events from its own queue, a slow one does not hold the listener or the others.
+type PostgresPubSub struct {
+	Db  *sql.DB
+	Log *slog.Logger
+
+	listener *pq.Listener
+	mu       sync.RWMutex
+	queues   []chan chat.Event
+}
+
+// NewPostgresPubSub starts listening on Channel with its own connection opened by the dsn
+func NewPostgresPubSub(db *sql.DB, dsn string, log *slog.Logger) (*PostgresPubSub, error) {
+	const op = "repo.chatpubsub.NewPostgresPubSub"
+
+	listener := pq.NewListener(dsn, minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Error("chat events listener failed", slog.String("op", op), slogError.Err(err))
+		}
+	})
+
+	err := listener.Listen(Channel)
+	if err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	p := &PostgresPubSub{
+		Db:       db,
+		Log:      log,
+		listener: listener,
+	}
+
+	go p.listen()
+
+	return p, nil
+}
+
+// Publish notifies every instance, the publishing one gets the event back like the others
+func (p *PostgresPubSub) Publish(ctx context.Context, event chat.Event) error {
+	const op = "repo.chatpubsub.Publish"
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(data) > maxPayload {
+		return fmt.Errorf("%s: %w: %d bytes", op, ErrPayloadTooLarge, len(data))
+	}
+
+	_, err = p.Db.ExecContext(ctx, "SELECT pg_notify($1, $2)", Channel, string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Subscribe starts the goroutine handling the events of the subscriber in the order they are notified
+func (p *PostgresPubSub) Subscribe(handle func(event chat.Event)) {
+	queue := make(chan chat.Event, queueSize)
+
+	go func() {
+		for event := range queue {
+			handle(event)
+		}
+	}()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.queues = append(p.queues, queue)
+}
+
+func (p *PostgresPubSub) listen() {
+	const op = "repo.chatpubsub.listen"
+
+	for notification := range p.listener.Notify {
+		// nil tells the connection is re-established
+		if notification == nil {
+			p.Log.Warn("chat events listener reconnected, the events meanwhile are lost", slog.String("op", op))
+			p.resync()
+			continue
+		}
+
+		var event chat.Event
+
+		err := json.Unmarshal([]byte(notification.Extra), &event)
+		if err != nil {
+			p.Log.Error("failed to decode chat event", slog.String("op", op), slogError.Err(err))
+			continue
+		}
+
+		p.dispatch(event)
+	}
+}
+
+// resync tells the subscribers the events could be lost
+func (p *PostgresPubSub) resync() {
+	const op = "repo.chatpubsub.resync"
+
+	payload, err := json.Marshal(chat.ResyncFrame())
+	if err != nil {
+		p.Log.Error("failed to encode resync frame", slog.String("op", op), slogError.Err(err))
+		return
+	}
+
+	p.dispatch(chat.Event{Resync: true, Payload: payload})
+}
+
+// dispatch queues the event for every subscriber without waiting for them
+func (p *PostgresPubSub) dispatch(event chat.Event) {
+	const op = "repo.chatpubsub.dispatch"
+
+	p.mu.RLock()
+	queues := p.queues
+	p.mu.RUnlock()
+
+	for _, queue := range queues {
+		select {
+		case queue <- event:
+		default:
+			p.Log.Warn("chat events subscriber is behind, the event is dropped",
+				slog.String("op", op), slog.String("chat_id", event.ChatID))
+		}
+	}
+}
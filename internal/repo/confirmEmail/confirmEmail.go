@@ -458,3 +458,57 @@ func (r *Repo) ResetEmailChangeOTP(ctx context.Context, userID string) error {
 
 	return nil
 }
+
+// ExpireEmailOTP invalidates the OTP of the user, a new one is to be requested
+func (r *Repo) ExpireEmailOTP(ctx context.Context, userID string) error {
+	const op = "repo.confirmEmail.ExpireEmailOTP"
+
+	stmt, err := r.DB.PrepareContext(ctx, "UPDATE email_verifications SET expires_at = $1 WHERE user_id = $2")
+	if err != nil {
+		return fmt.Errorf("%s: failed to prepare query: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, time.Now().UTC(), userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to execute update: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExpirePasswordOTP invalidates the password reset OTP of the email, a new one is to be requested
+func (r *Repo) ExpirePasswordOTP(ctx context.Context, email string) error {
+	const op = "repo.confirmEmail.ExpirePasswordOTP"
+
+	stmt, err := r.DB.PrepareContext(ctx, "UPDATE password_verifications SET expires_at = $1, is_verified = false WHERE email = $2")
+	if err != nil {
+		return fmt.Errorf("%s: failed to prepare query: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, time.Now().UTC(), email)
+	if err != nil {
+		return fmt.Errorf("%s: failed to execute update: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExpireEmailChangeOTP invalidates the email change OTP of the user, a new one is to be requested
+func (r *Repo) ExpireEmailChangeOTP(ctx context.Context, userID string) error {
+	const op = "repo.confirmEmail.ExpireEmailChangeOTP"
+
+	stmt, err := r.DB.PrepareContext(ctx, "UPDATE email_change_verifications SET expires_at = $1, is_verified = false WHERE user_id = $2")
+	if err != nil {
+		return fmt.Errorf("%s: failed to prepare query: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, time.Now().UTC(), userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to execute update: %w", op, err)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/contracts"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
 	models "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
 	"github.com/imperatorofdwelling/Full-backend/pkg/checkers"
 	"time"
@@ -14,7 +15,7 @@ type Repo struct {
 	Db *sql.DB
 }
 
-func (r *Repo) AddContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time) error {
+func (r *Repo) AddContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time, price money.Money) error {
 	const op = "repo.Contracts.AddContract"
 
 	// Checking stay for existence
@@ -42,8 +43,8 @@ func (r *Repo) AddContract(ctx context.Context, userId, stayId string, dateStart
 		return fmt.Errorf("%s: start date and end date must be in the future", op)
 	}
 
-	// Preparing stay query to get price
-	selectStmt, err := r.Db.PrepareContext(ctx, "SELECT price, square, street, house, entrance, floor, room FROM stays WHERE id = $1")
+	// Preparing stay query to get the address
+	selectStmt, err := r.Db.PrepareContext(ctx, "SELECT address, house, entrance FROM stays WHERE id = $1")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -51,30 +52,22 @@ func (r *Repo) AddContract(ctx context.Context, userId, stayId string, dateStart
 
 	var stay models.Stay
 	err = selectStmt.QueryRowContext(ctx, stayId).Scan(
-		&stay.Price, &stay.Square, &stay.Street, &stay.House,
-		&stay.Entrance, &stay.Floor, &stay.Room,
+		&stay.Address, &stay.House, &stay.Entrance,
 	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Calculate the number of days between dateStart and dateEnd
-	duration := dateEnd.Sub(dateStart)
-	days := int(duration.Hours() / 24)
-
-	// Calculate the total price
-	totalPrice := float32(days) * stay.Price
-
-	// Preparing contract query to insert new contract
-	insertStmt, err := r.Db.PrepareContext(ctx, "INSERT INTO contracts (user_id, stay_id, price, date_start, date_end, square, street, house, entrance, floor, room, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)")
+	// Preparing contract query to insert new contract, stays keep no square, floor and room
+	insertStmt, err := r.Db.PrepareContext(ctx, "INSERT INTO contracts (user_id, stay_id, price, currency, date_start, date_end, square, street, house, entrance, floor, room, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, 0, $7, $8, $9, NULL, NULL, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	defer insertStmt.Close()
 
 	// Executing contract query
-	_, err = insertStmt.ExecContext(ctx, userId, stayId, totalPrice, dateStart, dateEnd,
-		stay.Square, stay.Street, stay.House, stay.Entrance, stay.Floor, stay.Room,
+	_, err = insertStmt.ExecContext(ctx, userId, stayId, price.Amount, price.Currency, dateStart, dateEnd,
+		stay.Address, stay.House, stay.Entrance,
 	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -83,7 +76,7 @@ func (r *Repo) AddContract(ctx context.Context, userId, stayId string, dateStart
 	return nil
 }
 
-func (r *Repo) UpdateContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time) (*contracts.ContractEntity, error) {
+func (r *Repo) UpdateContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time, price money.Money) (*contracts.ContractEntity, error) {
 	const op = "repo.Contracts.UpdateContract"
 
 	// Checking stay for existence
@@ -106,49 +99,28 @@ func (r *Repo) UpdateContract(ctx context.Context, userId, stayId string, dateSt
 		return nil, fmt.Errorf("%s: end date must be after start date", op)
 	}
 
-	// Preparing query to get the current price for the stay
-	var price float64
-	priceStmt, err := r.Db.PrepareContext(ctx, "SELECT price FROM stays WHERE id = $1")
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-	defer priceStmt.Close()
-
-	// Executing price query
-	err = priceStmt.QueryRowContext(ctx, stayId).Scan(&price)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-
-	// Calculate the number of days between dateStart and dateEnd
-	duration := dateEnd.Sub(dateStart)
-	days := int(duration.Hours() / 24)
-
-	// Calculate the total price
-	totalPrice := float64(days) * price
-
 	// Preparing query for updating the contract
-	updateStmt, err := r.Db.PrepareContext(ctx, "UPDATE contracts SET price = $1, date_start = $2, date_end = $3, updated_at = CURRENT_TIMESTAMP WHERE user_id = $4 AND stay_id = $5")
+	updateStmt, err := r.Db.PrepareContext(ctx, "UPDATE contracts SET price = $1, currency = $2, date_start = $3, date_end = $4, updated_at = CURRENT_TIMESTAMP WHERE user_id = $5 AND stay_id = $6")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer updateStmt.Close()
 
 	// Executing update query
-	_, err = updateStmt.ExecContext(ctx, totalPrice, dateStart, dateEnd, userId, stayId)
+	_, err = updateStmt.ExecContext(ctx, price.Amount, price.Currency, dateStart, dateEnd, userId, stayId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Retrieving the updated contract
 	var updatedContract contracts.ContractEntity
-	selectStmt, err := r.Db.PrepareContext(ctx, "SELECT user_id, stay_id, price, date_start, date_end FROM contracts WHERE user_id = $1 AND stay_id = $2")
+	selectStmt, err := r.Db.PrepareContext(ctx, "SELECT user_id, stay_id, price, currency, date_start, date_end FROM contracts WHERE user_id = $1 AND stay_id = $2")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer selectStmt.Close()
 
-	err = selectStmt.QueryRowContext(ctx, userId, stayId).Scan(&updatedContract.UserName, &updatedContract.StayName, &updatedContract.Price, &updatedContract.DateStart, &updatedContract.DateEnd)
+	err = selectStmt.QueryRowContext(ctx, userId, stayId).Scan(&updatedContract.UserName, &updatedContract.StayName, &updatedContract.Price, &updatedContract.Currency, &updatedContract.DateStart, &updatedContract.DateEnd)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -160,7 +132,7 @@ func (r *Repo) GetAllContracts(ctx context.Context, userId string) ([]contracts.
 	const op = "repo.Contracts.GetContractEntities"
 
 	// Preparing query
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT u.name AS user_name, s.name AS stay_name, c.price, c.date_start, c.date_end FROM contracts c INNER JOIN users u ON c.user_id = u.id INNER JOIN stays s ON c.stay_id = s.id WHERE c.user_id = $1")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT u.name AS user_name, s.name AS stay_name, c.price, c.currency, c.date_start, c.date_end FROM contracts c INNER JOIN users u ON c.user_id = u.id INNER JOIN stays s ON c.stay_id = s.id WHERE c.user_id = $1")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -178,7 +150,7 @@ func (r *Repo) GetAllContracts(ctx context.Context, userId string) ([]contracts.
 	// Getting results
 	for rows.Next() {
 		var entity contracts.ContractEntity
-		err := rows.Scan(&entity.UserName, &entity.StayName, &entity.Price, &entity.DateStart, &entity.DateEnd)
+		err := rows.Scan(&entity.UserName, &entity.StayName, &entity.Price, &entity.Currency, &entity.DateStart, &entity.DateEnd)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -0,0 +1,117 @@
+package currency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/lib/pq"
+)
+
+// foreignKeyViolation is the postgres error code of a deleted row still referenced by another table
+const foreignKeyViolation = "23503"
+
+type Repo struct {
+	Db *sql.DB
+}
+
+func (r *Repo) GetRates(ctx context.Context) ([]money.Rate, error) {
+	const op = "repo.currency.GetRates"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT currency, rate, created_at, updated_at FROM currency_rates ORDER BY currency")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rates []money.Rate
+
+	for rows.Next() {
+		var rate money.Rate
+
+		err = rows.Scan(&rate.Currency, &rate.Rate, &rate.CreatedAt, &rate.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rates, nil
+}
+
+// GetRate returns nil without an error when the currency has no rate
+func (r *Repo) GetRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	const op = "repo.currency.GetRate"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT currency, rate, created_at, updated_at FROM currency_rates WHERE currency = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var rate money.Rate
+
+	err = stmt.QueryRowContext(ctx, currency).Scan(&rate.Currency, &rate.Rate, &rate.CreatedAt, &rate.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &rate, nil
+}
+
+func (r *Repo) SetRate(ctx context.Context, currency money.Currency, rate float64) error {
+	const op = "repo.currency.SetRate"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO currency_rates (currency, rate) VALUES ($1, $2)
+		ON CONFLICT (currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, currency, rate)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteRate(ctx context.Context, currency money.Currency) error {
+	const op = "repo.currency.DeleteRate"
+
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM currency_rates WHERE currency = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, currency)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w: %s", op, service.ErrCurrencyInUse, currency)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	stays2 "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/imperatorofdwelling/Full-backend/pkg/checkers"
 )
 
@@ -64,7 +65,7 @@ func (r *Repo) RemoveFavourite(ctx context.Context, userId, stayID string) error
 	return nil
 }
 
-func (r *Repo) GetAllFavourites(ctx context.Context, userID string) ([]stays2.StayEntityFav, error) {
+func (r *Repo) GetAllFavourites(ctx context.Context, userID string, page api.PageRequest) (api.Page[stays2.StayEntityFav], error) {
 	const op = "repo.Favourite.GetAllFavouriteStays"
 
 	stmt, err := r.Db.PrepareContext(ctx, `
@@ -74,33 +75,34 @@ func (r *Repo) GetAllFavourites(ctx context.Context, userID string) ([]stays2.St
 		    s.location_id,
 		    s.name,
 		    s.type,
-		    s.number_of_bedrooms,
-		    s.number_of_beds,
-		    s.number_of_bathrooms,
 		    s.guests,
-		    s.is_smoking_prohibited,
-		    s.square,
-		    s.street,
+		    s.rating,
 		    s.house,
 		    s.entrance,
-		    s.floor,
-		    s.room,
+		    s.address,
+		    s.rooms_count,
+		    s.beds_count,
 		    s.price,
-		    l.city
+		    s.period,
+		    s.created_at,
+		    s.updated_at,
+		    f.created_at
 		FROM favourite f
 		JOIN stays s ON f.stay_id = s.id
-		JOIN locations l ON s.location_id = l.id
 		WHERE f.user_id = $1
+		  AND ($2::TIMESTAMP IS NULL OR (f.created_at, f.stay_id) > ($2::TIMESTAMP, $3::UUID))
+		ORDER BY f.created_at, f.stay_id
+		LIMIT $4
 	`)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: preparing statement: %w", op, err)
+		return api.Page[stays2.StayEntityFav]{}, fmt.Errorf("%s: preparing statement: %w", op, err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, userID)
+	rows, err := stmt.QueryContext(ctx, append([]interface{}{userID}, page.KeysetArgs()...)...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: querying favourite stays: %w", op, err)
+		return api.Page[stays2.StayEntityFav]{}, fmt.Errorf("%s: querying favourite stays: %w", op, err)
 	}
 	defer rows.Close()
 
@@ -114,28 +116,30 @@ func (r *Repo) GetAllFavourites(ctx context.Context, userID string) ([]stays2.St
 			&stay.LocationID,
 			&stay.Name,
 			&stay.Type,
-			&stay.NumberOfBedrooms,
-			&stay.NumberOfBeds,
-			&stay.NumberOfBathrooms,
 			&stay.Guests,
-			&stay.IsSmokingProhibited,
-			&stay.Square,
-			&stay.Street,
+			&stay.Rating,
 			&stay.House,
 			&stay.Entrance,
-			&stay.Floor,
-			&stay.Room,
+			&stay.Address,
+			&stay.RoomsCount,
+			&stay.BedsCount,
 			&stay.Price,
-			&stay.City,
+			&stay.Period,
+			&stay.CreatedAt,
+			&stay.UpdatedAt,
+			&stay.AddedAt,
 		); err != nil {
-			return nil, fmt.Errorf("%s: scanning row: %w", op, err)
+			return api.Page[stays2.StayEntityFav]{}, fmt.Errorf("%s: scanning row: %w", op, err)
 		}
 		stays = append(stays, stay)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: rows error: %w", op, err)
+		return api.Page[stays2.StayEntityFav]{}, fmt.Errorf("%s: rows error: %w", op, err)
 	}
 
-	return stays, nil
+	// favourite has no id of its own, the stay id is unique within the user's list
+	return api.NewPage(stays, page, func(s stays2.StayEntityFav) api.Cursor {
+		return api.Cursor{CreatedAt: s.AddedAt, ID: s.ID}
+	}), nil
 }
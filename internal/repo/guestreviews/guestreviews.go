@@ -0,0 +1,150 @@
+package guestreviews
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"time"
+)
+
+const guestReviewColumns = `id, reservation_id, stay_id, host_id, guest_id, rating, description, created_at, updated_at`
+
+type Repo struct {
+	Db *sql.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanGuestReview(row rowScanner, review *guestreviews.GuestReview) error {
+	return row.Scan(&review.ID, &review.ReservationID, &review.StayID, &review.HostID, &review.GuestID,
+		&review.Rating, &review.Description, &review.CreatedAt, &review.UpdatedAt)
+}
+
+// CreateGuestReview inserts the review, it fails with service.ErrGuestReviewExists if the reservation is already reviewed
+func (r *Repo) CreateGuestReview(ctx context.Context, review *guestreviews.Entity) (uuid.UUID, error) {
+	const op = "repo.guestreviews.CreateGuestReview"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO guest_reviews (reservation_id, stay_id, host_id, guest_id, rating, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (reservation_id) DO NOTHING
+		RETURNING id
+	`)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var id uuid.UUID
+
+	err = stmt.QueryRowContext(ctx, review.ReservationID, review.StayID, review.HostID, review.GuestID, review.Rating, review.Description, time.Now(), time.Now()).
+		Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, service.ErrGuestReviewExists)
+		}
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repo) GetGuestReviewByID(ctx context.Context, id uuid.UUID) (*guestreviews.GuestReview, error) {
+	const op = "repo.guestreviews.GetGuestReviewByID"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+guestReviewColumns+" FROM guest_reviews WHERE id = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var review guestreviews.GuestReview
+
+	err = scanGuestReview(stmt.QueryRowContext(ctx, id), &review)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrGuestReviewNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &review, nil
+}
+
+// GetGuestReviewsByGuestID returns the page of the public reviews of the guest
+func (r *Repo) GetGuestReviewsByGuestID(ctx context.Context, guestID uuid.UUID, page api.PageRequest) (api.Page[guestreviews.GuestReview], error) {
+	const op = "repo.guestreviews.GetGuestReviewsByGuestID"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+guestReviewColumns+`
+		FROM guest_reviews
+		WHERE guest_id = $4
+		  AND `+reservation.RevealedSQL("guest_reviews.reservation_id")+`
+		  AND ($1::TIMESTAMP IS NULL OR (created_at, id) > ($1::TIMESTAMP, $2::UUID))
+		ORDER BY created_at, id
+		LIMIT $3
+	`)
+	if err != nil {
+		return api.Page[guestreviews.GuestReview]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, append(page.KeysetArgs(), guestID)...)
+	if err != nil {
+		return api.Page[guestreviews.GuestReview]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reviews []guestreviews.GuestReview
+
+	for rows.Next() {
+		var review guestreviews.GuestReview
+
+		err = scanGuestReview(rows, &review)
+		if err != nil {
+			return api.Page[guestreviews.GuestReview]{}, fmt.Errorf("%s: %w", op, err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return api.Page[guestreviews.GuestReview]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return api.NewPage(reviews, page, func(gr guestreviews.GuestReview) api.Cursor {
+		return api.Cursor{CreatedAt: gr.CreatedAt, ID: gr.ID}
+	}), nil
+}
+
+// GetGuestSummary averages the public reviews of the guest
+func (r *Repo) GetGuestSummary(ctx context.Context, guestID uuid.UUID) (*guestreviews.Summary, error) {
+	const op = "repo.guestreviews.GetGuestSummary"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT COALESCE(AVG(rating), 0), COUNT(*)
+		FROM guest_reviews
+		WHERE guest_id = $1
+		  AND `+reservation.RevealedSQL("guest_reviews.reservation_id")+`
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var summary guestreviews.Summary
+
+	err = stmt.QueryRowContext(ctx, guestID).Scan(&summary.Rating, &summary.ReviewsCount)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &summary, nil
+}
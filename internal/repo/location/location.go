@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/gofrs/uuid"
 	models "github.com/imperatorofdwelling/Full-backend/internal/domain/models/location"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 )
 
 type Repo struct {
@@ -104,22 +105,30 @@ func (r *Repo) GetByID(ctx context.Context, id uuid.UUID) (*models.Location, err
 	return &location, nil
 }
 
-func (r *Repo) GetAll(ctx context.Context) (*[]models.Location, error) {
+func (r *Repo) GetAll(ctx context.Context, page api.PageRequest) (api.Page[models.Location], error) {
 	const op = "repo.location.GetAll"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM locations")
+	stmt, err := r.Db.PrepareContext(ctx, `
+        SELECT id, city, federal_district, fias_id, kladr_id, lat, lon, okato, oktmo,
+               population, region_iso_code, region_name, created_at, updated_at
+        FROM locations
+        WHERE $1::TIMESTAMP IS NULL OR (created_at, id) > ($1::TIMESTAMP, $2::UUID)
+        ORDER BY created_at, id
+        LIMIT $3
+    `)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[models.Location]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	defer stmt.Close()
 
 	var locations []models.Location
 
-	rows, err := stmt.QueryContext(ctx)
+	rows, err := stmt.QueryContext(ctx, page.KeysetArgs()...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[models.Location]{}, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var loc models.Location
@@ -140,17 +149,19 @@ func (r *Repo) GetAll(ctx context.Context) (*[]models.Location, error) {
 			&loc.CreatedAt,
 			&loc.UpdatedAt)
 		if err != nil {
-			return nil, err
+			return api.Page[models.Location]{}, fmt.Errorf("%s: %w", op, err)
 		}
 
 		locations = append(locations, loc)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return api.Page[models.Location]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &locations, nil
+	return api.NewPage(locations, page, func(l models.Location) api.Cursor {
+		return api.Cursor{CreatedAt: l.CreatedAt, ID: l.ID}
+	}), nil
 }
 
 func (r *Repo) DeleteByID(ctx context.Context, id uuid.UUID) error {
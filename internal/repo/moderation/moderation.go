@@ -0,0 +1,286 @@
+package moderation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/moderation"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"time"
+)
+
+// reportsSQL puts the stay and the user reports together, the reported user of a stay report is the stay owner
+const reportsSQL = `(
+	SELECT 'stay' AS type, sr.id, sr.user_id AS reporter_id, sr.stay_id, s.user_id AS reported_user_id,
+		sr.title, sr.description, sr.report_attach, sr.status, sr.assignee_id, sr.action, sr.resolution_comment,
+		sr.resolved_at, COALESCE(sr.created_at, CURRENT_TIMESTAMP) AS created_at,
+		COALESCE(sr.updated_at, sr.created_at, CURRENT_TIMESTAMP) AS updated_at
+	FROM stays_reports sr
+	INNER JOIN stays s ON s.id = sr.stay_id
+	UNION ALL
+	SELECT 'user', ur.id, ur.user_id, NULL::UUID, ur.owner_id,
+		ur.title, ur.description, ur.report_attach, ur.status, ur.assignee_id, ur.action, ur.resolution_comment,
+		ur.resolved_at, COALESCE(ur.created_at, CURRENT_TIMESTAMP),
+		COALESCE(ur.updated_at, ur.created_at, CURRENT_TIMESTAMP)
+	FROM users_reports ur
+) reports
+INNER JOIN users reporter ON reporter.id = reports.reporter_id
+INNER JOIN users reported ON reported.id = reports.reported_user_id`
+
+// reportColumns fixes the column order expected by scanReport
+const reportColumns = `reports.type, reports.id, reports.reporter_id, reports.stay_id, reports.reported_user_id,
+	reports.title, reports.description, reports.report_attach, reports.status, reports.assignee_id, reports.action,
+	reports.resolution_comment, reports.resolved_at, reports.created_at, reports.updated_at,
+	reporter.email, reported.email`
+
+type Repo struct {
+	Db *sql.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReport(row rowScanner, report *moderation.Report) error {
+	var resolvedAt sql.NullTime
+
+	err := row.Scan(&report.Type, &report.ID, &report.ReporterID, &report.StayID, &report.ReportedUserID,
+		&report.Title, &report.Description, &report.ReportAttach, &report.Status, &report.AssigneeID, &report.Action,
+		&report.ResolutionComment, &resolvedAt, &report.CreatedAt, &report.UpdatedAt,
+		&report.ReporterEmail, &report.ReportedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	if resolvedAt.Valid {
+		report.ResolvedAt = &resolvedAt.Time
+	}
+
+	return nil
+}
+
+// reportsTable is the table of the reports of the type
+func reportsTable(reportType moderation.Type) string {
+	if reportType == moderation.TypeStay {
+		return "stays_reports"
+	}
+	return "users_reports"
+}
+
+// GetReports returns the page of the moderation queue, the oldest reports first
+func (r *Repo) GetReports(ctx context.Context, filter moderation.Filter, page api.PageRequest) (api.Page[moderation.Report], error) {
+	const op = "repo.moderation.GetReports"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+reportColumns+`
+		FROM `+reportsSQL+`
+		WHERE ($4::TEXT = '' OR reports.status = $4::TEXT)
+		  AND ($5::TEXT = '' OR reports.type = $5::TEXT)
+		  AND ($6::UUID IS NULL OR reports.assignee_id = $6::UUID)
+		  AND ($1::TIMESTAMP IS NULL OR (reports.created_at, reports.id) > ($1::TIMESTAMP, $2::UUID))
+		ORDER BY reports.created_at, reports.id
+		LIMIT $3
+	`)
+	if err != nil {
+		return api.Page[moderation.Report]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, append(page.KeysetArgs(), filter.Status, filter.Type, filter.AssigneeID)...)
+	if err != nil {
+		return api.Page[moderation.Report]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reports []moderation.Report
+
+	for rows.Next() {
+		var report moderation.Report
+
+		err = scanReport(rows, &report)
+		if err != nil {
+			return api.Page[moderation.Report]{}, fmt.Errorf("%s: %w", op, err)
+		}
+		reports = append(reports, report)
+	}
+
+	if err = rows.Err(); err != nil {
+		return api.Page[moderation.Report]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return api.NewPage(reports, page, func(rp moderation.Report) api.Cursor {
+		return api.Cursor{CreatedAt: rp.CreatedAt, ID: rp.ID}
+	}), nil
+}
+
+func (r *Repo) GetReport(ctx context.Context, reportType moderation.Type, id uuid.UUID) (*moderation.Report, error) {
+	const op = "repo.moderation.GetReport"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+reportColumns+" FROM "+reportsSQL+" WHERE reports.type = $1 AND reports.id = $2")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var report moderation.Report
+
+	err = scanReport(stmt.QueryRowContext(ctx, reportType, id), &report)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrReportNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &report, nil
+}
+
+// AssignReport gives the report to the moderator, an open report goes in review
+func (r *Repo) AssignReport(ctx context.Context, reportType moderation.Type, id, assigneeID uuid.UUID) error {
+	const op = "repo.moderation.AssignReport"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE `+reportsTable(reportType)+`
+		SET assignee_id = $1, status = $2, updated_at = $3
+		WHERE id = $4 AND status IN ($5, $2)
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, assigneeID, moderation.StatusInReview, time.Now(), id, moderation.StatusOpen)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrReportClosed)
+	}
+
+	return nil
+}
+
+func (r *Repo) CreateNote(ctx context.Context, reportType moderation.Type, id, authorID uuid.UUID, text string) (*moderation.Note, error) {
+	const op = "repo.moderation.CreateNote"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO reports_notes (report_type, report_id, author_id, text, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, author_id, text, created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var note moderation.Note
+
+	err = stmt.QueryRowContext(ctx, reportType, id, authorID, text, time.Now()).
+		Scan(&note.ID, &note.AuthorID, &note.Text, &note.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &note, nil
+}
+
+func (r *Repo) GetNotes(ctx context.Context, reportType moderation.Type, id uuid.UUID) ([]moderation.Note, error) {
+	const op = "repo.moderation.GetNotes"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT id, author_id, text, created_at
+		FROM reports_notes
+		WHERE report_type = $1 AND report_id = $2
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, reportType, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	notes := []moderation.Note{}
+
+	for rows.Next() {
+		var note moderation.Note
+
+		err = rows.Scan(&note.ID, &note.AuthorID, &note.Text, &note.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notes, nil
+}
+
+// ResolveReport closes the report and unpublishes the reported stay in one transaction.
+// The moderator is assigned to the report nobody took. The suspension of the user is up to the user service.
+func (r *Repo) ResolveReport(ctx context.Context, report *moderation.Report, resolution *moderation.ResolutionEntity, moderatorID uuid.UUID) error {
+	const op = "repo.moderation.ResolveReport"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE `+reportsTable(report.Type)+`
+		SET status = $1, action = $2, resolution_comment = $3, resolved_at = $4, updated_at = $4,
+			assignee_id = COALESCE(assignee_id, $5)
+		WHERE id = $6 AND status IN ($7, $8)
+	`, resolution.Status, resolution.Action, resolution.Comment, now, moderatorID, report.ID,
+		moderation.StatusOpen, moderation.StatusInReview,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrReportClosed)
+	}
+
+	if resolution.Action != nil && *resolution.Action == moderation.ActionUnpublishStay {
+		// the host fixes the unpublished stay and submits it for review again
+		_, err = tx.ExecContext(ctx, "UPDATE stays SET status = $1, review_comment = $2, updated_at = $3 WHERE id = $4",
+			stays.StatusDraft, resolution.Comment, now, report.StayID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/payment"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"time"
+)
+
+type Repo struct {
+	Db *sql.DB
+}
+
+// CreatePayment inserts the payment unless its idempotence key is already taken or the reservation
+// has a payment that isn't canceled, then it fails with service.ErrPaymentExists
+func (r *Repo) CreatePayment(ctx context.Context, pay *payment.Payment) error {
+	const op = "repo.payment.CreatePayment"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO payments (idempotence_key, reservation_id, user_id, status, amount, currency, request, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at, updated_at
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+
+	err = stmt.QueryRowContext(ctx, pay.IdempotenceKey, pay.ReservationID, pay.UserID, pay.Status, pay.Amount.Amount, pay.Amount.Currency, []byte(pay.Request), now, now).
+		Scan(&pay.ID, &pay.CreatedAt, &pay.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, service.ErrPaymentExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetPaymentByKey(ctx context.Context, key string) (*payment.Payment, error) {
+	const op = "repo.payment.GetPaymentByKey"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT id, idempotence_key, reservation_id, user_id, COALESCE(provider_payment_id, ''), status, amount, currency, request, response, created_at, updated_at
+		FROM payments
+		WHERE idempotence_key = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var (
+		pay                   payment.Payment
+		reservationID, userID uuid.NullUUID
+		request, response     []byte
+	)
+
+	err = stmt.QueryRowContext(ctx, key).Scan(&pay.ID, &pay.IdempotenceKey, &reservationID, &userID, &pay.ProviderPaymentID, &pay.Status,
+		&pay.Amount.Amount, &pay.Amount.Currency, &request, &response, &pay.CreatedAt, &pay.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrPaymentNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reservationID.Valid {
+		pay.ReservationID = &reservationID.UUID
+	}
+	if userID.Valid {
+		pay.UserID = &userID.UUID
+	}
+
+	pay.Request, pay.Response = request, response
+
+	return &pay, nil
+}
+
+// UpdatePaymentStatus stores the provider response, the payments in a final status are never changed
+func (r *Repo) UpdatePaymentStatus(ctx context.Context, key string, providerID string, status payment.Status, response []byte) error {
+	const op = "repo.payment.UpdatePaymentStatus"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE payments
+		SET provider_payment_id = NULLIF($1, ''), status = $2, response = $3, updated_at = $4
+		WHERE idempotence_key = $5
+		  AND status NOT IN ('succeeded', 'canceled')
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, providerID, status, response, time.Now(), key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) DeletePaymentByKey(ctx context.Context, key string) error {
+	const op = "repo.payment.DeletePaymentByKey"
+
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM payments WHERE idempotence_key = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
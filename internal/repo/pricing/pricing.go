@@ -0,0 +1,233 @@
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+	"time"
+)
+
+type Repo struct {
+	Db *sql.DB
+}
+
+// GetBase returns nil without an error when the stay does not exist
+func (r *Repo) GetBase(ctx context.Context, stayID uuid.UUID) (*pricing.Base, error) {
+	const op = "repo.pricing.GetBase"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT id, user_id, price, currency, guests FROM stays WHERE id = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var base pricing.Base
+
+	err = stmt.QueryRowContext(ctx, stayID).Scan(&base.StayID, &base.UserID, &base.Price.Amount, &base.Price.Currency, &base.Guests)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &base, nil
+}
+
+// GetRules returns the default rules when the owner has not set any, seasons are not loaded
+func (r *Repo) GetRules(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error) {
+	const op = "repo.pricing.GetRules"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT weekend_surcharge_percent, weekly_discount_percent, monthly_discount_percent,
+		       cleaning_fee, extra_guest_fee, included_guests, updated_at
+		FROM stays_pricing
+		WHERE stay_id = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rules := pricing.DefaultRules(stayID)
+
+	err = stmt.QueryRowContext(ctx, stayID).Scan(
+		&rules.WeekendSurchargePercent, &rules.WeeklyDiscountPercent, &rules.MonthlyDiscountPercent,
+		&rules.CleaningFee, &rules.ExtraGuestFee, &rules.IncludedGuests, &rules.UpdatedAt,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &rules, nil
+}
+
+func (r *Repo) UpsertRules(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity) error {
+	const op = "repo.pricing.UpsertRules"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO stays_pricing (stay_id, weekend_surcharge_percent, weekly_discount_percent, monthly_discount_percent,
+		                           cleaning_fee, extra_guest_fee, included_guests)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (stay_id) DO UPDATE SET
+			weekend_surcharge_percent = EXCLUDED.weekend_surcharge_percent,
+			weekly_discount_percent = EXCLUDED.weekly_discount_percent,
+			monthly_discount_percent = EXCLUDED.monthly_discount_percent,
+			cleaning_fee = EXCLUDED.cleaning_fee,
+			extra_guest_fee = EXCLUDED.extra_guest_fee,
+			included_guests = EXCLUDED.included_guests,
+			updated_at = CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, stayID,
+		rules.WeekendSurchargePercent, rules.WeeklyDiscountPercent, rules.MonthlyDiscountPercent,
+		rules.CleaningFee, rules.ExtraGuestFee, rules.IncludedGuests,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetSeasons returns the seasons of the stay overlapping [from, to), zero times mean all of them
+func (r *Repo) GetSeasons(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]pricing.Season, error) {
+	const op = "repo.pricing.GetSeasons"
+
+	var fromArg, toArg interface{}
+	if !from.IsZero() && !to.IsZero() {
+		fromArg, toArg = from, to
+	}
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT id, stay_id, name, date_start, date_end, price, created_at, updated_at
+		FROM stays_seasonal_prices
+		WHERE stay_id = $1
+		  AND ($2::DATE IS NULL OR (date_start, date_end) OVERLAPS ($2::DATE, $3::DATE))
+		ORDER BY date_start
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, stayID, fromArg, toArg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	seasons := []pricing.Season{}
+
+	for rows.Next() {
+		var season pricing.Season
+
+		err = rows.Scan(&season.ID, &season.StayID, &season.Name, &season.DateStart, &season.DateEnd, &season.Price, &season.CreatedAt, &season.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		seasons = append(seasons, season)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return seasons, nil
+}
+
+// GetSeasonByID returns nil without an error when the season does not exist
+func (r *Repo) GetSeasonByID(ctx context.Context, id uuid.UUID) (*pricing.Season, error) {
+	const op = "repo.pricing.GetSeasonByID"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT id, stay_id, name, date_start, date_end, price, created_at, updated_at
+		FROM stays_seasonal_prices
+		WHERE id = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var season pricing.Season
+
+	err = stmt.QueryRowContext(ctx, id).Scan(&season.ID, &season.StayID, &season.Name, &season.DateStart, &season.DateEnd, &season.Price, &season.CreatedAt, &season.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &season, nil
+}
+
+func (r *Repo) CheckSeasonOverlaps(ctx context.Context, stayID uuid.UUID, from, to time.Time) (bool, error) {
+	const op = "repo.pricing.CheckSeasonOverlaps"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM stays_seasonal_prices
+			WHERE stay_id = $1 AND (date_start, date_end) OVERLAPS ($2::DATE, $3::DATE)
+		)
+	`)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var overlaps bool
+
+	err = stmt.QueryRowContext(ctx, stayID, from, to).Scan(&overlaps)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return overlaps, nil
+}
+
+func (r *Repo) CreateSeason(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity) error {
+	const op = "repo.pricing.CreateSeason"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO stays_seasonal_prices (stay_id, name, date_start, date_end, price)
+		VALUES ($1, $2, $3, $4, $5)
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, stayID, season.Name, season.DateStart, season.DateEnd, season.Price)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteSeason(ctx context.Context, id uuid.UUID) error {
+	const op = "repo.pricing.DeleteSeason"
+
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM stays_seasonal_prices WHERE id = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/cancellation"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
-	"github.com/imperatorofdwelling/Full-backend/pkg/checkers"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"time"
 )
@@ -18,54 +21,41 @@ type Repo struct {
 	Db *sql.DB
 }
 
-func (r *Repo) ConfirmCheckOutReservation(ctx context.Context, userID string, stayID string) error {
-	const op = "repo.reservation.ConfirmCheckOutReservation"
+// reservationColumns fixes the column order expected by scanReservation
+const reservationColumns = `id, stay_id, user_id, arrived, departure, guests, status, created_at, updated_at,
+	refund_percent, refund_amount, refund_currency, refund_status, review_deadline`
 
-	query := `
-		UPDATE reservations 
-		SET check_out = TRUE 
-		WHERE stay_id = $1 AND user_id = $2;
-	`
-
-	_, err := r.Db.ExecContext(ctx, query, stayID, userID)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	return nil
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
-func (r *Repo) CheckTimeForCheckOutReservation(ctx context.Context, userID string, stayID string) (bool, error) {
-	const op = "repo.reservation.CheckTimeForCheckOutReservation"
-
-	var departure time.Time
-
-	query := `
-       SELECT arrived 
-       FROM reservations 
-       WHERE user_id = $1 AND stay_id = $2 
-       LIMIT 1;
-    `
+func scanReservation(row rowScanner, reserv *reservation.Reservation) error {
+	var (
+		refundPercent                sql.NullFloat64
+		refundAmount                 money.Amount
+		refundCurrency, refundStatus sql.NullString
+		reviewDeadline               sql.NullTime
+	)
 
-	err := r.Db.QueryRowContext(ctx, query, userID, stayID).Scan(&departure)
+	err := row.Scan(&reserv.ID, &reserv.StayID, &reserv.UserID, &reserv.Arrived, &reserv.Departure, &reserv.Guests, &reserv.Status, &reserv.CreatedAt, &reserv.UpdatedAt,
+		&refundPercent, &refundAmount, &refundCurrency, &refundStatus, &reviewDeadline)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return false, fmt.Errorf("%s: reservation not found: %w", op, err)
-		}
-		return false, fmt.Errorf("%s: %w", op, err)
+		return err
 	}
 
-	now := time.Now().Truncate(24 * time.Hour)
-	departureDate := departure.Truncate(24 * time.Hour)
-
-	result := departureDate.After(now)
+	if reviewDeadline.Valid {
+		reserv.ReviewDeadline = &reviewDeadline.Time
+	}
 
-	if result {
-		return true, fmt.Errorf("%s: checkout time not yet reached. Current date: %v, Departure date: %v",
-			op, now, departureDate)
+	if refundStatus.Valid {
+		reserv.Refund = &reservation.Refund{
+			Percent: refundPercent.Float64,
+			Amount:  money.New(refundAmount, money.Currency(refundCurrency.String)),
+			Status:  reservation.RefundStatus(refundStatus.String),
+		}
 	}
 
-	return false, nil
+	return nil
 }
 
 func (r *Repo) CheckIfUserIsOwner(ctx context.Context, userID uuid.UUID, stayID uuid.UUID) (bool, error) {
@@ -86,40 +76,160 @@ func (r *Repo) CheckIfUserIsOwner(ctx context.Context, userID uuid.UUID, stayID
 	return true, nil
 }
 
-func (r *Repo) CheckReservationIsFree(ctx context.Context, reservationObject *reservation.ReservationEntity) error {
+// queryer runs the checks on the database or inside the transaction that locked the stay
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// lockStay locks the stay row until the transaction ends, the bookings and the blocks of the stay
+// are checked and written under the lock so that the concurrent ones don't take the same dates
+func lockStay(ctx context.Context, tx *sql.Tx, stayID uuid.UUID) error {
+	var id uuid.UUID
+
+	err := tx.QueryRowContext(ctx, "SELECT id FROM stays WHERE id = $1 FOR UPDATE", stayID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return service.ErrStayNotFound
+	}
+
+	return err
+}
+
+// CheckReservationIsFree checks that no other reservation takes the dates, except is the reservation being changed
+func (r *Repo) CheckReservationIsFree(ctx context.Context, reservationObject *reservation.ReservationEntity, except uuid.UUID) error {
 	const op = "repo.reservation.CheckReservationIsFree"
 
+	err := checkIsFree(ctx, r.Db, reservationObject, except)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func checkIsFree(ctx context.Context, q queryer, reservationObject *reservation.ReservationEntity, except uuid.UUID) error {
 	query := `
         SELECT COUNT(*) 
         FROM reservations 
         WHERE stay_id = $1 
+          AND id <> $4
+          AND status NOT IN ('declined', 'cancelled', 'no_show')
           AND (arrived, departure) OVERLAPS ($2, $3)
     `
 
 	var count int
-	err := r.Db.QueryRowContext(ctx, query, reservationObject.StayID, reservationObject.Arrived, reservationObject.Departure).Scan(&count)
+	err := q.QueryRowContext(ctx, query, reservationObject.StayID, reservationObject.Arrived, reservationObject.Departure, except).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return service.ErrAlreadyReservedDate
+	}
+
+	return nil
+}
+
+func (r *Repo) CheckReservationIsNotBlocked(ctx context.Context, reservationObject *reservation.ReservationEntity) error {
+	const op = "repo.reservation.CheckReservationIsNotBlocked"
+
+	err := checkIsNotBlocked(ctx, r.Db, reservationObject)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	return nil
+}
+
+func checkIsNotBlocked(ctx context.Context, q queryer, reservationObject *reservation.ReservationEntity) error {
+	query := `
+        SELECT COUNT(*) 
+        FROM stays_blocked_dates 
+        WHERE stay_id = $1 
+          AND (date_start, date_end) OVERLAPS ($2, $3)
+    `
+
+	var count int
+	err := q.QueryRowContext(ctx, query, reservationObject.StayID, reservationObject.Arrived, reservationObject.Departure).Scan(&count)
+	if err != nil {
+		return err
+	}
+
 	if count > 0 {
-		return fmt.Errorf("%s: %w", op, service.ErrAlreadyReservedDate)
+		return service.ErrDatesBlocked
 	}
 
 	return nil
 }
 
-func (r *Repo) CreateReservation(ctx context.Context, reservation *reservation.ReservationEntity, userID string) error {
+// CreateReservation inserts the reservation with its initial status and logs the status to the history.
+// Only the public stays are booked, it fails with service.ErrStayNotFound for the stay that is not published
+// or whose host is suspended and with service.ErrTooManyGuests when the stay does not accommodate the guests.
+// The dates are checked again under the stay lock, the reservation taking them in the meantime fails it with
+// service.ErrAlreadyReservedDate and the block with service.ErrDatesBlocked.
+func (r *Repo) CreateReservation(ctx context.Context, reserv *reservation.ReservationEntity, userID string, status reservation.Status) error {
 	const op = "repo.reservation.CreateReservation"
 
-	stmt, err := r.Db.PrepareContext(ctx, "INSERT INTO reservations (stay_id, user_id, arrived, departure, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)")
+	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	defer stmt.Close()
+	defer tx.Rollback()
+
+	var capacity int
+
+	err = tx.QueryRowContext(ctx,
+		"SELECT guests FROM stays WHERE id = $1 AND "+stays.PublicSQL("stays")+" FOR UPDATE", reserv.StayID,
+	).Scan(&capacity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Zero is the capacity the owner has not set
+	if capacity > 0 && reserv.Guests > capacity {
+		return fmt.Errorf("%s: %w: %d guests at most", op, service.ErrTooManyGuests, capacity)
+	}
 
-	_, err = stmt.ExecContext(ctx, reservation.StayID, userID, reservation.Arrived, reservation.Departure, time.Now(), time.Now())
+	err = checkIsFree(ctx, tx, reserv, uuid.Nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = checkIsNotBlocked(ctx, tx, reserv)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id uuid.UUID
+
+	// The stay cancellation policy is copied to the reservation, it is the one applied on cancellation
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO reservations (stay_id, user_id, arrived, departure, guests, status, cancellation_policy, cancellation_tiers, created_at, updated_at)
+		SELECT s.id, $2, $3, $4, $8, $5, s.cancellation_policy, s.cancellation_tiers, $6, $7
+		FROM stays s
+		WHERE s.id = $1 AND `+stays.PublicSQL("s")+`
+		RETURNING id`,
+		reserv.StayID, userID, reserv.Arrived, reserv.Departure, status, time.Now(), time.Now(), reserv.Guests,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO reservations_status_history (reservation_id, from_status, to_status, changed_by, reason, created_at) VALUES ($1, NULL, $2, $3, '', $4)",
+		id, status, userID, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -127,24 +237,53 @@ func (r *Repo) CreateReservation(ctx context.Context, reservation *reservation.R
 	return nil
 }
 
-func (r *Repo) UpdateReservationByID(ctx context.Context, reservation *reservation.ReservationUpdateEntity) error {
-	const op = "repo.reservation.UpdateReservationByID"
+// ChangeStatus moves the reservation from change.From to change.To and logs the change to the history,
+// the completion opens the review window of the reservation.
+// It fails with service.ErrInvalidStatusTransition if the reservation status was changed in the meantime.
+func (r *Repo) ChangeStatus(ctx context.Context, change *reservation.StatusChange) error {
+	const op = "repo.reservation.ChangeStatus"
 
-	exists, err := checkers.CheckReservationExists(ctx, r.Db, reservation.ID.String())
+	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	if !exists {
-		return fmt.Errorf("%s: reservation not found", op)
+
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	var reviewDeadline *time.Time
+	if change.To == reservation.StatusCompleted {
+		deadline := reservation.ReviewDeadline(now)
+		reviewDeadline = &deadline
 	}
 
-	stmt, err := r.Db.PrepareContext(ctx, "UPDATE reservations SET arrived = $1, departure = $2, updated_at = $3 WHERE id = $4")
+	result, err := tx.ExecContext(ctx,
+		"UPDATE reservations SET status = $1, updated_at = $2, review_deadline = COALESCE($3, review_deadline) WHERE id = $4 AND status = $5",
+		change.To, now, reviewDeadline, change.ReservationID, change.From,
+	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, reservation.Arrived, reservation.Departure, time.Now(), reservation.ID)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrInvalidStatusTransition)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO reservations_status_history (reservation_id, from_status, to_status, changed_by, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		change.ReservationID, change.From, change.To, change.ChangedBy, change.Reason, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -152,17 +291,89 @@ func (r *Repo) UpdateReservationByID(ctx context.Context, reservation *reservati
 	return nil
 }
 
-func (r *Repo) DeleteReservationByID(ctx context.Context, id uuid.UUID) error {
-	const op = "repo.reservation.deleteReservationByID"
+func (r *Repo) GetStatusHistory(ctx context.Context, id uuid.UUID) ([]reservation.StatusChange, error) {
+	const op = "repo.reservation.GetStatusHistory"
 
-	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM reservations WHERE id = $1")
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT id, reservation_id, from_status, to_status, changed_by, reason, created_at
+		FROM reservations_status_history
+		WHERE reservation_id = $1
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	history := []reservation.StatusChange{}
+
+	for rows.Next() {
+		var change reservation.StatusChange
+
+		err = rows.Scan(&change.ID, &change.ReservationID, &change.From, &change.To, &change.ChangedBy, &change.Reason, &change.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		history = append(history, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
+
+// GetReservationByStayAndUser returns the latest reservation of the user for the stay in one of the statuses, nil if there is none
+func (r *Repo) GetReservationByStayAndUser(ctx context.Context, stayID, userID uuid.UUID, statuses []reservation.Status) (*reservation.Reservation, error) {
+	const op = "repo.reservation.GetReservationByStayAndUser"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+reservationColumns+" FROM reservations WHERE stay_id = $1 AND user_id = $2 AND status = ANY($3) ORDER BY arrived DESC LIMIT 1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	names := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		names = append(names, string(status))
+	}
+
+	var reserv reservation.Reservation
+
+	err = scanReservation(stmt.QueryRowContext(ctx, stayID, userID, pq.Array(names)), &reserv)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &reserv, nil
+}
+
+// SetRefund records the refund of the cancelled reservation
+func (r *Repo) SetRefund(ctx context.Context, id uuid.UUID, refund *reservation.Refund) error {
+	const op = "repo.reservation.SetRefund"
+
+	stmt, err := r.Db.PrepareContext(ctx, "UPDATE reservations SET refund_percent = $1, refund_amount = $2, refund_currency = $3, refund_status = $4, updated_at = $5 WHERE id = $6")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, id)
+	_, err = stmt.ExecContext(ctx, refund.Percent, refund.Amount.Amount, refund.Amount.Currency, refund.Status, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -170,89 +381,146 @@ func (r *Repo) DeleteReservationByID(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *Repo) CheckReservationIfExistsByUserId(ctx context.Context, id uuid.UUID) (bool, error) {
-	const op = "repo.reservation.CheckReservationIfExists"
+// GetReservationCancellationPolicy returns the stay cancellation policy saved on the reservation at booking time
+func (r *Repo) GetReservationCancellationPolicy(ctx context.Context, id uuid.UUID) (cancellation.Policy, error) {
+	const op = "repo.reservation.GetReservationCancellationPolicy"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT EXISTS(SELECT 1 FROM reservations WHERE user_id = $1 and check_out = false)")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT cancellation_policy, cancellation_tiers FROM reservations WHERE id = $1")
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return cancellation.Policy{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	defer stmt.Close()
 
-	var exists bool
+	var (
+		policyType sql.NullString
+		tiersData  []byte
+		tiers      []cancellation.Tier
+	)
 
-	err = stmt.QueryRowContext(ctx, id).Scan(&exists)
+	err = stmt.QueryRowContext(ctx, id).Scan(&policyType, &tiersData)
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return cancellation.Policy{}, fmt.Errorf("%s: %w", op, service.ErrNotFoundReservation)
+		}
+		return cancellation.Policy{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return exists, nil
+	if tiersData != nil {
+		err = json.Unmarshal(tiersData, &tiers)
+		if err != nil {
+			return cancellation.Policy{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// The reservations of the removed stays have no policy, they fall back to the default one
+	if !policyType.Valid {
+		policyType.String = string(cancellation.Flexible)
+	}
+
+	policy, err := cancellation.NewPolicy(cancellation.PolicyType(policyType.String), tiers)
+	if err != nil {
+		return cancellation.Policy{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return policy, nil
 }
 
-func (r *Repo) CheckIfReservationExists(ctx context.Context, id uuid.UUID) (bool, error) {
-	const op = "repo.reservation.CheckIfReservationExists"
+// GetPaidAmount returns the amount of the succeeded payment of the reservation
+func (r *Repo) GetPaidAmount(ctx context.Context, id uuid.UUID) (money.Money, error) {
+	const op = "repo.reservation.GetPaidAmount"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT EXISTS(SELECT 1 FROM reservations WHERE id = $1)")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT amount, currency FROM payments WHERE reservation_id = $1 AND status = 'succeeded'")
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return money.Money{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	defer stmt.Close()
 
-	var exists bool
+	var (
+		amount   money.Amount
+		currency money.Currency
+	)
 
-	err = stmt.QueryRowContext(ctx, id).Scan(&exists)
+	err = stmt.QueryRowContext(ctx, id).Scan(&amount, &currency)
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return money.Money{}, fmt.Errorf("%s: %w", op, service.ErrPaymentNotFound)
+		}
+		return money.Money{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return exists, nil
+	return money.New(amount, currency), nil
 }
 
-func (r *Repo) CheckIfArrivalIsCorrect(ctx context.Context, userID uuid.UUID, stayID uuid.UUID, reserv reservation.ReservationCheckInEntity) (bool, error) {
-	const op = "repo.reservation.CheckIfArrivalIsCorrect"
+// GetStayInstantBook reports whether the stay is booked without the owner approval
+func (r *Repo) GetStayInstantBook(ctx context.Context, stayID uuid.UUID) (bool, error) {
+	const op = "repo.reservation.GetStayInstantBook"
 
-	var arrived time.Time
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT instant_book FROM stays WHERE id = $1")
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
 
-	query := `
-       SELECT arrived 
-       FROM reservations 
-       WHERE user_id = $1 AND stay_id = $2 
-       LIMIT 1;
-    `
+	defer stmt.Close()
 
-	err := r.Db.QueryRowContext(ctx, query, reserv.UserID, stayID).Scan(&arrived)
+	var instantBook bool
+
+	err = stmt.QueryRowContext(ctx, stayID).Scan(&instantBook)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return false, nil
+			return false, fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
 		}
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	now := time.Now().Truncate(24 * time.Hour)
-	arrivedDate := arrived.Truncate(24 * time.Hour)
+	return instantBook, nil
+}
 
-	result := now.After(arrivedDate) || now.Equal(arrivedDate)
+// UpdateReservationByID moves the reservation to the new dates and change.To status, the status change is logged to the history.
+// It fails with service.ErrReservationNotEditable if the reservation status was changed in the meantime.
+// The new dates are checked again under the stay lock like in CreateReservation.
+func (r *Repo) UpdateReservationByID(ctx context.Context, reserv *reservation.ReservationUpdateEntity, change *reservation.StatusChange) error {
+	const op = "repo.reservation.UpdateReservationByID"
 
-	if !result {
-		return false, fmt.Errorf("%s: date check failed. Now: %v, Arrived: %v, Now(truncated): %v, Arrived(truncated): %v",
-			op, time.Now(), arrived, now, arrivedDate)
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return true, nil
-}
+	defer tx.Rollback()
 
-func (r *Repo) CheckInApproval(ctx context.Context, stayID uuid.UUID, reservationUser reservation.ReservationCheckInEntity) error {
-	const op = "repo.reservation.CheckInApproval"
+	var stayID uuid.UUID
 
-	query := `
-		UPDATE reservations 
-		SET check_in = TRUE 
-		WHERE stay_id = $1 AND user_id = $2;
-	`
+	err = tx.QueryRowContext(ctx, "SELECT stay_id FROM reservations WHERE id = $1", reserv.ID).Scan(&stayID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, service.ErrNotFoundReservation)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = lockStay(ctx, tx, stayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
-	result, err := r.Db.ExecContext(ctx, query, stayID, reservationUser.UserID)
+	dates := &reservation.ReservationEntity{StayID: stayID, Arrived: reserv.Arrived, Departure: reserv.Departure}
+
+	err = checkIsFree(ctx, tx, dates, reserv.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = checkIsNotBlocked(ctx, tx, dates)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE reservations SET arrived = $1, departure = $2, status = $3, updated_at = $4 WHERE id = $5 AND status = $6",
+		reserv.Arrived, reserv.Departure, change.To, time.Now(), reserv.ID, change.From,
+	)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -263,16 +531,49 @@ func (r *Repo) CheckInApproval(ctx context.Context, stayID uuid.UUID, reservatio
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: %w", op, service.ErrReservationNotFound)
+		return fmt.Errorf("%s: %w", op, service.ErrReservationNotEditable)
+	}
+
+	if *change.From != change.To {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO reservations_status_history (reservation_id, from_status, to_status, changed_by, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+			reserv.ID, change.From, change.To, change.ChangedBy, change.Reason, time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (r *Repo) CheckIfReservationExistsByStayID(ctx context.Context, stayID uuid.UUID) (bool, error) {
-	const op = "repo.reservation.CheckIfReservationExistsByStayID"
+func (r *Repo) DeleteReservationByID(ctx context.Context, id uuid.UUID) error {
+	const op = "repo.reservation.deleteReservationByID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT EXISTS(SELECT 1 FROM reservations WHERE stay_id = $1)")
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM reservations WHERE id = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) CheckIfReservationExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	const op = "repo.reservation.CheckIfReservationExists"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT EXISTS(SELECT 1 FROM reservations WHERE id = $1)")
 	if err != nil {
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
@@ -281,7 +582,7 @@ func (r *Repo) CheckIfReservationExistsByStayID(ctx context.Context, stayID uuid
 
 	var exists bool
 
-	err = stmt.QueryRowContext(ctx, stayID).Scan(&exists)
+	err = stmt.QueryRowContext(ctx, id).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
@@ -292,7 +593,7 @@ func (r *Repo) CheckIfReservationExistsByStayID(ctx context.Context, stayID uuid
 func (r *Repo) GetReservationByID(ctx context.Context, id uuid.UUID) (*reservation.Reservation, error) {
 	const op = "repo.reservation.GetReservationByID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM reservations WHERE id = $1")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+reservationColumns+" FROM reservations WHERE id = $1")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -300,10 +601,10 @@ func (r *Repo) GetReservationByID(ctx context.Context, id uuid.UUID) (*reservati
 
 	var reserv reservation.Reservation
 
-	err = stmt.QueryRowContext(ctx, id).Scan(&reserv.ID, &reserv.UserID, &reserv.StayID, &reserv.Arrived, &reserv.Departure, &reserv.UpdatedAt, &reserv.CreatedAt)
+	err = scanReservation(stmt.QueryRowContext(ctx, id), &reserv)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("%s: no reservation found with id %v", op, id)
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotFoundReservation)
 		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -311,21 +612,28 @@ func (r *Repo) GetReservationByID(ctx context.Context, id uuid.UUID) (*reservati
 	return &reserv, nil
 }
 
-func (r *Repo) GetAllReservationsByUserID(ctx context.Context, id uuid.UUID) (*[]reservation.Reservation, error) {
+func (r *Repo) GetAllReservationsByUserID(ctx context.Context, id uuid.UUID, page api.PageRequest) (api.Page[reservation.Reservation], error) {
 	const op = "repo.reservation.GetAllReservationsByUserID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM reservations WHERE user_id = $1")
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+reservationColumns+`
+		FROM reservations
+		WHERE user_id = $1
+		  AND ($2::TIMESTAMP IS NULL OR (created_at, id) > ($2::TIMESTAMP, $3::UUID))
+		ORDER BY created_at, id
+		LIMIT $4
+	`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[reservation.Reservation]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	defer stmt.Close()
 
 	var reservations []reservation.Reservation
 
-	rows, err := stmt.QueryContext(ctx, id)
+	rows, err := stmt.QueryContext(ctx, append([]interface{}{id}, page.KeysetArgs()...)...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[reservation.Reservation]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	defer rows.Close()
@@ -333,19 +641,21 @@ func (r *Repo) GetAllReservationsByUserID(ctx context.Context, id uuid.UUID) (*[
 	for rows.Next() {
 		var reserv reservation.Reservation
 
-		err = rows.Scan(&reserv.ID, &reserv.UserID, &reserv.StayID, &reserv.Arrived, &reserv.Departure, &reserv.UpdatedAt, &reserv.CreatedAt)
+		err = scanReservation(rows, &reserv)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return api.Page[reservation.Reservation]{}, fmt.Errorf("%s: %w", op, err)
 		}
 
 		reservations = append(reservations, reserv)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[reservation.Reservation]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &reservations, nil
+	return api.NewPage(reservations, page, func(r reservation.Reservation) api.Cursor {
+		return api.Cursor{CreatedAt: r.CreatedAt, ID: r.ID}
+	}), nil
 }
 
 func (r *Repo) GetFreeReservationsByUserID(ctx context.Context, id uuid.UUID) (*[]stays.Stay, error) {
@@ -353,13 +663,13 @@ func (r *Repo) GetFreeReservationsByUserID(ctx context.Context, id uuid.UUID) (*
 
 	query := `
 		SELECT 
-			id, user_id, location_id, name, type, number_of_bedrooms, 
-			number_of_beds, number_of_bathrooms, guests, rating, 
-			amenities, is_smoking_prohibited, square, street, house, 
-			entrance, floor, room, price, created_at, updated_at
+			id, user_id, location_id, name, type, guests, rating, 
+			amenities, house, entrance, address, rooms_count, beds_count, 
+			price, currency, period, owners_rules, cancellation_policy, 
+			describe_property, lat, lon, instant_book, created_at, updated_at
 		FROM stays 
 		WHERE user_id = $1 
-		AND id NOT IN (SELECT stay_id FROM reservations WHERE check_out = false)`
+		AND id NOT IN (SELECT stay_id FROM reservations WHERE status IN ('approved', 'paid', 'checked_in'))`
 
 	rows, err := r.Db.QueryContext(ctx, query, id)
 	if err != nil {
@@ -375,10 +685,11 @@ func (r *Repo) GetFreeReservationsByUserID(ctx context.Context, id uuid.UUID) (*
 
 		err = rows.Scan(
 			&reserv.ID, &reserv.UserID, &reserv.LocationID, &reserv.Name, &reserv.Type,
-			&reserv.NumberOfBedrooms, &reserv.NumberOfBeds, &reserv.NumberOfBathrooms,
-			&reserv.Guests, &reserv.Rating, &amenitiesJSON, &reserv.IsSmokingProhibited,
-			&reserv.Square, &reserv.Street, &reserv.House, &reserv.Entrance,
-			&reserv.Floor, &reserv.Room, &reserv.Price, &reserv.CreatedAt, &reserv.UpdatedAt,
+			&reserv.Guests, &reserv.Rating, &amenitiesJSON, &reserv.House, &reserv.Entrance,
+			&reserv.Address, &reserv.RoomsCount, &reserv.BedsCount,
+			&reserv.Price.Amount, &reserv.Price.Currency, &reserv.Period, &reserv.OwnersRules,
+			&reserv.CancellationPolicy, &reserv.DescribeProperty, &reserv.Lat, &reserv.Lon,
+			&reserv.InstantBook, &reserv.CreatedAt, &reserv.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -404,15 +715,15 @@ func (r *Repo) GetOccupiedReservationsByUserID(ctx context.Context, id uuid.UUID
 
 	query := `
 		SELECT 
-			s.id, s.user_id, s.location_id, s.name, s.type, s.number_of_bedrooms, 
-			s.number_of_beds, s.number_of_bathrooms, s.guests, s.rating, 
-			s.amenities, s.is_smoking_prohibited, s.square, s.street, s.house, 
-			s.entrance, s.floor, s.room, s.price, s.created_at, s.updated_at,
+			s.id, s.user_id, s.location_id, s.name, s.type, s.guests, s.rating, 
+			s.amenities, s.house, s.entrance, s.address, s.rooms_count, s.beds_count, 
+			s.price, s.currency, s.period, s.owners_rules, s.cancellation_policy, 
+			s.describe_property, s.created_at, s.updated_at,
 			r.arrived, r.departure
 		FROM stays s
 		JOIN reservations r ON s.id = r.stay_id
 		WHERE s.user_id = $1 
-		AND r.check_out = false
+		AND r.status IN ('approved', 'paid', 'checked_in')
 	`
 
 	rows, err := r.Db.QueryContext(ctx, query, id)
@@ -429,10 +740,10 @@ func (r *Repo) GetOccupiedReservationsByUserID(ctx context.Context, id uuid.UUID
 
 		err = rows.Scan(
 			&reserv.ID, &reserv.UserID, &reserv.LocationID, &reserv.Name, &reserv.Type,
-			&reserv.NumberOfBedrooms, &reserv.NumberOfBeds, &reserv.NumberOfBathrooms,
-			&reserv.Guests, &reserv.Rating, &amenitiesJSON, &reserv.IsSmokingProhibited,
-			&reserv.Square, &reserv.Street, &reserv.House, &reserv.Entrance,
-			&reserv.Floor, &reserv.Room, &reserv.Price, &reserv.CreatedAt, &reserv.UpdatedAt,
+			&reserv.Guests, &reserv.Rating, &amenitiesJSON, &reserv.House, &reserv.Entrance,
+			&reserv.Address, &reserv.RoomsCount, &reserv.BedsCount,
+			&reserv.Price.Amount, &reserv.Price.Currency, &reserv.Period, &reserv.OwnersRules,
+			&reserv.CancellationPolicy, &reserv.DescribeProperty, &reserv.CreatedAt, &reserv.UpdatedAt,
 			&reserv.ArrivedAt, &reserv.DepartureAt,
 		)
 		if err != nil {
@@ -453,3 +764,194 @@ func (r *Repo) GetOccupiedReservationsByUserID(ctx context.Context, id uuid.UUID
 
 	return &reservations, nil
 }
+
+func (r *Repo) GetReservationsByStayID(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.Reservation, error) {
+	const op = "repo.reservation.GetReservationsByStayID"
+
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE stay_id = $1
+		  AND status NOT IN ('declined', 'cancelled', 'no_show')
+		  AND (arrived, departure) OVERLAPS ($2, $3)
+		ORDER BY arrived
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query, stayID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reservations []reservation.Reservation
+
+	for rows.Next() {
+		var reserv reservation.Reservation
+
+		err = scanReservation(rows, &reserv)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		reservations = append(reservations, reserv)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reservations, nil
+}
+
+// CreateStayBlock blocks the dates of the stay, the dates are checked again under the stay lock
+// and the reservation taking them in the meantime fails it with service.ErrAlreadyReservedDate
+func (r *Repo) CreateStayBlock(ctx context.Context, block *reservation.StayBlockEntity) (*reservation.StayBlock, error) {
+	const op = "repo.reservation.CreateStayBlock"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	err = lockStay(ctx, tx, block.StayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = checkIsFree(ctx, tx, &reservation.ReservationEntity{
+		StayID:    block.StayID,
+		Arrived:   block.DateStart,
+		Departure: block.DateEnd,
+	}, uuid.Nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var created reservation.StayBlock
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO stays_blocked_dates (stay_id, date_start, date_end, reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, stay_id, date_start, date_end, reason, created_at, updated_at`,
+		block.StayID, block.DateStart, block.DateEnd, block.Reason, time.Now(), time.Now(),
+	).Scan(&created.ID, &created.StayID, &created.DateStart, &created.DateEnd, &created.Reason, &created.CreatedAt, &created.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &created, nil
+}
+
+func (r *Repo) GetStayBlockByID(ctx context.Context, id uuid.UUID) (*reservation.StayBlock, error) {
+	const op = "repo.reservation.GetStayBlockByID"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT id, stay_id, date_start, date_end, reason, created_at, updated_at FROM stays_blocked_dates WHERE id = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var block reservation.StayBlock
+
+	err = stmt.QueryRowContext(ctx, id).Scan(&block.ID, &block.StayID, &block.DateStart, &block.DateEnd, &block.Reason, &block.CreatedAt, &block.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrStayBlockNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &block, nil
+}
+
+// GetStayBlocks returns every block of the stay by the first day
+func (r *Repo) GetStayBlocks(ctx context.Context, stayID uuid.UUID) ([]reservation.StayBlock, error) {
+	const op = "repo.reservation.GetStayBlocks"
+
+	query := `
+		SELECT id, stay_id, date_start, date_end, reason, created_at, updated_at
+		FROM stays_blocked_dates
+		WHERE stay_id = $1
+		ORDER BY date_start
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	blocks, err := scanStayBlocks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blocks, nil
+}
+
+func (r *Repo) GetStayBlocksByStayID(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.StayBlock, error) {
+	const op = "repo.reservation.GetStayBlocksByStayID"
+
+	query := `
+		SELECT id, stay_id, date_start, date_end, reason, created_at, updated_at
+		FROM stays_blocked_dates
+		WHERE stay_id = $1
+		  AND (date_start, date_end) OVERLAPS ($2, $3)
+		ORDER BY date_start
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query, stayID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	blocks, err := scanStayBlocks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blocks, nil
+}
+
+func scanStayBlocks(rows *sql.Rows) ([]reservation.StayBlock, error) {
+	blocks := []reservation.StayBlock{}
+
+	for rows.Next() {
+		var block reservation.StayBlock
+
+		err := rows.Scan(&block.ID, &block.StayID, &block.DateStart, &block.DateEnd, &block.Reason, &block.CreatedAt, &block.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, rows.Err()
+}
+
+func (r *Repo) DeleteStayBlockByID(ctx context.Context, id uuid.UUID) error {
+	const op = "repo.reservation.DeleteStayBlockByID"
+
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM stays_blocked_dates WHERE id = $1")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/lib/pq"
+)
+
+type Repo struct {
+	Db *sql.DB
+}
+
+// GetRoles returns the roles with the objects granted to them
+func (r *Repo) GetRoles(ctx context.Context) ([]role.Role, error) {
+	const op = "repo.role.GetRoles"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT r.id, r.name, o.id, o.route, o.action
+		FROM role r
+		LEFT JOIN role_object ro ON ro.role_id = r.id
+		LEFT JOIN adm_object o ON o.id = ro.object_id
+		ORDER BY r.id, o.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var roles []role.Role
+
+	for rows.Next() {
+		var (
+			roleID   int
+			name     string
+			objectID sql.NullInt64
+			route    sql.NullString
+			actions  pq.StringArray
+		)
+
+		err = rows.Scan(&roleID, &name, &objectID, &route, &actions)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if len(roles) == 0 || roles[len(roles)-1].ID != roleID {
+			roles = append(roles, role.Role{ID: roleID, Name: name, Objects: []role.Object{}})
+		}
+
+		if objectID.Valid {
+			last := &roles[len(roles)-1]
+			last.Objects = append(last.Objects, role.Object{ID: int(objectID.Int64), Route: route.String, Actions: toActions(actions)})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// CheckRoleIfExists reports whether the role is in the role table
+func (r *Repo) CheckRoleIfExists(ctx context.Context, id int) (bool, error) {
+	const op = "repo.role.CheckRoleIfExists"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT EXISTS(SELECT 1 FROM role WHERE id = $1)")
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var exists bool
+
+	err = stmt.QueryRowContext(ctx, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+func (r *Repo) GetObjects(ctx context.Context) ([]role.Object, error) {
+	const op = "repo.role.GetObjects"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT id, route, action FROM adm_object ORDER BY route, id")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var objects []role.Object
+
+	for rows.Next() {
+		var (
+			object  role.Object
+			actions pq.StringArray
+		)
+
+		err = rows.Scan(&object.ID, &object.Route, &actions)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		object.Actions = toActions(actions)
+		objects = append(objects, object)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return objects, nil
+}
+
+func (r *Repo) GetObjectByID(ctx context.Context, id int) (*role.Object, error) {
+	const op = "repo.role.GetObjectByID"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT id, route, action FROM adm_object WHERE id = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var (
+		object  role.Object
+		actions pq.StringArray
+	)
+
+	err = stmt.QueryRowContext(ctx, id).Scan(&object.ID, &object.Route, &actions)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrAdmObjectNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	object.Actions = toActions(actions)
+
+	return &object, nil
+}
+
+func (r *Repo) CreateObject(ctx context.Context, object *role.ObjectEntity) (*role.Object, error) {
+	const op = "repo.role.CreateObject"
+
+	stmt, err := r.Db.PrepareContext(ctx, "INSERT INTO adm_object (route, action) VALUES ($1, $2) RETURNING id")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	created := role.Object{Route: object.Route, Actions: object.Actions}
+
+	err = stmt.QueryRowContext(ctx, object.Route, pq.Array(object.Actions)).Scan(&created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &created, nil
+}
+
+// GrantObject gives the role the actions of the object, granting it again changes nothing
+func (r *Repo) GrantObject(ctx context.Context, roleID, objectID int) error {
+	const op = "repo.role.GrantObject"
+
+	stmt, err := r.Db.PrepareContext(ctx, "INSERT INTO role_object (role_id, object_id) VALUES ($1, $2) ON CONFLICT DO NOTHING")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, roleID, objectID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repo) RevokeObject(ctx context.Context, roleID, objectID int) error {
+	const op = "repo.role.RevokeObject"
+
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM role_object WHERE role_id = $1 AND object_id = $2")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, roleID, objectID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrGrantNotFound)
+	}
+
+	return nil
+}
+
+// GetPermissions resolves the objects granted to the role into the actions it may do by route
+func (r *Repo) GetPermissions(ctx context.Context, roleID int) (role.Permissions, error) {
+	const op = "repo.role.GetPermissions"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT o.route, o.action
+		FROM role_object ro
+		JOIN adm_object o ON o.id = ro.object_id
+		WHERE ro.role_id = $1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	perms := role.Permissions{}
+
+	for rows.Next() {
+		var (
+			route   string
+			actions pq.StringArray
+		)
+
+		err = rows.Scan(&route, &actions)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if perms[route] == nil {
+			perms[route] = map[role.Action]bool{}
+		}
+
+		for _, action := range actions {
+			perms[route][role.Action(action)] = true
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return perms, nil
+}
+
+func toActions(actions pq.StringArray) []role.Action {
+	res := make([]role.Action, 0, len(actions))
+	for _, action := range actions {
+		res = append(res, role.Action(action))
+	}
+	return res
+}
@@ -0,0 +1,279 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"time"
+)
+
+// sessionColumns fixes the column order expected by scanSession
+const sessionColumns = `s.id, s.user_id, u.role_id, s.user_agent, s.ip, s.created_at, s.last_used_at, s.expires_at`
+
+type Repo struct {
+	Db *sql.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner, session *model.Session, extra ...interface{}) error {
+	dest := []interface{}{
+		&session.ID,
+		&session.UserID,
+		&session.RoleID,
+		&session.UserAgent,
+		&session.IP,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+	}
+
+	return row.Scan(append(dest, extra...)...)
+}
+
+// CreateSession saves the session with its first refresh token
+func (r *Repo) CreateSession(ctx context.Context, session *model.Session, tokenHash string) error {
+	const op = "repo.session.CreateSession"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO sessions (user_id, user_agent, ip, created_at, last_used_at, expires_at)
+		VALUES ($1, $2, $3, $4, $4, $5)
+		RETURNING id
+	`, session.UserID, session.UserAgent, session.IP, session.CreatedAt, session.ExpiresAt).Scan(&session.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sessions_refresh_tokens (session_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+	`, session.ID, tokenHash, session.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken replaces the refresh token of the session with the new one and returns the session.
+// The token used before is a stolen one or a replayed one, the whole session is revoked then
+// and the error is service.ErrRefreshTokenReused.
+func (r *Repo) RotateRefreshToken(ctx context.Context, tokenHash, newTokenHash string) (*model.Session, error) {
+	const op = "repo.session.RotateRefreshToken"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	var (
+		session   model.Session
+		tokenID   uuid.UUID
+		usedAt    sql.NullTime
+		revokedAt sql.NullTime
+	)
+
+	err = scanSession(tx.QueryRowContext(ctx, `
+		SELECT `+sessionColumns+`, t.id, t.used_at, s.revoked_at
+		FROM sessions_refresh_tokens t
+		INNER JOIN sessions s ON s.id = t.session_id
+		INNER JOIN users u ON u.id = s.user_id
+		WHERE t.token_hash = $1
+		FOR UPDATE OF t, s
+	`, tokenHash), &session, &tokenID, &usedAt, &revokedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrInvalidRefreshToken)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	if revokedAt.Valid || now.After(session.ExpiresAt) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrInvalidRefreshToken)
+	}
+
+	if usedAt.Valid {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE sessions SET revoked_at = $1, revoke_reason = $2 WHERE id = $3
+		`, now, model.ReasonTokenReused, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, service.ErrRefreshTokenReused)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE sessions_refresh_tokens SET used_at = $1 WHERE id = $2", now, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sessions_refresh_tokens (session_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+	`, session.ID, newTokenHash, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE sessions SET last_used_at = $1 WHERE id = $2", now, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	session.LastUsedAt = now
+
+	return &session, nil
+}
+
+// GetSessions returns the active sessions of the user, the last used first
+func (r *Repo) GetSessions(ctx context.Context, userID uuid.UUID) ([]model.Session, error) {
+	const op = "repo.session.GetSessions"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+sessionColumns+`
+		FROM sessions s
+		INNER JOIN users u ON u.id = s.user_id
+		WHERE s.user_id = $1 AND s.revoked_at IS NULL AND s.expires_at > $2
+		ORDER BY s.last_used_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	sessions := []model.Session{}
+
+	for rows.Next() {
+		var session model.Session
+
+		err = scanSession(rows, &session)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends the active session of the user
+func (r *Repo) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, reason model.RevokeReason) error {
+	const op = "repo.session.RevokeSession"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE sessions SET revoked_at = $1, revoke_reason = $2
+		WHERE id = $3 AND user_id = $4 AND revoked_at IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, time.Now(), reason, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrSessionNotFound)
+	}
+
+	return nil
+}
+
+// RevokeSessionByToken ends the session the refresh token belongs to, the unknown token is ignored
+func (r *Repo) RevokeSessionByToken(ctx context.Context, tokenHash string, reason model.RevokeReason) error {
+	const op = "repo.session.RevokeSessionByToken"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE sessions SET revoked_at = $1, revoke_reason = $2
+		WHERE revoked_at IS NULL
+		  AND id = (SELECT session_id FROM sessions_refresh_tokens WHERE token_hash = $3)
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, time.Now(), reason, tokenHash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions ends the sessions of the user except the kept one, uuid.Nil keeps none
+func (r *Repo) RevokeOtherSessions(ctx context.Context, userID, keepID uuid.UUID, reason model.RevokeReason) error {
+	const op = "repo.session.RevokeOtherSessions"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE sessions SET revoked_at = $1, revoke_reason = $2
+		WHERE user_id = $3 AND id <> $4 AND revoked_at IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, time.Now(), reason, userID, keepID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -4,25 +4,109 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gofrs/uuid"
 	models "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/cancellation"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/geo"
 	filtrationSort "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/sort"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"github.com/lib/pq"
 	"sort"
 	"strings"
 	"time"
 )
 
+// stayColumns fixes the column order expected by scanStay
+const stayColumns = `id, user_id, location_id, name, type, guests, rating, amenities, house, entrance,
+	created_at, updated_at, address, rooms_count, beds_count, price, currency, period, owners_rules,
+	cancellation_policy, describe_property, lat, lon, instant_book, cancellation_tiers, reviews_count, status, review_comment`
+
+// publicStaysSQL filters the stays shown in the public listings, only the published stays
+// of the hosts who are not suspended are shown
+var publicStaysSQL = models.PublicSQL("stays")
+
+// priceInSQL converts the stay price into the currency passed as the $param query argument
+func priceInSQL(param int) string {
+	return fmt.Sprintf(`(price
+		* (SELECT cr.rate FROM currency_rates cr WHERE cr.currency = stays.currency)
+		/ (SELECT cr.rate FROM currency_rates cr WHERE cr.currency = $%d))`, param)
+}
+
 type Repo struct {
 	Db *sql.DB
 }
 
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanStay reads the stayColumns into stay, extra destinations are scanned after them
+func scanStay(row rowScanner, stay *models.Stay, extra ...interface{}) error {
+	var amenitiesData, tiersData []byte
+
+	dest := []interface{}{
+		&stay.ID,
+		&stay.UserID,
+		&stay.LocationID,
+		&stay.Name,
+		&stay.Type,
+		&stay.Guests,
+		&stay.Rating,
+		&amenitiesData,
+		&stay.House,
+		&stay.Entrance,
+		&stay.CreatedAt,
+		&stay.UpdatedAt,
+		&stay.Address,
+		&stay.RoomsCount,
+		&stay.BedsCount,
+		&stay.Price.Amount,
+		&stay.Price.Currency,
+		&stay.Period,
+		&stay.OwnersRules,
+		&stay.CancellationPolicy,
+		&stay.DescribeProperty,
+		&stay.Lat,
+		&stay.Lon,
+		&stay.InstantBook,
+		&tiersData,
+		&stay.ReviewsCount,
+		&stay.Status,
+		&stay.ReviewComment,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return err
+	}
+
+	if tiersData != nil {
+		err = json.Unmarshal(tiersData, &stay.CancellationTiers)
+		if err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(amenitiesData, &stay.Amenities)
+}
+
+// tiersJSON keeps the cancellation tiers NULL for the standard policies
+func tiersJSON(tiers []cancellation.Tier) ([]byte, error) {
+	if len(tiers) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(tiers)
+}
+
 func (r *Repo) CreateStay(ctx context.Context, stay *models.StayEntity) error {
 	const op = "repo.stays.CreateStay"
 
 	stmt, err := r.Db.PrepareContext(ctx,
-		"INSERT INTO stays(user_id, location_id, name, type, guests, amenities, house, entrance, address, rooms_count, beds_count, price, period, owners_rules, cancellation_policy, describe_property, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)")
+		"INSERT INTO stays(user_id, location_id, name, type, guests, amenities, house, entrance, address, rooms_count, beds_count, price, currency, period, owners_rules, cancellation_policy, describe_property, lat, lon, instant_book, cancellation_tiers, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, COALESCE($20, TRUE), $21, $22, $23)")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -32,7 +116,12 @@ func (r *Repo) CreateStay(ctx context.Context, stay *models.StayEntity) error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = stmt.ExecContext(ctx, stay.UserID, stay.LocationID, stay.Name, stay.Type, stay.Guests, amenitiesJSON, stay.House, stay.Entrance, stay.Address, stay.RoomsCount, stay.BedsCount, stay.Price, stay.Period, stay.OwnersRules, stay.CancellationPolicy, stay.DescribeProperty, time.Now(), time.Now())
+	tiers, err := tiersJSON(stay.CancellationTiers)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, stay.UserID, stay.LocationID, stay.Name, stay.Type, stay.Guests, amenitiesJSON, stay.House, stay.Entrance, stay.Address, stay.RoomsCount, stay.BedsCount, stay.Price.Amount, stay.Price.Currency, stay.Period, stay.OwnersRules, stay.CancellationPolicy, stay.DescribeProperty, stay.Lat, stay.Lon, stay.InstantBook, tiers, time.Now(), time.Now())
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -48,12 +137,14 @@ func (r *Repo) GetStatistics(ctx context.Context, userId string) (*models.Statis
 				COUNT(*) FILTER (WHERE NOT EXISTS (
 					SELECT 1 FROM reservations r 
 					WHERE r.stay_id = s.id 
+					AND r.status NOT IN ('declined', 'cancelled', 'no_show')
 					AND r.arrived <= NOW() 
 					AND r.departure > NOW()
 				)) AS stay_free,
 				COUNT(*) FILTER (WHERE EXISTS (
 					SELECT 1 FROM reservations r 
 					WHERE r.stay_id = s.id 
+					AND r.status NOT IN ('declined', 'cancelled', 'no_show')
 					AND r.arrived <= NOW() 
 					AND r.departure > NOW()
 				)) AS stay_occupied
@@ -76,7 +167,7 @@ func (r *Repo) GetStatistics(ctx context.Context, userId string) (*models.Statis
 func (r *Repo) GetStayByID(ctx context.Context, id uuid.UUID) (*models.Stay, error) {
 	const op = "repo.stays.getStayByID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM stays WHERE id=$1")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+stayColumns+" FROM stays WHERE id=$1")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -90,56 +181,76 @@ func (r *Repo) GetStayByID(ctx context.Context, id uuid.UUID) (*models.Stay, err
 		return nil, fmt.Errorf("%s: %w", op, row.Err())
 	}
 
-	var amenitiesData []byte
-
-	err = row.Scan(
-		&stay.ID,
-		&stay.UserID,
-		&stay.LocationID,
-		&stay.Name,
-		&stay.Type,
-		&stay.Guests,
-		&stay.Rating,
-		&amenitiesData,
-		&stay.House,
-		&stay.Entrance,
-		&stay.CreatedAt,
-		&stay.UpdatedAt,
-		&stay.Address,
-		&stay.RoomsCount,
-		&stay.BedsCount,
-		&stay.Price,
-		&stay.Period,
-		&stay.OwnersRules,
-		&stay.CancellationPolicy,
-		&stay.DescribeProperty,
-	)
+	err = scanStay(row, &stay)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if err = json.Unmarshal(amenitiesData, &stay.Amenities); err != nil {
+
+	return &stay, nil
+}
+
+// GetPublicStayByID returns the stay when it is public or belongs to the user, nil if there is no such stay
+func (r *Repo) GetPublicStayByID(ctx context.Context, id, userID uuid.UUID) (*models.Stay, error) {
+	const op = "repo.stays.GetPublicStayByID"
+
+	row := r.Db.QueryRowContext(ctx,
+		"SELECT "+stayColumns+" FROM stays WHERE id = $1 AND ("+publicStaysSQL+" OR stays.user_id = $2)", id, userID)
+
+	var stay models.Stay
+
+	err := scanStay(row, &stay)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &stay, nil
 }
 
-func (r *Repo) GetStays(ctx context.Context) ([]models.StayResponse, error) {
+func (r *Repo) GetStays(ctx context.Context, page api.PageRequest) (api.Page[models.StayResponse], error) {
 	const op = "repo.stays.getStays"
 
+	stays, err := r.getStaysPage(ctx, publicStaysSQL, page)
+	if err != nil {
+		return api.Page[models.StayResponse]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stays, nil
+}
+
+// GetStaysForReview returns the page of the stays pending review, the oldest stays first
+func (r *Repo) GetStaysForReview(ctx context.Context, page api.PageRequest) (api.Page[models.StayResponse], error) {
+	const op = "repo.stays.GetStaysForReview"
+
+	stays, err := r.getStaysPage(ctx, "stays.status = '"+string(models.StatusPendingReview)+"'", page)
+	if err != nil {
+		return api.Page[models.StayResponse]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stays, nil
+}
+
+// getStaysPage returns the page of the stays matching the condition with their images
+func (r *Repo) getStaysPage(ctx context.Context, condition string, page api.PageRequest) (api.Page[models.StayResponse], error) {
 	stmt, err := r.Db.PrepareContext(ctx, `
-		SELECT *
+		SELECT `+stayColumns+`
 		FROM stays
+		WHERE `+condition+`
+		  AND ($1::TIMESTAMP IS NULL OR (created_at, id) > ($1::TIMESTAMP, $2::UUID))
+		ORDER BY created_at, id
+		LIMIT $3
 	`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[models.StayResponse]{}, err
 	}
 
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx)
+	rows, err := stmt.QueryContext(ctx, page.KeysetArgs()...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[models.StayResponse]{}, err
 	}
 
 	defer rows.Close()
@@ -149,55 +260,39 @@ func (r *Repo) GetStays(ctx context.Context) ([]models.StayResponse, error) {
 	for rows.Next() {
 		var stay models.StayResponse
 
-		var amenitiesData []byte
-
-		err = rows.Scan(
-			&stay.ID,
-			&stay.UserID,
-			&stay.LocationID,
-			&stay.Name,
-			&stay.Type,
-			&stay.Guests,
-			&stay.Rating,
-			&amenitiesData,
-			&stay.House,
-			&stay.Entrance,
-			&stay.CreatedAt,
-			&stay.UpdatedAt,
-			&stay.Address,
-			&stay.RoomsCount,
-			&stay.BedsCount,
-			&stay.Price,
-			&stay.Period,
-			&stay.OwnersRules,
-			&stay.CancellationPolicy,
-			&stay.DescribeProperty,
-		)
+		err = scanStay(rows, &stay.Stay)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return api.Page[models.StayResponse]{}, err
 		}
 
-		if err = json.Unmarshal(amenitiesData, &stay.Amenities); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
+		stays = append(stays, stay)
+	}
 
-		images, err := r.GetImagesByStayID(ctx, stay.ID)
+	if err = rows.Err(); err != nil {
+		return api.Page[models.StayResponse]{}, err
+	}
+
+	result := api.NewPage(stays, page, func(s models.StayResponse) api.Cursor {
+		return api.Cursor{CreatedAt: s.CreatedAt, ID: s.ID}
+	})
+
+	for i := range result.Items {
+		images, err := r.GetImagesByStayID(ctx, result.Items[i].ID)
 		if err != nil {
-			return nil, err
+			return api.Page[models.StayResponse]{}, err
 		}
 
-		stay.Images = images
-
-		stays = append(stays, stay)
+		result.Items[i].Images = images
 	}
 
-	return stays, nil
+	return result, nil
 }
 
+// UpdateStayByID saves the edited stay, the published one goes back to the review in the same statement
 func (r *Repo) UpdateStayByID(ctx context.Context, stay *models.StayEntity, id uuid.UUID) error {
 	const op = "repo.stays.updateStayByID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "UPDATE stays SET location_id=$1, name=$2, type=$3, guests=$4, amenities=$5, house=$6, entrance=$7, address=$8, rooms_count=$9, beds_count=$10, price=$11, period=$12, owners_rules=$13, cancellation_policy=$14, describe_property=$15, updated_at=$16 WHERE id=$17")
+	stmt, err := r.Db.PrepareContext(ctx, "UPDATE stays SET location_id=$1, name=$2, type=$3, guests=$4, amenities=$5, house=$6, entrance=$7, address=$8, rooms_count=$9, beds_count=$10, price=$11, currency=$12, period=$13, owners_rules=$14, cancellation_policy=$15, describe_property=$16, lat=$17, lon=$18, instant_book=COALESCE($19, instant_book), cancellation_tiers=$20, updated_at=$21, status=CASE WHEN status=$23 THEN $24 ELSE status END WHERE id=$22")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -209,6 +304,11 @@ func (r *Repo) UpdateStayByID(ctx context.Context, stay *models.StayEntity, id u
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	tiers, err := tiersJSON(stay.CancellationTiers)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	_, err = stmt.ExecContext(
 		ctx,
 		stay.LocationID,
@@ -221,13 +321,20 @@ func (r *Repo) UpdateStayByID(ctx context.Context, stay *models.StayEntity, id u
 		stay.Address,
 		stay.RoomsCount,
 		stay.BedsCount,
-		stay.Price,
+		stay.Price.Amount,
+		stay.Price.Currency,
 		stay.Period,
 		stay.OwnersRules,
 		stay.CancellationPolicy,
 		stay.DescribeProperty,
+		stay.Lat,
+		stay.Lon,
+		stay.InstantBook,
+		tiers,
 		time.Now(),
 		id,
+		models.StatusPublished,
+		models.StatusPendingReview,
 	)
 
 	if err != nil {
@@ -255,6 +362,39 @@ func (r *Repo) DeleteStayByID(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// UpdateStayStatus moves the stay from the status to the next one, the empty comment keeps the one the stay has.
+// The stay moved by someone else in the meantime is not changed.
+func (r *Repo) UpdateStayStatus(ctx context.Context, id uuid.UUID, from, to models.Status, comment *string) error {
+	const op = "repo.stays.UpdateStayStatus"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE stays
+		SET status = $1, review_comment = COALESCE($2, review_comment), updated_at = $3
+		WHERE id = $4 AND status = $5
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, to, comment, time.Now(), id, from)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrInvalidStayStatus)
+	}
+
+	return nil
+}
+
 func (r *Repo) CheckStayIfExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
 	const op = "repo.stays.CheckStayIfExistsByID"
 
@@ -278,7 +418,7 @@ func (r *Repo) CheckStayIfExistsByID(ctx context.Context, id uuid.UUID) (bool, e
 func (r *Repo) GetStaysByUserID(ctx context.Context, userId uuid.UUID) ([]*models.Stay, error) {
 	const op = "repo.stays.GetStaysByUserID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM stays WHERE user_id=$1")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+stayColumns+" FROM stays WHERE user_id=$1")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -293,41 +433,15 @@ func (r *Repo) GetStaysByUserID(ctx context.Context, userId uuid.UUID) ([]*model
 	defer rows.Close()
 
 	var stays []*models.Stay
-	var amenitiesData []byte
 
 	for rows.Next() {
 		var stay models.Stay
 
-		err = rows.Scan(
-			&stay.ID,
-			&stay.UserID,
-			&stay.LocationID,
-			&stay.Name,
-			&stay.Type,
-			&stay.Guests,
-			&stay.Rating,
-			&amenitiesData,
-			&stay.House,
-			&stay.Entrance,
-			&stay.CreatedAt,
-			&stay.UpdatedAt,
-			&stay.Address,
-			&stay.RoomsCount,
-			&stay.BedsCount,
-			&stay.Price,
-			&stay.Period,
-			&stay.OwnersRules,
-			&stay.CancellationPolicy,
-			&stay.DescribeProperty,
-		)
+		err = scanStay(rows, &stay)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		if err = json.Unmarshal(amenitiesData, &stay.Amenities); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-
 		stays = append(stays, &stay)
 	}
 
@@ -451,7 +565,7 @@ func (r *Repo) DeleteStayImage(ctx context.Context, imageId uuid.UUID) error {
 func (r *Repo) GetStaysByLocationID(ctx context.Context, id uuid.UUID) (*[]models.Stay, error) {
 	const op = "repo.stays.GetStaysByLocationID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM stays WHERE location_id=$1")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+stayColumns+" FROM stays WHERE location_id=$1 AND "+publicStaysSQL)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -464,41 +578,15 @@ func (r *Repo) GetStaysByLocationID(ctx context.Context, id uuid.UUID) (*[]model
 	}
 
 	var stays []models.Stay
-	var amenitiesData []byte
 
 	for rows.Next() {
 		var stay models.Stay
 
-		err = rows.Scan(
-			&stay.ID,
-			&stay.UserID,
-			&stay.LocationID,
-			&stay.Name,
-			&stay.Type,
-			&stay.Guests,
-			&stay.Rating,
-			&amenitiesData,
-			&stay.House,
-			&stay.Entrance,
-			&stay.CreatedAt,
-			&stay.UpdatedAt,
-			&stay.Address,
-			&stay.RoomsCount,
-			&stay.BedsCount,
-			&stay.Price,
-			&stay.Period,
-			&stay.OwnersRules,
-			&stay.CancellationPolicy,
-			&stay.DescribeProperty,
-		)
+		err = scanStay(rows, &stay)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		if err = json.Unmarshal(amenitiesData, &stay.Amenities); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-
 		stays = append(stays, stay)
 	}
 
@@ -525,8 +613,8 @@ func (this *Repo) Filtration(ctx context.Context, search models.Filtration) ([]m
 	}
 
 	query := `
-		SELECT * FROM stays
-		WHERE location_id = $1
+		SELECT ` + stayColumns + ` FROM stays
+		WHERE location_id = $1 AND ` + publicStaysSQL + `
 	`
 
 	args := []interface{}{
@@ -534,22 +622,34 @@ func (this *Repo) Filtration(ctx context.Context, search models.Filtration) ([]m
 	}
 	count := 1
 
+	// currency of the search is bound once on the first use by the price filter or the price sorts
+	currencyParam := 0
+	priceIn := func() string {
+		if currencyParam == 0 {
+			args = append(args, search.Currency)
+			count++
+			currencyParam = len(args)
+		}
+		return priceInSQL(currencyParam)
+	}
+
 	if search.PriceMin != -1 && search.PriceMax != -1 {
+		price := priceIn()
 		count++
 		nextCount := count + 1
-		query += fmt.Sprintf(" AND price BETWEEN $%d AND $%d", count, nextCount)
-		args = append(args, fmt.Sprintf("%f", search.PriceMin), fmt.Sprintf("%f", search.PriceMax))
+		query += fmt.Sprintf(" AND %s BETWEEN $%d AND $%d", price, count, nextCount)
+		args = append(args, search.PriceMin, search.PriceMax)
 		count++
 	}
 
 	if len(search.NumberOfBedrooms) > 0 {
 		count++
 		query += fmt.Sprintf(" AND rooms_count = ANY($%d)", count)
-		roomsStr := make([]string, len(search.NumberOfBedrooms))
+		rooms := make([]int64, len(search.NumberOfBedrooms))
 		for i, val := range search.NumberOfBedrooms {
-			roomsStr[i] = fmt.Sprintf("%d", val)
+			rooms[i] = int64(val)
 		}
-		args = append(args, pq.Array(roomsStr))
+		args = append(args, pq.Array(rooms))
 	}
 
 	if len(search.Rating) > 0 {
@@ -560,6 +660,31 @@ func (this *Repo) Filtration(ctx context.Context, search models.Filtration) ([]m
 		count++
 	}
 
+	if search.Guests > 0 {
+		count++
+		query += fmt.Sprintf(" AND guests >= $%d", count)
+		args = append(args, search.Guests)
+	}
+
+	if search.HasDates() {
+		count++
+		nextCount := count + 1
+		query += fmt.Sprintf(`
+		AND NOT EXISTS (
+			SELECT 1 FROM reservations r
+			WHERE r.stay_id = stays.id
+			AND r.status NOT IN ('declined', 'cancelled', 'no_show')
+			AND (r.arrived, r.departure) OVERLAPS ($%[1]d, $%[2]d)
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM stays_blocked_dates b
+			WHERE b.stay_id = stays.id
+			AND (b.date_start, b.date_end) OVERLAPS ($%[1]d, $%[2]d)
+		)`, count, nextCount)
+		args = append(args, search.Arrival, search.Departure)
+		count++
+	}
+
 	if len(search.Amenities) > 0 {
 		//Создаем временный массив для условий
 		var conditions []string
@@ -579,10 +704,20 @@ func (this *Repo) Filtration(ctx context.Context, search models.Filtration) ([]m
 		query += " ORDER BY created_at DESC"
 		break
 	case filtrationSort.HighlyRecommended:
-		query += " ORDER BY rating DESC, updated_at DESC"
+		query += " ORDER BY rating_score DESC, reviews_count DESC, updated_at DESC"
 		break
 	case filtrationSort.LowlyRecommended:
-		query += " ORDER BY rating ASC, updated_at ASC"
+		query += " ORDER BY rating_score ASC, reviews_count DESC, updated_at ASC"
+		break
+	case filtrationSort.Nearest:
+		query += " ORDER BY " + geo.DistanceSQL("lat", "lon", len(args)+1, len(args)+2) + " ASC NULLS LAST"
+		args = append(args, *search.Lat, *search.Lon)
+		break
+	case filtrationSort.Cheap:
+		query += " ORDER BY " + priceIn() + " ASC, rating_score DESC"
+		break
+	case filtrationSort.Expensive:
+		query += " ORDER BY " + priceIn() + " DESC, rating_score DESC"
 		break
 	default:
 	}
@@ -600,37 +735,116 @@ func (this *Repo) Filtration(ctx context.Context, search models.Filtration) ([]m
 	}
 	defer rows.Close()
 
-	var amenitiesData []byte
-
 	var stays []models.Stay
 	for rows.Next() {
 		var stay models.Stay
-		if err = rows.Scan(
-			&stay.ID,
-			&stay.UserID,
-			&stay.LocationID,
-			&stay.Name,
-			&stay.Type,
-			&stay.Guests,
-			&stay.Rating,
-			&amenitiesData,
-			&stay.House,
-			&stay.Entrance,
-			&stay.CreatedAt,
-			&stay.UpdatedAt,
-			&stay.Address,
-			&stay.RoomsCount,
-			&stay.BedsCount,
-			&stay.Price,
-			&stay.Period,
-			&stay.OwnersRules,
-			&stay.CancellationPolicy,
-			&stay.DescribeProperty,
-		); err != nil {
+		if err = scanStay(rows, &stay); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		if err = json.Unmarshal(amenitiesData, &stay.Amenities); err != nil {
+		stays = append(stays, stay)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stays, nil
+}
+
+func (r *Repo) GetNearbyStays(ctx context.Context, point geo.Point, radiusKm float64) ([]models.StayNearby, error) {
+	const op = "repo.stays.GetNearbyStays"
+
+	stays, err := r.findStaysByGeo(ctx, geo.BoundingBoxAround(point, radiusKm), point, radiusKm)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stays, nil
+}
+
+func (r *Repo) GetStaysInBoundingBox(ctx context.Context, box geo.BoundingBox) ([]models.StayNearby, error) {
+	const op = "repo.stays.GetStaysInBoundingBox"
+
+	stays, err := r.findStaysByGeo(ctx, box, box.Center(), 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stays, nil
+}
+
+// findStaysByGeo returns stays inside the box ordered by the distance to center.
+// Zero radiusKm means the stays are not limited by the distance. The box across
+// the antimeridian takes the stays east of its west edge or west of its east edge.
+func (r *Repo) findStaysByGeo(ctx context.Context, box geo.BoundingBox, center geo.Point, radiusKm float64) ([]models.StayNearby, error) {
+	query := `
+		SELECT ` + stayColumns + `, distance FROM (
+			SELECT *, ` + geo.DistanceSQL("lat", "lon", 5, 6) + ` AS distance
+			FROM stays
+			WHERE lat BETWEEN $1 AND $2
+			  AND (lon BETWEEN $3 AND $4 OR ($3::DOUBLE PRECISION > $4::DOUBLE PRECISION AND (lon >= $3 OR lon <= $4)))
+			  AND ` + publicStaysSQL + `
+		) s
+		WHERE $7::DOUBLE PRECISION = 0 OR distance <= $7::DOUBLE PRECISION
+		ORDER BY distance
+	`
+
+	rows, err := r.Db.QueryContext(ctx, query, box.MinLat, box.MaxLat, box.MinLon, box.MaxLon, center.Lat, center.Lon, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stays []models.StayNearby
+
+	for rows.Next() {
+		var stay models.StayNearby
+
+		err = scanStay(rows, &stay.Stay, &stay.DistanceKm)
+		if err != nil {
+			return nil, err
+		}
+
+		stays = append(stays, stay)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stays, nil
+}
+
+func (r *Repo) SearchStays(ctx context.Context, query string, limit int) ([]models.StaySearchResult, error) {
+	const op = "repo.stays.SearchStays"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+stayColumns+`, ts_rank(search_vector, q) AS rank
+		FROM stays, websearch_to_tsquery('russian', $1) q
+		WHERE search_vector @@ q AND `+publicStaysSQL+`
+		ORDER BY rank DESC, created_at DESC
+		LIMIT $2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var stays []models.StaySearchResult
+
+	for rows.Next() {
+		var stay models.StaySearchResult
+
+		err = scanStay(rows, &stay.Stay, &stay.Rank)
+		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
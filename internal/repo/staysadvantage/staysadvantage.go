@@ -3,9 +3,11 @@ package staysadvantage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/gofrs/uuid"
 	models "github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysadvantage"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	"time"
 )
 
@@ -34,7 +36,7 @@ func (r *Repo) CreateStaysAdvantage(ctx context.Context, stayAdv *models.StayAdv
 func (r *Repo) DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID) error {
 	const op = "repo.staysadvantage.DeleteStaysAdvantageByID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM stays_advantages WHERE stay_id = $1")
+	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM stays_advantages WHERE id = $1")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -49,6 +51,29 @@ func (r *Repo) DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+func (r *Repo) GetStaysAdvantageByID(ctx context.Context, id uuid.UUID) (*models.StayAdvantage, error) {
+	const op = "repo.staysadvantage.GetStaysAdvantageByID"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT id, stay_id, advantage_id, title, image, created_at, updated_at FROM stays_advantages WHERE id = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer stmt.Close()
+
+	var stayAdv models.StayAdvantage
+
+	err = stmt.QueryRowContext(ctx, id).Scan(&stayAdv.ID, &stayAdv.StayID, &stayAdv.AdvantageID, &stayAdv.Title, &stayAdv.Image, &stayAdv.CreatedAt, &stayAdv.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrAdvantageNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &stayAdv, nil
+}
+
 func (r *Repo) CheckStaysAdvantageIfExists(ctx context.Context, id uuid.UUID) (bool, error) {
 	const op = "repo.staysadvantage.CheckStaysAdvantageIfExists"
 
@@ -3,26 +3,99 @@ package staysreviews
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysreviews"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"time"
 )
 
+// revealedSQL tells whether the review is public
+var revealedSQL = reservation.RevealedSQL("stays_reviews.reservation_id")
+
+// reviewColumns fixes the column order expected by scanReview
+var reviewColumns = `id, stay_id, user_id, reservation_id, title, description, rating,
+	cleanliness, accuracy, location, communication, value, reply, replied_at, created_at, updated_at, ` + revealedSQL
+
 type Repo struct {
 	Db *sql.DB
 }
 
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReview(row rowScanner, review *staysreviews.StaysReview) error {
+	var (
+		reservationID uuid.NullUUID
+		reply         sql.NullString
+		repliedAt     sql.NullTime
+	)
+
+	err := row.Scan(&review.ID, &review.StayID, &review.UserID, &reservationID, &review.Title, &review.Description, &review.Rating,
+		&review.Criteria.Cleanliness, &review.Criteria.Accuracy, &review.Criteria.Location, &review.Criteria.Communication, &review.Criteria.Value,
+		&reply, &repliedAt, &review.CreatedAt, &review.UpdatedAt, &review.Revealed)
+	if err != nil {
+		return err
+	}
+
+	if reservationID.Valid {
+		review.ReservationID = &reservationID.UUID
+	}
+
+	if reply.Valid {
+		review.Reply = &staysreviews.Reply{Text: reply.String, RepliedAt: repliedAt.Time}
+	}
+
+	return nil
+}
+
+// CreateStaysReview inserts the review and recomputes the rating of its stay in the same transaction.
+// It fails with service.ErrStaysReviewExists if the reservation is already reviewed.
 func (r *Repo) CreateStaysReview(ctx context.Context, stayReview *staysreviews.StaysReviewEntity) error {
 	const op = "repo.staysreviews.CreateStaysReview"
 
-	stmt, err := r.Db.PrepareContext(ctx, "INSERT INTO stays_reviews (stay_id, user_id, title, description, rating, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)")
+	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, stayReview.StayID, stayReview.UserID, stayReview.Title, stayReview.Description, stayReview.Rating, time.Now(), time.Now())
+	defer tx.Rollback()
+
+	err = lockStay(ctx, tx, stayReview.StayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id uuid.UUID
+
+	c := stayReview.Criteria
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO stays_reviews (stay_id, user_id, reservation_id, title, description, rating,
+			cleanliness, accuracy, location, communication, value, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (reservation_id) DO NOTHING
+		RETURNING id
+	`, stayReview.StayID, stayReview.UserID, stayReview.ReservationID, stayReview.Title, stayReview.Description, stayReview.Rating,
+		c.Cleanliness, c.Accuracy, c.Location, c.Communication, c.Value, time.Now(), time.Now(),
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, service.ErrStaysReviewExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = recomputeStayRating(ctx, tx, stayReview.StayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -30,16 +103,42 @@ func (r *Repo) CreateStaysReview(ctx context.Context, stayReview *staysreviews.S
 	return nil
 }
 
+// UpdateStaysReviewByID changes the review and recomputes the rating of its stay in the same transaction
 func (r *Repo) UpdateStaysReviewByID(ctx context.Context, stayReview *staysreviews.StaysReviewEntity, id uuid.UUID) error {
 	const op = "repo.staysreviews.UpdateStaysReviewByID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "UPDATE stays_reviews SET title = $1, description = $2, rating = $3, updated_at = $4 WHERE id = $5")
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	stayID, err := lockReviewStay(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	c := stayReview.Criteria
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE stays_reviews
+		SET title = $1, description = $2, rating = $3, cleanliness = $4, accuracy = $5, location = $6,
+			communication = $7, value = $8, updated_at = $9
+		WHERE id = $10
+	`, stayReview.Title, stayReview.Description, stayReview.Rating, c.Cleanliness, c.Accuracy, c.Location,
+		c.Communication, c.Value, time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = recomputeStayRating(ctx, tx, stayID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, stayReview.Title, stayReview.Description, stayReview.Rating, time.Now(), id)
+	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -47,36 +146,220 @@ func (r *Repo) UpdateStaysReviewByID(ctx context.Context, stayReview *staysrevie
 	return nil
 }
 
+// DeleteStaysReviewByID deletes the review and recomputes the rating of its stay in the same transaction
 func (r *Repo) DeleteStaysReviewByID(ctx context.Context, id uuid.UUID) error {
 	const op = "repo.staysreviews.DeleteStaysReviewByID"
 
-	stmt, err := r.Db.PrepareContext(ctx, "DELETE FROM stays_reviews WHERE id = $1")
+	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	defer stmt.Close()
+	defer tx.Rollback()
+
+	stayID, err := lockReviewStay(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM stays_reviews WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = recomputeStayRating(ctx, tx, stayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RecomputeStaysRatings recomputes the rating of every stay from its reviews, one transaction per stay,
+// and returns the number of the recomputed stays
+func (r *Repo) RecomputeStaysRatings(ctx context.Context) (int, error) {
+	const op = "repo.staysreviews.RecomputeStaysRatings"
+
+	rows, err := r.Db.QueryContext(ctx, "SELECT id FROM stays ORDER BY id")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var stayIDs []uuid.UUID
+
+	for rows.Next() {
+		var stayID uuid.UUID
+
+		if err := rows.Scan(&stayID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		stayIDs = append(stayIDs, stayID)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, stayID := range stayIDs {
+		err = r.RecomputeStayRating(ctx, stayID)
+		if err != nil {
+			return i, fmt.Errorf("%s: stay %s: %w", op, stayID, err)
+		}
+	}
+
+	return len(stayIDs), nil
+}
+
+// RecomputeRevealedRatings recomputes the ratings of the stays whose reviews were revealed by the review window
+// of their reservation closing in (from, to], and returns the number of the recomputed stays
+func (r *Repo) RecomputeRevealedRatings(ctx context.Context, from, to time.Time) (int, error) {
+	const op = "repo.staysreviews.RecomputeRevealedRatings"
+
+	rows, err := r.Db.QueryContext(ctx, `
+		SELECT DISTINCT sr.stay_id
+		FROM stays_reviews sr
+		JOIN reservations rr ON rr.id = sr.reservation_id
+		WHERE rr.review_deadline > $1 AND rr.review_deadline <= $2
+	`, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var stayIDs []uuid.UUID
+
+	for rows.Next() {
+		var stayID uuid.UUID
+
+		if err := rows.Scan(&stayID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		stayIDs = append(stayIDs, stayID)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, stayID := range stayIDs {
+		err = r.RecomputeStayRating(ctx, stayID)
+		if err != nil {
+			return i, fmt.Errorf("%s: stay %s: %w", op, stayID, err)
+		}
+	}
+
+	return len(stayIDs), nil
+}
+
+// RecomputeStayRating recomputes the rating of the stay from its reviews, the reviews revealed
+// by the review of the guest or by the closed review window are counted in from then on
+func (r *Repo) RecomputeStayRating(ctx context.Context, stayID uuid.UUID) error {
+	const op = "repo.staysreviews.RecomputeStayRating"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
 
-	_, err = stmt.Exec(id)
+	err = lockStay(ctx, tx, stayID)
+	if err != nil {
+		// the stay was deleted in the meantime
+		if errors.Is(err, service.ErrStayNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = recomputeStayRating(ctx, tx, stayID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// lockStay locks the stay row until the end of the transaction, the review changes of the stay
+// are serialized so that the recomputed rating never misses a concurrent change
+func lockStay(ctx context.Context, tx *sql.Tx, stayID uuid.UUID) error {
+	var id uuid.UUID
+
+	err := tx.QueryRowContext(ctx, "SELECT id FROM stays WHERE id = $1 FOR UPDATE", stayID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return service.ErrStayNotFound
+		}
+		return err
+	}
+
 	return nil
 }
 
+// lockReviewStay locks the stay of the review and returns its id
+func lockReviewStay(ctx context.Context, tx *sql.Tx, id uuid.UUID) (uuid.UUID, error) {
+	var stayID uuid.UUID
+
+	err := tx.QueryRowContext(ctx, "SELECT stay_id FROM stays_reviews WHERE id = $1", id).Scan(&stayID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, service.ErrStaysReviewNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	return stayID, lockStay(ctx, tx, stayID)
+}
+
+// recomputeStayRating stores the average rating, the number of reviews and the rating score of the stay,
+// the hidden reviews are left out
+func recomputeStayRating(ctx context.Context, tx *sql.Tx, stayID uuid.UUID) error {
+	var (
+		average float64
+		count   int
+	)
+
+	err := tx.QueryRowContext(ctx, "SELECT COALESCE(AVG(rating), 0), COUNT(*) FROM stays_reviews WHERE stay_id = $1 AND "+revealedSQL, stayID).
+		Scan(&average, &count)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE stays SET rating = $1, reviews_count = $2, rating_score = $3 WHERE id = $4",
+		average, count, staysreviews.BayesianScore(average, count), stayID,
+	)
+
+	return err
+}
+
 func (r *Repo) FindOneStaysReviewByID(ctx context.Context, id uuid.UUID) (*staysreviews.StaysReview, error) {
 	const op = "repo.staysreviews.FindOneStaysReviewByID"
 
 	var stayReview staysreviews.StaysReview
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM stays_reviews WHERE id = $1")
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+reviewColumns+" FROM stays_reviews WHERE id = $1")
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	err = stmt.QueryRowContext(ctx, id).Scan(&stayReview.ID, &stayReview.StayID, &stayReview.UserID, &stayReview.Title, &stayReview.Description, &stayReview.Rating, &stayReview.CreatedAt, &stayReview.UpdatedAt)
+	err = scanReview(stmt.QueryRowContext(ctx, id), &stayReview)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -84,34 +367,103 @@ func (r *Repo) FindOneStaysReviewByID(ctx context.Context, id uuid.UUID) (*stays
 	return &stayReview, nil
 }
 
-func (r *Repo) FindAllStaysReviews(ctx context.Context) ([]staysreviews.StaysReview, error) {
+// FindAllStaysReviews returns the page of the public reviews, only of the stay if stayID is set
+func (r *Repo) FindAllStaysReviews(ctx context.Context, stayID *uuid.UUID, page api.PageRequest) (api.Page[staysreviews.StaysReview], error) {
 	const op = "repo.staysreviews.FindAllStaysReviews"
 
-	stmt, err := r.Db.PrepareContext(ctx, "SELECT * FROM stays_reviews")
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+reviewColumns+`
+		FROM stays_reviews
+		WHERE ($1::TIMESTAMP IS NULL OR (created_at, id) > ($1::TIMESTAMP, $2::UUID))
+		  AND ($4::UUID IS NULL OR stay_id = $4::UUID)
+		  AND `+revealedSQL+`
+		ORDER BY created_at, id
+		LIMIT $3
+	`)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[staysreviews.StaysReview]{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
 	var stayReviews []staysreviews.StaysReview
 
-	rows, err := stmt.QueryContext(ctx)
+	rows, err := stmt.QueryContext(ctx, append(page.KeysetArgs(), stayID)...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[staysreviews.StaysReview]{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		var stayReview staysreviews.StaysReview
 
-		err := rows.Scan(&stayReview.ID, &stayReview.StayID, &stayReview.UserID, &stayReview.Title, &stayReview.Description, &stayReview.Rating, &stayReview.CreatedAt, &stayReview.UpdatedAt)
+		err := scanReview(rows, &stayReview)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return api.Page[staysreviews.StaysReview]{}, fmt.Errorf("%s: %w", op, err)
 		}
 		stayReviews = append(stayReviews, stayReview)
 	}
 
-	return stayReviews, nil
+	if err := rows.Err(); err != nil {
+		return api.Page[staysreviews.StaysReview]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return api.NewPage(stayReviews, page, func(sr staysreviews.StaysReview) api.Cursor {
+		return api.Cursor{CreatedAt: sr.CreatedAt, ID: sr.ID}
+	}), nil
+}
+
+// GetRatingSummary averages the overall rating and the criteria of the public reviews of the stay
+func (r *Repo) GetRatingSummary(ctx context.Context, stayID uuid.UUID) (*staysreviews.RatingSummary, error) {
+	const op = "repo.staysreviews.GetRatingSummary"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT COUNT(*), COALESCE(AVG(rating), 0), COALESCE(AVG(cleanliness), 0), COALESCE(AVG(accuracy), 0),
+			COALESCE(AVG(location), 0), COALESCE(AVG(communication), 0), COALESCE(AVG(value), 0)
+		FROM stays_reviews
+		WHERE stay_id = $1
+		  AND `+revealedSQL+`
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	summary := staysreviews.RatingSummary{StayID: stayID}
+
+	err = stmt.QueryRowContext(ctx, stayID).Scan(&summary.ReviewsCount, &summary.Rating, &summary.Cleanliness, &summary.Accuracy,
+		&summary.Location, &summary.Communication, &summary.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &summary, nil
+}
+
+// SetReply stores the reply of the stay owner, it fails with service.ErrReplyExists if the review is already replied
+func (r *Repo) SetReply(ctx context.Context, id uuid.UUID, text string) error {
+	const op = "repo.staysreviews.SetReply"
+
+	stmt, err := r.Db.PrepareContext(ctx, "UPDATE stays_reviews SET reply = $1, replied_at = $2 WHERE id = $3 AND reply IS NULL")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, text, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrReplyExists)
+	}
+
+	return nil
 }
 
 func (r *Repo) CheckIfExists(ctx context.Context, id uuid.UUID) (bool, error) {
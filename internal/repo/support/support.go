@@ -0,0 +1,268 @@
+package support
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"time"
+)
+
+// escalationColumns fixes the column order expected by scanEscalation
+const escalationColumns = `id, chat_id, requester_id, reason, status, operator_id, resolution_note,
+	response_due_at, resolution_due_at, assigned_at, closed_at, created_at, updated_at`
+
+type Repo struct {
+	Db *sql.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEscalation(row rowScanner, e *support.Escalation) error {
+	var assignedAt, closedAt sql.NullTime
+
+	err := row.Scan(&e.ID, &e.ChatID, &e.RequesterID, &e.Reason, &e.Status, &e.OperatorID, &e.ResolutionNote,
+		&e.ResponseDueAt, &e.ResolutionDueAt, &assignedAt, &closedAt, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if assignedAt.Valid {
+		e.AssignedAt = &assignedAt.Time
+	}
+	if closedAt.Valid {
+		e.ClosedAt = &closedAt.Time
+	}
+
+	return nil
+}
+
+// CreateEscalation puts the chat in the queue of the operators, the chat with an escalation in work
+// is not escalated again
+func (r *Repo) CreateEscalation(ctx context.Context, chatID, requesterID uuid.UUID, reason string, now time.Time) (*support.Escalation, error) {
+	const op = "repo.support.CreateEscalation"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		INSERT INTO chat_escalations (chat_id, requester_id, reason, status, response_due_at, resolution_due_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (chat_id) WHERE status <> 'closed' DO NOTHING
+		RETURNING `+escalationColumns)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var escalation support.Escalation
+
+	err = scanEscalation(stmt.QueryRowContext(ctx, chatID, requesterID, reason, support.StatusOpen,
+		now.Add(support.ResponseSLA), now.Add(support.ResolutionSLA), now), &escalation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrChatEscalated)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &escalation, nil
+}
+
+// GetEscalations returns the page of the operator queue, the oldest escalations first
+func (r *Repo) GetEscalations(ctx context.Context, filter support.Filter, now time.Time, page api.PageRequest) (api.Page[support.Escalation], error) {
+	const op = "repo.support.GetEscalations"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+escalationColumns+`
+		FROM chat_escalations
+		WHERE ($4::TEXT = '' OR status = $4::TEXT)
+		  AND ($5::UUID IS NULL OR operator_id = $5::UUID)
+		  AND (NOT $6::BOOLEAN OR (status <> 'closed'
+		      AND ((assigned_at IS NULL AND response_due_at < $7) OR resolution_due_at < $7)))
+		  AND ($1::TIMESTAMP IS NULL OR (created_at, id) > ($1::TIMESTAMP, $2::UUID))
+		ORDER BY created_at, id
+		LIMIT $3
+	`)
+	if err != nil {
+		return api.Page[support.Escalation]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, append(page.KeysetArgs(), filter.Status, filter.OperatorID, filter.Overdue, now)...)
+	if err != nil {
+		return api.Page[support.Escalation]{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var escalations []support.Escalation
+
+	for rows.Next() {
+		var escalation support.Escalation
+
+		err = scanEscalation(rows, &escalation)
+		if err != nil {
+			return api.Page[support.Escalation]{}, fmt.Errorf("%s: %w", op, err)
+		}
+		escalations = append(escalations, escalation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return api.Page[support.Escalation]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return api.NewPage(escalations, page, func(e support.Escalation) api.Cursor {
+		return api.Cursor{CreatedAt: e.CreatedAt, ID: e.ID}
+	}), nil
+}
+
+func (r *Repo) GetEscalation(ctx context.Context, id uuid.UUID) (*support.Escalation, error) {
+	const op = "repo.support.GetEscalation"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT "+escalationColumns+" FROM chat_escalations WHERE id = $1")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var escalation support.Escalation
+
+	err = scanEscalation(stmt.QueryRowContext(ctx, id), &escalation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrEscalationNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &escalation, nil
+}
+
+// GetChatEscalations returns the escalations of the chat, the latest first
+func (r *Repo) GetChatEscalations(ctx context.Context, chatID uuid.UUID) ([]support.Escalation, error) {
+	const op = "repo.support.GetChatEscalations"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		SELECT `+escalationColumns+`
+		FROM chat_escalations
+		WHERE chat_id = $1
+		ORDER BY created_at DESC, id DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	escalations := []support.Escalation{}
+
+	for rows.Next() {
+		var escalation support.Escalation
+
+		err = scanEscalation(rows, &escalation)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		escalations = append(escalations, escalation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return escalations, nil
+}
+
+// AssignEscalation gives the escalation to the operator and makes them the operator of the chat in one transaction.
+// The response timer stops at the first assignment. It fails with service.ErrEscalationChanged
+// if the escalation was closed or its operator is not current anymore.
+func (r *Repo) AssignEscalation(ctx context.Context, id uuid.UUID, current *uuid.UUID, operatorID uuid.UUID, now time.Time) (*support.Escalation, error) {
+	const op = "repo.support.AssignEscalation"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	var escalation support.Escalation
+
+	err = scanEscalation(tx.QueryRowContext(ctx, `
+		UPDATE chat_escalations
+		SET operator_id = $1, status = $2, assigned_at = COALESCE(assigned_at, $3), updated_at = $3
+		WHERE id = $4 AND status <> $5 AND operator_id IS NOT DISTINCT FROM $6
+		RETURNING `+escalationColumns,
+		operatorID, support.StatusAssigned, now, id, support.StatusClosed, current,
+	), &escalation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrEscalationChanged)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE chat SET operator_id = $1, updated_at = $2 WHERE chat_id = $3",
+		operatorID, now, escalation.ChatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &escalation, nil
+}
+
+// CloseEscalation leaves the resolution note and takes the operator out of the chat in one transaction.
+// The operator is assigned to the escalation nobody took. It fails with service.ErrEscalationChanged
+// if the escalation was closed or its operator is not current anymore.
+func (r *Repo) CloseEscalation(ctx context.Context, id uuid.UUID, current *uuid.UUID, operatorID uuid.UUID, note string, now time.Time) (*support.Escalation, error) {
+	const op = "repo.support.CloseEscalation"
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback()
+
+	var escalation support.Escalation
+
+	err = scanEscalation(tx.QueryRowContext(ctx, `
+		UPDATE chat_escalations
+		SET status = $1, resolution_note = $2, closed_at = $3, updated_at = $3, operator_id = COALESCE(operator_id, $4)
+		WHERE id = $5 AND status <> $1 AND operator_id IS NOT DISTINCT FROM $6
+		RETURNING `+escalationColumns,
+		support.StatusClosed, note, now, operatorID, id, current,
+	), &escalation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrEscalationChanged)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE chat SET operator_id = NULL, updated_at = $1 WHERE chat_id = $2",
+		now, escalation.ChatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &escalation, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
 	"github.com/imperatorofdwelling/Full-backend/internal/repo"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	_ "github.com/lib/pq"
 	"github.com/pkg/errors"
 	"time"
@@ -280,3 +281,88 @@ func (r *Repository) UpdateUserPfp(ctx context.Context, userId uuid.UUID, imageP
 	}
 	return nil
 }
+
+// GetSuspension returns the last suspension of the user, it may be over already
+func (r *Repository) GetSuspension(ctx context.Context, id uuid.UUID) (model.Suspension, error) {
+	const op = "repo.user.GetSuspension"
+
+	stmt, err := r.Db.PrepareContext(ctx, "SELECT suspension_reason, suspended_until, banned_at IS NOT NULL FROM users WHERE id = $1")
+	if err != nil {
+		return model.Suspension{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var (
+		suspension model.Suspension
+		until      sql.NullTime
+	)
+
+	err = stmt.QueryRowContext(ctx, id).Scan(&suspension.Reason, &until, &suspension.Banned)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Suspension{}, fmt.Errorf("%s: %w", op, service.ErrUserNotFound)
+		}
+		return model.Suspension{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if until.Valid {
+		suspension.Until = &until.Time
+	}
+
+	return suspension, nil
+}
+
+// SetSuspension replaces the suspension of the user, the empty one reinstates the user. The ban keeps the time the user was banned first
+func (r *Repository) SetSuspension(ctx context.Context, id uuid.UUID, suspension model.Suspension) error {
+	const op = "repo.user.SetSuspension"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE users
+		SET suspension_reason = $1,
+			suspended_until = $2,
+			banned_at = CASE WHEN $3 THEN COALESCE(banned_at, $4) END
+		WHERE id = $5
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, suspension.Reason, suspension.Until, suspension.Banned, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, service.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// ExtendSuspension suspends the user until the time, a ban or a longer suspension the user has is kept
+func (r *Repository) ExtendSuspension(ctx context.Context, id uuid.UUID, reason string, until time.Time) error {
+	const op = "repo.user.ExtendSuspension"
+
+	stmt, err := r.Db.PrepareContext(ctx, `
+		UPDATE users
+		SET suspended_until = $1, suspension_reason = $2
+		WHERE id = $3 AND banned_at IS NULL AND (suspended_until IS NULL OR suspended_until < $1)
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, until, reason, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
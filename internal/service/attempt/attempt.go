@@ -0,0 +1,112 @@
+package attempt
+
+import (
+	"context"
+	"fmt"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"time"
+)
+
+// Service limits the attempts by the counters of the store.
+// Every attempt is counted by Take before it is made, so the parallel attempts can't get past the limits,
+// the successful one is taken back by Release or Reset.
+type Service struct {
+	Store interfaces.AttemptStore
+}
+
+// Check returns service.ErrTooManyAttempts with the longest wait when any of the limits is locked
+func (s *Service) Check(ctx context.Context, limits ...model.Limit) error {
+	const op = "service.attempt.Check"
+
+	now := time.Now()
+
+	var retryAfter time.Duration
+
+	for _, limit := range limits {
+		if limit.Key == "" {
+			continue
+		}
+
+		counter, err := s.Store.GetAttempts(ctx, limit.Key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		retryAfter = max(retryAfter, counter.RetryAfter(now))
+	}
+
+	if retryAfter > 0 {
+		return fmt.Errorf("%s: %w: %w", op, service.ErrTooManyAttempts, &model.LockedError{RetryAfter: retryAfter})
+	}
+
+	return nil
+}
+
+// Take counts the attempt on every limit in one step with the lock check. It returns service.ErrTooManyAttempts
+// with the longest wait when any of the limits is locked, the attempt isn't counted on the locked ones.
+func (s *Service) Take(ctx context.Context, limits ...model.Limit) error {
+	const op = "service.attempt.Take"
+
+	now := time.Now()
+
+	var retryAfter time.Duration
+
+	for _, limit := range limits {
+		if limit.Key == "" {
+			continue
+		}
+
+		counter, taken, err := s.Store.TakeAttempt(ctx, limit.Key, limit.Policy)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !taken {
+			retryAfter = max(retryAfter, counter.RetryAfter(now))
+		}
+	}
+
+	if retryAfter > 0 {
+		return fmt.Errorf("%s: %w: %w", op, service.ErrTooManyAttempts, &model.LockedError{RetryAfter: retryAfter})
+	}
+
+	return nil
+}
+
+// Release takes back the successful attempt counted by Take on the limits whose failures are kept
+func (s *Service) Release(ctx context.Context, limits ...model.Limit) error {
+	const op = "service.attempt.Release"
+
+	for _, limit := range limits {
+		if limit.Key == "" {
+			continue
+		}
+
+		err := s.Store.ReleaseAttempt(ctx, limit.Key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// Reset forgets the failures of the limits after the successful attempt
+func (s *Service) Reset(ctx context.Context, limits ...model.Limit) error {
+	const op = "service.attempt.Reset"
+
+	for _, limit := range limits {
+		if limit.Key == "" {
+			continue
+		}
+
+		err := s.Store.ResetAttempts(ctx, limit.Key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
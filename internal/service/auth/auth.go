@@ -2,19 +2,27 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/apikey"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
 	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/auth"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	"net/mail"
 	"strings"
+	"time"
 )
 
 type Service struct {
 	AuthRepo         interfaces.AuthRepository
 	UserRepo         interfaces.UserRepository
 	ConfirmEmailRepo interfaces.ConfirmEmailRepository
+	SessionRepo      interfaces.SessionRepo
+	APIKeyRepo       interfaces.APIKeyRepo
+	Limiter          interfaces.AttemptLimiter
 }
 
 func (s *Service) Register(ctx context.Context, user model.Registration) (uuid.UUID, error) {
@@ -51,6 +59,16 @@ func (s *Service) Register(ctx context.Context, user model.Registration) (uuid.U
 
 func (s *Service) Login(ctx context.Context, user model.Login) (uuid.UUID, int, error) {
 	const op = "service.auth.Login"
+
+	account := attempt.AccountLimit(attempt.KindLogin, user.Email)
+	ip := attempt.IPLimit(ctx, attempt.KindLogin)
+
+	// the attempt is counted before the password is checked and taken back when it succeeds
+	err := s.Limiter.Take(ctx, account, ip)
+	if err != nil {
+		return uuid.Nil, -1, fmt.Errorf("%s: %w", op, err)
+	}
+
 	userExists, err := s.UserRepo.CheckUserExists(ctx, user.Email)
 	if err != nil {
 		return uuid.Nil, -1, err
@@ -65,12 +83,238 @@ func (s *Service) Login(ctx context.Context, user model.Login) (uuid.UUID, int,
 		return id, -1, err
 	}
 
+	// the address keeps its failures, one known account must not let it guess the others
+	err = s.succeed(ctx, account, ip)
+	if err != nil {
+		return uuid.Nil, -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.checkSuspension(ctx, id)
+	if err != nil {
+		return uuid.Nil, -1, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return id, roleID, err
 }
 
+// CreateSession starts the session of the logged in user on the device
+func (s *Service) CreateSession(ctx context.Context, userID uuid.UUID, roleID int, device session.Device) (*session.Tokens, error) {
+	const op = "service.auth.CreateSession"
+
+	refreshToken, err := session.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	created := session.Session{
+		UserID:     userID,
+		RoleID:     roleID,
+		UserAgent:  device.UserAgent,
+		IP:         device.IP,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(session.RefreshTokenTTL),
+		Current:    true,
+	}
+
+	err = s.SessionRepo.CreateSession(ctx, &created, session.HashToken(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &session.Tokens{Session: created, RefreshToken: refreshToken}, nil
+}
+
+// RefreshSession rotates the refresh token of the session. The reused refresh token revokes the whole session.
+func (s *Service) RefreshSession(ctx context.Context, refreshToken string) (*session.Tokens, error) {
+	const op = "service.auth.RefreshSession"
+
+	if refreshToken == "" {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrInvalidRefreshToken)
+	}
+
+	newRefreshToken, err := session.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	refreshed, err := s.SessionRepo.RotateRefreshToken(ctx, session.HashToken(refreshToken), session.HashToken(newRefreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.checkSuspension(ctx, refreshed.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	refreshed.Current = true
+
+	return &session.Tokens{Session: *refreshed, RefreshToken: newRefreshToken}, nil
+}
+
+// Logout ends the session of the refresh token, the logout without the token does nothing
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	const op = "service.auth.Logout"
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	err := s.SessionRepo.RevokeSessionByToken(ctx, session.HashToken(refreshToken), session.ReasonLogout)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetSessions returns the active sessions of the user, the session of the request is marked as the current one
+func (s *Service) GetSessions(ctx context.Context, userID string) ([]session.Session, error) {
+	const op = "service.auth.GetSessions"
+
+	sessions, err := s.SessionRepo.GetSessions(ctx, uuid.FromStringOrNil(userID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if currentID, ok := session.IDFromContext(ctx); ok {
+		for i := range sessions {
+			sessions[i].Current = sessions[i].ID == currentID
+		}
+	}
+
+	return sessions, nil
+}
+
+func (s *Service) RevokeSession(ctx context.Context, userID string, sessionID uuid.UUID) error {
+	const op = "service.auth.RevokeSession"
+
+	err := s.SessionRepo.RevokeSession(ctx, uuid.FromStringOrNil(userID), sessionID, session.ReasonRevoked)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CreateAPIKey issues the API key of the user, the key is returned once and only its hash is kept
+func (s *Service) CreateAPIKey(ctx context.Context, userID string, key *apikey.Entity) (*apikey.Created, error) {
+	const op = "service.auth.CreateAPIKey"
+
+	err := key.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	id := uuid.FromStringOrNil(userID)
+
+	keys, err := s.APIKeyRepo.GetAPIKeys(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(keys) >= apikey.MaxKeysPerUser {
+		return nil, fmt.Errorf("%s: %w: %d keys at most", op, service.ErrAPIKeyLimit, apikey.MaxKeysPerUser)
+	}
+
+	secret, err := apikey.NewKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created := apikey.Created{
+		APIKey: apikey.APIKey{
+			UserID:    id,
+			Name:      key.Name,
+			Prefix:    apikey.DisplayPrefix(secret),
+			Scopes:    key.Scopes,
+			CreatedAt: time.Now(),
+		},
+		Key: secret,
+	}
+
+	if key.ExpiresInDays != nil {
+		expiresAt := created.CreatedAt.AddDate(0, 0, *key.ExpiresInDays)
+		created.ExpiresAt = &expiresAt
+	}
+
+	err = s.APIKeyRepo.CreateAPIKey(ctx, &created.APIKey, apikey.Hash(secret))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &created, nil
+}
+
+func (s *Service) GetAPIKeys(ctx context.Context, userID string) ([]apikey.APIKey, error) {
+	const op = "service.auth.GetAPIKeys"
+
+	keys, err := s.APIKeyRepo.GetAPIKeys(ctx, uuid.FromStringOrNil(userID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, userID string, id uuid.UUID) error {
+	const op = "service.auth.RevokeAPIKey"
+
+	err := s.APIKeyRepo.RevokeAPIKey(ctx, uuid.FromStringOrNil(userID), id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey finds the user of the active API key
+func (s *Service) AuthenticateAPIKey(ctx context.Context, key string) (*apikey.Principal, error) {
+	const op = "service.auth.AuthenticateAPIKey"
+
+	if !apikey.IsKey(key) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrInvalidAPIKey)
+	}
+
+	principal, err := s.APIKeyRepo.Authenticate(ctx, apikey.Hash(key))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return principal, nil
+}
+
+// checkSuspension returns service.ErrUserSuspended or service.ErrUserBanned for the blocked user
+func (s *Service) checkSuspension(ctx context.Context, userID uuid.UUID) error {
+	suspension, err := s.UserRepo.GetSuspension(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if suspension.Active(time.Now()) {
+		if suspension.Banned {
+			return fmt.Errorf("%w: %s", service.ErrUserBanned, suspension.String())
+		}
+		return fmt.Errorf("%w: %s", service.ErrUserSuspended, suspension.String())
+	}
+
+	return nil
+}
+
 func (s *Service) CheckEmailOTP(ctx context.Context, userID, otp string) error {
 	const op = "service.auth.CheckEmailOTP"
 
+	account := attempt.AccountLimit(attempt.KindEmailOTP, userID)
+	ip := attempt.IPLimit(ctx, attempt.KindEmailOTP)
+
+	err := s.Limiter.Take(ctx, account, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	isVerified, err := s.AuthRepo.CheckIfUserEmailValidated(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("%s : %w", op, err)
@@ -99,7 +343,14 @@ func (s *Service) CheckEmailOTP(ctx context.Context, userID, otp string) error {
 	}
 
 	if otpFromDB != otp {
-		return fmt.Errorf("invalid OTP")
+		return fmt.Errorf("%s: %w", op, s.failOTP(ctx, account, ip, func() error {
+			return s.ConfirmEmailRepo.ExpireEmailOTP(ctx, userID)
+		}))
+	}
+
+	err = s.succeed(ctx, account, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	err = s.AuthRepo.EmailVerification(ctx, userID)
@@ -118,6 +369,14 @@ func (s *Service) CheckEmailOTP(ctx context.Context, userID, otp string) error {
 func (s *Service) CheckPasswordOTP(ctx context.Context, email, otp string) error {
 	const op = "service.auth.CheckPasswordOTP"
 
+	account := attempt.AccountLimit(attempt.KindPasswordOTP, email)
+	ip := attempt.IPLimit(ctx, attempt.KindPasswordOTP)
+
+	err := s.Limiter.Take(ctx, account, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	exist, err := s.ConfirmEmailRepo.CheckPasswordOTPExists(ctx, email)
 	if err != nil {
 		return fmt.Errorf("%s : %w", op, err)
@@ -138,7 +397,14 @@ func (s *Service) CheckPasswordOTP(ctx context.Context, email, otp string) error
 	}
 
 	if otpFromDB != otp {
-		return fmt.Errorf("invalid OTP")
+		return fmt.Errorf("%s: %w", op, s.failOTP(ctx, account, ip, func() error {
+			return s.ConfirmEmailRepo.ExpirePasswordOTP(ctx, email)
+		}))
+	}
+
+	err = s.succeed(ctx, account, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	err = s.AuthRepo.PasswordVerification(ctx, email)
@@ -152,6 +418,14 @@ func (s *Service) CheckPasswordOTP(ctx context.Context, email, otp string) error
 func (s *Service) CheckEmailChangeOTP(ctx context.Context, userID, otp string) error {
 	const op = "service.auth.CheckEmailChangeOTP"
 
+	account := attempt.AccountLimit(attempt.KindEmailChangeOTP, userID)
+	ip := attempt.IPLimit(ctx, attempt.KindEmailChangeOTP)
+
+	err := s.Limiter.Take(ctx, account, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	exist, err := s.ConfirmEmailRepo.CheckEmailChangeOTPExists(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("%s : %w", op, err)
@@ -171,7 +445,14 @@ func (s *Service) CheckEmailChangeOTP(ctx context.Context, userID, otp string) e
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	if otpFromDB != otp {
-		return fmt.Errorf("invalid OTP")
+		return fmt.Errorf("%s: %w", op, s.failOTP(ctx, account, ip, func() error {
+			return s.ConfirmEmailRepo.ExpireEmailChangeOTP(ctx, userID)
+		}))
+	}
+
+	err = s.succeed(ctx, account, ip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	err = s.AuthRepo.ConfirmEmailChangeOTP(ctx, userID)
@@ -182,6 +463,36 @@ func (s *Service) CheckEmailChangeOTP(ctx context.Context, userID, otp string) e
 	return nil
 }
 
+// succeed forgets the failures of the account and takes back the attempt counted on the address
+func (s *Service) succeed(ctx context.Context, account, ip attempt.Limit) error {
+	err := s.Limiter.Reset(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	return s.Limiter.Release(ctx, ip)
+}
+
+// failOTP answers the wrong OTP, its attempt is already counted. The OTP is invalidated by expire when the attempts
+// got locked, it can't be guessed further and a new one is to be requested after the lock.
+func (s *Service) failOTP(ctx context.Context, account, ip attempt.Limit, expire func() error) error {
+	locked := s.Limiter.Check(ctx, account, ip)
+	if locked == nil {
+		return service.ErrInvalidOTP
+	}
+
+	if !errors.Is(locked, service.ErrTooManyAttempts) {
+		return locked
+	}
+
+	err := expire()
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w, it is invalidated: %w", service.ErrInvalidOTP, locked)
+}
+
 func (s *Service) validate(user model.Registration) bool {
 	if strings.TrimSpace(user.Name) == "" || strings.TrimSpace(user.Email) == "" {
 		return false
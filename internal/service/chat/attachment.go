@@ -0,0 +1,127 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/internal/service/file"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// maxAttachmentName is the length of the name column
+const maxAttachmentName = 255
+
+// SendAttachment saves the image or the pdf of the participant and sends the message carrying it,
+// service.ErrMessageNotDelivered comes with the saved message when the connections are not notified
+func (s *Service) SendAttachment(ctx context.Context, chatID, userID, name, text string, data []byte) (*message.Message, error) {
+	const op = "service.chat.SendAttachment"
+
+	_, err := s.GetParticipantChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if utf8.RuneCountInString(text) > message.MaxTextLength {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrMessageTooLong)
+	}
+
+	att, ext, err := newAttachment(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	att.Path, err = s.FileSvc.UploadPrivateFile(data, ext, file.FilePathChatAttachments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saved, err := s.Repo.SendAttachmentInChat(ctx, chatID, userID, text, att)
+	if err != nil {
+		if rmErr := s.FileSvc.RemovePrivateFile(att.Path); rmErr != nil {
+			return nil, fmt.Errorf("%s: %w: %w", op, err, rmErr)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.Broadcast(ctx, chatID, chat.MessageFrame(*saved))
+	if err != nil {
+		return saved, fmt.Errorf("%s: %w: %s", op, service.ErrMessageNotDelivered, err.Error())
+	}
+
+	return saved, nil
+}
+
+// OpenAttachment opens the file of the attachment for the participant, the caller closes it
+func (s *Service) OpenAttachment(ctx context.Context, chatID, userID, attachmentID string) (*message.Attachment, *os.File, error) {
+	const op = "service.chat.OpenAttachment"
+
+	_, err := s.GetParticipantChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = uuid.Parse(attachmentID); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, service.ErrAttachmentNotFound)
+	}
+
+	att, err := s.Repo.GetAttachment(ctx, chatID, attachmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if att == nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, service.ErrAttachmentNotFound)
+	}
+
+	f, err := s.FileSvc.OpenPrivateFile(att.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return att, f, nil
+}
+
+// newAttachment tells the type of the file by its content and returns the attachment with the file extension
+func newAttachment(name string, data []byte) (message.Attachment, string, error) {
+	att := message.Attachment{
+		MimeType: http.DetectContentType(data),
+		Name:     filepath.Base(name),
+		Size:     int64(len(data)),
+	}
+
+	// the long names keep their ending with the extension
+	if runes := []rune(att.Name); len(runes) > maxAttachmentName {
+		att.Name = string(runes[len(runes)-maxAttachmentName:])
+	}
+
+	switch att.MimeType {
+	case "image/jpeg", "image/png":
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return message.Attachment{}, "", service.ErrInvalidAttachment
+		}
+
+		att.Type = message.AttachmentImage
+		att.Width, att.Height = &cfg.Width, &cfg.Height
+
+		if att.MimeType == "image/png" {
+			return att, string(file.PngImageType), nil
+		}
+		return att, string(file.JpgImageType), nil
+	case "application/pdf":
+		att.Type = message.AttachmentPDF
+		return att, ".pdf", nil
+	default:
+		return message.Attachment{}, "", service.ErrInvalidAttachment
+	}
+}
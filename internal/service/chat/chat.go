@@ -2,17 +2,23 @@ package chat
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/message"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 )
 
 type Service struct {
-	Repo interfaces.ChatRepository
+	Repo    interfaces.ChatRepository
+	PubSub  interfaces.ChatPubSub
+	FileSvc interfaces.FileService
 }
 
-func (s *Service) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Chat, error) {
+func (s *Service) GetChatsByUserID(ctx context.Context, userID string) ([]*chat.Overview, error) {
 	const op = "service.chat.GetChatsByUserID"
 
 	chats, err := s.Repo.GetChatsByUserID(ctx, userID)
@@ -45,12 +51,12 @@ func (s *Service) GetOrCreateChatID(ctx context.Context, userID, otherUserID str
 	return chats, nil
 }
 
-func (s *Service) GetMessagesByChatID(ctx context.Context, chatID string) ([]*message.Entity, error) {
+func (s *Service) GetMessagesByChatID(ctx context.Context, chatID string, page api.PageRequest) (api.Page[message.Message], error) {
 	const op = "service.chat.GetMessagesByChatID"
 
-	messages, err := s.Repo.GetMessagesByChatID(ctx, chatID)
+	messages, err := s.Repo.GetMessagesByChatID(ctx, chatID, page)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[message.Message]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return messages, nil
@@ -67,13 +73,133 @@ func (s *Service) SendMessage(ctx context.Context, senderId string, receiverId s
 	return nil
 }
 
+// SendMessageInChat saves the message of the participant and delivers it to the chat,
+// service.ErrMessageNotDelivered tells the message is saved but the connections are not notified
 func (s *Service) SendMessageInChat(ctx context.Context, chatId, senderId string, msg message.Entity) error {
 	const op = "service.chat.SendMessageInChat"
 
-	err := s.Repo.SendMessageInChat(ctx, chatId, senderId, msg)
+	// The participants change while the connections stay open, the operator leaves the chat with the escalation closed
+	_, err := s.GetParticipantChat(ctx, chatId, senderId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	saved, err := s.Repo.SendMessageInChat(ctx, chatId, senderId, msg)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	err = s.Broadcast(ctx, chatId, chat.MessageFrame(*saved))
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrMessageNotDelivered, err.Error())
+	}
+
 	return nil
 }
+
+// SendTyping tells the other participants the user is typing, it is not saved
+func (s *Service) SendTyping(ctx context.Context, chatID, userID string) error {
+	const op = "service.chat.SendTyping"
+
+	_, err := s.GetParticipantChat(ctx, chatID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.Broadcast(ctx, chatID, chat.Frame{Type: chat.FrameTyping, ChatID: chatID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkRead moves the read marker of the participant to the message and tells the others about it,
+// service.ErrMarkerNotDelivered comes with the saved marker when they are not told
+func (s *Service) MarkRead(ctx context.Context, chatID, userID, messageID string) (*chat.ReadMarker, error) {
+	const op = "service.chat.MarkRead"
+
+	_, err := s.GetParticipantChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = uuid.Parse(messageID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrMessageNotFound)
+	}
+
+	marker, err := s.Repo.MarkRead(ctx, chatID, userID, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if marker == nil {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrMessageNotFound)
+	}
+
+	err = s.Broadcast(ctx, chatID, chat.ReadFrame(*marker))
+	if err != nil {
+		return marker, fmt.Errorf("%s: %w: %s", op, service.ErrMarkerNotDelivered, err.Error())
+	}
+
+	return marker, nil
+}
+
+func (s *Service) GetReadMarkers(ctx context.Context, chatID string) ([]chat.ReadMarker, error) {
+	const op = "service.chat.GetReadMarkers"
+
+	markers, err := s.Repo.GetReadMarkers(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return markers, nil
+}
+
+// Broadcast delivers the frame to the connections of the chat on every instance except the one the request came from
+func (s *Service) Broadcast(ctx context.Context, chatID string, frame chat.Frame) error {
+	payload, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+
+	return s.PubSub.Publish(ctx, chat.Event{
+		ChatID:           chatID,
+		ExceptConnection: chat.ConnectionIDFromContext(ctx),
+		Payload:          payload,
+	})
+}
+
+// Disconnect delivers the frame to the connections of the chat and closes the ones of the user leaving it
+func (s *Service) Disconnect(ctx context.Context, chatID, userID string, frame chat.Frame) error {
+	payload, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+
+	return s.PubSub.Publish(ctx, chat.Event{
+		ChatID:         chatID,
+		DisconnectUser: userID,
+		Payload:        payload,
+	})
+}
+
+// GetParticipantChat returns the chat the user takes part in
+func (s *Service) GetParticipantChat(ctx context.Context, chatID, userID string) (*chat.Chat, error) {
+	const op = "service.chat.GetParticipantChat"
+
+	found, err := s.Repo.GetChatByChatID(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrChatNotFound)
+	}
+
+	if !found.IsParticipant(userID) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrNotChatParticipant)
+	}
+
+	return found, nil
+}
@@ -5,17 +5,24 @@ import (
 	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/attempt"
 	"github.com/imperatorofdwelling/Full-backend/pkg/sendMail"
 )
 
 type Service struct {
 	ConfirmEmailRepo interfaces.ConfirmEmailRepository
 	UserRepo         interfaces.UserRepository
+	Limiter          interfaces.AttemptLimiter
 }
 
 func (s *Service) CreateOTPEmail(ctx context.Context, userID string) error {
 	const op = "service.confirmEmail.CreateOTP"
 
+	err := s.throttleResend(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
 	exist, err := s.ConfirmEmailRepo.CheckEmailOTPExists(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("%s : %w", op, err)
@@ -63,6 +70,11 @@ func (s *Service) CreateOTPEmail(ctx context.Context, userID string) error {
 func (s *Service) CreateOTPPassword(ctx context.Context, email string) error {
 	const op = "service.confirmEmail.CreateOTPPassword"
 
+	err := s.throttleResend(ctx, email)
+	if err != nil {
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
 	userExists, err := s.UserRepo.CheckUserExists(ctx, email)
 	if err != nil {
 		return fmt.Errorf("%s : %w", op, err)
@@ -123,6 +135,11 @@ func (s *Service) CreateOTPPassword(ctx context.Context, email string) error {
 func (s *Service) SendOtpForEmailChange(ctx context.Context, userID string) error {
 	const op = "service.confirmEmail.SendOtpForEmailChange"
 
+	err := s.throttleResend(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
 	exist, err := s.ConfirmEmailRepo.CheckEmailChangeOTPExists(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("%s : %w", op, err)
@@ -169,6 +186,13 @@ func (s *Service) SendOtpForEmailChange(ctx context.Context, userID string) erro
 
 }
 
+// throttleResend counts the OTP requested for the account, the requests over attempt.ResendPolicy are rejected
+func (s *Service) throttleResend(ctx context.Context, account string) error {
+	limits := []attempt.Limit{attempt.ResendLimit(account), attempt.IPLimit(ctx, attempt.KindOTPResend)}
+
+	return s.Limiter.Take(ctx, limits...)
+}
+
 func (s *Service) SendOTPEmail(ctx context.Context, userID, userOTP, title string) error {
 	const op = "service.confirmEmail.sendOTPEmail"
 
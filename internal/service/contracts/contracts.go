@@ -2,20 +2,39 @@ package contracts
 
 import (
 	"fmt"
+	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/contracts"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	"golang.org/x/net/context"
 	"time"
 )
 
 type Service struct {
-	Repo interfaces.ContractsRepo
+	Repo       interfaces.ContractsRepo
+	PrcSvc     interfaces.PricingService
+	ReservRepo interfaces.ReservationRepo
+}
+
+// bookedStatuses are the statuses of the reservation the contract is made for
+var bookedStatuses = []reservation.Status{
+	reservation.StatusRequested,
+	reservation.StatusApproved,
+	reservation.StatusPaid,
+	reservation.StatusCheckedIn,
 }
 
 func (s *Service) AddContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time) error {
 	const op = "service.Contracts.AddContract"
 
-	err := s.Repo.AddContract(ctx, userId, stayId, dateStart, dateEnd)
+	quote, err := s.quote(ctx, userId, stayId, dateStart, dateEnd)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.Repo.AddContract(ctx, userId, stayId, dateStart, dateEnd, quote.Total)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -25,7 +44,12 @@ func (s *Service) AddContract(ctx context.Context, userId, stayId string, dateSt
 func (s *Service) UpdateContract(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time) (*contracts.ContractEntity, error) {
 	const op = "service.Contracts.UpdateContract"
 
-	contract, err := s.Repo.UpdateContract(ctx, userId, stayId, dateStart, dateEnd)
+	quote, err := s.quote(ctx, userId, stayId, dateStart, dateEnd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	contract, err := s.Repo.UpdateContract(ctx, userId, stayId, dateStart, dateEnd, quote.Total)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -42,3 +66,24 @@ func (s *Service) GetAllContracts(ctx context.Context, userId string) ([]contrac
 	}
 	return allContracts, nil
 }
+
+// quote prices the contract with the stay pricing rules for the guests of the user's reservation of the stay,
+// the contract of the user without a reservation is priced for a single guest
+func (s *Service) quote(ctx context.Context, userId, stayId string, dateStart, dateEnd time.Time) (*pricing.Quote, error) {
+	stayID, err := uuid.FromString(stayId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", service.ErrValid, err.Error())
+	}
+
+	reserv, err := s.ReservRepo.GetReservationByStayAndUser(ctx, stayID, uuid.FromStringOrNil(userId), bookedStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	guests := 1
+	if reserv != nil {
+		guests = reserv.Guests
+	}
+
+	return s.PrcSvc.Quote(ctx, stayID, dateStart, dateEnd, guests)
+}
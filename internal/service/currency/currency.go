@@ -0,0 +1,110 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+)
+
+type Service struct {
+	Repo interfaces.CurrencyRepo
+}
+
+func (s *Service) GetRates(ctx context.Context) ([]money.Rate, error) {
+	const op = "service.currency.GetRates"
+
+	rates, err := s.Repo.GetRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rates, nil
+}
+
+func (s *Service) GetRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	const op = "service.currency.GetRate"
+
+	if err := currency.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	rate, err := s.Repo.GetRate(ctx, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rate == nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrCurrencyNotFound, currency)
+	}
+
+	return rate, nil
+}
+
+func (s *Service) SetRate(ctx context.Context, currency money.Currency, rate float64) error {
+	const op = "service.currency.SetRate"
+
+	if err := currency.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	if rate <= 0 {
+		return fmt.Errorf("%s: %w: rate must be positive", op, service.ErrValid)
+	}
+
+	if currency == money.BaseCurrency && rate != 1 {
+		return fmt.Errorf("%s: %w: rate of the base currency %s is always 1", op, service.ErrValid, money.BaseCurrency)
+	}
+
+	err := s.Repo.SetRate(ctx, currency, rate)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteRate(ctx context.Context, currency money.Currency) error {
+	const op = "service.currency.DeleteRate"
+
+	if currency == money.BaseCurrency {
+		return fmt.Errorf("%s: %w: base currency %s can't be deleted", op, service.ErrValid, money.BaseCurrency)
+	}
+
+	if _, err := s.GetRate(ctx, currency); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := s.Repo.DeleteRate(ctx, currency)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) Convert(ctx context.Context, amount money.Money, to money.Currency) (money.Money, error) {
+	const op = "service.currency.Convert"
+
+	if amount.Currency == to {
+		return amount, nil
+	}
+
+	from, err := s.GetRate(ctx, amount.Currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	toRate, err := s.GetRate(ctx, to)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	converted, err := amount.Convert(*from, *toRate)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converted, nil
+}
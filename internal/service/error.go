@@ -11,12 +11,30 @@ var (
 
 	ErrStayNotFound      = errors.New("stay not found")
 	ErrStayImageNotFound = errors.New("stay image not found")
+	ErrInvalidGeoQuery   = errors.New("invalid geo query")
+	ErrInvalidSearch     = errors.New("invalid search query")
+	ErrHistoryNotSaved   = errors.New("search is done but the history is not saved")
+
+	ErrCurrencyNotFound = errors.New("currency not found")
+	ErrCurrencyInUse    = errors.New("currency is used by stays")
+
+	ErrInvalidPricing = errors.New("invalid pricing")
+	ErrInvalidQuote   = errors.New("invalid quote")
+	ErrSeasonNotFound = errors.New("seasonal price not found")
+	ErrSeasonOverlap  = errors.New("seasonal prices overlap")
 
 	ErrAdvantageNotFound = errors.New("advantage not found")
 
 	ErrNotFoundReservation = errors.New("reservation not found")
 
 	ErrStaysReviewNotFound = errors.New("stays review not found")
+	ErrStaysReviewExists   = errors.New("reservation is already reviewed")
+	ErrStayNotCompleted    = errors.New("reviews are open after the stay is completed")
+	ErrReplyExists         = errors.New("review is already replied")
+	ErrReviewWindowClosed  = errors.New("review window of the reservation is closed")
+	ErrReviewRevealed      = errors.New("review can't be changed once it is public")
+	ErrGuestReviewExists   = errors.New("guest of the reservation is already reviewed")
+	ErrGuestReviewNotFound = errors.New("guest review not found")
 	ErrEmailAlreadyExists  = errors.New("email already exists")
 	ErrNotFound            = errors.New("not found")
 	ErrUpdateFailed        = errors.New("update failed")
@@ -29,6 +47,69 @@ var (
 	ErrTimeNotCome          = errors.New("time not come")
 	ErrReservationNotFound  = errors.New("reservation not found")
 	ErrTimeHasNotCome       = errors.New("time has not come")
+	ErrDatesBlocked         = errors.New("dates blocked by owner")
+	ErrInvalidCalendarRange = errors.New("invalid calendar range")
+	ErrStayBlockNotFound    = errors.New("stay block not found")
+	ErrInvalidGuests        = errors.New("invalid number of guests")
+	ErrTooManyGuests        = errors.New("stay does not accommodate so many guests")
+
+	ErrInvalidStatusTransition   = errors.New("invalid reservation status transition")
+	ErrReservationNotEditable    = errors.New("reservation can't be changed in its status")
+	ErrNotReservationParticipant = errors.New("user is neither the guest nor the stay owner")
+	ErrNotReservationGuest       = errors.New("user is not the guest of the reservation")
+	ErrRefundNotFailed           = errors.New("reservation has no failed refund")
+
+	ErrPaymentNotFound     = errors.New("payment not found")
+	ErrPaymentExists       = errors.New("payment already exists")
+	ErrIdempotenceKeyTaken = errors.New("idempotence key is used by another payment")
+	ErrPaymentUnavailable  = errors.New("payment service unavailable")
+	ErrReservationPaying   = errors.New("reservation already has a pending or succeeded payment")
+
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrAdmObjectNotFound = errors.New("adm object not found")
+	ErrGrantNotFound     = errors.New("role is not granted the object")
+	ErrPermissionDenied  = errors.New("permission denied")
 
 	ErrUserNotOwner = errors.New("user not owner")
+
+	ErrReportNotFound        = errors.New("report not found")
+	ErrReportClosed          = errors.New("report is already resolved or rejected")
+	ErrResolutionNotNotified = errors.New("resolution is saved but the users are not notified")
+	ErrModeratorNotFound     = errors.New("moderator not found")
+
+	ErrStayIncomplete    = errors.New("stay listing is incomplete")
+	ErrInvalidStayStatus = errors.New("invalid stay status transition")
+
+	ErrChatNotFound        = errors.New("chat not found")
+	ErrNotChatParticipant  = errors.New("user is not a participant of the chat")
+	ErrMessageNotFound     = errors.New("message not found")
+	ErrInvalidAttachment   = errors.New("attachment must be a jpeg or png image or a pdf")
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrAttachmentTooLarge  = errors.New("attachment is too large")
+	ErrMessageTooLong      = errors.New("message text is too long")
+	ErrMessageNotDelivered = errors.New("message is saved but the chat is not notified")
+	ErrMarkerNotDelivered  = errors.New("read marker is saved but the chat is not notified")
+
+	ErrEscalationNotFound     = errors.New("escalation not found")
+	ErrEscalationClosed       = errors.New("escalation is already closed")
+	ErrEscalationAssigned     = errors.New("escalation is assigned to another operator")
+	ErrEscalationChanged      = errors.New("escalation was changed in the meantime")
+	ErrNotEscalationOperator  = errors.New("user is not the operator of the escalation")
+	ErrChatEscalated          = errors.New("chat is already escalated")
+	ErrOperatorNotFound       = errors.New("support operator not found")
+	ErrEscalationNotDelivered = errors.New("escalation is saved but the chat is not notified")
+
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token is reused, the session is revoked")
+
+	ErrTooManyAttempts = errors.New("too many attempts")
+	ErrInvalidOTP      = errors.New("invalid OTP")
+
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidAPIKey  = errors.New("invalid, expired or revoked api key")
+	ErrAPIKeyLimit    = errors.New("api keys limit is reached")
+
+	ErrUserSuspended = errors.New("user is suspended")
+	ErrUserBanned    = errors.New("user is banned")
 )
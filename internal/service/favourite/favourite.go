@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	stays2 "github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 )
 
 type Service struct {
@@ -33,12 +34,12 @@ func (s *Service) RemoveFromFavourites(ctx context.Context, userId, stayID strin
 	return nil
 }
 
-func (s *Service) GetAllFavourites(ctx context.Context, userID string) ([]stays2.StayEntityFav, error) {
+func (s *Service) GetAllFavourites(ctx context.Context, userID string, page api.PageRequest) (api.Page[stays2.StayEntityFav], error) {
 	const op = "service.Favourite.GetAllFavourites"
 
-	favorites, err := s.Repo.GetAllFavourites(ctx, userID)
+	favorites, err := s.Repo.GetAllFavourites(ctx, userID, page)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return api.Page[stays2.StayEntityFav]{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return favorites, nil
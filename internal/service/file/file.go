@@ -17,7 +17,8 @@ const (
 	SvgImageType     ImageType = ".svg"
 	UnknownImageType ImageType = "unknown"
 
-	MaxImageMemorySize = 2 * (1024 * 1024)
+	MaxImageMemorySize      = 2 * (1024 * 1024)
+	MaxAttachmentMemorySize = 10 * (1024 * 1024)
 )
 
 type PathType string
@@ -28,6 +29,16 @@ const (
 	FilePathUsersReportsImages PathType = "/images/users_reports_images"
 	FilePathStaysReportsImages PathType = "/images/stays_reports_images"
 	FilePathUsersPFPImages     PathType = "/images/users_pfp_images"
+
+	// FilePathChatAttachments is under the private root, only the chat participants download the attachments
+	FilePathChatAttachments PathType = "/chat_attachments"
+)
+
+const (
+	// staticRoot is served to everyone by the file handler
+	staticRoot = "./static"
+	// privateRoot is not served, the handlers owning its files check the access
+	privateRoot = "./private"
 )
 
 type Service struct{}
@@ -35,28 +46,73 @@ type Service struct{}
 func (s *Service) UploadImage(img []byte, t ImageType, filePath PathType) (string, error) {
 	const op = "service.FileService.CreateImage"
 
+	name, err := s.writeFile(staticRoot, img, string(t), filePath)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return name, nil
+}
+
+// UploadPrivateFile saves the file under the private root, it is not served by the file handler
+func (s *Service) UploadPrivateFile(data []byte, ext string, filePath PathType) (string, error) {
+	const op = "service.FileService.UploadPrivateFile"
+
+	name, err := s.writeFile(privateRoot, data, ext, filePath)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return name, nil
+}
+
+// OpenPrivateFile opens the file saved by UploadPrivateFile
+func (s *Service) OpenPrivateFile(fileName string) (*os.File, error) {
+	const op = "service.FileService.OpenPrivateFile"
+
+	f, err := os.Open(filepath.Join(privateRoot, filepath.Clean("/"+fileName)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return f, nil
+}
+
+func (s *Service) RemovePrivateFile(fileName string) error {
+	const op = "service.FileService.RemovePrivateFile"
+
+	err := os.Remove(filepath.Join(privateRoot, filepath.Clean("/"+fileName)))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// writeFile saves the data with a random name under the root and returns its name relative to the root
+func (s *Service) writeFile(root string, data []byte, ext string, filePath PathType) (string, error) {
 	fileName, err := s.GenRandomFileName()
 	if err != nil {
 		return "", err
 	}
 
-	fileWithPath := fmt.Sprintf("./static%s/%s%s", filePath, fileName, t)
+	fileWithPath := fmt.Sprintf("%s%s/%s%s", root, filePath, fileName, ext)
 
 	if err := os.MkdirAll(filepath.Dir(fileWithPath), os.ModePerm); err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", err
 	}
 
 	file, err := os.Create(fileWithPath)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", err
 	}
 	defer file.Close()
 
-	if _, err := file.Write(img); err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+	if _, err := file.Write(data); err != nil {
+		return "", err
 	}
 
-	return fmt.Sprintf("%s/%s%s", filePath, fileName, t), nil
+	return fmt.Sprintf("%s/%s%s", filePath, fileName, ext), nil
 }
 
 func (s *Service) RemoveFile(fileName string) error {
@@ -0,0 +1,88 @@
+package guestreviews
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/guestreviews"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"time"
+)
+
+type Service struct {
+	Repo      interfaces.GuestReviewsRepo
+	ResSvc    interfaces.ReservationService
+	StaysSvc  interfaces.StaysService
+	StRevRepo interfaces.StaysReviewsRepo
+}
+
+// CreateGuestReview adds the review of the guest of the completed reservation by the stay owner, one review
+// per reservation, while the review window of the reservation is open. The review stays hidden until the guest
+// reviews the stay or the window is closed.
+func (s *Service) CreateGuestReview(ctx context.Context, review *guestreviews.Entity, hostID string) (*guestreviews.GuestReview, error) {
+	const op = "service.guestreviews.CreateGuestReview"
+
+	err := review.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	reserv, err := s.ResSvc.GetReservationByID(ctx, review.ReservationID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stay, err := s.StaysSvc.GetStayByID(ctx, reserv.StayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if stay.UserID != uuid.FromStringOrNil(hostID) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
+	}
+
+	if reserv.Status != reservation.StatusCompleted {
+		return nil, fmt.Errorf("%s: %w: reservation is %s", op, service.ErrStayNotCompleted, reserv.Status)
+	}
+
+	if !reserv.CanBeReviewed(time.Now()) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrReviewWindowClosed)
+	}
+
+	review.StayID = reserv.StayID
+	review.HostID = stay.UserID
+	review.GuestID = reserv.UserID
+
+	id, err := s.Repo.CreateGuestReview(ctx, review)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the review of the stay written by the guest is revealed now and counts in the stay rating
+	err = s.StRevRepo.RecomputeStayRating(ctx, reserv.StayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := s.Repo.GetGuestReviewByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// GetGuestReviews returns the page of the public reviews of the guest
+func (s *Service) GetGuestReviews(ctx context.Context, guestID uuid.UUID, page api.PageRequest) (api.Page[guestreviews.GuestReview], error) {
+	const op = "service.guestreviews.GetGuestReviews"
+
+	reviews, err := s.Repo.GetGuestReviewsByGuestID(ctx, guestID, page)
+	if err != nil {
+		return api.Page[guestreviews.GuestReview]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
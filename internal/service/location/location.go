@@ -7,6 +7,7 @@ import (
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	models "github.com/imperatorofdwelling/Full-backend/internal/domain/models/location"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"strings"
 )
 
@@ -40,12 +41,12 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*models.Location,
 	return loc, nil
 }
 
-func (s *Service) GetAll(ctx context.Context) (*[]models.Location, error) {
+func (s *Service) GetAll(ctx context.Context, page api.PageRequest) (api.Page[models.Location], error) {
 	const op = "service.location.GetAll"
 
-	locs, err := s.Repo.GetAll(ctx)
+	locs, err := s.Repo.GetAll(ctx, page)
 	if err != nil {
-		return nil, err
+		return api.Page[models.Location]{}, err
 	}
 
 	return locs, nil
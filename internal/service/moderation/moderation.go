@@ -0,0 +1,211 @@
+package moderation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/moderation"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"github.com/imperatorofdwelling/Full-backend/pkg/sendMail"
+)
+
+// Route is the object of the moderators, the roles granted it see and handle the reports
+const Route = "/moderation"
+
+type Service struct {
+	Repo     interfaces.ModerationRepo
+	UserRepo interfaces.UserRepository
+	UserSvc  interfaces.UserService
+	RoleSvc  interfaces.RoleService
+}
+
+func (s *Service) GetReports(ctx context.Context, filter moderation.Filter, page api.PageRequest) (api.Page[moderation.Report], error) {
+	const op = "service.moderation.GetReports"
+
+	err := filter.Validate()
+	if err != nil {
+		return api.Page[moderation.Report]{}, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	reports, err := s.Repo.GetReports(ctx, filter, page)
+	if err != nil {
+		return api.Page[moderation.Report]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reports, nil
+}
+
+// GetReport returns the report with the internal notes of the moderators
+func (s *Service) GetReport(ctx context.Context, reportType moderation.Type, id uuid.UUID) (*moderation.ReportDetails, error) {
+	const op = "service.moderation.GetReport"
+
+	report, err := s.getReport(ctx, reportType, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	notes, err := s.Repo.GetNotes(ctx, reportType, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &moderation.ReportDetails{Report: *report, Notes: notes}, nil
+}
+
+// AssignReport gives the report to a moderator, the one assigning takes it when no assignee is set.
+// The open report goes in review.
+func (s *Service) AssignReport(ctx context.Context, reportType moderation.Type, id uuid.UUID, assign *moderation.AssignEntity, moderatorID string) (*moderation.Report, error) {
+	const op = "service.moderation.AssignReport"
+
+	assigneeID := uuid.FromStringOrNil(moderatorID)
+	if assign.AssigneeID != nil {
+		assigneeID = *assign.AssigneeID
+
+		err := s.checkModerator(ctx, assigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	report, err := s.getReport(ctx, reportType, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if report.Status.IsClosed() {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrReportClosed)
+	}
+
+	err = s.Repo.AssignReport(ctx, reportType, id, assigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report, err = s.Repo.GetReport(ctx, reportType, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return report, nil
+}
+
+func (s *Service) CreateNote(ctx context.Context, reportType moderation.Type, id uuid.UUID, note *moderation.NoteEntity, authorID string) (*moderation.Note, error) {
+	const op = "service.moderation.CreateNote"
+
+	err := note.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	_, err = s.getReport(ctx, reportType, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := s.Repo.CreateNote(ctx, reportType, id, uuid.FromStringOrNil(authorID), note.Text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// ResolveReport closes the report, applies the action of the resolution and emails the reporter.
+// The warned user is emailed the comment of the resolution. The resolution is kept when the emails fail,
+// the error is service.ErrResolutionNotNotified then.
+func (s *Service) ResolveReport(ctx context.Context, reportType moderation.Type, id uuid.UUID, resolution *moderation.ResolutionEntity, moderatorID string) error {
+	const op = "service.moderation.ResolveReport"
+
+	report, err := s.getReport(ctx, reportType, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = resolution.Validate(report.Type)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	if report.Status.IsClosed() {
+		return fmt.Errorf("%s: %w", op, service.ErrReportClosed)
+	}
+
+	// the user service drops the cached suspension, the report is not resolved while the user is not suspended
+	if resolution.Action != nil && *resolution.Action == moderation.ActionSuspendUser {
+		err = s.UserSvc.ExtendSuspension(ctx, report.ReportedUserID.String(), resolution.Comment, resolution.SuspendDays)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = s.Repo.ResolveReport(ctx, report, resolution, uuid.FromStringOrNil(moderatorID))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = notifyResolution(report, resolution)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrResolutionNotNotified, err.Error())
+	}
+
+	return nil
+}
+
+func (s *Service) getReport(ctx context.Context, reportType moderation.Type, id uuid.UUID) (*moderation.Report, error) {
+	err := reportType.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", service.ErrValid, err.Error())
+	}
+
+	return s.Repo.GetReport(ctx, reportType, id)
+}
+
+// checkModerator makes sure the role of the user is granted the moderation
+func (s *Service) checkModerator(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.UserRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return service.ErrModeratorNotFound
+		}
+		return err
+	}
+
+	allowed, err := s.RoleSvc.HasPermission(ctx, int(user.RoleID), Route, role.ActionRead)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fmt.Errorf("%w: user %s is not a moderator", service.ErrModeratorNotFound, userID)
+	}
+
+	return nil
+}
+
+func notifyResolution(report *moderation.Report, resolution *moderation.ResolutionEntity) error {
+	body := fmt.Sprintf("Your report %q is %s.", report.Title, resolution.Status)
+	if resolution.Comment != "" {
+		body += "\r\n\r\n" + resolution.Comment
+	}
+
+	err := sendMail.EmailSend(report.ReporterEmail, "Report "+string(resolution.Status), body)
+	if err != nil {
+		return err
+	}
+
+	if resolution.Action != nil && *resolution.Action == moderation.ActionWarn {
+		body = "We received a report about you and ask you to follow the rules of the platform."
+		if resolution.Comment != "" {
+			body += "\r\n\r\n" + resolution.Comment
+		}
+
+		return sendMail.EmailSend(report.ReportedUserEmail, "Warning", body)
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	yoomodel "github.com/eclipsemode/go-yookassa-sdk/yookassa/model"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/api/kafka"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/payment"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+)
+
+type Service struct {
+	Repo     interfaces.PaymentRepo
+	ResSvc   interfaces.ReservationService
+	PrcSvc   interfaces.PricingService
+	Producer interfaces.KafkaProducer
+	Payouts  interfaces.PayoutProducer
+}
+
+// CreatePayment records the payment of the reservation and sends it to the payment provider.
+// A repeated request with the same key returns the recorded payment without creating a new one,
+// the returned flag tells whether the payment was created by this call.
+func (s *Service) CreatePayment(ctx context.Context, key string, reservationID uuid.UUID, userID string, req yoomodel.Payment) (*payment.Payment, bool, error) {
+	const op = "service.payment.CreatePayment"
+
+	userUUID := uuid.FromStringOrNil(userID)
+
+	existing, err := s.existingPayment(ctx, key, reservationID, userUUID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	reserv, err := s.ResSvc.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reserv.UserID != userUUID {
+		return nil, false, fmt.Errorf("%s: %w", op, service.ErrNotReservationParticipant)
+	}
+
+	if !reserv.Status.CanTransitionTo(reservation.StatusPaid) {
+		return nil, false, fmt.Errorf("%s: %w: %s reservation can't be paid", op, service.ErrInvalidStatusTransition, reserv.Status)
+	}
+
+	amount, err := s.checkAmount(ctx, reserv, req.Amount)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	request, err := json.Marshal(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pay := &payment.Payment{
+		IdempotenceKey: key,
+		ReservationID:  &reservationID,
+		UserID:         &userUUID,
+		Status:         payment.StatusPending,
+		Amount:         amount,
+		Request:        request,
+	}
+
+	err = s.Repo.CreatePayment(ctx, pay)
+	if err != nil {
+		// Two requests with the same key raced and the other one has created the payment,
+		// or the reservation is already being paid with another key
+		if errors.Is(err, service.ErrPaymentExists) {
+			existing, err = s.existingPayment(ctx, key, reservationID, userUUID)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", op, err)
+			}
+			if existing == nil {
+				return nil, false, fmt.Errorf("%s: %w", op, service.ErrReservationPaying)
+			}
+			return existing, false, nil
+		}
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.Producer.SendMessage(kafka.PaymentReqTopic, key, req)
+	if err != nil {
+		// The payment never reached the provider, the client may retry with the same key
+		if delErr := s.Repo.DeletePaymentByKey(ctx, key); delErr != nil {
+			return nil, false, fmt.Errorf("%s: %w", op, delErr)
+		}
+		return nil, false, fmt.Errorf("%s: %w: %s", op, service.ErrPaymentUnavailable, err.Error())
+	}
+
+	return pay, true, nil
+}
+
+func (s *Service) GetPayment(ctx context.Context, key string, userID string) (*payment.Payment, error) {
+	const op = "service.payment.GetPayment"
+
+	pay, err := s.Repo.GetPaymentByKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if pay.UserID == nil || *pay.UserID != uuid.FromStringOrNil(userID) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrPaymentNotFound)
+	}
+
+	return pay, nil
+}
+
+// HandleProviderResponse stores the payment status reported by the provider, a succeeded payment marks the reservation as paid.
+// The response may come more than once, the repeated one only settles the reservation again.
+func (s *Service) HandleProviderResponse(ctx context.Context, key string, res yoomodel.Payment) error {
+	const op = "service.payment.HandleProviderResponse"
+
+	pay, err := s.Repo.GetPaymentByKey(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !pay.Status.IsFinal() {
+		response, err := json.Marshal(res)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		pay.Status = payment.Status(res.Status)
+
+		err = s.Repo.UpdatePaymentStatus(ctx, key, res.ID, pay.Status, response)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if pay.Status != payment.StatusSucceeded {
+		return nil
+	}
+
+	err = s.settle(ctx, pay)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// settle marks the reservation of the succeeded payment as paid. The reservation may have been cancelled,
+// declined or removed while the payment was pending, then the guest gets the whole payment back.
+func (s *Service) settle(ctx context.Context, pay *payment.Payment) error {
+	if pay.ReservationID == nil {
+		return s.refund(pay, "the reservation is removed")
+	}
+
+	reserv, err := s.ResSvc.GetReservationByID(ctx, *pay.ReservationID)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFoundReservation) {
+			return s.refund(pay, "the reservation is removed")
+		}
+		return err
+	}
+
+	switch {
+	case reserv.Status == reservation.StatusPaid:
+		return nil
+	case reserv.Status.CanTransitionTo(reservation.StatusPaid):
+		return s.ResSvc.ChangeStatus(ctx, reserv.ID, reservation.StatusPaid, nil, "payment "+pay.IdempotenceKey+" succeeded")
+	default:
+		return s.refund(pay, fmt.Sprintf("the reservation is %s", reserv.Status))
+	}
+}
+
+// refund requests the whole payment back from the payout service, the key lets it drop the repeated requests
+func (s *Service) refund(pay *payment.Payment, reason string) error {
+	req := reservation.RefundRequest{
+		IdempotenceKey: "refund-payment-" + pay.IdempotenceKey,
+		Amount:         pay.Amount,
+		Description:    fmt.Sprintf("Refund of the payment %s, %s", pay.IdempotenceKey, reason),
+	}
+
+	if pay.ReservationID != nil {
+		req.ReservationID = *pay.ReservationID
+	}
+	if pay.UserID != nil {
+		req.UserID = *pay.UserID
+	}
+
+	return s.Payouts.RequestRefund(req)
+}
+
+// existingPayment returns the payment recorded with the key, the key can't be reused for another reservation or user
+func (s *Service) existingPayment(ctx context.Context, key string, reservationID, userID uuid.UUID) (*payment.Payment, error) {
+	pay, err := s.Repo.GetPaymentByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, service.ErrPaymentNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if pay.ReservationID == nil || *pay.ReservationID != reservationID || pay.UserID == nil || *pay.UserID != userID {
+		return nil, service.ErrIdempotenceKeyTaken
+	}
+
+	return pay, nil
+}
+
+// checkAmount makes sure the guest pays the price of the reservation quoted for its guests
+func (s *Service) checkAmount(ctx context.Context, reserv *reservation.Reservation, amount *yoomodel.Amount) (money.Money, error) {
+	if amount == nil {
+		return money.Money{}, fmt.Errorf("%w: payment amount is required", service.ErrValid)
+	}
+
+	value, err := money.ParseAmount(amount.Value)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%w: invalid payment amount %q", service.ErrValid, amount.Value)
+	}
+
+	currency, err := money.ParseCurrency(string(amount.Currency))
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%w: %s", service.ErrValid, err.Error())
+	}
+
+	quote, err := s.PrcSvc.Quote(ctx, reserv.StayID, reserv.Arrived, reserv.Departure, reserv.Guests)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	paid := money.New(value, currency)
+
+	if paid != quote.Total {
+		return money.Money{}, fmt.Errorf("%w: payment amount must be %s", service.ErrValid, quote.Total)
+	}
+
+	return paid, nil
+}
@@ -0,0 +1,158 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"time"
+)
+
+type Service struct {
+	Repo interfaces.PricingRepo
+}
+
+func (s *Service) GetRules(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error) {
+	const op = "service.pricing.GetRules"
+
+	if _, err := s.getBase(ctx, stayID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rules, err := s.Repo.GetRules(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rules.Seasons, err = s.Repo.GetSeasons(ctx, stayID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
+
+func (s *Service) UpdateRules(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity, userID string) error {
+	const op = "service.pricing.UpdateRules"
+
+	if err := rules.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrInvalidPricing, err.Error())
+	}
+
+	if err := s.checkOwner(ctx, stayID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := s.Repo.UpsertRules(ctx, stayID, rules)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) CreateSeason(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity, userID string) error {
+	const op = "service.pricing.CreateSeason"
+
+	if err := season.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrInvalidPricing, err.Error())
+	}
+
+	if err := s.checkOwner(ctx, stayID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	overlaps, err := s.Repo.CheckSeasonOverlaps(ctx, stayID, season.DateStart, season.DateEnd)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if overlaps {
+		return fmt.Errorf("%s: %w", op, service.ErrSeasonOverlap)
+	}
+
+	err = s.Repo.CreateSeason(ctx, stayID, season)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteSeason(ctx context.Context, stayID, seasonID uuid.UUID, userID string) error {
+	const op = "service.pricing.DeleteSeason"
+
+	season, err := s.Repo.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if season == nil || season.StayID != stayID {
+		return fmt.Errorf("%s: %w", op, service.ErrSeasonNotFound)
+	}
+
+	if err = s.checkOwner(ctx, stayID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.Repo.DeleteSeason(ctx, seasonID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) Quote(ctx context.Context, stayID uuid.UUID, arrival, departure time.Time, guests int) (*pricing.Quote, error) {
+	const op = "service.pricing.Quote"
+
+	base, err := s.getBase(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rules, err := s.Repo.GetRules(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rules.Seasons, err = s.Repo.GetSeasons(ctx, stayID, pricing.Day(arrival), pricing.Day(departure))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	quote, err := pricing.Calculate(*base, *rules, arrival, departure, guests)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrInvalidQuote, err.Error())
+	}
+
+	return quote, nil
+}
+
+func (s *Service) getBase(ctx context.Context, stayID uuid.UUID) (*pricing.Base, error) {
+	base, err := s.Repo.GetBase(ctx, stayID)
+	if err != nil {
+		return nil, err
+	}
+
+	if base == nil {
+		return nil, service.ErrStayNotFound
+	}
+
+	return base, nil
+}
+
+func (s *Service) checkOwner(ctx context.Context, stayID uuid.UUID, userID string) error {
+	base, err := s.getBase(ctx, stayID)
+	if err != nil {
+		return err
+	}
+
+	if base.UserID != uuid.FromStringOrNil(userID) {
+		return service.ErrUserNotOwner
+	}
+
+	return nil
+}
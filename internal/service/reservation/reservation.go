@@ -2,32 +2,54 @@ package reservation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/cancellation"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"time"
 )
 
+// maxCalendarDays limits the range of a single calendar request.
+const maxCalendarDays = 366
+
 type Service struct {
-	Repo interfaces.ReservationRepo
+	Repo    interfaces.ReservationRepo
+	PrcSvc  interfaces.PricingService
+	Payouts interfaces.PayoutProducer
 }
 
 func (s *Service) ConfirmCheckOutReservation(ctx context.Context, userID string, stayID string) error {
 	const op = "service.reservation.ConfirmCheckOutReservation"
 
-	timeExist, err := s.Repo.CheckTimeForCheckOutReservation(ctx, userID, stayID)
+	userUUID, err := uuid.FromString(userID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if timeExist {
+	stayUUID, err := uuid.FromString(stayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	reserv, err := s.Repo.GetReservationByStayAndUser(ctx, stayUUID, userUUID, []reservation.Status{reservation.StatusCheckedIn})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reserv == nil {
+		return fmt.Errorf("%s: %w", op, service.ErrNoReservations)
+	}
+
+	if dayOf(reserv.Departure).After(dayOf(time.Now())) {
 		return fmt.Errorf("%s: %w", op, service.ErrTimeHasNotCome)
 	}
 
-	err = s.Repo.ConfirmCheckOutReservation(ctx, userID, stayID)
+	err = s.transit(ctx, reserv, reservation.StatusCompleted, &userUUID, "")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -35,7 +57,7 @@ func (s *Service) ConfirmCheckOutReservation(ctx context.Context, userID string,
 	return nil
 }
 
-func (s *Service) ConfirmCheckInReservation(ctx context.Context, userID string, stayID string, reserv reservation.ReservationCheckInEntity) error {
+func (s *Service) ConfirmCheckInReservation(ctx context.Context, userID string, stayID string, guest reservation.ReservationCheckInEntity) error {
 	const op = "service.reservation.ConfirmCheckInReservation"
 
 	userUUID, err := uuid.FromString(userID)
@@ -57,25 +79,65 @@ func (s *Service) ConfirmCheckInReservation(ctx context.Context, userID string,
 		return fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
 	}
 
-	exists, err := s.Repo.CheckIfReservationExistsByStayID(ctx, stayUUID)
+	reserv, err := s.Repo.GetReservationByStayAndUser(ctx, stayUUID, guest.UserID, []reservation.Status{reservation.StatusApproved, reservation.StatusPaid})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if !exists {
+	if reserv == nil {
 		return fmt.Errorf("%s: %w", op, service.ErrNoReservations)
 	}
 
-	dateErr, err := s.Repo.CheckIfArrivalIsCorrect(ctx, userUUID, stayUUID, reserv)
+	if dayOf(reserv.Arrived).After(dayOf(time.Now())) {
+		return fmt.Errorf("%s: %w", op, service.ErrTimeNotCome)
+	}
+
+	err = s.transit(ctx, reserv, reservation.StatusCheckedIn, &userUUID, "")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ApproveReservation lets the stay owner accept a reservation request
+func (s *Service) ApproveReservation(ctx context.Context, id uuid.UUID, ownerID string) error {
+	const op = "service.reservation.ApproveReservation"
+
+	err := s.ownerTransit(ctx, id, ownerID, reservation.StatusApproved, "")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeclineReservation lets the stay owner reject a reservation request, the dates become free again
+func (s *Service) DeclineReservation(ctx context.Context, id uuid.UUID, ownerID string, reason string) error {
+	const op = "service.reservation.DeclineReservation"
+
+	err := s.ownerTransit(ctx, id, ownerID, reservation.StatusDeclined, reason)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkNoShow lets the stay owner close a reservation whose guest didn't come on the arrival day
+func (s *Service) MarkNoShow(ctx context.Context, id uuid.UUID, ownerID string) error {
+	const op = "service.reservation.MarkNoShow"
+
+	reserv, err := s.Repo.GetReservationByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if !dateErr {
+	if dayOf(reserv.Arrived).After(dayOf(time.Now())) {
 		return fmt.Errorf("%s: %w", op, service.ErrTimeNotCome)
 	}
 
-	err = s.Repo.CheckInApproval(ctx, stayUUID, reserv)
+	err = s.ownerTransit(ctx, id, ownerID, reservation.StatusNoShow, "")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -83,31 +145,245 @@ func (s *Service) ConfirmCheckInReservation(ctx context.Context, userID string,
 	return nil
 }
 
-func (s *Service) CheckReservation(ctx context.Context, reservationObj *reservation.ReservationEntity, userID string) error {
-	const op = "service.reservation.CheckReservation"
+// CancelReservation cancels the reservation on behalf of the guest or the stay owner.
+// A paid reservation is refunded from its payment according to the cancellation policy in force at booking time,
+// or in full when the owner cancels it. The refund is requested from the payout service and recorded on the reservation,
+// a failed request doesn't undo the cancellation and is sent again by RetryRefund.
+func (s *Service) CancelReservation(ctx context.Context, id uuid.UUID, userID string, reason string) (*reservation.Refund, error) {
+	const op = "service.reservation.CancelReservation"
 
-	now := time.Now().Truncate(24 * time.Hour)
+	reserv, err := s.Repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	if reservationObj.Arrived.Before(now) {
-		return fmt.Errorf("%s: %w", op, service.ErrInvalidArrivalDate)
+	userUUID := uuid.FromStringOrNil(userID)
+
+	byOwner := false
+	if reserv.UserID != userUUID {
+		byOwner, err = s.Repo.CheckIfUserIsOwner(ctx, userUUID, reserv.StayID)
+		if err != nil && !errors.Is(err, service.ErrUserNotOwner) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !byOwner {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotReservationParticipant)
+		}
 	}
 
-	if !reservationObj.Departure.After(reservationObj.Arrived.Add(24*time.Hour - time.Nanosecond)) {
-		return fmt.Errorf("%s: %w", op, service.ErrInvalidDepartureDate)
+	if !reserv.Status.CanTransitionTo(reservation.StatusCancelled) {
+		return nil, fmt.Errorf("%s: %w: %s -> %s", op, service.ErrInvalidStatusTransition, reserv.Status, reservation.StatusCancelled)
+	}
+
+	refund := &reservation.Refund{Status: reservation.RefundNotRequired}
+
+	// Nothing was charged before the reservation is paid
+	paid := reserv.Status == reservation.StatusPaid
+	if paid {
+		refund.Percent, err = s.refundPercent(ctx, reserv, byOwner)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	uuidUserId := uuid.FromStringOrNil(userID)
+	err = s.transit(ctx, reserv, reservation.StatusCancelled, &userUUID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	exists, err := s.Repo.CheckReservationIfExistsByUserId(ctx, uuidUserId)
+	if paid {
+		s.requestRefund(ctx, reserv, refund)
+	}
+
+	err = s.Repo.SetRefund(ctx, id, refund)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refund, nil
+}
+
+// RetryRefund requests the failed refund of the cancelled reservation again, only the guest and the stay owner can retry it
+func (s *Service) RetryRefund(ctx context.Context, id uuid.UUID, userID string) (*reservation.Refund, error) {
+	const op = "service.reservation.RetryRefund"
+
+	reserv, err := s.Repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userUUID := uuid.FromStringOrNil(userID)
+
+	if reserv.UserID != userUUID {
+		owner, err := s.Repo.CheckIfUserIsOwner(ctx, userUUID, reserv.StayID)
+		if err != nil && !errors.Is(err, service.ErrUserNotOwner) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !owner {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotReservationParticipant)
+		}
+	}
+
+	if reserv.Refund == nil || reserv.Refund.Status != reservation.RefundFailed {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrRefundNotFailed)
+	}
+
+	refund := *reserv.Refund
+
+	s.requestRefund(ctx, reserv, &refund)
+
+	err = s.Repo.SetRefund(ctx, id, &refund)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &refund, nil
+}
+
+// refundPercent applies the cancellation policy saved on the reservation at booking time,
+// the owner cancellation is refunded in full
+func (s *Service) refundPercent(ctx context.Context, reserv *reservation.Reservation, byOwner bool) (float64, error) {
+	if byOwner {
+		return 100, nil
+	}
+
+	policy, err := s.Repo.GetReservationCancellationPolicy(ctx, reserv.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return policy.RefundPercent(cancellation.DaysBeforeArrival(time.Now(), reserv.Arrived)), nil
+}
+
+// requestRefund sends the refund percent of the paid amount to the payout service.
+// The refund is left failed when the payment can't be read or the request isn't sent, RetryRefund sends it again.
+func (s *Service) requestRefund(ctx context.Context, reserv *reservation.Reservation, refund *reservation.Refund) {
+	paid, err := s.Repo.GetPaidAmount(ctx, reserv.ID)
+	if err != nil {
+		refund.Status = reservation.RefundFailed
+		return
+	}
+
+	refund.Amount = paid.Percent(refund.Percent)
+	if refund.Amount.Amount <= 0 {
+		refund.Status = reservation.RefundNotRequired
+		return
+	}
+
+	err = s.Payouts.RequestRefund(reservation.RefundRequest{
+		IdempotenceKey: refundKey(reserv.ID),
+		ReservationID:  reserv.ID,
+		UserID:         reserv.UserID,
+		Amount:         refund.Amount,
+		Description:    fmt.Sprintf("Refund of the cancelled reservation %s", reserv.ID),
+	})
+
+	refund.Status = reservation.RefundRequested
+	if err != nil {
+		refund.Status = reservation.RefundFailed
+	}
+}
+
+// refundKey is the idempotence key of the reservation refund, a reservation is refunded once
+func refundKey(id uuid.UUID) string {
+	return "refund-" + id.String()
+}
+
+// ChangeStatus moves the reservation to the status if the lifecycle allows it.
+// changedBy is nil for the changes made by the system itself.
+func (s *Service) ChangeStatus(ctx context.Context, id uuid.UUID, to reservation.Status, changedBy *uuid.UUID, reason string) error {
+	const op = "service.reservation.ChangeStatus"
+
+	reserv, err := s.Repo.GetReservationByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if exists {
-		return fmt.Errorf("%s: %w", op, service.ErrAlreadyReserved)
+	err = s.transit(ctx, reserv, to, changedBy, reason)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = s.Repo.CheckReservationIsFree(ctx, reservationObj)
+	return nil
+}
+
+// GetReservationHistory returns the status changes of the reservation, only the guest and the stay owner can see them
+func (s *Service) GetReservationHistory(ctx context.Context, id uuid.UUID, userID string) ([]reservation.StatusChange, error) {
+	const op = "service.reservation.GetReservationHistory"
+
+	reserv, err := s.Repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userUUID := uuid.FromStringOrNil(userID)
+
+	if reserv.UserID != userUUID {
+		owner, err := s.Repo.CheckIfUserIsOwner(ctx, userUUID, reserv.StayID)
+		if err != nil && !errors.Is(err, service.ErrUserNotOwner) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !owner {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotReservationParticipant)
+		}
+	}
+
+	history, err := s.Repo.GetStatusHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
+
+func (s *Service) ownerTransit(ctx context.Context, id uuid.UUID, ownerID string, to reservation.Status, reason string) error {
+	reserv, err := s.Repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ownerUUID := uuid.FromStringOrNil(ownerID)
+
+	owner, err := s.Repo.CheckIfUserIsOwner(ctx, ownerUUID, reserv.StayID)
+	if err != nil {
+		return err
+	}
+
+	if !owner {
+		return service.ErrUserNotOwner
+	}
+
+	return s.transit(ctx, reserv, to, &ownerUUID, reason)
+}
+
+// transit validates the transition against the lifecycle and stores it with the audit record
+func (s *Service) transit(ctx context.Context, reserv *reservation.Reservation, to reservation.Status, changedBy *uuid.UUID, reason string) error {
+	if !reserv.Status.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", service.ErrInvalidStatusTransition, reserv.Status, to)
+	}
+
+	from := reserv.Status
+
+	return s.Repo.ChangeStatus(ctx, &reservation.StatusChange{
+		ReservationID: reserv.ID,
+		From:          &from,
+		To:            to,
+		ChangedBy:     changedBy,
+		Reason:        reason,
+	})
+}
+
+func (s *Service) CheckReservation(ctx context.Context, reservationObj *reservation.ReservationEntity, userID string) error {
+	const op = "service.reservation.CheckReservation"
+
+	err := checkGuests(reservationObj)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.checkDates(ctx, reservationObj, uuid.Nil)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -115,10 +391,59 @@ func (s *Service) CheckReservation(ctx context.Context, reservationObj *reservat
 	return nil
 }
 
+// checkGuests books the stay for one guest when the number of guests is omitted
+func checkGuests(reservationObj *reservation.ReservationEntity) error {
+	if reservationObj.Guests == 0 {
+		reservationObj.Guests = 1
+	}
+
+	if reservationObj.Guests < 0 {
+		return fmt.Errorf("%w: guests must not be negative", service.ErrInvalidGuests)
+	}
+
+	return nil
+}
+
+// checkDates checks that the dates are bookable and free of the blocks and the other reservations than except
+func (s *Service) checkDates(ctx context.Context, reservationObj *reservation.ReservationEntity, except uuid.UUID) error {
+	now := time.Now().Truncate(24 * time.Hour)
+
+	if reservationObj.Arrived.Before(now) {
+		return service.ErrInvalidArrivalDate
+	}
+
+	if !reservationObj.Departure.After(reservationObj.Arrived.Add(24*time.Hour - time.Nanosecond)) {
+		return service.ErrInvalidDepartureDate
+	}
+
+	err := s.Repo.CheckReservationIsFree(ctx, reservationObj, except)
+	if err != nil {
+		return err
+	}
+
+	return s.Repo.CheckReservationIsNotBlocked(ctx, reservationObj)
+}
+
 func (s *Service) CreateReservation(ctx context.Context, reserv *reservation.ReservationEntity, userID string) error {
 	const op = "service.reservation.CreateReservation"
 
-	err := s.Repo.CreateReservation(ctx, reserv, userID)
+	err := checkGuests(reserv)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	instantBook, err := s.Repo.GetStayInstantBook(ctx, reserv.StayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Request to book stays wait for the owner approval
+	status := reservation.StatusRequested
+	if instantBook {
+		status = reservation.StatusApproved
+	}
+
+	err = s.Repo.CreateReservation(ctx, reserv, userID, status)
 	if err != nil {
 		return err
 	}
@@ -126,7 +451,9 @@ func (s *Service) CreateReservation(ctx context.Context, reserv *reservation.Res
 	return nil
 }
 
-func (s *Service) UpdateReservation(ctx context.Context, reserv *reservation.ReservationUpdateEntity) error {
+// UpdateReservation changes the dates of the reservation, only its guest can do it.
+// The new dates are checked the same way as the booked ones, an approved request to book goes back to the owner approval.
+func (s *Service) UpdateReservation(ctx context.Context, reserv *reservation.ReservationUpdateEntity, userID string) error {
 	const op = "service.reservation.UpdateReservation"
 
 	foundReserv, err := s.Repo.GetReservationByID(ctx, reserv.ID)
@@ -138,15 +465,50 @@ func (s *Service) UpdateReservation(ctx context.Context, reserv *reservation.Res
 		return fmt.Errorf("%s: %w", op, service.ErrNotFoundReservation)
 	}
 
-	err = s.Repo.UpdateReservationByID(ctx, reserv)
+	if foundReserv.UserID != uuid.FromStringOrNil(userID) {
+		return fmt.Errorf("%s: %w", op, service.ErrNotReservationGuest)
+	}
+
+	if foundReserv.Status != reservation.StatusRequested && foundReserv.Status != reservation.StatusApproved {
+		return fmt.Errorf("%s: %w", op, service.ErrReservationNotEditable)
+	}
+
+	err = s.checkDates(ctx, &reservation.ReservationEntity{
+		StayID:    foundReserv.StayID,
+		Arrived:   reserv.Arrived,
+		Departure: reserv.Departure,
+	}, foundReserv.ID)
 	if err != nil {
-		return err
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	instantBook, err := s.Repo.GetStayInstantBook(ctx, foundReserv.StayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	to := foundReserv.Status
+	if !instantBook {
+		to = reservation.StatusRequested
+	}
+
+	err = s.Repo.UpdateReservationByID(ctx, reserv, &reservation.StatusChange{
+		ReservationID: foundReserv.ID,
+		From:          &foundReserv.Status,
+		To:            to,
+		ChangedBy:     &foundReserv.UserID,
+		Reason:        "dates changed",
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (s *Service) DeleteReservationByID(ctx context.Context, id uuid.UUID) error {
+// DeleteReservationByID removes the reservation request, only its guest can do it.
+// The reservations past the request are kept for the history and can only be cancelled.
+func (s *Service) DeleteReservationByID(ctx context.Context, id uuid.UUID, userID string) error {
 	const op = "service.reservation.DeleteReservationByID"
 
 	isExists, err := s.Repo.CheckIfReservationExists(ctx, id)
@@ -158,6 +520,19 @@ func (s *Service) DeleteReservationByID(ctx context.Context, id uuid.UUID) error
 		return fmt.Errorf("%s: %w", op, service.ErrNotFoundReservation)
 	}
 
+	foundReserv, err := s.Repo.GetReservationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if foundReserv.UserID != uuid.FromStringOrNil(userID) {
+		return fmt.Errorf("%s: %w", op, service.ErrNotReservationGuest)
+	}
+
+	if foundReserv.Status != reservation.StatusRequested {
+		return fmt.Errorf("%s: %w", op, service.ErrReservationNotEditable)
+	}
+
 	err = s.Repo.DeleteReservationByID(ctx, id)
 	if err != nil {
 		return err
@@ -181,14 +556,14 @@ func (s *Service) GetReservationByID(ctx context.Context, id uuid.UUID) (*reserv
 	return foundReserv, nil
 }
 
-func (s *Service) GetAllReservationsByUser(ctx context.Context, id uuid.UUID) (*[]reservation.Reservation, error) {
+func (s *Service) GetAllReservationsByUser(ctx context.Context, id uuid.UUID, page api.PageRequest) (api.Page[reservation.Reservation], error) {
 	const op = "service.reservation.GetAllReservationsByUser"
 
 	// TODO Check user if exists
 
-	reserv, err := s.Repo.GetAllReservationsByUserID(ctx, id)
+	reserv, err := s.Repo.GetAllReservationsByUserID(ctx, id, page)
 	if err != nil {
-		return nil, err
+		return api.Page[reservation.Reservation]{}, err
 	}
 
 	return reserv, nil
@@ -215,3 +590,151 @@ func (s *Service) GetOccupiedReservationsByUserID(ctx context.Context, id uuid.U
 
 	return reserv, nil
 }
+
+func (s *Service) GetStayCalendar(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.CalendarDay, error) {
+	const op = "service.reservation.GetStayCalendar"
+
+	from, to = dayOf(from), dayOf(to)
+
+	if !to.After(from) || to.Sub(from) > maxCalendarDays*24*time.Hour {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrInvalidCalendarRange)
+	}
+
+	reservations, err := s.Repo.GetReservationsByStayID(ctx, stayID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	blocks, err := s.Repo.GetStayBlocksByStayID(ctx, stayID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var days []reservation.CalendarDay
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		status := reservation.CalendarDayFree
+
+		for _, block := range blocks {
+			if coversDay(block.DateStart, block.DateEnd, day) {
+				status = reservation.CalendarDayBlocked
+				break
+			}
+		}
+
+		// Reservations take precedence over manual blocks
+		for _, reserv := range reservations {
+			if !coversDay(reserv.Arrived, reserv.Departure, day) {
+				continue
+			}
+
+			status = reservation.CalendarDayReserved
+			if reserv.Status == reservation.StatusCheckedIn {
+				status = reservation.CalendarDayCheckedIn
+				break
+			}
+		}
+
+		days = append(days, reservation.CalendarDay{
+			Date:   day.Format(time.DateOnly),
+			Status: status,
+		})
+	}
+
+	return days, nil
+}
+
+// GetStayBlocks returns the blocks of the stay to its owner
+func (s *Service) GetStayBlocks(ctx context.Context, stayID uuid.UUID, userID string) ([]reservation.StayBlock, error) {
+	const op = "service.reservation.GetStayBlocks"
+
+	owner, err := s.Repo.CheckIfUserIsOwner(ctx, uuid.FromStringOrNil(userID), stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !owner {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
+	}
+
+	blocks, err := s.Repo.GetStayBlocks(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blocks, nil
+}
+
+func (s *Service) CreateStayBlock(ctx context.Context, block *reservation.StayBlockEntity, userID string) (*reservation.StayBlock, error) {
+	const op = "service.reservation.CreateStayBlock"
+
+	if !block.DateEnd.After(block.DateStart) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrInvalidCalendarRange)
+	}
+
+	owner, err := s.Repo.CheckIfUserIsOwner(ctx, uuid.FromStringOrNil(userID), block.StayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !owner {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
+	}
+
+	err = s.Repo.CheckReservationIsFree(ctx, &reservation.ReservationEntity{
+		StayID:    block.StayID,
+		Arrived:   block.DateStart,
+		Departure: block.DateEnd,
+	}, uuid.Nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := s.Repo.CreateStayBlock(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// DeleteStayBlock removes the block of the stay, the block of another stay is not found
+func (s *Service) DeleteStayBlock(ctx context.Context, stayID, blockID uuid.UUID, userID string) error {
+	const op = "service.reservation.DeleteStayBlock"
+
+	block, err := s.Repo.GetStayBlockByID(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if block.StayID != stayID {
+		return fmt.Errorf("%s: %w", op, service.ErrStayBlockNotFound)
+	}
+
+	owner, err := s.Repo.CheckIfUserIsOwner(ctx, uuid.FromStringOrNil(userID), block.StayID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !owner {
+		return fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
+	}
+
+	err = s.Repo.DeleteStayBlockByID(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// dayOf truncates t to the beginning of its calendar day
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// coversDay reports whether the [start, end) range occupies the given day.
+// The end day itself stays free, so a departure day can be the next arrival day.
+func coversDay(start, end, day time.Time) bool {
+	return !dayOf(start).After(day) && dayOf(end).After(day)
+}
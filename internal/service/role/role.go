@@ -0,0 +1,157 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"sync"
+	"time"
+)
+
+// permissionsTTL bounds how long the grants changed by another instance stay unnoticed
+const permissionsTTL = time.Minute
+
+type Service struct {
+	Repo interfaces.RoleRepo
+
+	mu    sync.RWMutex
+	cache map[int]cachedPermissions
+}
+
+type cachedPermissions struct {
+	perms    role.Permissions
+	loadedAt time.Time
+}
+
+// HasPermission reports whether the role may do the action on the route, the grants of a role are cached for permissionsTTL
+func (s *Service) HasPermission(ctx context.Context, roleID int, route string, action role.Action) (bool, error) {
+	const op = "service.role.HasPermission"
+
+	perms, err := s.permissions(ctx, roleID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return perms.Allows(route, action), nil
+}
+
+func (s *Service) GetRoles(ctx context.Context) ([]role.Role, error) {
+	const op = "service.role.GetRoles"
+
+	roles, err := s.Repo.GetRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+func (s *Service) GetObjects(ctx context.Context) ([]role.Object, error) {
+	const op = "service.role.GetObjects"
+
+	objects, err := s.Repo.GetObjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return objects, nil
+}
+
+func (s *Service) CreateObject(ctx context.Context, object *role.ObjectEntity) (*role.Object, error) {
+	const op = "service.role.CreateObject"
+
+	if err := object.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	created, err := s.Repo.CreateObject(ctx, object)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) GrantObject(ctx context.Context, roleID, objectID int) error {
+	const op = "service.role.GrantObject"
+
+	if err := s.checkGrant(ctx, roleID, objectID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := s.Repo.GrantObject(ctx, roleID, objectID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(roleID)
+
+	return nil
+}
+
+func (s *Service) RevokeObject(ctx context.Context, roleID, objectID int) error {
+	const op = "service.role.RevokeObject"
+
+	if err := s.checkGrant(ctx, roleID, objectID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := s.Repo.RevokeObject(ctx, roleID, objectID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidate(roleID)
+
+	return nil
+}
+
+func (s *Service) checkGrant(ctx context.Context, roleID, objectID int) error {
+	exists, err := s.Repo.CheckRoleIfExists(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return service.ErrRoleNotFound
+	}
+
+	_, err = s.Repo.GetObjectByID(ctx, objectID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) permissions(ctx context.Context, roleID int) (role.Permissions, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[roleID]
+	s.mu.RUnlock()
+
+	if ok && time.Since(cached.loadedAt) < permissionsTTL {
+		return cached.perms, nil
+	}
+
+	perms, err := s.Repo.GetPermissions(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.cache == nil {
+		s.cache = make(map[int]cachedPermissions)
+	}
+	s.cache[roleID] = cachedPermissions{perms: perms, loadedAt: time.Now()}
+	s.mu.Unlock()
+
+	return perms, nil
+}
+
+func (s *Service) invalidate(roleID int) {
+	s.mu.Lock()
+	delete(s.cache, roleID)
+	s.mu.Unlock()
+}
@@ -2,15 +2,25 @@ package stays
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	_ "github.com/ekomobile/dadata"
 	"github.com/gofrs/uuid"
 	staysInterface "github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/money"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/cancellation"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/geo"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/stays/pricing"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	"github.com/imperatorofdwelling/Full-backend/internal/service/file"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
 	"mime/multipart"
+	"strings"
 	"sync"
+	"time"
 )
 
 type Service struct {
@@ -18,8 +28,19 @@ type Service struct {
 	LocSvc  staysInterface.LocationService
 	FileSvc staysInterface.FileService
 	UserSvc staysInterface.UserService
+	ResSvc  staysInterface.ReservationService
+	HistSvc staysInterface.SearchHistoryService
+	CurSvc  staysInterface.CurrencyService
+	PrcSvc  staysInterface.PricingService
 }
 
+const (
+	// MaxSearchQueryLength matches the size of search_history.name
+	MaxSearchQueryLength = 255
+	DefaultSearchLimit   = 20
+	MaxSearchLimit       = 100
+)
+
 func (s *Service) CreateStay(ctx context.Context, stay *stays.StayEntity) error {
 	const op = "service.stays.CreateStay"
 
@@ -40,6 +61,16 @@ func (s *Service) CreateStay(ctx context.Context, stay *stays.StayEntity) error
 		return fmt.Errorf("%s: %w", op, service.ErrUserNotFound)
 	}
 
+	err = s.checkPrice(ctx, &stay.Price)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = checkCancellationPolicy(stay)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	err = s.Repo.CreateStay(ctx, stay)
 	if err != nil {
 		return err
@@ -74,27 +105,39 @@ func (s *Service) GetStayByID(ctx context.Context, id uuid.UUID) (*stays.Stay, e
 	return stay, nil
 }
 
-func (s *Service) GetStays(ctx context.Context) ([]stays.StayResponse, error) {
-	const op = "service.stays.GetStays"
+// GetPublicStayByID returns the published stay of the host who is not suspended, the owner gets the stay in any status
+func (s *Service) GetPublicStayByID(ctx context.Context, id uuid.UUID, userID string) (*stays.Stay, error) {
+	const op = "service.stays.GetPublicStayByID"
 
-	staysFromRepo, err := s.Repo.GetStays(ctx)
+	stay, err := s.Repo.GetPublicStayByID(ctx, id, uuid.FromStringOrNil(userID))
 	if err != nil {
 		return nil, err
 	}
 
-	return staysFromRepo, nil
+	if stay == nil {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+	}
+
+	return stay, nil
 }
 
-func (s *Service) DeleteStayByID(ctx context.Context, id uuid.UUID) error {
-	const op = "service.stays.DeleteStay"
+func (s *Service) GetStays(ctx context.Context, page api.PageRequest) (api.Page[stays.StayResponse], error) {
+	const op = "service.stays.GetStays"
 
-	exists, err := s.Repo.CheckStayIfExistsByID(ctx, id)
+	staysFromRepo, err := s.Repo.GetStays(ctx, page)
 	if err != nil {
-		return err
+		return api.Page[stays.StayResponse]{}, err
 	}
 
-	if !exists {
-		return fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+	return staysFromRepo, nil
+}
+
+func (s *Service) DeleteStayByID(ctx context.Context, id uuid.UUID, userID string) error {
+	const op = "service.stays.DeleteStay"
+
+	err := s.checkOwner(ctx, id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	err = s.Repo.DeleteStayByID(ctx, id)
@@ -105,9 +148,17 @@ func (s *Service) DeleteStayByID(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (s *Service) UpdateStayByID(ctx context.Context, stay *stays.StayEntity, id uuid.UUID) (*stays.Stay, error) {
+// UpdateStayByID saves the stay of the user, the stay can't be handed over to another user and the published stay is hidden until the changes are reviewed
+func (s *Service) UpdateStayByID(ctx context.Context, stay *stays.StayEntity, id uuid.UUID, userID string) (*stays.Stay, error) {
 	const op = "service.stays.UpdateStayByID"
 
+	err := s.checkOwner(ctx, id, userID)
+	if err != nil {
+		return &stays.Stay{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stay.UserID = uuid.FromStringOrNil(userID)
+
 	foundLocation, err := s.LocSvc.GetByID(ctx, stay.LocationID)
 	if err != nil {
 		return &stays.Stay{}, err
@@ -117,6 +168,16 @@ func (s *Service) UpdateStayByID(ctx context.Context, stay *stays.StayEntity, id
 		return &stays.Stay{}, fmt.Errorf("%s: %w", op, service.ErrLocationNotFound)
 	}
 
+	err = s.checkPrice(ctx, &stay.Price)
+	if err != nil {
+		return &stays.Stay{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = checkCancellationPolicy(stay)
+	if err != nil {
+		return &stays.Stay{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	err = s.Repo.UpdateStayByID(ctx, stay, id)
 	if err != nil {
 		return &stays.Stay{}, err
@@ -191,16 +252,12 @@ func (s *Service) GetMainImageByStayID(ctx context.Context, id uuid.UUID) (stays
 	return image, nil
 }
 
-func (s *Service) CreateImages(ctx context.Context, filesHeaders []*multipart.FileHeader, stayID uuid.UUID) error {
+func (s *Service) CreateImages(ctx context.Context, filesHeaders []*multipart.FileHeader, stayID uuid.UUID, userID string) error {
 	const op = "service.stays.CreateImages"
 
-	isExists, err := s.Repo.CheckStayIfExistsByID(ctx, stayID)
+	err := s.checkOwner(ctx, stayID, userID)
 	if err != nil {
-		return err
-	}
-
-	if !isExists {
-		return fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	errChan := make(chan error, len(filesHeaders))
@@ -251,16 +308,12 @@ func (s *Service) CreateImages(ctx context.Context, filesHeaders []*multipart.Fi
 	return nil
 }
 
-func (s *Service) CreateMainImage(ctx context.Context, fileHeader *multipart.FileHeader, stayID uuid.UUID) error {
+func (s *Service) CreateMainImage(ctx context.Context, fileHeader *multipart.FileHeader, stayID uuid.UUID, userID string) error {
 	const op = "service.stays.CreateMainImages"
 
-	isExists, err := s.Repo.CheckStayIfExistsByID(ctx, stayID)
+	err := s.checkOwner(ctx, stayID, userID)
 	if err != nil {
-		return err
-	}
-
-	if !isExists {
-		return fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	img, err := fileHeader.Open()
@@ -289,7 +342,7 @@ func (s *Service) CreateMainImage(ctx context.Context, fileHeader *multipart.Fil
 	return nil
 }
 
-func (s *Service) DeleteStayImage(ctx context.Context, imageID uuid.UUID) error {
+func (s *Service) DeleteStayImage(ctx context.Context, imageID uuid.UUID, userID string) error {
 	const op = "service.stays.DeleteStayImage"
 
 	stayImage, err := s.Repo.GetStayImageByID(ctx, imageID)
@@ -301,6 +354,11 @@ func (s *Service) DeleteStayImage(ctx context.Context, imageID uuid.UUID) error
 		return fmt.Errorf("%s: %w", op, service.ErrStayImageNotFound)
 	}
 
+	err = s.checkOwner(ctx, stayImage.StayID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	err = s.FileSvc.RemoveFile(stayImage.ImageName)
 	if err != nil {
 		return err
@@ -313,6 +371,206 @@ func (s *Service) DeleteStayImage(ctx context.Context, imageID uuid.UUID) error
 	return nil
 }
 
+// GetCompleteness lists the fields the stay of the user misses to be submitted for review
+func (s *Service) GetCompleteness(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Completeness, error) {
+	const op = "service.stays.GetCompleteness"
+
+	err := s.checkOwner(ctx, stayID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stay, err := s.Repo.GetStayByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	completeness, err := s.checkCompleteness(ctx, stay)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &completeness, nil
+}
+
+// SubmitStay sends the draft of the user for review, the incomplete stay can't be submitted
+func (s *Service) SubmitStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error) {
+	const op = "service.stays.SubmitStay"
+
+	err := s.checkOwner(ctx, stayID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stay, err := s.Repo.GetStayByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	completeness, err := s.checkCompleteness(ctx, stay)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !completeness.Complete {
+		return nil, fmt.Errorf("%s: %w: missing %s", op, service.ErrStayIncomplete, strings.Join(completeness.Missing, ", "))
+	}
+
+	updated, err := s.moveStay(ctx, stay, stays.StatusPendingReview, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// ArchiveStay hides the draft or the published stay of the user
+func (s *Service) ArchiveStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error) {
+	const op = "service.stays.ArchiveStay"
+
+	err := s.checkOwner(ctx, stayID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stay, err := s.Repo.GetStayByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := s.moveStay(ctx, stay, stays.StatusArchived, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// UnarchiveStay turns the archived stay of the user into a draft, it is submitted for review again to be published
+func (s *Service) UnarchiveStay(ctx context.Context, stayID uuid.UUID, userID string) (*stays.Stay, error) {
+	const op = "service.stays.UnarchiveStay"
+
+	err := s.checkOwner(ctx, stayID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stay, err := s.Repo.GetStayByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := s.moveStay(ctx, stay, stays.StatusDraft, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func (s *Service) GetStaysForReview(ctx context.Context, page api.PageRequest) (api.Page[stays.StayResponse], error) {
+	const op = "service.stays.GetStaysForReview"
+
+	result, err := s.Repo.GetStaysForReview(ctx, page)
+	if err != nil {
+		return api.Page[stays.StayResponse]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// ReviewStay publishes the approved stay or sends the rejected one back to the drafts with the comment
+func (s *Service) ReviewStay(ctx context.Context, stayID uuid.UUID, review *stays.ReviewEntity) (*stays.Stay, error) {
+	const op = "service.stays.ReviewStay"
+
+	err := review.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	exists, err := s.Repo.CheckStayIfExistsByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+	}
+
+	stay, err := s.Repo.GetStayByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if stay.Status != stays.StatusPendingReview {
+		return nil, fmt.Errorf("%s: %w: stay is %s", op, service.ErrInvalidStayStatus, stay.Status)
+	}
+
+	next := stays.StatusPublished
+	if review.Decision == stays.DecisionReject {
+		next = stays.StatusDraft
+	}
+
+	updated, err := s.moveStay(ctx, stay, next, &review.Comment)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// moveStay changes the status of the stay and returns the updated stay
+func (s *Service) moveStay(ctx context.Context, stay *stays.Stay, next stays.Status, comment *string) (*stays.Stay, error) {
+	if !stay.Status.CanMoveTo(next) {
+		return nil, fmt.Errorf("%w: stay can't move from %s to %s", service.ErrInvalidStayStatus, stay.Status, next)
+	}
+
+	err := s.Repo.UpdateStayStatus(ctx, stay.ID, stay.Status, next, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repo.GetStayByID(ctx, stay.ID)
+}
+
+// checkCompleteness checks the required fields and the main image of the stay
+func (s *Service) checkCompleteness(ctx context.Context, stay *stays.Stay) (stays.Completeness, error) {
+	hasMainImage := true
+
+	_, err := s.Repo.GetMainImageByStayID(ctx, stay.ID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return stays.Completeness{}, err
+		}
+		hasMainImage = false
+	}
+
+	return stays.CheckCompleteness(stay, hasMainImage), nil
+}
+
+// checkOwner makes sure the stay exists and belongs to the user
+func (s *Service) checkOwner(ctx context.Context, stayID uuid.UUID, userID string) error {
+	exists, err := s.Repo.CheckStayIfExistsByID(ctx, stayID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return service.ErrStayNotFound
+	}
+
+	stay, err := s.Repo.GetStayByID(ctx, stayID)
+	if err != nil {
+		return err
+	}
+
+	if stay.UserID != uuid.FromStringOrNil(userID) {
+		return service.ErrUserNotOwner
+	}
+
+	return nil
+}
+
 func (s *Service) GetStaysByLocationID(ctx context.Context, id uuid.UUID) (*[]stays.Stay, error) {
 	const op = "service.stays.GetStaysByLocationID"
 
@@ -335,9 +593,249 @@ func (s *Service) GetStaysByLocationID(ctx context.Context, id uuid.UUID) (*[]st
 func (s *Service) Filtration(ctx context.Context, search stays.Filtration) ([]stays.Stay, error) {
 	const op = "service.stays.Filtration"
 
+	_, err := s.CurSvc.GetRate(ctx, search.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	result, err := s.Repo.Filtration(ctx, search)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	return result, nil
 }
+
+func (s *Service) GetCalendar(ctx context.Context, stayID uuid.UUID, from, to time.Time) ([]reservation.CalendarDay, error) {
+	const op = "service.stays.GetCalendar"
+
+	exists, err := s.Repo.CheckStayIfExistsByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+	}
+
+	days, err := s.ResSvc.GetStayCalendar(ctx, stayID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return days, nil
+}
+
+func (s *Service) GetCalendarBlocks(ctx context.Context, stayID uuid.UUID, userID string) ([]reservation.StayBlock, error) {
+	const op = "service.stays.GetCalendarBlocks"
+
+	exists, err := s.Repo.CheckStayIfExistsByID(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+	}
+
+	blocks, err := s.ResSvc.GetStayBlocks(ctx, stayID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blocks, nil
+}
+
+func (s *Service) CreateCalendarBlock(ctx context.Context, block *reservation.StayBlockEntity, userID string) (*reservation.StayBlock, error) {
+	const op = "service.stays.CreateCalendarBlock"
+
+	exists, err := s.Repo.CheckStayIfExistsByID(ctx, block.StayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+	}
+
+	created, err := s.ResSvc.CreateStayBlock(ctx, block, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) DeleteCalendarBlock(ctx context.Context, stayID, blockID uuid.UUID, userID string) error {
+	const op = "service.stays.DeleteCalendarBlock"
+
+	err := s.ResSvc.DeleteStayBlock(ctx, stayID, blockID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetNearbyStays(ctx context.Context, point geo.Point, radiusKm float64) ([]stays.StayNearby, error) {
+	const op = "service.stays.GetNearbyStays"
+
+	if err := point.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrInvalidGeoQuery, err.Error())
+	}
+
+	if radiusKm <= 0 || radiusKm > geo.MaxRadiusKm {
+		return nil, fmt.Errorf("%s: %w: radius must be between 0 and %.0f km", op, service.ErrInvalidGeoQuery, geo.MaxRadiusKm)
+	}
+
+	result, err := s.Repo.GetNearbyStays(ctx, point, radiusKm)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+func (s *Service) GetStaysInBoundingBox(ctx context.Context, box geo.BoundingBox) ([]stays.StayNearby, error) {
+	const op = "service.stays.GetStaysInBoundingBox"
+
+	if err := box.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrInvalidGeoQuery, err.Error())
+	}
+
+	result, err := s.Repo.GetStaysInBoundingBox(ctx, box)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// SearchStays runs a ranked full-text search over the stays and, for a logged-in user, saves the query to the search history.
+func (s *Service) SearchStays(ctx context.Context, query string, limit int, userID string) ([]stays.StaySearchResult, error) {
+	const op = "service.stays.SearchStays"
+
+	query = strings.TrimSpace(query)
+	if query == "" || len([]rune(query)) > MaxSearchQueryLength {
+		return nil, fmt.Errorf("%s: %w: query must be 1 to %d characters long", op, service.ErrInvalidSearch, MaxSearchQueryLength)
+	}
+
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	result, err := s.Repo.SearchStays(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the search does not fail for the history, service.ErrHistoryNotSaved comes with the result
+	if userID != "" {
+		err = s.HistSvc.AddHistory(ctx, userID, query)
+		if err != nil {
+			return result, fmt.Errorf("%s: %w: %s", op, service.ErrHistoryNotSaved, err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+// checkPrice normalizes the currency of the price and makes sure it has a rate to convert with
+func (s *Service) checkPrice(ctx context.Context, price *money.Money) error {
+	currency, err := money.ParseCurrency(price.Currency.String())
+	if err != nil {
+		return fmt.Errorf("%w: %s", service.ErrValid, err.Error())
+	}
+
+	if price.Amount < 0 {
+		return fmt.Errorf("%w: price must not be negative", service.ErrValid)
+	}
+
+	_, err = s.CurSvc.GetRate(ctx, currency)
+	if err != nil {
+		return err
+	}
+
+	*price = money.New(price.Amount, currency)
+
+	return nil
+}
+
+// checkCancellationPolicy normalizes the policy type and keeps the tiers of the custom policy only
+func checkCancellationPolicy(stay *stays.StayEntity) error {
+	policyType, err := cancellation.ParsePolicyType(stay.CancellationPolicy.String())
+	if err != nil {
+		return fmt.Errorf("%w: %s", service.ErrValid, err.Error())
+	}
+
+	policy, err := cancellation.NewPolicy(policyType, stay.CancellationTiers)
+	if err != nil {
+		return fmt.Errorf("%w: %s", service.ErrValid, err.Error())
+	}
+
+	stay.CancellationPolicy = policy.Type
+	stay.CancellationTiers = nil
+
+	if policy.Type == cancellation.Custom {
+		stay.CancellationTiers = policy.Tiers
+	}
+
+	return nil
+}
+
+func (s *Service) GetQuote(ctx context.Context, stayID uuid.UUID, arrival, departure time.Time, guests int) (*pricing.Quote, error) {
+	const op = "service.stays.GetQuote"
+
+	quote, err := s.PrcSvc.Quote(ctx, stayID, arrival, departure, guests)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return quote, nil
+}
+
+func (s *Service) GetPricing(ctx context.Context, stayID uuid.UUID) (*pricing.Rules, error) {
+	const op = "service.stays.GetPricing"
+
+	rules, err := s.PrcSvc.GetRules(ctx, stayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
+
+func (s *Service) UpdatePricing(ctx context.Context, stayID uuid.UUID, rules *pricing.RulesEntity, userID string) error {
+	const op = "service.stays.UpdatePricing"
+
+	err := s.PrcSvc.UpdateRules(ctx, stayID, rules, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) CreateSeasonalPrice(ctx context.Context, stayID uuid.UUID, season *pricing.SeasonEntity, userID string) error {
+	const op = "service.stays.CreateSeasonalPrice"
+
+	err := s.PrcSvc.CreateSeason(ctx, stayID, season, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteSeasonalPrice(ctx context.Context, stayID, seasonID uuid.UUID, userID string) error {
+	const op = "service.stays.DeleteSeasonalPrice"
+
+	err := s.PrcSvc.DeleteSeason(ctx, stayID, seasonID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
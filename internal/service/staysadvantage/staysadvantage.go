@@ -17,7 +17,8 @@ type Service struct {
 	FileSvc interfaces.FileService
 }
 
-func (s *Service) CreateStaysAdvantage(ctx context.Context, stayReq *models.StayAdvantageCreateReq) error {
+// CreateStaysAdvantage adds the advantage to the stay, only the stay owner can do it
+func (s *Service) CreateStaysAdvantage(ctx context.Context, stayReq *models.StayAdvantageCreateReq, userID string) error {
 	const op = "service.staysadvantage.CreateStaysAdvantage"
 
 	stay, err := s.StaySvc.GetStayByID(ctx, stayReq.StayID)
@@ -29,6 +30,10 @@ func (s *Service) CreateStaysAdvantage(ctx context.Context, stayReq *models.Stay
 		return fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
 	}
 
+	if stay.UserID != uuid.FromStringOrNil(userID) {
+		return fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
+	}
+
 	adv, err := s.AdvSvc.GetAdvantageByID(ctx, stayReq.AdvantageID)
 	if err != nil {
 		return err
@@ -58,19 +63,29 @@ func (s *Service) CreateStaysAdvantage(ctx context.Context, stayReq *models.Stay
 	return nil
 }
 
-func (s *Service) DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID) error {
+// DeleteStaysAdvantageByID removes the advantage of the stay, only the stay owner can do it
+func (s *Service) DeleteStaysAdvantageByID(ctx context.Context, id uuid.UUID, userID string) error {
 	const op = "service.staysadvantage.DeleteStaysAdvantageByID"
 
-	isExists, err := s.Repo.CheckStaysAdvantageIfExists(ctx, id)
+	stayAdv, err := s.Repo.GetStaysAdvantageByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if !isExists {
-		return fmt.Errorf("%s: %w", op, service.ErrAdvantageNotFound)
+	stay, err := s.StaySvc.GetStayByID(ctx, stayAdv.StayID)
+	if err != nil {
+		return err
+	}
+
+	if stay == nil {
+		return fmt.Errorf("%s: %w", op, service.ErrStayNotFound)
+	}
+
+	if stay.UserID != uuid.FromStringOrNil(userID) {
+		return fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
 	}
 
-	err = s.StaySvc.DeleteStayByID(ctx, id)
+	err = s.Repo.DeleteStaysAdvantageByID(ctx, id)
 	if err != nil {
 		return err
 	}
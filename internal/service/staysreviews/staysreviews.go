@@ -5,18 +5,50 @@ import (
 	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/reservation"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/staysreviews"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"time"
 )
 
 type Service struct {
-	Repo interfaces.StaysReviewsRepo
+	Repo     interfaces.StaysReviewsRepo
+	ResSvc   interfaces.ReservationService
+	StaysSvc interfaces.StaysService
 }
 
+// CreateStaysReview adds the review of the completed reservation by its guest, one review per reservation,
+// while the review window of the reservation is open. The review stays hidden until the host reviews
+// the guest or the window is closed.
 func (s *Service) CreateStaysReview(ctx context.Context, stayReview *staysreviews.StaysReviewEntity) error {
 	const op = "service.staysreviews.CreateStaysReview"
 
-	err := s.Repo.CreateStaysReview(ctx, stayReview)
+	err := stayReview.Validate()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	reserv, err := s.ResSvc.GetReservationByID(ctx, stayReview.ReservationID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reserv.UserID != stayReview.UserID {
+		return fmt.Errorf("%s: %w", op, service.ErrNotReservationGuest)
+	}
+
+	if reserv.Status != reservation.StatusCompleted {
+		return fmt.Errorf("%s: %w: reservation is %s", op, service.ErrStayNotCompleted, reserv.Status)
+	}
+
+	if !reserv.CanBeReviewed(time.Now()) {
+		return fmt.Errorf("%s: %w", op, service.ErrReviewWindowClosed)
+	}
+
+	stayReview.StayID = reserv.StayID
+
+	err = s.Repo.CreateStaysReview(ctx, stayReview)
 	if err != nil {
 		return err
 	}
@@ -24,18 +56,27 @@ func (s *Service) CreateStaysReview(ctx context.Context, stayReview *staysreview
 	return nil
 }
 
-func (s *Service) UpdateStaysReview(ctx context.Context, stayReview *staysreviews.StaysReviewEntity, id uuid.UUID) (*staysreviews.StaysReview, error) {
+// UpdateStaysReview changes the review of the user while it is hidden, the review stays with its author
+func (s *Service) UpdateStaysReview(ctx context.Context, stayReview *staysreviews.StaysReviewEntity, id uuid.UUID, userID string) (*staysreviews.StaysReview, error) {
 	const op = "service.staysreviews.UpdateStaysReview"
 
-	isExists, err := s.Repo.CheckIfExists(ctx, id)
+	err := stayReview.Validate()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
 	}
 
-	if !isExists {
-		return nil, fmt.Errorf("%s: %w", op, service.ErrStaysReviewNotFound)
+	review, err := s.checkAuthor(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the reviews without reservation were public from the start and stay editable
+	if review.ReservationID != nil && review.Revealed {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrReviewRevealed)
 	}
 
+	stayReview.UserID = uuid.FromStringOrNil(userID)
+
 	err = s.Repo.UpdateStaysReviewByID(ctx, stayReview, id)
 	if err != nil {
 		return nil, err
@@ -49,7 +90,8 @@ func (s *Service) UpdateStaysReview(ctx context.Context, stayReview *staysreview
 	return model, nil
 }
 
-func (s *Service) FindOneStaysReview(ctx context.Context, id uuid.UUID) (*staysreviews.StaysReview, error) {
+// FindOneStaysReview returns the public review, the hidden one is shown only to its author
+func (s *Service) FindOneStaysReview(ctx context.Context, id uuid.UUID, userID string) (*staysreviews.StaysReview, error) {
 	const op = "service.staysreviews.FindOneStaysReview"
 
 	foundStayReview, err := s.Repo.FindOneStaysReviewByID(ctx, id)
@@ -61,19 +103,31 @@ func (s *Service) FindOneStaysReview(ctx context.Context, id uuid.UUID) (*staysr
 		return nil, fmt.Errorf("%s: %w", op, service.ErrStaysReviewNotFound)
 	}
 
+	if !foundStayReview.Revealed && foundStayReview.UserID != uuid.FromStringOrNil(userID) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStaysReviewNotFound)
+	}
+
 	return foundStayReview, nil
 }
 
-func (s *Service) DeleteStaysReview(ctx context.Context, id uuid.UUID) error {
-	const op = "service.staysreviews.DeleteStaysReview"
+// RecomputeRevealedRatings counts the reviews revealed by the review windows closed in (from, to] in the ratings of their stays
+func (s *Service) RecomputeRevealedRatings(ctx context.Context, from, to time.Time) (int, error) {
+	const op = "service.staysreviews.RecomputeRevealedRatings"
 
-	isExists, err := s.Repo.CheckIfExists(ctx, id)
+	count, err := s.Repo.RecomputeRevealedRatings(ctx, from, to)
 	if err != nil {
-		return err
+		return count, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if !isExists {
-		return fmt.Errorf("%s: %w", op, service.ErrStaysReviewNotFound)
+	return count, nil
+}
+
+func (s *Service) DeleteStaysReview(ctx context.Context, id uuid.UUID, userID string) error {
+	const op = "service.staysreviews.DeleteStaysReview"
+
+	_, err := s.checkAuthor(ctx, id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	err = s.Repo.DeleteStaysReviewByID(ctx, id)
@@ -84,13 +138,91 @@ func (s *Service) DeleteStaysReview(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (s *Service) FindAllStaysReviews(ctx context.Context) ([]staysreviews.StaysReview, error) {
+// checkAuthor makes sure the review exists and is written by the user, the review is returned
+func (s *Service) checkAuthor(ctx context.Context, id uuid.UUID, userID string) (*staysreviews.StaysReview, error) {
+	isExists, err := s.Repo.CheckIfExists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isExists {
+		return nil, service.ErrStaysReviewNotFound
+	}
+
+	review, err := s.Repo.FindOneStaysReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.UserID != uuid.FromStringOrNil(userID) {
+		return nil, service.ErrUserNotOwner
+	}
+
+	return review, nil
+}
+
+// FindAllStaysReviews returns the page of the reviews, the reviews of a single stay come with the averages of its ratings
+func (s *Service) FindAllStaysReviews(ctx context.Context, stayID *uuid.UUID, page api.PageRequest) (*staysreviews.StaysReviewsPage, error) {
 	const op = "service.staysreviews.FindAllStaysReviews"
 
-	reviews, err := s.Repo.FindAllStaysReviews(ctx)
+	reviews, err := s.Repo.FindAllStaysReviews(ctx, stayID, page)
 	if err != nil {
 		return nil, err
 	}
 
-	return reviews, nil
+	result := &staysreviews.StaysReviewsPage{Page: reviews}
+
+	if stayID != nil {
+		result.Summary, err = s.Repo.GetRatingSummary(ctx, *stayID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return result, nil
+}
+
+// ReplyToStaysReview stores the public reply of the stay owner, the review can be replied once
+func (s *Service) ReplyToStaysReview(ctx context.Context, id uuid.UUID, userID string, reply *staysreviews.ReplyEntity) (*staysreviews.StaysReview, error) {
+	const op = "service.staysreviews.ReplyToStaysReview"
+
+	err := reply.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	isExists, err := s.Repo.CheckIfExists(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !isExists {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStaysReviewNotFound)
+	}
+
+	review, err := s.Repo.FindOneStaysReviewByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the host can't see the review before it is revealed
+	if !review.Revealed {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrStaysReviewNotFound)
+	}
+
+	stay, err := s.StaysSvc.GetStayByID(ctx, review.StayID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if stay.UserID != uuid.FromStringOrNil(userID) {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrUserNotOwner)
+	}
+
+	err = s.Repo.SetReply(ctx, id, reply.Text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.Repo.FindOneStaysReviewByID(ctx, id)
 }
@@ -0,0 +1,248 @@
+package support
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/chat"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/role"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/support"
+	"github.com/imperatorofdwelling/Full-backend/internal/service"
+	"github.com/imperatorofdwelling/Full-backend/pkg/api"
+	"time"
+)
+
+// Route is the object of the support operators, the roles granted it work the escalation queue
+const Route = "/support"
+
+type Service struct {
+	Repo     interfaces.SupportRepo
+	ChatSvc  interfaces.ChatService
+	UserRepo interfaces.UserRepository
+	RoleSvc  interfaces.RoleService
+}
+
+// Escalate puts the chat of the participant in the operator queue. The escalation is kept when the chat
+// connections are not notified, the error is service.ErrEscalationNotDelivered then.
+func (s *Service) Escalate(ctx context.Context, chatID, userID string, entity *support.EscalationEntity) (*support.Escalation, error) {
+	const op = "service.support.Escalate"
+
+	err := entity.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	_, err = s.ChatSvc.GetParticipantChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	escalation, err := s.Repo.CreateEscalation(ctx, uuid.FromStringOrNil(chatID), uuid.FromStringOrNil(userID), entity.Reason, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.notify(ctx, op, escalation)
+}
+
+// GetChatEscalations returns the escalations of the chat with the resolution notes to its participant
+func (s *Service) GetChatEscalations(ctx context.Context, chatID, userID string) ([]support.Escalation, error) {
+	const op = "service.support.GetChatEscalations"
+
+	_, err := s.ChatSvc.GetParticipantChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	escalations, err := s.Repo.GetChatEscalations(ctx, uuid.FromStringOrNil(chatID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	for i := range escalations {
+		escalations[i].SetOverdue(now)
+	}
+
+	return escalations, nil
+}
+
+func (s *Service) GetEscalations(ctx context.Context, filter support.Filter, page api.PageRequest) (api.Page[support.Escalation], error) {
+	const op = "service.support.GetEscalations"
+
+	err := filter.Validate()
+	if err != nil {
+		return api.Page[support.Escalation]{}, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	now := time.Now()
+
+	escalations, err := s.Repo.GetEscalations(ctx, filter, now, page)
+	if err != nil {
+		return api.Page[support.Escalation]{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range escalations.Items {
+		escalations.Items[i].SetOverdue(now)
+	}
+
+	return escalations, nil
+}
+
+func (s *Service) GetEscalation(ctx context.Context, id uuid.UUID) (*support.Escalation, error) {
+	const op = "service.support.GetEscalation"
+
+	escalation, err := s.Repo.GetEscalation(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	escalation.SetOverdue(time.Now())
+
+	return escalation, nil
+}
+
+// AssignEscalation gives the escalation to an operator, the one assigning takes it when no operator is set.
+// The operator joins the chat as its third participant. The escalation of another operator is taken
+// only when the reassignment is asked for, the previous operator leaves the chat then.
+func (s *Service) AssignEscalation(ctx context.Context, id uuid.UUID, assign *support.AssignEntity, operatorID string) (*support.Escalation, error) {
+	const op = "service.support.AssignEscalation"
+
+	assigneeID := uuid.FromStringOrNil(operatorID)
+	if assign.OperatorID != nil {
+		assigneeID = *assign.OperatorID
+
+		err := s.checkOperator(ctx, assigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	escalation, err := s.Repo.GetEscalation(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if escalation.Status == support.StatusClosed {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrEscalationClosed)
+	}
+
+	previous := escalation.OperatorID
+	if previous != nil && *previous != assigneeID && !assign.Reassign {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrEscalationAssigned)
+	}
+
+	escalation, err = s.Repo.AssignEscalation(ctx, id, previous, assigneeID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if previous != nil && *previous != assigneeID {
+		return s.notifyLeaving(ctx, op, escalation, *previous)
+	}
+
+	return s.notify(ctx, op, escalation)
+}
+
+// CloseEscalation leaves the resolution note for both sides of the chat and takes the operator out of it.
+// Only the operator of the escalation or an admin can close it, the operator closing the open escalation takes it.
+func (s *Service) CloseEscalation(ctx context.Context, id uuid.UUID, entity *support.CloseEntity, operatorID string) (*support.Escalation, error) {
+	const op = "service.support.CloseEscalation"
+
+	err := entity.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	escalation, err := s.Repo.GetEscalation(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if escalation.Status == support.StatusClosed {
+		return nil, fmt.Errorf("%s: %w", op, service.ErrEscalationClosed)
+	}
+
+	closerID := uuid.FromStringOrNil(operatorID)
+
+	current := escalation.OperatorID
+	if current != nil && *current != closerID {
+		admin, err := s.isAdmin(ctx, closerID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if !admin {
+			return nil, fmt.Errorf("%s: %w", op, service.ErrNotEscalationOperator)
+		}
+	}
+
+	escalation, err = s.Repo.CloseEscalation(ctx, id, current, closerID, entity.Note, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.notifyLeaving(ctx, op, escalation, *escalation.OperatorID)
+}
+
+// notify sends the escalation to the connections of the chat
+func (s *Service) notify(ctx context.Context, op string, escalation *support.Escalation) (*support.Escalation, error) {
+	escalation.SetOverdue(time.Now())
+
+	err := s.ChatSvc.Broadcast(ctx, escalation.ChatID.String(), chat.EscalationFrame(*escalation))
+	if err != nil {
+		return escalation, fmt.Errorf("%s: %w: %s", op, service.ErrEscalationNotDelivered, err.Error())
+	}
+
+	return escalation, nil
+}
+
+// notifyLeaving sends the escalation to the connections of the chat and disconnects the operator leaving it
+func (s *Service) notifyLeaving(ctx context.Context, op string, escalation *support.Escalation, operatorID uuid.UUID) (*support.Escalation, error) {
+	escalation.SetOverdue(time.Now())
+
+	err := s.ChatSvc.Disconnect(ctx, escalation.ChatID.String(), operatorID.String(), chat.EscalationFrame(*escalation))
+	if err != nil {
+		return escalation, fmt.Errorf("%s: %w: %s", op, service.ErrEscalationNotDelivered, err.Error())
+	}
+
+	return escalation, nil
+}
+
+// isAdmin tells whether the user has the admin role
+func (s *Service) isAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	user, err := s.UserRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return user.RoleID == role.AdminID, nil
+}
+
+// checkOperator makes sure the role of the user is granted the support
+func (s *Service) checkOperator(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.UserRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return service.ErrOperatorNotFound
+		}
+		return err
+	}
+
+	allowed, err := s.RoleSvc.HasPermission(ctx, int(user.RoleID), Route, role.ActionUpdate)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fmt.Errorf("%w: user %s is not a support operator", service.ErrOperatorNotFound, userID)
+	}
+
+	return nil
+}
@@ -8,18 +8,39 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/interfaces"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/newPassword"
+	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/session"
 	model "github.com/imperatorofdwelling/Full-backend/internal/domain/models/user"
 	"github.com/imperatorofdwelling/Full-backend/internal/service"
 	fileSvc "github.com/imperatorofdwelling/Full-backend/internal/service/file"
 	"github.com/imperatorofdwelling/Full-backend/pkg/checkers"
 	"github.com/imperatorofdwelling/Full-backend/pkg/sendMail"
 	"mime/multipart"
+	"sync"
+	"time"
+)
+
+const (
+	// suspensionsTTL bounds how long a suspension set by another instance stays unnoticed
+	suspensionsTTL = 30 * time.Second
+	// maxCachedSuspensions bounds the cache, the users over it are checked in the database every time
+	maxCachedSuspensions = 100_000
 )
 
 type Service struct {
 	UserRepo         interfaces.UserRepository
 	ConfirmEmailRepo interfaces.ConfirmEmailRepository
 	FileSvc          interfaces.FileService
+	GuestReviewsRepo interfaces.GuestReviewsRepo
+	SessionRepo      interfaces.SessionRepo
+
+	mu          sync.RWMutex
+	suspensions map[uuid.UUID]cachedSuspension
+	prunedAt    time.Time
+}
+
+type cachedSuspension struct {
+	suspension model.Suspension
+	loadedAt   time.Time
 }
 
 func (s *Service) GetUserByID(ctx context.Context, idStr string) (model.User, error) {
@@ -34,6 +55,11 @@ func (s *Service) GetUserByID(ctx context.Context, idStr string) (model.User, er
 	if err != nil {
 		return model.User{}, fmt.Errorf("%s: %w", op, err)
 	}
+
+	result.GuestRating, err = s.GuestReviewsRepo.GetGuestSummary(ctx, id)
+	if err != nil {
+		return model.User{}, fmt.Errorf("%s: %w", op, err)
+	}
 	return result, nil
 }
 
@@ -118,6 +144,14 @@ func (s *Service) UpdateUserPasswordByEmail(ctx context.Context, newPass newPass
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	// the session changing the password is kept, the password reset of a logged out user ends every session
+	keepID, _ := session.IDFromContext(ctx)
+
+	err = s.SessionRepo.RevokeOtherSessions(ctx, uuid.FromStringOrNil(userID), keepID, session.ReasonPasswordChanged)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
@@ -403,3 +437,133 @@ func CompareSHA256Passwords(hashedPassword, plainPassword string) bool {
 	hash := sha256.Sum256([]byte(plainPassword))
 	return hashedPassword == hex.EncodeToString(hash[:])
 }
+
+// GetActiveSuspension returns the suspension blocking the user now, nil when the user is not blocked.
+// The suspensions are cached for suspensionsTTL since every authorized request checks them,
+// the expired ones are dropped once per suspensionsTTL.
+func (s *Service) GetActiveSuspension(ctx context.Context, userID string) (*model.Suspension, error) {
+	const op = "service.user.GetActiveSuspension"
+
+	id, err := uuid.FromString(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.mu.RLock()
+	cached, ok := s.suspensions[id]
+	s.mu.RUnlock()
+
+	if !ok || time.Since(cached.loadedAt) >= suspensionsTTL {
+		suspension, err := s.UserRepo.GetSuspension(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		cached = cachedSuspension{suspension: suspension, loadedAt: time.Now()}
+
+		s.mu.Lock()
+		if s.suspensions == nil {
+			s.suspensions = make(map[uuid.UUID]cachedSuspension)
+		}
+		s.pruneSuspensions(cached.loadedAt)
+		if len(s.suspensions) < maxCachedSuspensions {
+			s.suspensions[id] = cached
+		}
+		s.mu.Unlock()
+	}
+
+	if !cached.suspension.Active(time.Now()) {
+		return nil, nil
+	}
+
+	return &cached.suspension, nil
+}
+
+// SuspendUser suspends the user for the days or bans them, the suspension the user has is replaced
+func (s *Service) SuspendUser(ctx context.Context, idStr string, suspension *model.SuspensionEntity) (*model.Suspension, error) {
+	const op = "service.user.SuspendUser"
+
+	id, err := uuid.FromString(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	err = suspension.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	suspended := model.Suspension{Reason: suspension.Reason, Banned: suspension.Ban}
+	if !suspension.Ban {
+		until := time.Now().AddDate(0, 0, suspension.Days)
+		suspended.Until = &until
+	}
+
+	err = s.UserRepo.SetSuspension(ctx, id, suspended)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.forgetSuspension(id)
+
+	return &suspended, nil
+}
+
+// ExtendSuspension suspends the user for the days, a ban or a longer suspension the user has is kept
+func (s *Service) ExtendSuspension(ctx context.Context, idStr string, reason string, days int) error {
+	const op = "service.user.ExtendSuspension"
+
+	id, err := uuid.FromString(idStr)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	err = s.UserRepo.ExtendSuspension(ctx, id, reason, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.forgetSuspension(id)
+
+	return nil
+}
+
+// ReinstateUser lifts the suspension or the ban of the user
+func (s *Service) ReinstateUser(ctx context.Context, idStr string) error {
+	const op = "service.user.ReinstateUser"
+
+	id, err := uuid.FromString(idStr)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", op, service.ErrValid, err.Error())
+	}
+
+	err = s.UserRepo.SetSuspension(ctx, id, model.Suspension{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.forgetSuspension(id)
+
+	return nil
+}
+
+func (s *Service) forgetSuspension(id uuid.UUID) {
+	s.mu.Lock()
+	delete(s.suspensions, id)
+	s.mu.Unlock()
+}
+
+// pruneSuspensions drops the expired suspensions from the cache, it must be called with the lock held
+func (s *Service) pruneSuspensions(now time.Time) {
+	if now.Sub(s.prunedAt) < suspensionsTTL {
+		return
+	}
+
+	for id, cached := range s.suspensions {
+		if now.Sub(cached.loadedAt) >= suspensionsTTL {
+			delete(s.suspensions, id)
+		}
+	}
+
+	s.prunedAt = now
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"github.com/go-chi/render"
 	"github.com/imperatorofdwelling/Full-backend/internal/domain/models/response"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 func WriteJson(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
@@ -33,3 +36,22 @@ func WriteError(w http.ResponseWriter, r *http.Request, status int, err interfac
 
 	render.JSON(w, r, response)
 }
+
+// WriteErrorCode writes the error with the code the clients tell the errors of the same status by
+func WriteErrorCode(w http.ResponseWriter, r *http.Request, status int, code string, err interface{}) {
+	render.Status(r, status)
+
+	render.JSON(w, r, map[string]interface{}{
+		"error": fmt.Sprintf("%v", err),
+		"code":  code,
+	})
+}
+
+// WriteTooManyRequests writes the 429 error with the code, the Retry-After header is set when the wait is known
+func WriteTooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration, code string, err interface{}) {
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+
+	WriteErrorCode(w, r, http.StatusTooManyRequests, code, err)
+}
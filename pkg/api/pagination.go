@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/gofrs/uuid"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = errors.New("invalid limit")
+)
+
+// Cursor is a keyset position: the (created_at, id) of the last item of the previous page.
+// Clients get it encoded as an opaque string and must not rely on its format.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (c Cursor) String() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor got from Page.NextCursor. Empty string means the first page.
+func ParseCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	uid, err := uuid.FromString(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: t, ID: uid}, nil
+}
+
+type PageRequest struct {
+	Limit int
+	After *Cursor
+}
+
+// NewPageRequest builds a page request from the "limit" and "cursor" query parameters.
+// Missing limit falls back to DefaultLimit, a limit above MaxLimit is cut down to it.
+func NewPageRequest(query url.Values) (PageRequest, error) {
+	page := PageRequest{Limit: DefaultLimit}
+
+	if l := query.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			return PageRequest{}, ErrInvalidLimit
+		}
+
+		page.Limit = min(limit, MaxLimit)
+	}
+
+	after, err := ParseCursor(query.Get("cursor"))
+	if err != nil {
+		return PageRequest{}, err
+	}
+
+	page.After = after
+
+	return page, nil
+}
+
+// KeysetArgs returns the cursor created_at, cursor id and limit arguments for a keyset condition like
+// "($1::TIMESTAMP IS NULL OR (created_at, id) > ($1::TIMESTAMP, $2::UUID)) ORDER BY created_at, id LIMIT $3".
+// One extra row is requested so NewPage can tell whether there is a next page.
+func (p PageRequest) KeysetArgs() []interface{} {
+	if p.After == nil {
+		return []interface{}{nil, nil, p.Limit + 1}
+	}
+
+	return []interface{}{p.After.CreatedAt, p.After.ID, p.Limit + 1}
+}
+
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPage cuts items fetched with PageRequest.KeysetArgs to the page size and sets the cursor of the next page.
+func NewPage[T any](items []T, req PageRequest, cursorOf func(T) Cursor) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	if len(items) <= req.Limit {
+		return Page[T]{Items: items}
+	}
+
+	items = items[:req.Limit]
+
+	return Page[T]{
+		Items:      items,
+		NextCursor: cursorOf(items[len(items)-1]).String(),
+	}
+}
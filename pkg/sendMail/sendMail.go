@@ -7,6 +7,11 @@ import (
 )
 
 func SimpleEmailSend(userMail, userOTP, title string) error {
+	return EmailSend(userMail, title, fmt.Sprintf("Your code: %s", userOTP))
+}
+
+// EmailSend sends the plain text body to the user
+func EmailSend(userMail, title, body string) error {
 	smtpUser := os.Getenv("SMTP_USER")
 	smtpPassword := os.Getenv("SMTP_PASSWORD")
 	smtpHost := os.Getenv("SMTP_HOST")
@@ -30,7 +35,7 @@ func SimpleEmailSend(userMail, userOTP, title string) error {
 		"MIME-Version: 1.0\r\n"+
 		"Content-Type: text/plain; charset=UTF-8\r\n"+
 		"\r\n"+
-		"Your code: %s", "Someone Of Dwellers", smtpUser, userMail, title, userOTP))
+		"%s", "Someone Of Dwellers", smtpUser, userMail, title, body))
 
 	err := smtp.SendMail(
 		fmt.Sprintf("%s:%s", smtpHost, smtpPort),